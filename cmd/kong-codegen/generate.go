@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// initialisms are upper-cased in generated identifiers, following the
+// naming used by the kong package.
+var initialisms = map[string]string{
+	"acl": "ACL", "api": "API", "ca": "CA", "dns": "DNS", "grpc": "GRPC",
+	"grpcs": "GRPCS", "http": "HTTP", "https": "HTTPS", "id": "ID", "ip": "IP",
+	"json": "JSON", "jwt": "JWT", "sni": "SNI", "snis": "SNIs", "ssl": "SSL",
+	"tcp": "TCP", "tls": "TLS", "ttl": "TTL", "udp": "UDP", "uri": "URI",
+	"url": "URL", "uuid": "UUID",
+}
+
+// goName converts a snake_case or kebab-case name into a Go identifier.
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	}) {
+		if i, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(i)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// singular returns the singular form of the name of an entity collection.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+type entity struct {
+	name     string
+	typeName string
+	fields   []field
+}
+
+type field struct {
+	name   string
+	schema map[string]interface{}
+}
+
+type generator struct {
+	pkg      string
+	entities []entity
+	// typeNames maps entity names to the names of their types, to
+	// resolve foreign keys.
+	typeNames map[string]string
+	buf       bytes.Buffer
+	// declared holds the names of the declared types, to avoid
+	// generating them twice.
+	declared map[string]bool
+}
+
+func newGenerator(pkg string) *generator {
+	return &generator{
+		pkg:       pkg,
+		typeNames: map[string]string{},
+		declared:  map[string]bool{},
+	}
+}
+
+func (g *generator) addEntity(name, typeName string, schema kong.Schema) {
+	if typeName == "" {
+		typeName = goName(singular(name))
+	}
+	g.typeNames[name] = typeName
+	g.entities = append(g.entities, entity{
+		name:     name,
+		typeName: typeName,
+		fields:   schemaFields(schema["fields"]),
+	})
+}
+
+func (g *generator) addPlugin(name string, schema kong.Schema) error {
+	for _, f := range schemaFields(schema["fields"]) {
+		if f.name == "config" {
+			g.entities = append(g.entities, entity{
+				name:     name,
+				typeName: goName(name) + "Config",
+				fields:   schemaFields(f.schema["fields"]),
+			})
+			return nil
+		}
+	}
+	return fmt.Errorf("no config field in the schema of plugin %q", name)
+}
+
+// schemaFields converts the fields of a Kong schema, a list of
+// single-key objects, into fields.
+func schemaFields(v interface{}) []field {
+	list, _ := v.([]interface{})
+	var fields []field
+	for _, item := range list {
+		m, _ := item.(map[string]interface{})
+		for name, s := range m {
+			schema, _ := s.(map[string]interface{})
+			fields = append(fields, field{name: name, schema: schema})
+		}
+	}
+	return fields
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate() ([]byte, error) {
+	g.printf("// Code generated by kong-codegen. DO NOT EDIT.\n\n")
+	g.printf("package %s\n\n", g.pkg)
+
+	hasServices := false
+	for _, e := range g.entities {
+		hasServices = hasServices || g.hasService(e)
+	}
+	if hasServices {
+		g.printf("import (\n\"context\"\n\"fmt\"\n\n\"github.com/kong/go-kong/kong\"\n)\n\n")
+	}
+
+	for _, e := range g.entities {
+		g.generateStruct(e.typeName, e.fields)
+	}
+	for _, e := range g.entities {
+		if g.hasService(e) {
+			g.generateService(e)
+		}
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) generateStruct(typeName string, fields []field) {
+	if g.declared[typeName] {
+		return
+	}
+	g.declared[typeName] = true
+
+	// nested records are declared after the struct using them
+	var nested []func()
+	g.printf("// %s was generated from a Kong schema.\n", typeName)
+	g.printf("type %s struct {\n", typeName)
+	for _, f := range fields {
+		fieldName := goName(f.name)
+		goType := g.goType(typeName+fieldName, f.schema, &nested, true)
+		g.printf("%s %s `json:\"%s,omitempty\" yaml:\"%s,omitempty\"`\n", fieldName, goType, f.name, f.name)
+	}
+	g.printf("}\n\n")
+	for _, n := range nested {
+		n()
+	}
+}
+
+// goType returns the Go type of a field described by schema. Records are
+// declared as types named typeName.
+func (g *generator) goType(typeName string, schema map[string]interface{},
+	nested *[]func(), pointer bool,
+) string {
+	ptr := ""
+	if pointer {
+		ptr = "*"
+	}
+	typ, _ := schema["type"].(string)
+	switch typ {
+	case "string":
+		return ptr + "string"
+	case "integer":
+		return ptr + "int"
+	case "number":
+		return ptr + "float64"
+	case "boolean":
+		return ptr + "bool"
+	case "array", "set":
+		elements, _ := schema["elements"].(map[string]interface{})
+		return "[]" + g.goType(singular(typeName), elements, nested, true)
+	case "map":
+		values, _ := schema["values"].(map[string]interface{})
+		return "map[string]" + g.goType(typeName+"Value", values, nested, false)
+	case "record":
+		fields := schemaFields(schema["fields"])
+		*nested = append(*nested, func() { g.generateStruct(typeName, fields) })
+		return "*" + typeName
+	case "foreign":
+		reference, _ := schema["reference"].(string)
+		if t, ok := g.typeNames[reference]; ok {
+			return "*" + t
+		}
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// hasService tells whether a service is generated for e: only entities
+// (not plugin configs) with an id field get one.
+func (g *generator) hasService(e entity) bool {
+	if g.typeNames[e.name] != e.typeName {
+		return false
+	}
+	for _, f := range e.fields {
+		if f.name == "id" {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) generateService(e entity) {
+	t := e.typeName
+	g.printf(`// %[1]sService performs CRUD operations on %[2]s.
+type %[1]sService struct {
+	client *kong.Client
+}
+
+// New%[1]sService returns a %[1]sService using client.
+func New%[1]sService(client *kong.Client) *%[1]sService {
+	return &%[1]sService{client: client}
+}
+
+func (s *%[1]sService) do(ctx context.Context, method, endpoint string,
+	qs, body, v interface{},
+) error {
+	req, err := s.client.NewRequest(method, endpoint, qs, body)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, req, v)
+	return err
+}
+
+// Create creates a %[1]s in Kong.
+func (s *%[1]sService) Create(ctx context.Context, entity *%[1]s) (*%[1]s, error) {
+	endpoint, method := "/%[2]s", "POST"
+	if entity.ID != nil {
+		endpoint, method = "/%[2]s/"+*entity.ID, "PUT"
+	}
+	var res %[1]s
+	if err := s.do(ctx, method, endpoint, nil, entity, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Get fetches a %[1]s in Kong.
+func (s *%[1]sService) Get(ctx context.Context, id string) (*%[1]s, error) {
+	var res %[1]s
+	if err := s.do(ctx, "GET", fmt.Sprintf("/%[2]s/%%s", id), nil, nil, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Update updates a %[1]s in Kong.
+func (s *%[1]sService) Update(ctx context.Context, entity *%[1]s) (*%[1]s, error) {
+	if entity.ID == nil {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+	var res %[1]s
+	if err := s.do(ctx, "PATCH", "/%[2]s/"+*entity.ID, nil, entity, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Delete deletes a %[1]s in Kong.
+func (s *%[1]sService) Delete(ctx context.Context, id string) error {
+	return s.do(ctx, "DELETE", fmt.Sprintf("/%[2]s/%%s", id), nil, nil, nil)
+}
+
+// ListAll fetches all %[2]s in Kong.
+func (s *%[1]sService) ListAll(ctx context.Context) ([]*%[1]s, error) {
+	type query struct {
+		Size   int    `+"`url:\"size,omitempty\"`"+`
+		Offset string `+"`url:\"offset,omitempty\"`"+`
+	}
+	q := query{Size: 1000}
+	var res []*%[1]s
+	for {
+		var page struct {
+			Data   []*%[1]s `+"`json:\"data\"`"+`
+			Offset *string `+"`json:\"offset\"`"+`
+		}
+		if err := s.do(ctx, "GET", "/%[2]s", &q, nil, &page); err != nil {
+			return nil, err
+		}
+		res = append(res, page.Data...)
+		if page.Offset == nil {
+			return res, nil
+		}
+		q.Offset = *page.Offset
+	}
+}
+
+`, t, e.name)
+}
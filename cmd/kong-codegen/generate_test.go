@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func schema(t *testing.T, s string) kong.Schema {
+	var res kong.Schema
+	require.NoError(t, json.Unmarshal([]byte(s), &res))
+	return res
+}
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "ID", goName("id"))
+	assert.Equal(t, "CACertificates", goName("ca_certificates"))
+	assert.Equal(t, "TLSVerifyDepth", goName("tls_verify_depth"))
+	assert.Equal(t, "RateLimiting", goName("rate-limiting"))
+	assert.Equal(t, "HTTPSRedirectStatusCode", goName("https_redirect_status_code"))
+}
+
+func TestSingular(t *testing.T) {
+	assert.Equal(t, "service", singular("services"))
+	assert.Equal(t, "key_authentication", singular("key_authentications"))
+	assert.Equal(t, "policy", singular("policies"))
+	assert.Equal(t, "class", singular("classes"))
+	assert.Equal(t, "acl", singular("acl"))
+}
+
+func TestGenerate(t *testing.T) {
+	g := newGenerator("mykong")
+	g.addEntity("services", "", schema(t, `{"fields": [
+		{"id": {"type": "string", "uuid": true}},
+		{"name": {"type": "string"}},
+		{"port": {"type": "integer"}},
+		{"tags": {"type": "set", "elements": {"type": "string"}}}
+	]}`))
+	g.addEntity("routes", "", schema(t, `{"fields": [
+		{"id": {"type": "string", "uuid": true}},
+		{"service": {"type": "foreign", "reference": "services"}},
+		{"headers": {"type": "map", "keys": {"type": "string"},
+			"values": {"type": "array", "elements": {"type": "string"}}}},
+		{"strip_path": {"type": "boolean"}}
+	]}`))
+	require.NoError(t, g.addPlugin("rate-limiting", schema(t, `{"fields": [
+		{"name": {"type": "string"}},
+		{"config": {"type": "record", "fields": [
+			{"minute": {"type": "number"}},
+			{"redis": {"type": "record", "fields": [{"host": {"type": "string"}}]}}
+		]}}
+	]}`)))
+	assert.Error(t, g.addPlugin("broken", schema(t, `{"fields": []}`)))
+
+	src, err := g.generate()
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "zz_generated.go", src, 0)
+	require.NoError(t, err, string(src))
+
+	out := string(src)
+	assert.Contains(t, out, "package mykong")
+	assert.Contains(t, out, "type Service struct")
+	assert.Regexp(t, "Tags +\\[\\]\\*string +`json:\"tags,omitempty\"", out)
+	assert.Regexp(t, "Service +\\*Service +`json:\"service,omitempty\"", out)
+	assert.Regexp(t, "Headers +map\\[string\\]\\[\\]\\*string", out)
+	assert.Contains(t, out, "type RateLimitingConfig struct")
+	assert.Regexp(t, "Redis +\\*RateLimitingConfigRedis", out)
+	assert.Contains(t, out, "type RateLimitingConfigRedis struct")
+	assert.Contains(t, out, "func NewServiceService(client *kong.Client) *ServiceService")
+	assert.Contains(t, out, "func NewRouteService(client *kong.Client) *RouteService")
+	assert.NotContains(t, out, "RateLimitingConfigService")
+}
+
+func TestGeneratePluginsOnly(t *testing.T) {
+	g := newGenerator("mykong")
+	require.NoError(t, g.addPlugin("cors", schema(t, `{"fields": [
+		{"config": {"type": "record", "fields": [{"origins": {"type": "array", "elements": {"type": "string"}}}]}}
+	]}`)))
+	src, err := g.generate()
+	require.NoError(t, err)
+	// no service is generated, so nothing must be imported
+	assert.NotContains(t, string(src), "import")
+	assert.Contains(t, string(src), "type CorsConfig struct")
+}
@@ -0,0 +1,100 @@
+// Command kong-codegen reads entity and plugin schemas from the /schemas
+// endpoints of a running Kong and generates typed Go structs for them,
+// along with service stubs performing CRUD operations on the entities
+// through a *kong.Client.
+//
+// It is meant to be run with go generate, e.g.:
+//
+//	//go:generate go run github.com/kong/go-kong/cmd/kong-codegen -package mykong -out zz_generated_kong.go -entities services,routes -plugins rate-limiting
+//
+// Entity types are named after the singular form of the entity name,
+// which can be overridden with the entity=TypeName syntax, e.g.
+// -entities ca_certificates=CACert.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "kong-codegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	var (
+		addr     = flag.String("kong-addr", "", "URL of the Kong Admin API (defaults to $KONG_ADMIN_URL or http://localhost:8001)")
+		pkg      = flag.String("package", "", "name of the generated package (defaults to $GOPACKAGE)")
+		out      = flag.String("out", "", "output file (defaults to stdout)")
+		entities = flag.String("entities", "", "comma-separated list of entities to generate, e.g. services,routes")
+		plugins  = flag.String("plugins", "", "comma-separated list of plugins to generate configs for")
+	)
+	flag.Parse()
+
+	if *pkg == "" {
+		*pkg = os.Getenv("GOPACKAGE")
+	}
+	if *pkg == "" {
+		return fmt.Errorf("-package is required outside of go generate")
+	}
+	if *entities == "" && *plugins == "" {
+		return fmt.Errorf("at least one of -entities or -plugins is required")
+	}
+
+	var baseURL *string
+	if *addr != "" {
+		baseURL = addr
+	}
+	client, err := kong.NewClient(baseURL, nil)
+	if err != nil {
+		return err
+	}
+
+	g := newGenerator(*pkg)
+	ctx := context.Background()
+	for _, e := range splitList(*entities) {
+		name, typeName, _ := strings.Cut(e, "=")
+		schema, err := client.Schemas.Get(ctx, name)
+		if err != nil {
+			return fmt.Errorf("fetching schema of entity %q: %w", name, err)
+		}
+		g.addEntity(name, typeName, schema)
+	}
+	for _, name := range splitList(*plugins) {
+		schema, err := client.Plugins.GetFullSchema(ctx, kong.String(name))
+		if err != nil {
+			return fmt.Errorf("fetching schema of plugin %q: %w", name, err)
+		}
+		if err := g.addPlugin(name, schema); err != nil {
+			return err
+		}
+	}
+
+	src, err := g.generate()
+	if err != nil {
+		return err
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*out, src, 0o644) //nolint:gosec
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			res = append(res, e)
+		}
+	}
+	return res
+}
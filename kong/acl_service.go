@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractACLService handles consumer ACL groups in Kong.
@@ -41,7 +40,7 @@ func (s *ACLService) Create(ctx context.Context,
 	}
 
 	var createdACLGroup ACLGroup
-	err = json.Unmarshal(cred, &createdACLGroup)
+	err = s.client.decode(cred, &createdACLGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +59,7 @@ func (s *ACLService) Get(ctx context.Context,
 	}
 
 	var aclGroup ACLGroup
-	err = json.Unmarshal(cred, &aclGroup)
+	err = s.client.decode(cred, &aclGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +78,7 @@ func (s *ACLService) Update(ctx context.Context,
 	}
 
 	var updatedACLGroup ACLGroup
-	err = json.Unmarshal(cred, &updatedACLGroup)
+	err = s.client.decode(cred, &updatedACLGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +110,7 @@ func (s *ACLService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var aclGroup ACLGroup
-		err = json.Unmarshal(b, &aclGroup)
+		err = s.client.decode(b, &aclGroup)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -157,7 +156,7 @@ func (s *ACLService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var aclGroup ACLGroup
-		err = json.Unmarshal(b, &aclGroup)
+		err = s.client.decode(b, &aclGroup)
 		if err != nil {
 			return nil, nil, err
 		}
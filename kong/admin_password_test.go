@@ -0,0 +1,54 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminServicePasswordAndToken(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		body := map[string]interface{}{}
+		if r.ContentLength > 0 {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		}
+		bodies[key] = body
+		switch key {
+		case "POST /admins/password_resets":
+			w.WriteHeader(http.StatusCreated)
+		case "PATCH /admins/password_resets", "PATCH /admins/self/password":
+			w.WriteHeader(http.StatusOK)
+		case "PATCH /admins/self/token":
+			_, _ = w.Write([]byte(`{"token":"new-token"}`))
+		default:
+			t.Errorf("unexpected request: %s", key)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Admins.RequestPasswordReset(defaultCtx, String("admin@example.com")))
+	assert.Equal(t, map[string]interface{}{"email": "admin@example.com"}, bodies["POST /admins/password_resets"])
+
+	require.NoError(t, client.Admins.ResetPassword(defaultCtx,
+		String("admin@example.com"), String("s3cret"), String("reset-token")))
+	assert.Equal(t, map[string]interface{}{
+		"email": "admin@example.com", "password": "s3cret", "token": "reset-token",
+	}, bodies["PATCH /admins/password_resets"])
+	assert.Error(t, client.Admins.ResetPassword(defaultCtx, String("admin@example.com"), String("s3cret"), nil))
+
+	require.NoError(t, client.Admins.UpdatePassword(defaultCtx, String("old"), String("new")))
+	assert.Equal(t, map[string]interface{}{"old_password": "old", "password": "new"},
+		bodies["PATCH /admins/self/password"])
+
+	token, err := client.Admins.RegenerateToken(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "new-token", *token)
+}
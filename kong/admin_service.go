@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -38,6 +37,14 @@ type AbstractAdminService interface {
 	// GetConsumer fetches the Consumer that gets generated for an Admin when
 	// the Admin is created.
 	GetConsumer(ctx context.Context, emailOrID *string) (*Consumer, error)
+	// RequestPasswordReset sends a password reset email to an Admin.
+	RequestPasswordReset(ctx context.Context, email *string) error
+	// ResetPassword sets the password of an Admin with a password reset token.
+	ResetPassword(ctx context.Context, email, password, token *string) error
+	// UpdatePassword changes the password of the authenticated Admin.
+	UpdatePassword(ctx context.Context, oldPassword, password *string) error
+	// RegenerateToken generates a new RBAC token for the authenticated Admin.
+	RegenerateToken(ctx context.Context) (*string, error)
 }
 
 // AdminService handles Admins in Kong.
@@ -182,7 +189,7 @@ func (s *AdminService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var admin Admin
-		err = json.Unmarshal(b, &admin)
+		err = s.client.decode(b, &admin)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -347,3 +354,90 @@ func (s *AdminService) GetConsumer(ctx context.Context,
 	}
 	return &consumer, nil
 }
+
+// RequestPasswordReset makes Kong send an email with a password reset
+// link to the Admin with email. It requires Kong to be configured to
+// send emails (smtp_mock off and admin_gui_url set).
+func (s *AdminService) RequestPasswordReset(ctx context.Context,
+	email *string,
+) error {
+	if isEmptyString(email) {
+		return fmt.Errorf("email cannot be nil for RequestPasswordReset operation")
+	}
+
+	body := Admin{Email: email}
+	req, err := s.client.NewRequest("POST", "/admins/password_resets", nil, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// ResetPassword sets the password of the Admin with email, using the
+// token of the link sent by RequestPasswordReset.
+func (s *AdminService) ResetPassword(ctx context.Context,
+	email, password, token *string,
+) error {
+	if isEmptyString(email) || isEmptyString(password) || isEmptyString(token) {
+		return fmt.Errorf("email, password and token cannot be nil for ResetPassword operation")
+	}
+
+	body := Admin{Email: email, Password: password, Token: token}
+	req, err := s.client.NewRequest("PATCH", "/admins/password_resets", nil, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// UpdatePassword changes the password of the Admin authenticated with
+// the client from oldPassword to password.
+// Kong only exposes this endpoint for the authenticated Admin
+// (/admins/self/password).
+func (s *AdminService) UpdatePassword(ctx context.Context,
+	oldPassword, password *string,
+) error {
+	if isEmptyString(oldPassword) || isEmptyString(password) {
+		return fmt.Errorf("oldPassword and password cannot be nil for UpdatePassword operation")
+	}
+
+	body := struct {
+		OldPassword *string `json:"old_password"`
+		Password    *string `json:"password"`
+	}{oldPassword, password}
+	req, err := s.client.NewRequest("PATCH", "/admins/self/password", nil, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// RegenerateToken generates a new RBAC token for the Admin authenticated
+// with the client, invalidating the previous one, and returns it.
+// Kong only exposes this endpoint for the authenticated Admin
+// (/admins/self/token). Tokens of other Admins are managed through their
+// RBAC users.
+func (s *AdminService) RegenerateToken(ctx context.Context) (*string, error) {
+	req, err := s.client.NewRequest("PATCH", "/admins/self/token", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var token struct {
+		Token *string `json:"token"`
+	}
+	_, err = s.client.Do(ctx, req, &token)
+	if err != nil {
+		return nil, err
+	}
+	if token.Token == nil {
+		return nil, fmt.Errorf("no token in the response of Kong")
+	}
+	return token.Token, nil
+}
@@ -0,0 +1,3 @@
+// Package admission validates Kong entities against the schemas of a
+// running Kong, in a form suited to Kubernetes admission webhooks.
+package admission
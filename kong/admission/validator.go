@@ -0,0 +1,67 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// Response is the outcome of the validation of an entity. Its fields map
+// to the Allowed and Result (a metav1.Status) fields of an
+// AdmissionResponse.
+type Response struct {
+	Allowed bool
+	// Message explains why the entity was denied.
+	Message string
+	// Code is the HTTP status code of denied entities: 400 for entities
+	// which aren't valid JSON and 422 for entities rejected by Kong.
+	Code int32
+}
+
+func denied(code int, format string, args ...interface{}) Response {
+	return Response{Code: int32(code), Message: fmt.Sprintf(format, args...)}
+}
+
+// Validator validates entities with the /schemas endpoints of the Kong
+// targeted by a client. Validation doesn't create entities, so it works
+// with Kong in DB-less mode and on data planes too.
+type Validator struct {
+	client *kong.Client
+}
+
+// NewValidator returns a Validator validating entities with client.
+func NewValidator(client *kong.Client) *Validator {
+	return &Validator{client: client}
+}
+
+// Validate validates raw, the JSON representation of an entity of type
+// entityType as named in the Admin API (e.g. "services" or "plugins").
+//
+// Invalid entities result in a Response denying them, with the message
+// of Kong. An error is only returned if the entity couldn't be
+// validated, e.g. when Kong is unreachable or doesn't know entityType
+// (see kong.IsNotFoundErr); webhooks should then apply their failure
+// policy.
+func (v *Validator) Validate(ctx context.Context, entityType string, raw []byte) (Response, error) {
+	if entityType == "" {
+		return Response{}, fmt.Errorf("entityType cannot be empty")
+	}
+
+	// entities are validated as is, including plugins, so that Kong
+	// sees fields the structs of the kong package don't model
+	var entity map[string]interface{}
+	if err := json.Unmarshal(raw, &entity); err != nil {
+		return denied(http.StatusBadRequest, "invalid %s entity: %v", entityType, err), nil
+	}
+	valid, message, err := v.client.Schemas.Validate(ctx, entityType, entity)
+	if err != nil {
+		return Response{}, fmt.Errorf("validating %s entity: %w", entityType, err)
+	}
+	if !valid {
+		return denied(http.StatusUnprocessableEntity, "%s", message), nil
+	}
+	return Response{Allowed: true}, nil
+}
@@ -0,0 +1,79 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/schemas/services/validate":
+			if body["host"] == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"schema violation (host: required field missing)"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":"schema validation successful"}`))
+		case "/schemas/plugins/validate":
+			assert.Equal(t, "key-auth", body["name"])
+			if body["unknown"] != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"schema violation (unknown: unknown field)"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":"schema validation successful"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := kong.NewClient(kong.String(srv.URL), nil)
+	require.NoError(t, err)
+	v := NewValidator(client)
+	ctx := context.Background()
+
+	res, err := v.Validate(ctx, "services", []byte(`{"name":"foo","host":"example.com"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Response{Allowed: true}, res)
+
+	res, err = v.Validate(ctx, "services", []byte(`{"name":"foo"}`))
+	require.NoError(t, err)
+	assert.Equal(t, Response{
+		Code:    http.StatusUnprocessableEntity,
+		Message: "schema violation (host: required field missing)",
+	}, res)
+
+	res, err = v.Validate(ctx, "plugins", []byte(`{"name":"key-auth"}`))
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	res, err = v.Validate(ctx, "plugins", []byte(`{"name":"key-auth","unknown":true}`))
+	require.NoError(t, err)
+	assert.Equal(t, Response{
+		Code:    http.StatusUnprocessableEntity,
+		Message: "schema violation (unknown: unknown field)",
+	}, res)
+
+	res, err = v.Validate(ctx, "routes", []byte(`{"name":`))
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.EqualValues(t, http.StatusBadRequest, res.Code)
+
+	_, err = v.Validate(ctx, "foos", []byte(`{}`))
+	require.Error(t, err)
+	assert.True(t, kong.IsNotFoundErr(err))
+
+	_, err = v.Validate(ctx, "", []byte(`{}`))
+	assert.Error(t, err)
+}
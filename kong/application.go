@@ -0,0 +1,47 @@
+package kong
+
+// Application represents an Application of a Developer of the Dev Portal
+// in Kong.
+type Application struct {
+	ID          *string    `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        *string    `json:"name,omitempty" yaml:"name,omitempty"`
+	Description *string    `json:"description,omitempty" yaml:"description,omitempty"`
+	RedirectURI *string    `json:"redirect_uri,omitempty" yaml:"redirect_uri,omitempty"`
+	CustomID    *string    `json:"custom_id,omitempty" yaml:"custom_id,omitempty"`
+	Developer   *Developer `json:"developer,omitempty" yaml:"developer,omitempty"`
+	Consumer    *Consumer  `json:"consumer,omitempty" yaml:"consumer,omitempty"`
+	CreatedAt   *int       `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt   *int       `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// FriendlyName returns the endpoint key name or ID.
+func (a *Application) FriendlyName() string {
+	if a.Name != nil {
+		return *a.Name
+	}
+	if a.ID != nil {
+		return *a.ID
+	}
+	return ""
+}
+
+// Statuses of ApplicationInstances, as used by Kong for Dev Portal
+// entities.
+const (
+	ApplicationInstanceStatusApproved = 0
+	ApplicationInstanceStatusPending  = 1
+	ApplicationInstanceStatusRejected = 2
+	ApplicationInstanceStatusRevoked  = 3
+)
+
+// ApplicationInstance represents the registration (service contract) of
+// an Application to a Service in Kong.
+type ApplicationInstance struct {
+	ID          *string      `json:"id,omitempty" yaml:"id,omitempty"`
+	Application *Application `json:"application,omitempty" yaml:"application,omitempty"`
+	Service     *Service     `json:"service,omitempty" yaml:"service,omitempty"`
+	Status      *int         `json:"status,omitempty" yaml:"status,omitempty"`
+	Suspended   *bool        `json:"suspended,omitempty" yaml:"suspended,omitempty"`
+	CreatedAt   *int         `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt   *int         `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
@@ -0,0 +1,198 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractApplicationInstanceService handles the registrations of Dev
+// Portal Applications to Services in Kong.
+type AbstractApplicationInstanceService interface {
+	// Create registers an Application to a Service in Kong.
+	Create(ctx context.Context, applicationID *string,
+		instance *ApplicationInstance) (*ApplicationInstance, error)
+	// Get fetches an ApplicationInstance in Kong.
+	Get(ctx context.Context, applicationID, id *string) (*ApplicationInstance, error)
+	// Update updates an ApplicationInstance in Kong.
+	Update(ctx context.Context, applicationID *string,
+		instance *ApplicationInstance) (*ApplicationInstance, error)
+	// Delete deletes an ApplicationInstance in Kong.
+	Delete(ctx context.Context, applicationID, id *string) error
+	// List fetches a list of ApplicationInstances of an Application in Kong.
+	List(ctx context.Context, applicationID *string, opt *ListOpt) ([]*ApplicationInstance, *ListOpt, error)
+	// ListAll fetches all ApplicationInstances of an Application in Kong.
+	ListAll(ctx context.Context, applicationID *string) ([]*ApplicationInstance, error)
+	// ListForService fetches a list of ApplicationInstances of a Service in Kong.
+	ListForService(ctx context.Context, serviceNameOrID *string,
+		opt *ListOpt) ([]*ApplicationInstance, *ListOpt, error)
+}
+
+// ApplicationInstanceService handles the registrations of Dev Portal
+// Applications to Services in Kong.
+type ApplicationInstanceService service
+
+// Create registers the Application with applicationID to instance.Service.
+// Depending on the auto-approval setting of the application-registration
+// plugin of the Service, the registration is approved or pending.
+func (s *ApplicationInstanceService) Create(ctx context.Context,
+	applicationID *string, instance *ApplicationInstance,
+) (*ApplicationInstance, error) {
+	if isEmptyString(applicationID) {
+		return nil, fmt.Errorf("applicationID cannot be nil for Create operation")
+	}
+	if instance == nil || instance.Service == nil {
+		return nil, fmt.Errorf("service cannot be nil for Create operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v/application_instances", *applicationID)
+	req, err := s.client.NewRequest("POST", endpoint, nil, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdInstance ApplicationInstance
+	_, err = s.client.Do(ctx, req, &createdInstance)
+	if err != nil {
+		return nil, err
+	}
+	return &createdInstance, nil
+}
+
+// Get fetches the ApplicationInstance with id of the Application with
+// applicationID in Kong.
+func (s *ApplicationInstanceService) Get(ctx context.Context,
+	applicationID, id *string,
+) (*ApplicationInstance, error) {
+	if isEmptyString(applicationID) || isEmptyString(id) {
+		return nil, fmt.Errorf("applicationID and id cannot be nil for Get operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v/application_instances/%v", *applicationID, *id)
+	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var instance ApplicationInstance
+	_, err = s.client.Do(ctx, req, &instance)
+	if err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// Update updates an ApplicationInstance of the Application with
+// applicationID in Kong, e.g. setting its Status to
+// ApplicationInstanceStatusApproved approves a pending registration.
+func (s *ApplicationInstanceService) Update(ctx context.Context,
+	applicationID *string, instance *ApplicationInstance,
+) (*ApplicationInstance, error) {
+	if isEmptyString(applicationID) {
+		return nil, fmt.Errorf("applicationID cannot be nil for Update operation")
+	}
+	if instance == nil || isEmptyString(instance.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v/application_instances/%v", *applicationID, *instance.ID)
+	req, err := s.client.NewRequest("PATCH", endpoint, nil, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedInstance ApplicationInstance
+	_, err = s.client.Do(ctx, req, &updatedInstance)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedInstance, nil
+}
+
+// Delete deletes the ApplicationInstance with id of the Application with
+// applicationID in Kong, unregistering the Application from the Service.
+func (s *ApplicationInstanceService) Delete(ctx context.Context,
+	applicationID, id *string,
+) error {
+	if isEmptyString(applicationID) || isEmptyString(id) {
+		return fmt.Errorf("applicationID and id cannot be nil for Delete operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v/application_instances/%v", *applicationID, *id)
+	req, err := s.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of ApplicationInstances of the Application with
+// applicationID in Kong.
+// opt can be used to control pagination.
+func (s *ApplicationInstanceService) List(ctx context.Context,
+	applicationID *string, opt *ListOpt,
+) ([]*ApplicationInstance, *ListOpt, error) {
+	if isEmptyString(applicationID) {
+		return nil, nil, fmt.Errorf("applicationID cannot be nil for List operation")
+	}
+	endpoint := fmt.Sprintf("/applications/%v/application_instances", *applicationID)
+	return s.listInstances(ctx, endpoint, opt)
+}
+
+// ListAll fetches all ApplicationInstances of the Application with
+// applicationID in Kong.
+func (s *ApplicationInstanceService) ListAll(ctx context.Context,
+	applicationID *string,
+) ([]*ApplicationInstance, error) {
+	var instances, data []*ApplicationInstance
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, applicationID, opt)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, data...)
+	}
+	return instances, nil
+}
+
+// ListForService fetches a list of ApplicationInstances of the Service
+// with serviceNameOrID in Kong, i.e. the Applications registered to it.
+// opt can be used to control pagination.
+func (s *ApplicationInstanceService) ListForService(ctx context.Context,
+	serviceNameOrID *string, opt *ListOpt,
+) ([]*ApplicationInstance, *ListOpt, error) {
+	if isEmptyString(serviceNameOrID) {
+		return nil, nil, fmt.Errorf("serviceNameOrID cannot be nil for ListForService operation")
+	}
+	endpoint := fmt.Sprintf("/services/%v/application_instances", *serviceNameOrID)
+	return s.listInstances(ctx, endpoint, opt)
+}
+
+func (s *ApplicationInstanceService) listInstances(ctx context.Context,
+	endpoint string, opt *ListOpt,
+) ([]*ApplicationInstance, *ListOpt, error) {
+	data, next, err := s.client.list(ctx, endpoint, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	var instances []*ApplicationInstance
+
+	for _, object := range data {
+		b, err := object.MarshalJSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		var instance ApplicationInstance
+		err = s.client.decode(b, &instance)
+		if err != nil {
+			return nil, nil, err
+		}
+		instances = append(instances, &instance)
+	}
+
+	return instances, next, nil
+}
@@ -0,0 +1,183 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractApplicationService handles Dev Portal Applications in Kong.
+type AbstractApplicationService interface {
+	// Create creates an Application of a Developer in Kong.
+	Create(ctx context.Context, developerEmailOrID *string, application *Application) (*Application, error)
+	// Get fetches an Application in Kong.
+	Get(ctx context.Context, id *string) (*Application, error)
+	// Update updates an Application in Kong.
+	Update(ctx context.Context, application *Application) (*Application, error)
+	// Delete deletes an Application in Kong.
+	Delete(ctx context.Context, id *string) error
+	// List fetches a list of Applications in Kong.
+	List(ctx context.Context, opt *ListOpt) ([]*Application, *ListOpt, error)
+	// ListAll fetches all Applications in Kong.
+	ListAll(ctx context.Context) ([]*Application, error)
+	// ListForDeveloper fetches a list of Applications of a Developer in Kong.
+	ListForDeveloper(ctx context.Context, developerEmailOrID *string, opt *ListOpt) ([]*Application, *ListOpt, error)
+}
+
+// ApplicationService handles Dev Portal Applications in Kong.
+type ApplicationService service
+
+// Create creates an Application of the Developer with developerEmailOrID
+// in Kong. Kong creates the Consumer backing the Application.
+func (s *ApplicationService) Create(ctx context.Context,
+	developerEmailOrID *string, application *Application,
+) (*Application, error) {
+	if isEmptyString(developerEmailOrID) {
+		return nil, fmt.Errorf("developerEmailOrID cannot be nil for Create operation")
+	}
+	if application == nil {
+		return nil, fmt.Errorf("cannot create a nil application")
+	}
+
+	endpoint := fmt.Sprintf("/developers/%v/applications", *developerEmailOrID)
+	req, err := s.client.NewRequest("POST", endpoint, nil, application)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdApplication Application
+	_, err = s.client.Do(ctx, req, &createdApplication)
+	if err != nil {
+		return nil, err
+	}
+	return &createdApplication, nil
+}
+
+// Get fetches an Application in Kong.
+func (s *ApplicationService) Get(ctx context.Context,
+	id *string,
+) (*Application, error) {
+	if isEmptyString(id) {
+		return nil, fmt.Errorf("id cannot be nil for Get operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v", *id)
+	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var application Application
+	_, err = s.client.Do(ctx, req, &application)
+	if err != nil {
+		return nil, err
+	}
+	return &application, nil
+}
+
+// Update updates an Application in Kong.
+func (s *ApplicationService) Update(ctx context.Context,
+	application *Application,
+) (*Application, error) {
+	if application == nil {
+		return nil, fmt.Errorf("cannot update a nil application")
+	}
+	if isEmptyString(application.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v", *application.ID)
+	req, err := s.client.NewRequest("PATCH", endpoint, nil, application)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedApplication Application
+	_, err = s.client.Do(ctx, req, &updatedApplication)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedApplication, nil
+}
+
+// Delete deletes an Application in Kong, along with its
+// ApplicationInstances.
+func (s *ApplicationService) Delete(ctx context.Context,
+	id *string,
+) error {
+	if isEmptyString(id) {
+		return fmt.Errorf("id cannot be nil for Delete operation")
+	}
+
+	endpoint := fmt.Sprintf("/applications/%v", *id)
+	req, err := s.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of Applications in Kong.
+// opt can be used to control pagination.
+func (s *ApplicationService) List(ctx context.Context,
+	opt *ListOpt,
+) ([]*Application, *ListOpt, error) {
+	return s.listApplications(ctx, "/applications", opt)
+}
+
+// ListAll fetches all Applications in Kong.
+// This method can take a while if there
+// a lot of Applications present.
+func (s *ApplicationService) ListAll(ctx context.Context) ([]*Application, error) {
+	var applications, data []*Application
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		applications = append(applications, data...)
+	}
+	return applications, nil
+}
+
+// ListForDeveloper fetches a list of Applications of the Developer with
+// developerEmailOrID in Kong.
+// opt can be used to control pagination.
+func (s *ApplicationService) ListForDeveloper(ctx context.Context,
+	developerEmailOrID *string, opt *ListOpt,
+) ([]*Application, *ListOpt, error) {
+	if isEmptyString(developerEmailOrID) {
+		return nil, nil, fmt.Errorf("developerEmailOrID cannot be nil for ListForDeveloper operation")
+	}
+	endpoint := fmt.Sprintf("/developers/%v/applications", *developerEmailOrID)
+	return s.listApplications(ctx, endpoint, opt)
+}
+
+func (s *ApplicationService) listApplications(ctx context.Context,
+	endpoint string, opt *ListOpt,
+) ([]*Application, *ListOpt, error) {
+	data, next, err := s.client.list(ctx, endpoint, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	var applications []*Application
+
+	for _, object := range data {
+		b, err := object.MarshalJSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		var application Application
+		err = s.client.decode(b, &application)
+		if err != nil {
+			return nil, nil, err
+		}
+		applications = append(applications, &application)
+	}
+
+	return applications, next, nil
+}
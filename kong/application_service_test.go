@@ -0,0 +1,98 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationServices(t *testing.T) {
+	var registration, approval map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /developers/dev@example.com/applications":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"app1","name":"billing-app","developer":{"id":"dev1"},"consumer":{"id":"c1"}}`))
+		case "GET /developers/dev@example.com/applications":
+			_, _ = w.Write([]byte(`{"data":[{"id":"app1"}],"next":null}`))
+		case "GET /applications/app1":
+			_, _ = w.Write([]byte(`{"id":"app1","name":"billing-app"}`))
+		case "PATCH /applications/app1":
+			_, _ = w.Write([]byte(`{"id":"app1","name":"billing-app","redirect_uri":"https://example.com"}`))
+		case "GET /applications":
+			_, _ = w.Write([]byte(`{"data":[{"id":"app1"},{"id":"app2"}],"next":null}`))
+		case "DELETE /applications/app1":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /applications/app1/application_instances":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&registration))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"i1","application":{"id":"app1"},"service":{"id":"s1"},"status":1}`))
+		case "GET /applications/app1/application_instances/i1":
+			_, _ = w.Write([]byte(`{"id":"i1","status":1}`))
+		case "PATCH /applications/app1/application_instances/i1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&approval))
+			_, _ = w.Write([]byte(`{"id":"i1","status":0}`))
+		case "GET /applications/app1/application_instances":
+			_, _ = w.Write([]byte(`{"data":[{"id":"i1"}],"next":null}`))
+		case "GET /services/billing/application_instances":
+			_, _ = w.Write([]byte(`{"data":[{"id":"i1"},{"id":"i2"}],"next":null}`))
+		case "DELETE /applications/app1/application_instances/i1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	application, err := client.Applications.Create(defaultCtx, String("dev@example.com"),
+		&Application{Name: String("billing-app")})
+	require.NoError(t, err)
+	assert.Equal(t, "app1", *application.ID)
+	assert.Equal(t, "c1", *application.Consumer.ID)
+	applications, _, err := client.Applications.ListForDeveloper(defaultCtx, String("dev@example.com"), nil)
+	require.NoError(t, err)
+	assert.Len(t, applications, 1)
+	application, err = client.Applications.Get(defaultCtx, String("app1"))
+	require.NoError(t, err)
+	assert.Equal(t, "billing-app", application.FriendlyName())
+	application, err = client.Applications.Update(defaultCtx, &Application{
+		ID: String("app1"), RedirectURI: String("https://example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", *application.RedirectURI)
+	applications, err = client.Applications.ListAll(defaultCtx)
+	require.NoError(t, err)
+	assert.Len(t, applications, 2)
+
+	instance, err := client.ApplicationInstances.Create(defaultCtx, String("app1"),
+		&ApplicationInstance{Service: &Service{ID: String("s1")}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "s1"}, registration["service"])
+	assert.Equal(t, ApplicationInstanceStatusPending, *instance.Status)
+	_, err = client.ApplicationInstances.Create(defaultCtx, String("app1"), &ApplicationInstance{})
+	assert.Error(t, err)
+	instance, err = client.ApplicationInstances.Get(defaultCtx, String("app1"), String("i1"))
+	require.NoError(t, err)
+	assert.Equal(t, "i1", *instance.ID)
+	instance, err = client.ApplicationInstances.Update(defaultCtx, String("app1"), &ApplicationInstance{
+		ID: String("i1"), Status: Int(ApplicationInstanceStatusApproved),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "i1", "status": float64(0)}, approval)
+	assert.Equal(t, ApplicationInstanceStatusApproved, *instance.Status)
+	instances, err := client.ApplicationInstances.ListAll(defaultCtx, String("app1"))
+	require.NoError(t, err)
+	assert.Len(t, instances, 1)
+	instances, _, err = client.ApplicationInstances.ListForService(defaultCtx, String("billing"), nil)
+	require.NoError(t, err)
+	assert.Len(t, instances, 2)
+
+	require.NoError(t, client.ApplicationInstances.Delete(defaultCtx, String("app1"), String("i1")))
+	require.NoError(t, client.Applications.Delete(defaultCtx, String("app1")))
+}
@@ -0,0 +1,273 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Operation is a change to an entity applied by an Applier.
+type Operation struct {
+	Action MutationOperation `json:"action"`
+	// EntityType is the collection of the entity in the Admin API,
+	// e.g. "services" or "consumers/alice/key-auth".
+	EntityType string `json:"entity_type"`
+	// ID is the ID, or name, of the entity. It is required to update and
+	// delete entities, and creates entities with PUT if set.
+	ID string `json:"id,omitempty"`
+	// Entity is the entity to create, or the fields to update.
+	Entity interface{} `json:"entity,omitempty"`
+}
+
+func (o Operation) endpoint() string {
+	endpoint := "/" + strings.Trim(o.EntityType, "/")
+	if o.ID != "" {
+		endpoint += "/" + o.ID
+	}
+	return endpoint
+}
+
+// OperationStatus is the outcome of an Operation in an ApplyReport.
+type OperationStatus string
+
+const (
+	// OperationApplied is reported for operations applied successfully.
+	OperationApplied OperationStatus = "applied"
+	// OperationFailed is reported for the operation which failed.
+	OperationFailed OperationStatus = "failed"
+	// OperationSkipped is reported for the operations following the one
+	// which failed.
+	OperationSkipped OperationStatus = "skipped"
+	// OperationRolledBack is reported for applied operations which were
+	// reverted after another one failed.
+	OperationRolledBack OperationStatus = "rolled-back"
+	// OperationRollbackFailed is reported for applied operations which
+	// couldn't be reverted.
+	OperationRollbackFailed OperationStatus = "rollback-failed"
+)
+
+// OperationResult is the outcome of an Operation.
+type OperationResult struct {
+	Operation Operation       `json:"operation"`
+	Status    OperationStatus `json:"status"`
+	Error     string          `json:"error,omitempty"`
+	// Before is the entity before it was updated, deleted or replaced.
+	Before json.RawMessage `json:"before,omitempty"`
+	// After is the entity as returned by Kong once created or updated.
+	After json.RawMessage `json:"after,omitempty"`
+}
+
+// ApplyReport is the machine-readable outcome of Applier.Apply, e.g. to
+// be published by CI pipelines.
+type ApplyReport struct {
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Results    []OperationResult `json:"results"`
+	// Succeeded is true if all operations were applied.
+	Succeeded bool `json:"succeeded"`
+	// RolledBack is true if an operation failed and all the operations
+	// applied before it were reverted.
+	RolledBack bool   `json:"rolled_back"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Applier applies lists of operations to Kong, reverting them if one of
+// them fails.
+type Applier struct {
+	client   *Client
+	rollback bool
+	policies []namedPolicy
+}
+
+// NewApplier returns an Applier applying operations with client.
+// Rollback is enabled.
+func NewApplier(client *Client) *Applier {
+	return &Applier{client: client, rollback: true}
+}
+
+// SetRollback enables or disables the rollback of applied operations when
+// an operation fails.
+func (a *Applier) SetRollback(rollback bool) {
+	a.rollback = rollback
+}
+
+// Apply applies operations in order. Entities are fetched before being
+// updated, deleted or replaced with PUT, so that if an operation fails,
+// the operations applied before it can be reverted, in reverse order:
+// created entities are deleted, while the other entities are replaced
+// with PUT by their previous state.
+//
+// Rollback is best effort: entities deleted by Kong along with a deleted
+// entity, e.g. the routes of a service, aren't restored, and changes
+// made by others in the meantime are overwritten.
+//
+// The operations are first checked by the policies registered with
+// AddPolicy. If one of them vetoes the operations, none is applied.
+//
+// The returned report describes the outcome of every operation, even if
+// an error is returned.
+func (a *Applier) Apply(ctx context.Context, operations []Operation) (*ApplyReport, error) {
+	report := &ApplyReport{StartedAt: time.Now()}
+	defer func() { report.FinishedAt = time.Now() }()
+	checked, err := a.CheckPolicies(ctx, operations)
+	if err == nil {
+		operations = checked
+	}
+	for _, operation := range operations {
+		report.Results = append(report.Results, OperationResult{
+			Operation: operation,
+			Status:    OperationSkipped,
+		})
+	}
+	if err != nil {
+		report.Error = err.Error()
+		return report, err
+	}
+
+	for i := range report.Results {
+		result := &report.Results[i]
+		err := a.apply(ctx, result)
+		if err == nil {
+			result.Status = OperationApplied
+			continue
+		}
+		result.Status, result.Error = OperationFailed, err.Error()
+		err = fmt.Errorf("%s %s: %w", result.Operation.Action, result.Operation.endpoint(), err)
+		report.Error = err.Error()
+		if a.rollback {
+			report.RolledBack = a.revert(ctx, report.Results[:i])
+		}
+		return report, err
+	}
+	report.Succeeded = true
+	return report, nil
+}
+
+func (a *Applier) apply(ctx context.Context, result *OperationResult) error {
+	operation := result.Operation
+	var method string
+	switch operation.Action {
+	case MutationCreate:
+		method = http.MethodPost
+		if operation.ID != "" {
+			method = http.MethodPut
+			// PUT replaces the entity if it exists
+			if err := a.snapshot(ctx, result); err != nil && !IsNotFoundErr(err) {
+				return err
+			}
+		}
+	case MutationUpdate, MutationDelete:
+		if operation.ID == "" {
+			return fmt.Errorf("ID cannot be empty for %s operation", operation.Action)
+		}
+		method = http.MethodPatch
+		if operation.Action == MutationDelete {
+			method = http.MethodDelete
+		}
+		if err := a.snapshot(ctx, result); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action: %q", operation.Action)
+	}
+
+	var body interface{}
+	if operation.Action != MutationDelete {
+		body = operation.Entity
+	}
+	req, err := a.client.NewRequest(method, operation.endpoint(), nil, body)
+	if err != nil {
+		return err
+	}
+	var after json.RawMessage
+	if _, err := a.client.Do(ctx, req, &after); err != nil {
+		return err
+	}
+	if operation.Action != MutationDelete {
+		result.After = after
+	}
+	return nil
+}
+
+// snapshot fetches the entity of result before it's changed, so that the
+// change can be reverted.
+func (a *Applier) snapshot(ctx context.Context, result *OperationResult) error {
+	req, err := a.client.NewRequest(http.MethodGet, result.Operation.endpoint(), nil, nil)
+	if err != nil {
+		return err
+	}
+	var entity map[string]interface{}
+	if _, err := a.client.Do(ctx, req, &entity); err != nil {
+		return fmt.Errorf("fetching entity before change: %w", err)
+	}
+	// timestamps are set by Kong
+	delete(entity, "created_at")
+	delete(entity, "updated_at")
+	result.Before, err = json.Marshal(entity)
+	return err
+}
+
+// revert reverts applied operations in reverse order and returns true if
+// all of them were reverted.
+func (a *Applier) revert(ctx context.Context, applied []OperationResult) bool {
+	// revert the changes even if they failed because ctx is done
+	ctx = detach(ctx)
+	ok := true
+	for i := len(applied) - 1; i >= 0; i-- {
+		result := &applied[i]
+		if err := a.revertOne(ctx, result); err != nil {
+			result.Status, result.Error = OperationRollbackFailed, err.Error()
+			ok = false
+			continue
+		}
+		result.Status = OperationRolledBack
+	}
+	return ok
+}
+
+func (a *Applier) revertOne(ctx context.Context, result *OperationResult) error {
+	operation := result.Operation
+	endpoint := operation.endpoint()
+	method := http.MethodPut
+	var body interface{} = result.Before
+	if operation.Action == MutationCreate && result.Before == nil {
+		method, body = http.MethodDelete, nil
+		if operation.ID == "" {
+			var created struct {
+				ID *string `json:"id"`
+			}
+			if err := json.Unmarshal(result.After, &created); err != nil || isEmptyString(created.ID) {
+				return fmt.Errorf("ID of created entity unknown")
+			}
+			endpoint += "/" + *created.ID
+		}
+	}
+	req, err := a.client.NewRequest(method, endpoint, nil, body)
+	if err != nil {
+		return err
+	}
+	_, err = a.client.Do(ctx, req, nil)
+	return err
+}
+
+// detachedContext keeps the values of a context, but not its deadline
+// and cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+// detach returns a detachedContext of ctx, which may be nil.
+func detach(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return detachedContext{ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (c detachedContext) Value(key interface{}) interface{} { return c.parent.Value(key) }
@@ -0,0 +1,124 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplierApply(t *testing.T) {
+	var requests []string
+	bodies := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		requests = append(requests, request)
+		b, _ := io.ReadAll(r.Body)
+		bodies[request] = string(b)
+		switch request {
+		case "POST /services":
+			_, _ = w.Write([]byte(`{"id":"s1","host":"new"}`))
+		case "GET /services/b":
+			_, _ = w.Write([]byte(`{"id":"b","host":"old","created_at":1,"updated_at":2}`))
+		case "GET /routes/r":
+			_, _ = w.Write([]byte(`{"id":"r","paths":["/r"]}`))
+		case "POST /plugins":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"schema violation"}`))
+		case "GET /consumers/c":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	applier := NewApplier(client)
+
+	operations := []Operation{
+		{Action: MutationCreate, EntityType: "services", Entity: &Service{Host: String("new")}},
+		{Action: MutationUpdate, EntityType: "services", ID: "b", Entity: &Service{Host: String("changed")}},
+		{Action: MutationDelete, EntityType: "routes", ID: "r"},
+		{Action: MutationCreate, EntityType: "consumers", ID: "c", Entity: &Consumer{}},
+		{Action: MutationCreate, EntityType: "plugins", Entity: &Plugin{Name: String("nope")}},
+		{Action: MutationDelete, EntityType: "services", ID: "s1"},
+	}
+	report, err := applier.Apply(defaultCtx, operations)
+	require.Error(t, err)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Code())
+	assert.Contains(t, err.Error(), "create /plugins")
+	assert.False(t, report.Succeeded)
+	assert.True(t, report.RolledBack)
+	var statuses []OperationStatus
+	for _, result := range report.Results {
+		statuses = append(statuses, result.Status)
+	}
+	assert.Equal(t, []OperationStatus{
+		OperationRolledBack, OperationRolledBack, OperationRolledBack, OperationRolledBack,
+		OperationFailed, OperationSkipped,
+	}, statuses)
+	assert.JSONEq(t, `{"id":"b","host":"old"}`, string(report.Results[1].Before))
+	assert.JSONEq(t, `{"id":"s1","host":"new"}`, string(report.Results[0].After))
+	assert.Equal(t, []string{
+		"POST /services",
+		"GET /services/b",
+		"PATCH /services/b",
+		"GET /routes/r",
+		"DELETE /routes/r",
+		"GET /consumers/c",
+		"PUT /consumers/c",
+		"POST /plugins",
+		"DELETE /consumers/c",
+		"PUT /routes/r",
+		"PUT /services/b",
+		"DELETE /services/s1",
+	}, requests)
+	assert.JSONEq(t, `{"id":"b","host":"old"}`, bodies["PUT /services/b"])
+	assert.JSONEq(t, `{"id":"r","paths":["/r"]}`, bodies["PUT /routes/r"])
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"status":"rolled-back"`)
+
+	// without rollback, applied operations are left as they are
+	requests = nil
+	applier.SetRollback(false)
+	report, err = applier.Apply(defaultCtx, operations[:1:1])
+	require.NoError(t, err)
+	assert.True(t, report.Succeeded)
+	report, err = applier.Apply(defaultCtx, []Operation{operations[0], operations[4]})
+	require.Error(t, err)
+	assert.False(t, report.RolledBack)
+	assert.Equal(t, OperationApplied, report.Results[0].Status)
+	assert.Equal(t, []string{"POST /services", "POST /services", "POST /plugins"}, requests)
+
+	// rollback happens even if the context is canceled
+	requests = nil
+	applier.SetRollback(true)
+	ctx, cancel := context.WithCancel(defaultCtx)
+	client.SetMutationHook(func(context.Context, Mutation) { cancel() }, false)
+	report, err = applier.Apply(ctx, []Operation{operations[0], operations[4]})
+	require.Error(t, err)
+	assert.True(t, report.RolledBack)
+	assert.Equal(t, []string{"POST /services", "DELETE /services/s1"}, requests)
+
+	// rollback happens with a nil context
+	requests = nil
+	client.SetMutationHook(nil, false)
+	report, err = applier.Apply(nil, []Operation{operations[0], operations[4]}) //nolint:staticcheck
+	require.Error(t, err)
+	assert.True(t, report.RolledBack)
+	assert.Equal(t, []string{"POST /services", "POST /plugins", "DELETE /services/s1"}, requests)
+
+	_, err = applier.Apply(defaultCtx, []Operation{{Action: MutationUpdate, EntityType: "services"}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,215 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// AuditFindingKind is the kind of problem reported by Audit.
+type AuditFindingKind string
+
+const (
+	// AuditDanglingReference is reported for an entity referencing an
+	// entity which doesn't exist.
+	AuditDanglingReference AuditFindingKind = "dangling-reference"
+	// AuditUnreferenced is reported for an entity which is useless on its
+	// own and isn't referenced by any other entity.
+	AuditUnreferenced AuditFindingKind = "unreferenced"
+)
+
+// AuditFinding is a problem found by Audit.
+type AuditFinding struct {
+	Kind AuditFindingKind
+	// EntityType and EntityID identify the entity the problem was found
+	// on, e.g. "sni" and its ID.
+	EntityType string
+	EntityID   string
+	// ReferencedType and ReferencedID identify the missing entity for
+	// AuditDanglingReference findings.
+	ReferencedType string
+	ReferencedID   string
+	Message        string
+}
+
+// AuditReport holds the findings of Audit.
+type AuditReport struct {
+	Findings []AuditFinding
+}
+
+// Filter returns the findings of kind.
+func (r *AuditReport) Filter(kind AuditFindingKind) []AuditFinding {
+	var res []AuditFinding
+	for _, f := range r.Findings {
+		if f.Kind == kind {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+func (r *AuditReport) dangling(entityType string, entityID *string, refType string, refID *string) {
+	r.Findings = append(r.Findings, AuditFinding{
+		Kind:           AuditDanglingReference,
+		EntityType:     entityType,
+		EntityID:       derefString(entityID),
+		ReferencedType: refType,
+		ReferencedID:   derefString(refID),
+		Message: fmt.Sprintf("%s %s references %s %s which doesn't exist",
+			entityType, derefString(entityID), refType, derefString(refID)),
+	})
+}
+
+func (r *AuditReport) unreferenced(entityType string, entityID *string, message string) {
+	r.Findings = append(r.Findings, AuditFinding{
+		Kind:       AuditUnreferenced,
+		EntityType: entityType,
+		EntityID:   derefString(entityID),
+		Message:    fmt.Sprintf("%s %s %s", entityType, derefString(entityID), message),
+	})
+}
+
+type idSet map[string]bool
+
+func (s idSet) missing(ref *string) bool {
+	return ref != nil && !s[*ref]
+}
+
+// Audit scans the configuration of the Kong (and workspace) targeted by
+// client for references to entities which don't exist, such as SNIs
+// pointing at deleted certificates or plugins scoped to deleted
+// consumers, as well as for certificates and upstreams no entity refers
+// to. Targets are not checked: the Admin API only lists them under their
+// upstream, so targets of missing upstreams can't be found.
+//
+// Entities are listed one type at a time, so changes made to the
+// configuration while Audit runs can show up as findings.
+// Consumer groups are only checked if the Kong supports them.
+func Audit(ctx context.Context, client *Client) (*AuditReport, error) {
+	report := &AuditReport{}
+
+	services, err := client.Services.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing services: %w", err)
+	}
+	routes, err := client.Routes.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing routes: %w", err)
+	}
+	consumers, err := client.Consumers.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing consumers: %w", err)
+	}
+	checkConsumerGroups := true
+	consumerGroups, err := client.ConsumerGroups.ListAll(ctx)
+	if err != nil {
+		if !IsNotFoundErr(err) {
+			return nil, fmt.Errorf("listing consumer groups: %w", err)
+		}
+		checkConsumerGroups = false
+	}
+	plugins, err := client.Plugins.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins: %w", err)
+	}
+	certificates, err := client.Certificates.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing certificates: %w", err)
+	}
+	caCertificates, err := client.CACertificates.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing CA certificates: %w", err)
+	}
+	snis, err := client.SNIs.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing SNIs: %w", err)
+	}
+	upstreams, err := client.Upstreams.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing upstreams: %w", err)
+	}
+
+	serviceIDs, routeIDs, consumerIDs, consumerGroupIDs := idSet{}, idSet{}, idSet{}, idSet{}
+	certificateIDs, caCertificateIDs := idSet{}, idSet{}
+	for _, s := range services {
+		serviceIDs[*s.ID] = true
+	}
+	for _, r := range routes {
+		routeIDs[*r.ID] = true
+	}
+	for _, c := range consumers {
+		consumerIDs[*c.ID] = true
+	}
+	for _, cg := range consumerGroups {
+		consumerGroupIDs[*cg.ID] = true
+	}
+	for _, c := range certificates {
+		certificateIDs[*c.ID] = true
+	}
+	for _, c := range caCertificates {
+		caCertificateIDs[*c.ID] = true
+	}
+
+	referencedCertificates := idSet{}
+	referencedUpstreams := idSet{}
+	for _, s := range services {
+		if s.ClientCertificate != nil {
+			referencedCertificates[derefString(s.ClientCertificate.ID)] = true
+			if certificateIDs.missing(s.ClientCertificate.ID) {
+				report.dangling("service", s.ID, "certificate", s.ClientCertificate.ID)
+			}
+		}
+		for _, ca := range s.CACertificates {
+			if caCertificateIDs.missing(ca) {
+				report.dangling("service", s.ID, "ca_certificate", ca)
+			}
+		}
+		if s.Host != nil {
+			referencedUpstreams[*s.Host] = true
+		}
+	}
+	for _, r := range routes {
+		if r.Service != nil && serviceIDs.missing(r.Service.ID) {
+			report.dangling("route", r.ID, "service", r.Service.ID)
+		}
+	}
+	for _, p := range plugins {
+		if p.Service != nil && serviceIDs.missing(p.Service.ID) {
+			report.dangling("plugin", p.ID, "service", p.Service.ID)
+		}
+		if p.Route != nil && routeIDs.missing(p.Route.ID) {
+			report.dangling("plugin", p.ID, "route", p.Route.ID)
+		}
+		if p.Consumer != nil && consumerIDs.missing(p.Consumer.ID) {
+			report.dangling("plugin", p.ID, "consumer", p.Consumer.ID)
+		}
+		if checkConsumerGroups && p.ConsumerGroup != nil && consumerGroupIDs.missing(p.ConsumerGroup.ID) {
+			report.dangling("plugin", p.ID, "consumer_group", p.ConsumerGroup.ID)
+		}
+	}
+	for _, sni := range snis {
+		if sni.Certificate == nil {
+			continue
+		}
+		referencedCertificates[derefString(sni.Certificate.ID)] = true
+		if certificateIDs.missing(sni.Certificate.ID) {
+			report.dangling("sni", sni.ID, "certificate", sni.Certificate.ID)
+		}
+	}
+	for _, u := range upstreams {
+		if u.ClientCertificate != nil {
+			referencedCertificates[derefString(u.ClientCertificate.ID)] = true
+			if certificateIDs.missing(u.ClientCertificate.ID) {
+				report.dangling("upstream", u.ID, "certificate", u.ClientCertificate.ID)
+			}
+		}
+		if !referencedUpstreams[derefString(u.Name)] {
+			report.unreferenced("upstream", u.ID, "is not used as host by any service")
+		}
+	}
+	for _, c := range certificates {
+		if !referencedCertificates[*c.ID] {
+			report.unreferenced("certificate", c.ID, "is not referenced by any SNI, service or upstream")
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,62 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	responses := map[string]string{
+		"/services": `{"data":[
+			{"id":"s1","host":"up1","client_certificate":{"id":"gone-cert"}},
+			{"id":"s2","host":"example.com","ca_certificates":["ca1","gone-ca"]}
+		]}`,
+		"/routes":          `{"data":[{"id":"r1","service":{"id":"s1"}},{"id":"r2","service":{"id":"gone-service"}}]}`,
+		"/consumers":       `{"data":[{"id":"c1"}]}`,
+		"/plugins":         `{"data":[{"id":"p1","consumer":{"id":"gone-consumer"}},{"id":"p2","consumer_group":{"id":"gone-cg"}},{"id":"p3","route":{"id":"r1"}}]}`,
+		"/certificates":    `{"data":[{"id":"cert1"},{"id":"cert2"}]}`,
+		"/ca_certificates": `{"data":[{"id":"ca1"}]}`,
+		"/snis":            `{"data":[{"id":"sni1","certificate":{"id":"cert1"}},{"id":"sni2","certificate":{"id":"gone-cert2"}}]}`,
+		"/upstreams":       `{"data":[{"id":"u1","name":"up1"},{"id":"u2","name":"up2"}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			// consumer groups are not supported
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	report, err := Audit(defaultCtx, client)
+	require.NoError(t, err)
+
+	var dangling []string
+	for _, f := range report.Filter(AuditDanglingReference) {
+		dangling = append(dangling, f.EntityType+" "+f.EntityID+" -> "+f.ReferencedType+" "+f.ReferencedID)
+	}
+	assert.Equal(t, []string{
+		"service s1 -> certificate gone-cert",
+		"service s2 -> ca_certificate gone-ca",
+		"route r2 -> service gone-service",
+		"plugin p1 -> consumer gone-consumer",
+		"sni sni2 -> certificate gone-cert2",
+	}, dangling)
+
+	var unreferenced []string
+	for _, f := range report.Filter(AuditUnreferenced) {
+		unreferenced = append(unreferenced, f.EntityType+" "+f.EntityID)
+	}
+	assert.Equal(t, []string{"upstream u2", "certificate cert2"}, unreferenced)
+	assert.Equal(t, "sni sni2 references certificate gone-cert2 which doesn't exist",
+		report.Filter(AuditDanglingReference)[4].Message)
+}
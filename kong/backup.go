@@ -0,0 +1,261 @@
+package kong
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// BackupFormatVersion is the version of the archives written by Backup.
+// Restore rejects archives with a newer version.
+const BackupFormatVersion = 1
+
+// BackupArchive is the content of an archive written by Backup.
+type BackupArchive struct {
+	Version int `json:"version"`
+	// Workspace is the workspace the entities were read from, if any.
+	Workspace string `json:"workspace,omitempty"`
+	// Tags are the tags the entities were filtered on, if any.
+	Tags []string `json:"tags,omitempty"`
+
+	Certificates   []*Certificate   `json:"certificates,omitempty"`
+	SNIs           []*SNI           `json:"snis,omitempty"`
+	CACertificates []*CACertificate `json:"ca_certificates,omitempty"`
+	Services       []*Service       `json:"services,omitempty"`
+	Routes         []*Route         `json:"routes,omitempty"`
+	Upstreams      []*Upstream      `json:"upstreams,omitempty"`
+	Targets        []*Target        `json:"targets,omitempty"`
+	Consumers      []*Consumer      `json:"consumers,omitempty"`
+	ConsumerGroups []*ConsumerGroup `json:"consumer_groups,omitempty"`
+	Plugins        []*Plugin        `json:"plugins,omitempty"`
+}
+
+// BackupOpts configures Backup.
+type BackupOpts struct {
+	// Tags, if set, restricts the backup to entities with any of the tags.
+	Tags []string
+	// MatchAllTags restricts the backup to entities with all of Tags.
+	MatchAllTags bool
+	// Format is the format of the backup, DocumentFormatJSON, the default,
+	// or DocumentFormatNDJSON to write it as StreamBackup does.
+	Format DocumentFormat
+	// Redactor, if set, redacts sensitive fields, e.g. to share the
+	// backup. Redacted backups can't be restored as they are.
+	Redactor *Redactor
+	// PruneDefaults omits the fields set to their defaults, see
+	// PruneDefaults, for minimal, reviewable backups.
+	PruneDefaults bool
+}
+
+// Backup writes the entities of the Kong targeted by c to w as a JSON
+// BackupArchive. Only the workspace set on c is backed up.
+//
+// Certificates, SNIs, CA certificates, Services, Routes, Upstreams,
+// Targets, Consumers, Consumer Groups (if supported by Kong) and Plugins
+// are backed up. Consumer credentials and Consumer Group memberships are
+// not. Entities are held in memory, see StreamBackup for large gateways.
+func (c *Client) Backup(ctx context.Context, w io.Writer, opts BackupOpts) error {
+	switch opts.Format {
+	case "", DocumentFormatJSON:
+	case DocumentFormatNDJSON:
+		return c.StreamBackup(ctx, w, StreamBackupOpts{
+			Format:        DocumentFormatNDJSON,
+			Tags:          opts.Tags,
+			MatchAllTags:  opts.MatchAllTags,
+			Redactor:      opts.Redactor,
+			PruneDefaults: opts.PruneDefaults,
+		})
+	default:
+		return fmt.Errorf("unsupported format: %q", opts.Format)
+	}
+	archive := BackupArchive{
+		Version:   BackupFormatVersion,
+		Workspace: c.contextWorkspace(ctx),
+		Tags:      opts.Tags,
+	}
+	tags := StringSlice(opts.Tags...)
+	list := func(endpoint string, entities interface{}) error {
+		var all []json.RawMessage
+		opt := &ListOpt{Size: pageSize, Tags: tags, MatchAllTags: opts.MatchAllTags}
+		for opt != nil {
+			data, next, err := c.list(ctx, endpoint, opt)
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", endpoint, err)
+			}
+			all = append(all, data...)
+			opt = next
+		}
+		b, err := json.Marshal(all)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(b, entities)
+	}
+
+	for _, l := range []struct {
+		endpoint string
+		entities interface{}
+	}{
+		{"/certificates", &archive.Certificates},
+		{"/snis", &archive.SNIs},
+		{"/ca_certificates", &archive.CACertificates},
+		{"/services", &archive.Services},
+		{"/routes", &archive.Routes},
+		{"/upstreams", &archive.Upstreams},
+		{"/consumers", &archive.Consumers},
+		{"/plugins", &archive.Plugins},
+	} {
+		if err := list(l.endpoint, l.entities); err != nil {
+			return err
+		}
+	}
+	if err := list("/consumer_groups", &archive.ConsumerGroups); err != nil && !IsNotFoundErr(err) {
+		return err
+	}
+	for _, upstream := range archive.Upstreams {
+		var targets []*Target
+		if err := list("/upstreams/"+*upstream.ID+"/targets", &targets); err != nil {
+			return err
+		}
+		archive.Targets = append(archive.Targets, targets...)
+	}
+	// SNIs are backed up as entities of their own, restoring them
+	// along with the certificates would create them twice.
+	for _, certificate := range archive.Certificates {
+		certificate.SNIs = nil
+	}
+
+	res := &archive
+	if opts.PruneDefaults {
+		var err error
+		if res, err = newDefaultsPruner(c).pruneArchive(ctx, res); err != nil {
+			return err
+		}
+	}
+	if opts.Redactor != nil {
+		var err error
+		if res, err = opts.Redactor.RedactArchive(res); err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(res)
+}
+
+// Restore recreates the entities of an archive written by Backup in the
+// Kong (and workspace) targeted by c, in dependency order. Entities keep
+// their IDs, so references between them are preserved and existing
+// entities with the same IDs are overwritten, targets included.
+//
+// Restore stops at the first error, leaving the entities restored so far
+// in place. Certificates are validated as with CertificateService.Create.
+//
+// Streams written by StreamBackup, or by Backup as newline-delimited
+// JSON, are restored with RestoreStream.
+func (c *Client) Restore(ctx context.Context, r io.Reader) error {
+	ctx = withoutIdempotencyKey(ctx)
+	br := bufio.NewReader(r)
+	if start, _ := br.Peek(3); string(start) == "---" {
+		return c.RestoreStream(ctx, br)
+	}
+	dec := json.NewDecoder(br)
+	var first json.RawMessage
+	if err := dec.Decode(&first); err != nil {
+		return fmt.Errorf("decoding backup archive: %w", err)
+	}
+	if dec.More() {
+		// a header followed by records
+		return c.restoreStream(ctx, func() ([]byte, error) {
+			if first != nil {
+				data := first
+				first = nil
+				return data, nil
+			}
+			var data json.RawMessage
+			err := dec.Decode(&data)
+			return data, err
+		})
+	}
+	var archive BackupArchive
+	if err := json.Unmarshal(first, &archive); err != nil {
+		return fmt.Errorf("decoding backup archive: %w", err)
+	}
+	if archive.Version < 1 || archive.Version > BackupFormatVersion {
+		return fmt.Errorf("unsupported backup archive version %d", archive.Version)
+	}
+
+	for _, certificate := range archive.Certificates {
+		if _, err := c.Certificates.Create(ctx, certificate); err != nil {
+			return fmt.Errorf("restoring certificate %q: %w", certificate.FriendlyName(), err)
+		}
+	}
+	for _, sni := range archive.SNIs {
+		if _, err := c.SNIs.Create(ctx, sni); err != nil {
+			return fmt.Errorf("restoring SNI %q: %w", sni.FriendlyName(), err)
+		}
+	}
+	for _, caCertificate := range archive.CACertificates {
+		if _, err := c.CACertificates.Create(ctx, caCertificate); err != nil {
+			return fmt.Errorf("restoring CA certificate %q: %w", caCertificate.FriendlyName(), err)
+		}
+	}
+	for _, service := range archive.Services {
+		if _, err := c.Services.Create(ctx, service); err != nil {
+			return fmt.Errorf("restoring service %q: %w", service.FriendlyName(), err)
+		}
+	}
+	for _, route := range archive.Routes {
+		if _, err := c.Routes.Create(ctx, route); err != nil {
+			return fmt.Errorf("restoring route %q: %w", route.FriendlyName(), err)
+		}
+	}
+	for _, upstream := range archive.Upstreams {
+		if _, err := c.Upstreams.Create(ctx, upstream); err != nil {
+			return fmt.Errorf("restoring upstream %q: %w", upstream.FriendlyName(), err)
+		}
+	}
+	for _, target := range archive.Targets {
+		if target.Upstream == nil || isEmptyString(target.Upstream.ID) {
+			return fmt.Errorf("restoring target %q: missing upstream", target.FriendlyName())
+		}
+		if err := c.restoreTarget(ctx, target); err != nil {
+			return fmt.Errorf("restoring target %q: %w", target.FriendlyName(), err)
+		}
+	}
+	for _, consumer := range archive.Consumers {
+		if _, err := c.Consumers.Create(ctx, consumer); err != nil {
+			return fmt.Errorf("restoring consumer %q: %w", consumer.FriendlyName(), err)
+		}
+	}
+	for _, consumerGroup := range archive.ConsumerGroups {
+		if _, err := c.ConsumerGroups.Create(ctx, consumerGroup); err != nil {
+			return fmt.Errorf("restoring consumer group %q: %w", consumerGroup.FriendlyName(), err)
+		}
+	}
+	for _, plugin := range archive.Plugins {
+		if _, err := c.Plugins.Create(ctx, plugin); err != nil {
+			return fmt.Errorf("restoring plugin %q: %w", plugin.FriendlyName(), err)
+		}
+	}
+	return nil
+}
+
+// restoreTarget upserts target under its upstream. TargetService.Create
+// always POSTs, which duplicates targets already in Kong.
+func (c *Client) restoreTarget(ctx context.Context, target *Target) error {
+	if isEmptyString(target.ID) {
+		_, err := c.Targets.Create(ctx, target.Upstream.ID, target)
+		return err
+	}
+	endpoint := fmt.Sprintf("/upstreams/%v/targets/%v", *target.Upstream.ID, *target.ID)
+	req, err := c.NewRequest("PUT", endpoint, nil, target)
+	if err != nil {
+		return err
+	}
+	_, err = c.Do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,313 @@
+package kong
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+)
+
+// StreamBackupOpts configures StreamBackup.
+type StreamBackupOpts struct {
+	// Format is the format of the stream, DocumentFormatNDJSON, the
+	// default, or DocumentFormatYAML for a stream of YAML documents.
+	Format DocumentFormat
+	// Tags, if set, restricts the backup to entities with any of the tags.
+	Tags []string
+	// MatchAllTags restricts the backup to entities with all of Tags.
+	MatchAllTags bool
+	// Redactor, if set, redacts sensitive fields, see BackupOpts.
+	Redactor *Redactor
+	// PruneDefaults omits the fields set to their defaults, see
+	// PruneDefaults.
+	PruneDefaults bool
+}
+
+// BackupStreamHeader is the first record of a stream written by
+// StreamBackup.
+type BackupStreamHeader struct {
+	Version   int      `json:"version"`
+	Workspace string   `json:"workspace,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// StreamBackup writes the same entities as Backup to w, but one record per
+// entity as pages are listed rather than a single archive, so that its
+// memory use doesn't grow with the number of entities. Only the IDs of
+// Upstreams are kept, to list their Targets.
+//
+// The stream starts with a BackupStreamHeader, followed by EntityRecords,
+// typed as the fields of BackupArchive, in dependency order. Use
+// RestoreStream to restore it.
+func (c *Client) StreamBackup(ctx context.Context, w io.Writer, opts StreamBackupOpts) error {
+	format := opts.Format
+	if format == "" {
+		format = DocumentFormatNDJSON
+	}
+	if format != DocumentFormatNDJSON && format != DocumentFormatYAML {
+		return fmt.Errorf("unsupported format: %q", format)
+	}
+	write := func(record interface{}) error {
+		if format == DocumentFormatNDJSON {
+			return writeNDJSON(w, record)
+		}
+		b, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if b, err = yaml.JSONToYAML(b); err != nil {
+			return err
+		}
+		_, err = w.Write(append([]byte("---\n"), b...))
+		return err
+	}
+	if err := write(BackupStreamHeader{
+		Version:   BackupFormatVersion,
+		Workspace: c.contextWorkspace(ctx),
+		Tags:      opts.Tags,
+	}); err != nil {
+		return err
+	}
+
+	tags := StringSlice(opts.Tags...)
+	pruner := newDefaultsPruner(c)
+	var upstreamIDs []string
+	stream := func(entityType, endpoint string) error {
+		opt := &ListOpt{Size: pageSize, Tags: tags, MatchAllTags: opts.MatchAllTags}
+		for opt != nil {
+			data, next, err := c.list(ctx, endpoint, opt)
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", endpoint, err)
+			}
+			for _, entity := range data {
+				switch entityType {
+				case "certificates":
+					// SNIs are backed up as entities of their own, see Backup.
+					var certificate map[string]interface{}
+					if err := json.Unmarshal(entity, &certificate); err != nil {
+						return err
+					}
+					delete(certificate, "snis")
+					if entity, err = json.Marshal(certificate); err != nil {
+						return err
+					}
+				case "upstreams":
+					var upstream Upstream
+					if err := json.Unmarshal(entity, &upstream); err != nil {
+						return err
+					}
+					upstreamIDs = append(upstreamIDs, derefString(upstream.ID))
+				}
+				if opts.PruneDefaults {
+					if entity, err = pruner.prune(ctx, entityType, entity); err != nil {
+						return err
+					}
+				}
+				if opts.Redactor != nil {
+					if entity, err = opts.Redactor.Redact(entityType, entity); err != nil {
+						return err
+					}
+				}
+				if err := write(EntityRecord{Type: entityType, Entity: entity}); err != nil {
+					return err
+				}
+			}
+			opt = next
+		}
+		return nil
+	}
+
+	for _, l := range []struct {
+		entityType string
+		endpoint   string
+	}{
+		{"certificates", "/certificates"},
+		{"snis", "/snis"},
+		{"ca_certificates", "/ca_certificates"},
+		{"services", "/services"},
+		{"routes", "/routes"},
+		{"upstreams", "/upstreams"},
+	} {
+		if err := stream(l.entityType, l.endpoint); err != nil {
+			return err
+		}
+	}
+	for _, id := range upstreamIDs {
+		if err := stream("targets", "/upstreams/"+id+"/targets"); err != nil {
+			return err
+		}
+	}
+	if err := stream("consumers", "/consumers"); err != nil {
+		return err
+	}
+	if err := stream("consumer_groups", "/consumer_groups"); err != nil && !IsNotFoundErr(err) {
+		return err
+	}
+	return stream("plugins", "/plugins")
+}
+
+// RestoreStream recreates the entities of a stream written by StreamBackup,
+// in either format, like Restore, reading one entity at a time.
+func (c *Client) RestoreStream(ctx context.Context, r io.Reader) error {
+	return c.restoreStream(ctx, streamDecoder(r))
+}
+
+// restoreStream restores the records returned by next, until io.EOF.
+func (c *Client) restoreStream(ctx context.Context, next func() ([]byte, error)) error {
+	ctx = withoutIdempotencyKey(ctx)
+	var header BackupStreamHeader
+	data, err := next()
+	if err == io.EOF {
+		return fmt.Errorf("decoding backup stream: empty stream")
+	}
+	if err != nil {
+		return fmt.Errorf("decoding backup stream: %w", err)
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("decoding backup stream header: %w", err)
+	}
+	if header.Version < 1 || header.Version > BackupFormatVersion {
+		return fmt.Errorf("unsupported backup archive version %d", header.Version)
+	}
+	for {
+		data, err := next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decoding backup stream: %w", err)
+		}
+		var record EntityRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("decoding backup stream record: %w", err)
+		}
+		if err := c.restoreRecord(ctx, record); err != nil {
+			return err
+		}
+	}
+}
+
+// streamDecoder returns a function returning the records of r as JSON,
+// until io.EOF. Streams starting with "---" are read as YAML documents.
+func streamDecoder(r io.Reader) func() ([]byte, error) {
+	br := bufio.NewReader(r)
+	start, _ := br.Peek(3)
+	if string(start) != "---" {
+		dec := json.NewDecoder(br)
+		return func() ([]byte, error) {
+			var data json.RawMessage
+			err := dec.Decode(&data)
+			return data, err
+		}
+	}
+	var document bytes.Buffer
+	done := false
+	return func() ([]byte, error) {
+		for !done {
+			line, err := br.ReadBytes('\n')
+			if err == io.EOF {
+				done = true
+			} else if err != nil {
+				return nil, err
+			}
+			if bytes.Equal(bytes.TrimRight(line, "\r\n"), []byte("---")) {
+				if document.Len() == 0 {
+					continue
+				}
+				b, err := yaml.YAMLToJSON(document.Bytes())
+				document.Reset()
+				return b, err
+			}
+			document.Write(line)
+		}
+		if len(bytes.TrimSpace(document.Bytes())) == 0 {
+			return nil, io.EOF
+		}
+		b, err := yaml.YAMLToJSON(document.Bytes())
+		document.Reset()
+		return b, err
+	}
+}
+
+func (c *Client) restoreRecord(ctx context.Context, record EntityRecord) error {
+	var err error
+	var name string
+	switch record.Type {
+	case "certificates":
+		var certificate Certificate
+		if err = json.Unmarshal(record.Entity, &certificate); err == nil {
+			name = "certificate " + certificate.FriendlyName()
+			_, err = c.Certificates.Create(ctx, &certificate)
+		}
+	case "snis":
+		var sni SNI
+		if err = json.Unmarshal(record.Entity, &sni); err == nil {
+			name = "SNI " + sni.FriendlyName()
+			_, err = c.SNIs.Create(ctx, &sni)
+		}
+	case "ca_certificates":
+		var caCertificate CACertificate
+		if err = json.Unmarshal(record.Entity, &caCertificate); err == nil {
+			name = "CA certificate " + caCertificate.FriendlyName()
+			_, err = c.CACertificates.Create(ctx, &caCertificate)
+		}
+	case "services":
+		var service Service
+		if err = json.Unmarshal(record.Entity, &service); err == nil {
+			name = "service " + service.FriendlyName()
+			_, err = c.Services.Create(ctx, &service)
+		}
+	case "routes":
+		var route Route
+		if err = json.Unmarshal(record.Entity, &route); err == nil {
+			name = "route " + route.FriendlyName()
+			_, err = c.Routes.Create(ctx, &route)
+		}
+	case "upstreams":
+		var upstream Upstream
+		if err = json.Unmarshal(record.Entity, &upstream); err == nil {
+			name = "upstream " + upstream.FriendlyName()
+			_, err = c.Upstreams.Create(ctx, &upstream)
+		}
+	case "targets":
+		var target Target
+		if err = json.Unmarshal(record.Entity, &target); err == nil {
+			name = "target " + target.FriendlyName()
+			if target.Upstream == nil || isEmptyString(target.Upstream.ID) {
+				return fmt.Errorf("restoring %s: missing upstream", name)
+			}
+			err = c.restoreTarget(ctx, &target)
+		}
+	case "consumers":
+		var consumer Consumer
+		if err = json.Unmarshal(record.Entity, &consumer); err == nil {
+			name = "consumer " + consumer.FriendlyName()
+			_, err = c.Consumers.Create(ctx, &consumer)
+		}
+	case "consumer_groups":
+		var consumerGroup ConsumerGroup
+		if err = json.Unmarshal(record.Entity, &consumerGroup); err == nil {
+			name = "consumer group " + consumerGroup.FriendlyName()
+			_, err = c.ConsumerGroups.Create(ctx, &consumerGroup)
+		}
+	case "plugins":
+		var plugin Plugin
+		if err = json.Unmarshal(record.Entity, &plugin); err == nil {
+			name = "plugin " + plugin.FriendlyName()
+			_, err = c.Plugins.Create(ctx, &plugin)
+		}
+	default:
+		return fmt.Errorf("unknown entity type in backup stream: %q", record.Type)
+	}
+	if name == "" {
+		return fmt.Errorf("decoding %s: %w", record.Type, err)
+	}
+	if err != nil {
+		return fmt.Errorf("restoring %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamBackupRestoreStream(t *testing.T) {
+	defer func(size int) { pageSize = size }(pageSize)
+	pageSize = 1
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path + "?" + r.URL.Query().Get("offset") {
+		case "/certificates?":
+			_, _ = w.Write([]byte(`{"data":[{"id":"cert1","cert":"a\nb","key":"k","snis":["example.com"]}]}`))
+		case "/services?":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s1","name":"one"}],"offset":"page2"}`))
+		case "/services?page2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s2","name":"two"}]}`))
+		case "/upstreams?":
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","name":"up1"}]}`))
+		case "/upstreams/u1/targets?":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","target":"a:80","upstream":{"id":"u1"}}]}`))
+		case "/plugins?":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"cors","service":{"id":"s1"}}]}`))
+		case "/consumer_groups?":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer source.Close()
+	src, err := NewClient(String(source.URL), nil)
+	require.NoError(t, err)
+
+	var restored []string
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restored = append(restored, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer destination.Close()
+	dst, err := NewClient(String(destination.URL), nil)
+	require.NoError(t, err)
+	dst.SetSkipCertificateValidation(true)
+	expected := []string{
+		"PUT /certificates/cert1",
+		"PUT /services/s1",
+		"PUT /services/s2",
+		"PUT /upstreams/u1",
+		"PUT /upstreams/u1/targets/t1",
+		"PUT /plugins/p1",
+	}
+
+	t.Run("newline-delimited JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, src.StreamBackup(defaultCtx, &buf, StreamBackupOpts{}))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 7)
+		assert.JSONEq(t, `{"version":1}`, lines[0])
+		assert.JSONEq(t, `{"type":"certificates","entity":{"id":"cert1","cert":"a\nb","key":"k"}}`, lines[1])
+		var record EntityRecord
+		require.NoError(t, json.Unmarshal([]byte(lines[5]), &record))
+		assert.Equal(t, "targets", record.Type)
+
+		restored = nil
+		require.NoError(t, dst.RestoreStream(defaultCtx, &buf))
+		assert.Equal(t, expected, restored)
+	})
+	t.Run("newline-delimited JSON with Backup and Restore", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, src.Backup(defaultCtx, &buf, BackupOpts{Format: DocumentFormatNDJSON}))
+		assert.Equal(t, 7, strings.Count(buf.String(), "\n"))
+
+		restored = nil
+		require.NoError(t, dst.Restore(defaultCtx, &buf))
+		assert.Equal(t, expected, restored)
+	})
+	t.Run("YAML", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, src.StreamBackup(defaultCtx, &buf, StreamBackupOpts{Format: DocumentFormatYAML}))
+		assert.True(t, strings.HasPrefix(buf.String(), "---\nversion: 1\n---\n"))
+		assert.Equal(t, 7, strings.Count(buf.String(), "---\n"))
+
+		restored = nil
+		require.NoError(t, dst.Restore(defaultCtx, &buf))
+		assert.Equal(t, expected, restored)
+	})
+	t.Run("redacted", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, src.Backup(defaultCtx, &buf, BackupOpts{Redactor: NewRedactor()}))
+		assert.Contains(t, buf.String(), `"key": "[REDACTED]"`)
+		buf.Reset()
+		require.NoError(t, src.StreamBackup(defaultCtx, &buf, StreamBackupOpts{Redactor: NewRedactor()}))
+		assert.Contains(t, buf.String(), `{"type":"certificates","entity":{"cert":"a\nb","id":"cert1","key":"[REDACTED]"}}`)
+	})
+	t.Run("errors", func(t *testing.T) {
+		err := src.StreamBackup(defaultCtx, &bytes.Buffer{}, StreamBackupOpts{Format: DocumentFormatJSON})
+		assert.EqualError(t, err, `unsupported format: "json"`)
+		err = src.Backup(defaultCtx, &bytes.Buffer{}, BackupOpts{Format: DocumentFormatYAML})
+		assert.EqualError(t, err, `unsupported format: "yaml"`)
+		err = dst.RestoreStream(defaultCtx, strings.NewReader(`{"version":42}`))
+		assert.EqualError(t, err, "unsupported backup archive version 42")
+		err = dst.RestoreStream(defaultCtx, strings.NewReader(""))
+		assert.EqualError(t, err, "decoding backup stream: empty stream")
+		err = dst.RestoreStream(defaultCtx, strings.NewReader(`{"version":1}`+"\n"+`{"type":"nope","entity":{}}`))
+		assert.EqualError(t, err, `unknown entity type in backup stream: "nope"`)
+	})
+}
@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestore(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "foo/bar", r.URL.Query().Get("tags"))
+		switch r.URL.Path {
+		case "/staging/certificates":
+			_, _ = w.Write([]byte(`{"data":[{"id":"cert1","snis":["example.com"]}]}`))
+		case "/staging/snis":
+			_, _ = w.Write([]byte(`{"data":[{"id":"sni1","name":"example.com","certificate":{"id":"cert1"}}]}`))
+		case "/staging/services":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s1","name":"svc","host":"up1"}]}`))
+		case "/staging/routes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","service":{"id":"s1"}}]}`))
+		case "/staging/upstreams":
+			_, _ = w.Write([]byte(`{"data":[{"id":"u1","name":"up1"}]}`))
+		case "/staging/upstreams/u1/targets":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","target":"a:80","upstream":{"id":"u1"}}]}`))
+		case "/staging/plugins":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"cors","route":{"id":"r1"}}]}`))
+		case "/staging/consumer_groups":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer source.Close()
+	src, err := NewClient(String(source.URL), nil)
+	require.NoError(t, err)
+	src.SetWorkspace("staging")
+
+	var buf bytes.Buffer
+	require.NoError(t, src.Backup(defaultCtx, &buf, BackupOpts{Tags: []string{"foo", "bar"}}))
+
+	var archive BackupArchive
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+	assert.Equal(t, BackupFormatVersion, archive.Version)
+	assert.Equal(t, "staging", archive.Workspace)
+	assert.Nil(t, archive.Certificates[0].SNIs)
+	require.Len(t, archive.Targets, 1)
+
+	var restored []string
+	destination := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		restored = append(restored, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer destination.Close()
+	dst, err := NewClient(String(destination.URL), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, dst.Restore(defaultCtx, &buf))
+	assert.Equal(t, []string{
+		"PUT /certificates/cert1",
+		"PUT /snis/sni1",
+		"PUT /services/s1",
+		"PUT /routes/r1",
+		"PUT /upstreams/u1",
+		"PUT /upstreams/u1/targets/t1",
+		"PUT /plugins/p1",
+	}, restored)
+
+	err = dst.Restore(defaultCtx, strings.NewReader(`{"version":42}`))
+	assert.EqualError(t, err, "unsupported backup archive version 42")
+}
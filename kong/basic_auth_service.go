@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractBasicAuthService handles basic-auth credentials in Kong.
@@ -42,7 +41,7 @@ func (s *BasicAuthService) Create(ctx context.Context,
 	}
 
 	var createdBasicAuth BasicAuth
-	err = json.Unmarshal(cred, &createdBasicAuth)
+	err = s.client.decode(cred, &createdBasicAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +60,7 @@ func (s *BasicAuthService) Get(ctx context.Context,
 	}
 
 	var basicAuth BasicAuth
-	err = json.Unmarshal(cred, &basicAuth)
+	err = s.client.decode(cred, &basicAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +79,7 @@ func (s *BasicAuthService) Update(ctx context.Context,
 	}
 
 	var updatedBasicAuth BasicAuth
-	err = json.Unmarshal(cred, &updatedBasicAuth)
+	err = s.client.decode(cred, &updatedBasicAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +111,7 @@ func (s *BasicAuthService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var basicAuth BasicAuth
-		err = json.Unmarshal(b, &basicAuth)
+		err = s.client.decode(b, &basicAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -158,7 +157,7 @@ func (s *BasicAuthService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var basicAuth BasicAuth
-		err = json.Unmarshal(b, &basicAuth)
+		err = s.client.decode(b, &basicAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2,8 +2,12 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"strings"
 )
 
 // AbstractCACertificateService handles Certificates in Kong.
@@ -20,6 +24,10 @@ type AbstractCACertificateService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*CACertificate, *ListOpt, error)
 	// ListAll fetches all Certificates in Kong.
 	ListAll(ctx context.Context) ([]*CACertificate, error)
+	// DeleteWhere deletes the CACertificates in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*CACertificate) bool) ([]*CACertificate, error)
+	// GetByDigest fetches a CACertificate in Kong by its SHA-256 digest.
+	GetByDigest(ctx context.Context, digest *string) (*CACertificate, error)
 }
 
 // CACertificateService handles Certificates in Kong.
@@ -129,7 +137,7 @@ func (s *CACertificateService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var certificate CACertificate
-		err = json.Unmarshal(b, &certificate)
+		err = s.client.decode(b, &certificate)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -158,3 +166,73 @@ func (s *CACertificateService) ListAll(ctx context.Context) ([]*CACertificate,
 	}
 	return certificates, nil
 }
+
+// DeleteWhere deletes the CACertificates in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after CACertificates are deleted from them,
+// so that unlike deleting CACertificates while paginating, none are skipped.
+func (s *CACertificateService) DeleteWhere(ctx context.Context,
+	predicate func(*CACertificate) bool,
+) ([]*CACertificate, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *CACertificate) *string { return entity.ID }, predicate)
+}
+
+// GetByDigest fetches a CACertificate in Kong by the hex-encoded SHA-256
+// digest of its DER encoding, as computed by CACertificateDigest.
+// An APIError with a 404 status code is returned if no CACertificate
+// matches, so IsNotFoundErr can be used to check whether the CA
+// certificate has already been uploaded.
+func (s *CACertificateService) GetByDigest(ctx context.Context,
+	digest *string,
+) (*CACertificate, error) {
+	if isEmptyString(digest) {
+		return nil, fmt.Errorf("digest cannot be nil for GetByDigest operation")
+	}
+
+	type qs struct {
+		CertDigest string `url:"cert_digest"`
+		Offset     string `url:"offset,omitempty"`
+	}
+	// Versions of Kong which don't support filtering on cert_digest
+	// ignore the filter and list all the CA certificates, hence the
+	// additional check on every page.
+	q := qs{CertDigest: *digest}
+	for {
+		req, err := s.client.NewRequest("GET", "/ca_certificates", q, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Data []*CACertificate `json:"data"`
+			Next *string          `json:"offset"`
+		}
+		_, err = s.client.Do(ctx, req, &list)
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range list.Data {
+			if certificate.CertDigest != nil && strings.EqualFold(*certificate.CertDigest, *digest) {
+				return certificate, nil
+			}
+		}
+		if list.Next == nil {
+			break
+		}
+		q.Offset = *list.Next
+	}
+	return nil, NewAPIError(http.StatusNotFound,
+		fmt.Sprintf("no CA certificate with digest %q", *digest))
+}
+
+// CACertificateDigest computes the digest Kong stores in the cert_digest
+// field of a CACertificate: the hex-encoded SHA-256 digest of the DER
+// encoding of the first certificate found in certPEM.
+func CACertificateDigest(certPEM string) (string, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", fmt.Errorf("certificate is not PEM encoded")
+	}
+	sum := sha256.Sum256(block.Bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -1,6 +1,8 @@
 package kong
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/uuid"
@@ -267,3 +269,65 @@ func compareCACertificates(expected, actual []*CACertificate) bool {
 
 	return (compareSlices(expectedUsernames, actualUsernames))
 }
+
+func TestCACertificateGetByDigest(t *testing.T) {
+	digest, err := CACertificateDigest(caCert1)
+	require.NoError(t, err)
+	assert.Len(t, digest, 64)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ca_certificates", r.URL.Path)
+		if r.URL.Query().Get("cert_digest") == digest {
+			_, _ = w.Write([]byte(`{"data":[{"id":"ca1","cert_digest":"` + digest + `"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, err = client.CACertificates.GetByDigest(defaultCtx, nil)
+	assert.Error(t, err)
+
+	caCert, err := client.CACertificates.GetByDigest(defaultCtx, String(digest))
+	require.NoError(t, err)
+	assert.Equal(t, "ca1", *caCert.ID)
+
+	caCert, err = client.CACertificates.GetByDigest(defaultCtx, String("deadbeef"))
+	assert.Nil(t, caCert)
+	assert.True(t, IsNotFoundErr(err))
+
+	_, err = CACertificateDigest("foo")
+	assert.Error(t, err)
+}
+
+func TestCACertificateGetByDigestUnfiltered(t *testing.T) {
+	digest, err := CACertificateDigest(caCert1)
+	require.NoError(t, err)
+
+	// the filter is ignored and the CA certificate is on the second page
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.URL.Query().Get("cert_digest"))
+		switch r.URL.Query().Get("offset") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ca0","cert_digest":"other"}],"offset":"page2"}`))
+		case "page2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ca1","cert_digest":"` + digest + `"}],"offset":null}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	caCert, err := client.CACertificates.GetByDigest(defaultCtx, String(digest))
+	require.NoError(t, err)
+	assert.Equal(t, "ca1", *caCert.ID)
+
+	_, err = client.CACertificates.GetByDigest(defaultCtx, String("deadbeef"))
+	assert.True(t, IsNotFoundErr(err))
+}
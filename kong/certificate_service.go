@@ -2,8 +2,9 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 )
 
 // AbstractCertificateService handles Certificates in Kong.
@@ -20,6 +21,10 @@ type AbstractCertificateService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Certificate, *ListOpt, error)
 	// ListAll fetches all Certificates in Kong.
 	ListAll(ctx context.Context) ([]*Certificate, error)
+	// DeleteWhere deletes the Certificates in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Certificate) bool) ([]*Certificate, error)
+	// ListExpiring fetches all Certificates in Kong expiring within the given duration.
+	ListExpiring(ctx context.Context, within time.Duration) ([]*ExpiringCertificate, error)
 }
 
 // CertificateService handles Certificates in Kong.
@@ -29,9 +34,19 @@ type CertificateService service
 // If an ID is specified, it will be used to
 // create a certificate in Kong, otherwise an ID
 // is auto-generated.
+// The certificate is validated with ValidateCertificate before being
+// sent, unless validation has been disabled with
+// Client.SetSkipCertificateValidation. Expired certificates are only
+// rejected after Client.SetRejectExpiredCertificates.
 func (s *CertificateService) Create(ctx context.Context,
 	certificate *Certificate,
 ) (*Certificate, error) {
+	if certificate == nil {
+		return nil, fmt.Errorf("cannot create a nil certificate")
+	}
+	if err := s.client.validateCertificate(certificate); err != nil {
+		return nil, err
+	}
 	queryPath := "/certificates"
 	method := "POST"
 	if certificate.ID != nil {
@@ -74,12 +89,22 @@ func (s *CertificateService) Get(ctx context.Context,
 }
 
 // Update updates a Certificate in Kong
+// The certificate is validated with ValidateCertificate before being
+// sent, unless validation has been disabled with
+// Client.SetSkipCertificateValidation. Expired certificates are only
+// rejected after Client.SetRejectExpiredCertificates.
 func (s *CertificateService) Update(ctx context.Context,
 	certificate *Certificate,
 ) (*Certificate, error) {
+	if certificate == nil {
+		return nil, fmt.Errorf("cannot update a nil certificate")
+	}
 	if isEmptyString(certificate.ID) {
 		return nil, fmt.Errorf("ID cannot be nil for Update operation")
 	}
+	if err := s.client.validateCertificate(certificate); err != nil {
+		return nil, err
+	}
 
 	endpoint := fmt.Sprintf("/certificates/%v", *certificate.ID)
 	req, err := s.client.NewRequest("PATCH", endpoint, nil, certificate)
@@ -129,7 +154,7 @@ func (s *CertificateService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var certificate Certificate
-		err = json.Unmarshal(b, &certificate)
+		err = s.client.decode(b, &certificate)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -158,3 +183,87 @@ func (s *CertificateService) ListAll(ctx context.Context) ([]*Certificate,
 	}
 	return certificates, nil
 }
+
+// DeleteWhere deletes the Certificates in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Certificates are deleted from them,
+// so that unlike deleting Certificates while paginating, none are skipped.
+func (s *CertificateService) DeleteWhere(ctx context.Context,
+	predicate func(*Certificate) bool,
+) ([]*Certificate, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Certificate) *string { return entity.ID }, predicate)
+}
+
+// ExpiringCertificate is a Certificate along with its expiry date.
+type ExpiringCertificate struct {
+	// Certificate is the certificate as returned by Kong,
+	// including the SNIs associated with it.
+	Certificate *Certificate
+	// NotAfter is the earliest expiry date of the certificate
+	// and of the alternate certificate, if any.
+	NotAfter time.Time
+	// Err is the error parsing the certificate, if any, in which case
+	// NotAfter is zero.
+	Err error
+}
+
+// ListExpiring fetches all Certificates in Kong which expire within
+// the given duration, including the ones which already expired.
+// Certificates are sorted by expiry date, the earliest first, after
+// the certificates which can't be parsed, reported with their error.
+// This method can take a while if there
+// a lot of Certificates present.
+func (s *CertificateService) ListExpiring(ctx context.Context,
+	within time.Duration,
+) ([]*ExpiringCertificate, error) {
+	certificates, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(within)
+
+	var expiring []*ExpiringCertificate
+	for _, certificate := range certificates {
+		notAfter, err := certificateNotAfter(certificate)
+		if err != nil {
+			expiring = append(expiring, &ExpiringCertificate{
+				Certificate: certificate,
+				Err:         fmt.Errorf("certificate %s: %w", certificate.FriendlyName(), err),
+			})
+			continue
+		}
+		if notAfter.Before(deadline) {
+			expiring = append(expiring, &ExpiringCertificate{
+				Certificate: certificate,
+				NotAfter:    notAfter,
+			})
+		}
+	}
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].NotAfter.Before(expiring[j].NotAfter)
+	})
+	return expiring, nil
+}
+
+// certificateNotAfter returns the earliest expiry date of the leaf
+// certificates of c.
+func certificateNotAfter(c *Certificate) (time.Time, error) {
+	var notAfter time.Time
+	for _, pemCert := range []*string{c.Cert, c.CertAlt} {
+		if pemCert == nil {
+			continue
+		}
+		chain, err := parseCertificateChain(*pemCert)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if notAfter.IsZero() || chain[0].NotAfter.Before(notAfter) {
+			notAfter = chain[0].NotAfter
+		}
+	}
+	if notAfter.IsZero() {
+		return time.Time{}, fmt.Errorf("no certificate data")
+	}
+	return notAfter, nil
+}
@@ -1,7 +1,11 @@
 package kong
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -427,3 +431,38 @@ func compareCertificates(T *testing.T, expected, actual []*Certificate) bool {
 
 	return (compareSlices(expectedUsernames, actualUsernames))
 }
+
+func TestCertificateListExpiring(t *testing.T) {
+	soon := newTestCert(t, "soon", time.Now().Add(24*time.Hour), nil)
+	expired := newTestCert(t, "expired", time.Now().Add(-time.Hour), nil)
+	later := newTestCert(t, "later", time.Now().AddDate(1, 0, 0), nil)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/certificates", r.URL.Path)
+		body := map[string]interface{}{
+			"data": []*Certificate{
+				{ID: String("1"), Cert: String(soon.certPEM), SNIs: StringSlice("soon.example.com")},
+				{ID: String("2"), Cert: String(later.certPEM), CertAlt: String(expired.certPEM)},
+				{ID: String("3"), Cert: String(later.certPEM)},
+				{ID: String("4"), Cert: String("garbage")},
+			},
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	expiring, err := client.Certificates.ListExpiring(defaultCtx, 7*24*time.Hour)
+	require.NoError(t, err)
+	require.Len(t, expiring, 3)
+	assert.Equal(t, "4", *expiring[0].Certificate.ID)
+	assert.Error(t, expiring[0].Err)
+	assert.True(t, expiring[0].NotAfter.IsZero())
+	assert.Equal(t, "2", *expiring[1].Certificate.ID)
+	assert.NoError(t, expiring[1].Err)
+	assert.Equal(t, expired.cert.NotAfter, expiring[1].NotAfter)
+	assert.Equal(t, "1", *expiring[2].Certificate.ID)
+	assert.Equal(t, StringSlice("soon.example.com"), expiring[2].Certificate.SNIs)
+}
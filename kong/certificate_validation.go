@@ -0,0 +1,153 @@
+package kong
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+// SetSkipCertificateValidation disables or enables the client-side
+// validation of PEM certificates and keys performed by
+// CertificateService.Create and CertificateService.Update.
+// By default, validation is enabled.
+func (c *Client) SetSkipCertificateValidation(skip bool) {
+	c.skipCertificateValidation.Store(skip)
+}
+
+// SetRejectExpiredCertificates makes CertificateService.Create and
+// CertificateService.Update also reject certificates whose leaf has
+// expired, see ValidateCertificateNotExpired. Kong itself accepts expired
+// certificates, so this is disabled by default.
+func (c *Client) SetRejectExpiredCertificates(reject bool) {
+	c.rejectExpiredCertificates.Store(reject)
+}
+
+// validateCertificate validates certificate before it is sent, as
+// configured with SetSkipCertificateValidation and
+// SetRejectExpiredCertificates.
+func (c *Client) validateCertificate(certificate *Certificate) error {
+	if c.skipCertificateValidation.Load() {
+		return nil
+	}
+	if err := ValidateCertificate(certificate); err != nil {
+		return err
+	}
+	if c.rejectExpiredCertificates.Load() {
+		return ValidateCertificateNotExpired(certificate, time.Now())
+	}
+	return nil
+}
+
+// ValidateCertificate checks that the certificate/key pairs of
+// certificate (both the main and the alternate ones) are well-formed
+// PEM, that the key matches the certificate and that the chain is
+// ordered from leaf to root. Expired certificates, which Kong accepts,
+// pass; use ValidateCertificateNotExpired to reject them.
+// Pairs which are only partially set are validated as much as possible,
+// which allows validating certificates sent in partial updates. Pairs
+// where the certificate or the key is a vault reference, see
+// IsVaultReference, are resolved by Kong and are not validated.
+func ValidateCertificate(certificate *Certificate) error {
+	if certificate == nil {
+		return fmt.Errorf("certificate cannot be nil")
+	}
+	if err := validateCertificatePair(certificate.Cert, certificate.Key); err != nil {
+		return fmt.Errorf("invalid certificate: %w", err)
+	}
+	if err := validateCertificatePair(certificate.CertAlt, certificate.KeyAlt); err != nil {
+		return fmt.Errorf("invalid alternate certificate: %w", err)
+	}
+	return nil
+}
+
+// ValidateCertificateNotExpired checks that the leaf certificates of
+// certificate, the main and the alternate ones, have not expired at now.
+func ValidateCertificateNotExpired(certificate *Certificate, now time.Time) error {
+	if certificate == nil {
+		return fmt.Errorf("certificate cannot be nil")
+	}
+	for _, pair := range []struct {
+		cert *string
+		name string
+	}{
+		{certificate.Cert, "certificate"},
+		{certificate.CertAlt, "alternate certificate"},
+	} {
+		if pair.cert == nil || IsVaultReference(*pair.cert) {
+			continue
+		}
+		chain, err := parseCertificateChain(*pair.cert)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", pair.name, err)
+		}
+		if leaf := chain[0]; now.After(leaf.NotAfter) {
+			return fmt.Errorf("invalid %s: certificate %q expired on %s", pair.name,
+				leaf.Subject.String(), leaf.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+func validateCertificatePair(cert, key *string) error {
+	if (cert != nil && IsVaultReference(*cert)) || (key != nil && IsVaultReference(*key)) {
+		return nil
+	}
+	if cert != nil {
+		chain, err := parseCertificateChain(*cert)
+		if err != nil {
+			return err
+		}
+		if err := validateCertificateChain(chain); err != nil {
+			return err
+		}
+	}
+	if key != nil {
+		if block, _ := pem.Decode([]byte(*key)); block == nil {
+			return fmt.Errorf("key is not PEM encoded")
+		}
+	}
+	if cert != nil && key != nil {
+		if _, err := tls.X509KeyPair([]byte(*cert), []byte(*key)); err != nil {
+			return fmt.Errorf("key does not match certificate: %w", err)
+		}
+	}
+	return nil
+}
+
+// parseCertificateChain parses all the PEM certificates found in s.
+func parseCertificateChain(s string) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	rest := []byte(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q in certificate", block.Type)
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate #%d of the chain: %w", len(chain)+1, err)
+		}
+		chain = append(chain, c)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("certificate is not PEM encoded")
+	}
+	return chain, nil
+}
+
+func validateCertificateChain(chain []*x509.Certificate) error {
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return fmt.Errorf("certificate chain is not ordered from leaf to root: "+
+				"certificate #%d (%q) is not signed by certificate #%d (%q)",
+				i+1, chain[i].Subject.String(), i+2, chain[i+1].Subject.String())
+		}
+	}
+	return nil
+}
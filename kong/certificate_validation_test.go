@@ -0,0 +1,183 @@
+package kong
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM string
+	keyPEM  string
+}
+
+func newTestCert(t *testing.T, cn string, notAfter time.Time, parent *testCert) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})),
+	}
+}
+
+func TestValidateCertificate(t *testing.T) {
+	nextYear := time.Now().AddDate(1, 0, 0)
+	ca := newTestCert(t, "ca", nextYear, nil)
+	leaf := newTestCert(t, "leaf", nextYear, &ca)
+	expired := newTestCert(t, "expired", time.Now().Add(-time.Minute), &ca)
+	other := newTestCert(t, "other", nextYear, nil)
+
+	for _, tt := range []struct {
+		name        string
+		certificate *Certificate
+		wantErr     string
+	}{
+		{
+			name:        "valid chain",
+			certificate: &Certificate{Cert: String(leaf.certPEM + ca.certPEM), Key: String(leaf.keyPEM)},
+		},
+		{
+			name:        "partial update with tags only",
+			certificate: &Certificate{Tags: StringSlice("foo")},
+		},
+		{
+			name:        "not PEM",
+			certificate: &Certificate{Cert: String("foo"), Key: String("bar")},
+			wantErr:     "invalid certificate: certificate is not PEM encoded",
+		},
+		{
+			name:        "key mismatch",
+			certificate: &Certificate{Cert: String(leaf.certPEM), Key: String(other.keyPEM)},
+			wantErr:     "invalid certificate: key does not match certificate",
+		},
+		{
+			// Kong accepts expired certificates
+			name:        "expired",
+			certificate: &Certificate{Cert: String(expired.certPEM), Key: String(expired.keyPEM)},
+		},
+		{
+			name:        "chain in wrong order",
+			certificate: &Certificate{Cert: String(ca.certPEM + leaf.certPEM), Key: String(ca.keyPEM)},
+			wantErr:     "certificate chain is not ordered from leaf to root",
+		},
+		{
+			name: "invalid alternate certificate",
+			certificate: &Certificate{
+				Cert:    String(leaf.certPEM),
+				Key:     String(leaf.keyPEM),
+				CertAlt: String(other.certPEM),
+				KeyAlt:  String(leaf.keyPEM),
+			},
+			wantErr: "invalid alternate certificate: key does not match certificate",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCertificate(tt.certificate)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateCertificateNotExpired(t *testing.T) {
+	nextYear := time.Now().AddDate(1, 0, 0)
+	valid := newTestCert(t, "valid", nextYear, nil)
+	expired := newTestCert(t, "expired", time.Now().Add(-time.Minute), nil)
+
+	assert.NoError(t, ValidateCertificateNotExpired(&Certificate{Cert: String(valid.certPEM)}, time.Now()))
+	assert.NoError(t, ValidateCertificateNotExpired(&Certificate{Tags: StringSlice("foo")}, time.Now()))
+	err := ValidateCertificateNotExpired(&Certificate{
+		Cert:    String(valid.certPEM),
+		CertAlt: String(expired.certPEM),
+	}, time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid alternate certificate: certificate "CN=expired" expired on`)
+	assert.NoError(t, ValidateCertificateNotExpired(&Certificate{Cert: String(expired.certPEM)},
+		time.Now().Add(-time.Hour)))
+}
+
+func TestCertificateCreateValidation(t *testing.T) {
+	client, err := NewClient(String("http://localhost:1"), nil)
+	require.NoError(t, err)
+
+	// validation fails before any request is made
+	_, err = client.Certificates.Create(defaultCtx, &Certificate{Cert: String("foo"), Key: String("bar")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid certificate")
+
+	// expired certificates are only rejected on demand
+	expired := newTestCert(t, "expired", time.Now().Add(-time.Minute), nil)
+	certificate := &Certificate{Cert: String(expired.certPEM), Key: String(expired.keyPEM)}
+	_, err = client.Certificates.Create(defaultCtx, certificate)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "making HTTP request")
+	client.SetRejectExpiredCertificates(true)
+	_, err = client.Certificates.Create(defaultCtx, certificate)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expired on")
+
+	// with validation disabled, the request reaches the (unreachable) server
+	client.SetSkipCertificateValidation(true)
+	_, err = client.Certificates.Create(defaultCtx, certificate)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "making HTTP request")
+	_, err = client.Certificates.Create(defaultCtx, &Certificate{Cert: String("foo"), Key: String("bar")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "making HTTP request")
+}
+
+func TestCertificateCreateVaultReferences(t *testing.T) {
+	client, err := NewClient(String("http://localhost:1"), nil)
+	require.NoError(t, err)
+	client.SetRejectExpiredCertificates(true)
+
+	// vault references are resolved by Kong, the request reaches the
+	// (unreachable) server
+	valid := newTestCert(t, "valid", time.Now().AddDate(1, 0, 0), nil)
+	for _, certificate := range []*Certificate{
+		{Cert: String("{vault://env/CERT}"), Key: String("{vault://env/KEY}")},
+		{Cert: String(valid.certPEM), Key: String("{vault://env/KEY}")},
+		{CertAlt: String("{vault://env/CERT_ALT}"), KeyAlt: String("{vault://env/KEY_ALT}")},
+	} {
+		_, err = client.Certificates.Create(defaultCtx, certificate)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "making HTTP request")
+	}
+}
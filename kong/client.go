@@ -11,7 +11,9 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"os"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/kong/go-kong/kong/custom"
@@ -33,7 +35,13 @@ type service struct {
 var defaultCtx = context.Background()
 
 // Client talks to the Admin API or control plane of a
-// Kong cluster
+// Kong cluster.
+//
+// A Client is safe for concurrent use by multiple goroutines, including
+// calls to its Set* methods, which affect the requests started after they
+// return. To work on a different workspace without affecting other users
+// of a shared client, use WithWorkspace for single calls or ForWorkspace
+// for a derived client.
 type Client struct {
 	client                  *http.Client
 	baseRootURL             string
@@ -45,6 +53,8 @@ type Client struct {
 	Consumers               AbstractConsumerService
 	Developers              AbstractDeveloperService
 	DeveloperRoles          AbstractDeveloperRoleService
+	Applications            AbstractApplicationService
+	ApplicationInstances    AbstractApplicationInstanceService
 	Services                AbstractSvcService
 	Routes                  AbstractRouteService
 	CACertificates          AbstractCACertificateService
@@ -64,6 +74,9 @@ type Client struct {
 	Keys                    AbstractKeyService
 	KeySets                 AbstractKeySetService
 	Licenses                AbstractLicenseService
+	Clustering              AbstractClusteringService
+	UserInfo                AbstractUserInfoService
+	Vitals                  AbstractVitalsService
 
 	credentials       abstractCredentialService
 	KeyAuths          AbstractKeyAuthService
@@ -81,10 +94,32 @@ type Client struct {
 
 	Schemas AbstractSchemaService
 
-	logger         io.Writer
-	debug          bool
+	logger         atomic.Value // holds a logWriter; use writeLog()/SetLogger().
+	debug          atomic.Bool
 	CustomEntities AbstractCustomEntityService
 
+	skipCertificateValidation atomic.Bool
+	rejectExpiredCertificates atomic.Bool
+	dbless                    atomic.Bool
+	role                      atomic.Value
+	dryRun                    atomic.Value
+	readOnly                  atomic.Bool
+	mutationHook              atomic.Value
+	konnectMode               atomic.Bool
+	idempotentCreates         atomic.Bool
+	defaultTags               atomic.Value
+	protectedTags             atomic.Value
+	slowRequest               atomic.Value
+	deprecationHook           atomic.Value
+	strictDecoding            atomic.Value
+	jsonCodec                 atomic.Value
+	redactor                  atomic.Value
+	requestCompression        atomic.Int64
+	latency                   *latencyRecorder // Shared with derived clients.
+	traffic                   *trafficRecorder // Shared with derived clients.
+	plugins                   *pluginCache     // Shared with derived clients.
+	pacer                     *pacer           // Shared with derived clients.
+
 	custom.Registry
 }
 
@@ -105,22 +140,33 @@ type Status struct {
 	ConfigurationHash string `json:"configuration_hash,omitempty" yaml:"configuration_hash,omitempty"`
 }
 
+// defaultHTTPClient returns the client used when none is given, with
+// DefaultTimeout for connections and requests.
+func defaultHTTPClient() *http.Client {
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: DefaultTimeout,
+		}).DialContext,
+		TLSHandshakeTimeout: DefaultTimeout,
+	}
+	return &http.Client{
+		Timeout:   DefaultTimeout,
+		Transport: transport,
+	}
+}
+
 // NewClient returns a Client which talks to Admin API of Kong
 func NewClient(baseURL *string, client *http.Client) (*Client, error) {
 	if client == nil {
-		transport := &http.Transport{
-			DialContext: (&net.Dialer{
-				Timeout: DefaultTimeout,
-			}).DialContext,
-			TLSHandshakeTimeout: DefaultTimeout,
-		}
-		client = &http.Client{
-			Timeout:   DefaultTimeout,
-			Transport: transport,
-		}
+		client = defaultHTTPClient()
+	}
+	kong := &Client{
+		client:  client,
+		latency: &latencyRecorder{},
+		traffic: &trafficRecorder{},
+		plugins: &pluginCache{},
+		pacer:   &pacer{},
 	}
-	kong := new(Client)
-	kong.client = client
 	var rootURL string
 	if baseURL != nil {
 		rootURL = *baseURL
@@ -134,51 +180,7 @@ func NewClient(baseURL *string, client *http.Client) (*Client, error) {
 		return nil, fmt.Errorf("parsing URL: %w", err)
 	}
 	kong.baseRootURL = url.String()
-
-	kong.common.client = kong
-	kong.ConsumerGroupConsumers = (*ConsumerGroupConsumerService)(&kong.common)
-	kong.ConsumerGroups = (*ConsumerGroupService)(&kong.common)
-	kong.Consumers = (*ConsumerService)(&kong.common)
-	kong.Developers = (*DeveloperService)(&kong.common)
-	kong.DeveloperRoles = (*DeveloperRoleService)(&kong.common)
-	kong.Services = (*Svcservice)(&kong.common)
-	kong.Routes = (*RouteService)(&kong.common)
-	kong.Plugins = (*PluginService)(&kong.common)
-	kong.Certificates = (*CertificateService)(&kong.common)
-	kong.CACertificates = (*CACertificateService)(&kong.common)
-	kong.SNIs = (*SNIService)(&kong.common)
-	kong.Upstreams = (*UpstreamService)(&kong.common)
-	kong.UpstreamNodeHealth = (*UpstreamNodeHealthService)(&kong.common)
-	kong.Targets = (*TargetService)(&kong.common)
-	kong.Workspaces = (*WorkspaceService)(&kong.common)
-	kong.Admins = (*AdminService)(&kong.common)
-	kong.RBACUsers = (*RBACUserService)(&kong.common)
-	kong.RBACRoles = (*RBACRoleService)(&kong.common)
-	kong.RBACEndpointPermissions = (*RBACEndpointPermissionService)(&kong.common)
-	kong.RBACEntityPermissions = (*RBACEntityPermissionService)(&kong.common)
-	kong.Vaults = (*VaultService)(&kong.common)
-	kong.Keys = (*KeyService)(&kong.common)
-	kong.KeySets = (*KeySetService)(&kong.common)
-	kong.Licenses = (*LicenseService)(&kong.common)
-
-	kong.credentials = (*credentialService)(&kong.common)
-	kong.KeyAuths = (*KeyAuthService)(&kong.common)
-	kong.BasicAuths = (*BasicAuthService)(&kong.common)
-	kong.HMACAuths = (*HMACAuthService)(&kong.common)
-	kong.JWTAuths = (*JWTAuthService)(&kong.common)
-	kong.MTLSAuths = (*MTLSAuthService)(&kong.common)
-	kong.ACLs = (*ACLService)(&kong.common)
-
-	kong.GraphqlRateLimitingCostDecorations = (*GraphqlRateLimitingCostDecorationService)(&kong.common)
-	kong.DegraphqlRoutes = (*DegraphqlRouteService)(&kong.common)
-
-	kong.Schemas = (*SchemaService)(&kong.common)
-
-	kong.Oauth2Credentials = (*Oauth2Service)(&kong.common)
-	kong.Tags = (*TagService)(&kong.common)
-	kong.Info = (*InfoService)(&kong.common)
-
-	kong.CustomEntities = (*CustomEntityService)(&kong.common)
+	kong.initServices()
 	kong.Registry = custom.NewDefaultRegistry()
 
 	for i := 0; i < len(defaultCustomEntities); i++ {
@@ -188,10 +190,63 @@ func NewClient(baseURL *string, client *http.Client) (*Client, error) {
 			return nil, err
 		}
 	}
-	kong.logger = os.Stderr
+	kong.SetLogger(os.Stderr)
 	return kong, nil
 }
 
+// initServices points the services of c to c itself.
+func (c *Client) initServices() {
+	c.common.client = c
+	c.ConsumerGroupConsumers = (*ConsumerGroupConsumerService)(&c.common)
+	c.ConsumerGroups = (*ConsumerGroupService)(&c.common)
+	c.Consumers = (*ConsumerService)(&c.common)
+	c.Developers = (*DeveloperService)(&c.common)
+	c.DeveloperRoles = (*DeveloperRoleService)(&c.common)
+	c.Applications = (*ApplicationService)(&c.common)
+	c.ApplicationInstances = (*ApplicationInstanceService)(&c.common)
+	c.Services = (*Svcservice)(&c.common)
+	c.Routes = (*RouteService)(&c.common)
+	c.Plugins = (*PluginService)(&c.common)
+	c.Certificates = (*CertificateService)(&c.common)
+	c.CACertificates = (*CACertificateService)(&c.common)
+	c.SNIs = (*SNIService)(&c.common)
+	c.Upstreams = (*UpstreamService)(&c.common)
+	c.UpstreamNodeHealth = (*UpstreamNodeHealthService)(&c.common)
+	c.Targets = (*TargetService)(&c.common)
+	c.Workspaces = (*WorkspaceService)(&c.common)
+	c.Admins = (*AdminService)(&c.common)
+	c.RBACUsers = (*RBACUserService)(&c.common)
+	c.RBACRoles = (*RBACRoleService)(&c.common)
+	c.RBACEndpointPermissions = (*RBACEndpointPermissionService)(&c.common)
+	c.RBACEntityPermissions = (*RBACEntityPermissionService)(&c.common)
+	c.Vaults = (*VaultService)(&c.common)
+	c.Keys = (*KeyService)(&c.common)
+	c.KeySets = (*KeySetService)(&c.common)
+	c.Licenses = (*LicenseService)(&c.common)
+	c.Clustering = (*ClusteringService)(&c.common)
+	c.UserInfo = (*UserInfoService)(&c.common)
+	c.Vitals = (*VitalsService)(&c.common)
+
+	c.credentials = (*credentialService)(&c.common)
+	c.KeyAuths = (*KeyAuthService)(&c.common)
+	c.BasicAuths = (*BasicAuthService)(&c.common)
+	c.HMACAuths = (*HMACAuthService)(&c.common)
+	c.JWTAuths = (*JWTAuthService)(&c.common)
+	c.MTLSAuths = (*MTLSAuthService)(&c.common)
+	c.ACLs = (*ACLService)(&c.common)
+
+	c.GraphqlRateLimitingCostDecorations = (*GraphqlRateLimitingCostDecorationService)(&c.common)
+	c.DegraphqlRoutes = (*DegraphqlRouteService)(&c.common)
+
+	c.Schemas = (*SchemaService)(&c.common)
+
+	c.Oauth2Credentials = (*Oauth2Service)(&c.common)
+	c.Tags = (*TagService)(&c.common)
+	c.Info = (*InfoService)(&c.common)
+
+	c.CustomEntities = (*CustomEntityService)(&c.common)
+}
+
 // SetWorkspace sets the Kong Enteprise workspace in the client.
 // Calling this function with an empty string resets the workspace to default workspace.
 func (c *Client) SetWorkspace(workspace string) {
@@ -215,6 +270,16 @@ func (c *Client) workspacedBaseURL(workspace string) string {
 	return c.baseRootURL
 }
 
+// relativePath returns the path of req relative to the workspace the
+// client targets.
+func (c *Client) relativePath(req *http.Request) string {
+	path := req.URL.Path
+	if base, err := url.Parse(c.workspacedBaseURL(c.requestWorkspace(req))); err == nil {
+		path = strings.TrimPrefix(path, strings.TrimSuffix(base.Path, "/"))
+	}
+	return path
+}
+
 // DoRAW executes an HTTP request and returns an http.Response
 // the caller is responsible for closing the response body.
 func (c *Client) DoRAW(ctx context.Context, req *http.Request) (*http.Response, error) {
@@ -222,7 +287,29 @@ func (c *Client) DoRAW(ctx context.Context, req *http.Request) (*http.Response,
 	if req == nil {
 		return nil, fmt.Errorf("request cannot be nil")
 	}
+	req = c.targetWorkspace(ctx, req)
+	if err := c.checkReadOnly(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkWritable(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkRole(req); err != nil {
+		return nil, err
+	}
+	if err := c.checkProtected(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := c.addDefaultTags(ctx, req); err != nil {
+		return nil, err
+	}
+	if resp, err := c.doDryRun(ctx, req); resp != nil || err != nil {
+		return resp, err
+	}
 	if ctx != nil {
+		if workspace, ok := req.Context().Value(requestWorkspaceCtxKey{}).(string); ok {
+			ctx = context.WithValue(ctx, requestWorkspaceCtxKey{}, workspace)
+		}
 		req = req.WithContext(ctx)
 	}
 
@@ -232,8 +319,26 @@ func (c *Client) DoRAW(ctx context.Context, req *http.Request) (*http.Response,
 		return nil, err
 	}
 
+	if err := c.pace(ctx); err != nil {
+		return nil, err
+	}
+	req, decompress, err := c.compressRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make the request
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	latency := time.Since(start)
+	c.recordLatency(req, resp, latency)
+	c.recordTraffic(req, resp)
+	if decompress {
+		decompressResponse(resp)
+	}
+	c.recordResponseMetadata(ctx, req, resp, latency)
+	c.observePacing(resp)
+	c.reportDeprecations(req, resp)
 	if err != nil {
 		return nil, fmt.Errorf("making HTTP request: %w", err)
 	}
@@ -252,6 +357,8 @@ func (c *Client) Do(
 	req *http.Request,
 	v interface{},
 ) (*Response, error) {
+	req = c.targetWorkspace(ctx, req)
+	mutation := c.prepareMutation(ctx, req)
 	resp, err := c.DoRAW(ctx, req)
 	if err != nil {
 		return nil, err
@@ -263,6 +370,7 @@ func (c *Client) Do(
 	}
 
 	response := newResponse(resp)
+	response.Deprecations = c.deprecations(req, resp)
 
 	// Check for API errors.
 	// If an error status code was returned, then parse the body and create
@@ -271,6 +379,12 @@ func (c *Client) Do(
 		return response, err
 	}
 
+	if mutation != nil {
+		if err = c.reportMutation(ctx, mutation, resp); err != nil {
+			return nil, fmt.Errorf("failed reading response body: %w", err)
+		}
+	}
+
 	if v != nil {
 		switch v := v.(type) {
 		case io.Writer:
@@ -280,6 +394,16 @@ func (c *Client) Do(
 			}
 			return response, nil
 		default:
+			if c.IsStrictDecoding() || c.customJSONCodec() != nil {
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return nil, fmt.Errorf("failed reading response body: %w", err)
+				}
+				if err := c.decode(body, v); err != nil {
+					return nil, fmt.Errorf("failed decoding response body: %w", err)
+				}
+				return response, nil
+			}
 			err = json.NewDecoder(resp.Body).Decode(v)
 			if err != nil {
 				return nil, fmt.Errorf("failed decoding response body: %w", err)
@@ -308,31 +432,33 @@ func ErrorOrResponseError(res *Response, err error) error {
 // the request to the logger set by SetLogger().
 // By default, debug logging is disabled.
 func (c *Client) SetDebugMode(enableDebug bool) {
-	c.debug = enableDebug
+	c.debug.Store(enableDebug)
 }
 
 func (c *Client) logRequest(r *http.Request) error {
-	if !c.debug {
+	if !c.debug.Load() {
 		return nil
 	}
 	dump, err := httputil.DumpRequestOut(r, true)
 	if err != nil {
 		return err
 	}
-	_, err = c.logger.Write(append(dump, '\n'))
-	return err
+	dump = c.redactDump(dump, r.URL.Path)
+	return c.writeLog(append(dump, '\n'))
 }
 
 func (c *Client) logResponse(r *http.Response) error {
-	if !c.debug {
+	if !c.debug.Load() {
 		return nil
 	}
 	dump, err := httputil.DumpResponse(r, true)
 	if err != nil {
 		return err
 	}
-	_, err = c.logger.Write(append(dump, '\n'))
-	return err
+	if r.Request != nil {
+		dump = c.redactDump(dump, r.Request.URL.Path)
+	}
+	return c.writeLog(append(dump, '\n'))
 }
 
 // SetLogger sets the debug logger, defaults to os.StdErr
@@ -340,7 +466,28 @@ func (c *Client) SetLogger(w io.Writer) {
 	if w == nil {
 		return
 	}
-	c.logger = w
+	c.logger.Store(logWriter{Writer: w, lock: &sync.Mutex{}})
+}
+
+// logWriter wraps the logger so that writers of different concrete
+// types can be stored in the same atomic.Value, along with the lock
+// serializing writes to it, which is shared with derived clients.
+type logWriter struct {
+	io.Writer
+	lock *sync.Mutex
+}
+
+// writeLog writes p to the logger. Writes are serialized so that the
+// debug dumps of concurrent requests don't interleave.
+func (c *Client) writeLog(p []byte) error {
+	w, _ := c.logger.Load().(logWriter)
+	if w.Writer == nil {
+		return nil
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := w.Write(p)
+	return err
 }
 
 // Status returns the status of a Kong node
@@ -0,0 +1,74 @@
+package kong
+
+// DataPlane represents a data plane connected to a Kong control plane,
+// as listed by the /clustering/data-planes endpoint.
+type DataPlane struct {
+	ID         *string `json:"id,omitempty" yaml:"id,omitempty"`
+	IP         *string `json:"ip,omitempty" yaml:"ip,omitempty"`
+	Hostname   *string `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Version    *string `json:"version,omitempty" yaml:"version,omitempty"`
+	SyncStatus *string `json:"sync_status,omitempty" yaml:"sync_status,omitempty"`
+	ConfigHash *string `json:"config_hash,omitempty" yaml:"config_hash,omitempty"`
+	LastSeen   *int64  `json:"last_seen,omitempty" yaml:"last_seen,omitempty"`
+	TTL        *int64  `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// Labels are set with the cluster_dp_labels setting of data planes
+	// (Kong 3.5+), e.g. to tell the zone or region they run in.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// FriendlyName returns the endpoint key hostname or ID.
+func (d *DataPlane) FriendlyName() string {
+	if d.Hostname != nil {
+		return *d.Hostname
+	}
+	if d.ID != nil {
+		return *d.ID
+	}
+	return ""
+}
+
+// Label returns the value of the label key of the data plane, or an
+// empty string if it doesn't have this label.
+func (d *DataPlane) Label(key string) string {
+	return d.Labels[key]
+}
+
+// ConvergenceReport tells whether the data planes of a control plane run
+// its current configuration.
+type ConvergenceReport struct {
+	// ExpectedHash is the hash of the configuration the data planes are
+	// expected to run, see ClusteringService.CheckConvergence.
+	ExpectedHash string
+	// DataPlanes are all the data planes connected to the control plane.
+	DataPlanes []*DataPlane
+	// Laggards are the data planes running another configuration.
+	Laggards []*DataPlane
+}
+
+// Converged returns true if there is at least one data plane and all data
+// planes run the configuration of the control plane.
+func (r *ConvergenceReport) Converged() bool {
+	return len(r.DataPlanes) > 0 && len(r.Laggards) == 0
+}
+
+// GroupByLabel splits the report by the value of the label key of the
+// data planes (e.g. "zone"), so that convergence can be checked per zone.
+// Data planes without this label are grouped under an empty value.
+func (r *ConvergenceReport) GroupByLabel(key string) map[string]*ConvergenceReport {
+	groups := map[string]*ConvergenceReport{}
+	group := func(value string) *ConvergenceReport {
+		if groups[value] == nil {
+			groups[value] = &ConvergenceReport{ExpectedHash: r.ExpectedHash}
+		}
+		return groups[value]
+	}
+	for _, dataPlane := range r.DataPlanes {
+		g := group(dataPlane.Label(key))
+		g.DataPlanes = append(g.DataPlanes, dataPlane)
+	}
+	for _, dataPlane := range r.Laggards {
+		g := group(dataPlane.Label(key))
+		g.Laggards = append(g.Laggards, dataPlane)
+	}
+	return groups
+}
@@ -0,0 +1,121 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractClusteringService handles the clustering of Kong nodes in
+// hybrid mode.
+type AbstractClusteringService interface {
+	// ListDataPlanes fetches a list of the data planes of a control plane.
+	ListDataPlanes(ctx context.Context, opt *ListOpt) ([]*DataPlane, *ListOpt, error)
+	// ListAllDataPlanes fetches all the data planes of a control plane.
+	ListAllDataPlanes(ctx context.Context) ([]*DataPlane, error)
+	// CheckConvergence compares the configuration of the data planes with
+	// the expected one, or with each other.
+	CheckConvergence(ctx context.Context, expectedHash string) (*ConvergenceReport, error)
+}
+
+// ClusteringService handles the clustering of Kong nodes in hybrid mode.
+type ClusteringService service
+
+// ListDataPlanes fetches a list of the data planes connected to the
+// control plane. opt can be used to control pagination.
+func (s *ClusteringService) ListDataPlanes(ctx context.Context,
+	opt *ListOpt,
+) ([]*DataPlane, *ListOpt, error) {
+	data, next, err := s.client.list(ctx, "/clustering/data-planes", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dataPlanes []*DataPlane
+
+	for _, object := range data {
+		b, err := object.MarshalJSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		var dataPlane DataPlane
+		err = s.client.decode(b, &dataPlane)
+		if err != nil {
+			return nil, nil, err
+		}
+		dataPlanes = append(dataPlanes, &dataPlane)
+	}
+
+	return dataPlanes, next, nil
+}
+
+// ListAllDataPlanes fetches all the data planes connected to the control
+// plane.
+func (s *ClusteringService) ListAllDataPlanes(ctx context.Context) ([]*DataPlane, error) {
+	var dataPlanes, data []*DataPlane
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.ListDataPlanes(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		dataPlanes = append(dataPlanes, data...)
+	}
+	return dataPlanes, nil
+}
+
+// CheckConvergence compares the configuration hash reported by each data
+// plane with expectedHash. Control planes don't report the hash of their
+// configuration, so if expectedHash is empty, the data planes are compared
+// with each other: the hash run by most data planes is expected, ties
+// going to the hash of the data plane seen last.
+// It suits deploy gates waiting for a configuration change to reach all
+// data planes.
+func (s *ClusteringService) CheckConvergence(ctx context.Context,
+	expectedHash string,
+) (*ConvergenceReport, error) {
+	dataPlanes, err := s.ListAllDataPlanes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing data planes: %w", err)
+	}
+	if expectedHash == "" {
+		expectedHash = majorityConfigHash(dataPlanes)
+	}
+
+	report := &ConvergenceReport{
+		ExpectedHash: expectedHash,
+		DataPlanes:   dataPlanes,
+	}
+	for _, dataPlane := range dataPlanes {
+		if derefString(dataPlane.ConfigHash) != expectedHash {
+			report.Laggards = append(report.Laggards, dataPlane)
+		}
+	}
+	return report, nil
+}
+
+// majorityConfigHash returns the configuration hash run by most data
+// planes, ties going to the hash of the data plane seen last.
+func majorityConfigHash(dataPlanes []*DataPlane) string {
+	counts := map[string]int{}
+	lastSeen := map[string]int64{}
+	for _, dataPlane := range dataPlanes {
+		hash := derefString(dataPlane.ConfigHash)
+		if hash == "" {
+			continue
+		}
+		counts[hash]++
+		if dataPlane.LastSeen != nil && *dataPlane.LastSeen > lastSeen[hash] {
+			lastSeen[hash] = *dataPlane.LastSeen
+		}
+	}
+	var majority string
+	for hash, count := range counts {
+		if majority == "" || count > counts[majority] ||
+			(count == counts[majority] && lastSeen[hash] > lastSeen[majority]) ||
+			(count == counts[majority] && lastSeen[hash] == lastSeen[majority] && hash < majority) {
+			majority = hash
+		}
+	}
+	return majority
+}
@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusteringServiceCheckConvergence(t *testing.T) {
+	dataPlanes := `[]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/clustering/data-planes":
+			if r.URL.Query().Get("offset") == "" {
+				_, _ = w.Write([]byte(`{"data":` + dataPlanes + `,"offset":"next"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":"dp3","hostname":"dp-3","config_hash":"abc"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	dataPlanes = `[{"id":"dp1","hostname":"dp-1","config_hash":"abc","sync_status":"normal","labels":{"zone":"eu"}},
+		{"id":"dp2","hostname":"dp-2","config_hash":"old","labels":{"zone":"us"}}]`
+	// the data planes are compared with each other
+	report, err := client.Clustering.CheckConvergence(defaultCtx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", report.ExpectedHash)
+	assert.Len(t, report.DataPlanes, 3)
+	require.Len(t, report.Laggards, 1)
+	assert.Equal(t, "dp-2", report.Laggards[0].FriendlyName())
+	assert.False(t, report.Converged())
+
+	zones := report.GroupByLabel("zone")
+	require.Len(t, zones, 3)
+	assert.True(t, zones["eu"].Converged())
+	assert.Equal(t, "abc", zones["eu"].ExpectedHash)
+	assert.False(t, zones["us"].Converged())
+	assert.Equal(t, "dp-2", zones["us"].Laggards[0].FriendlyName())
+	assert.Equal(t, "dp-3", zones[""].DataPlanes[0].FriendlyName())
+
+	// with an expected hash, all data planes can lag behind
+	report, err = client.Clustering.CheckConvergence(defaultCtx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, "new", report.ExpectedHash)
+	assert.Len(t, report.Laggards, 3)
+
+	// ties go to the data plane seen last
+	dataPlanes = `[{"id":"dp1","config_hash":"new","last_seen":1700000000}]`
+	report, err = client.Clustering.CheckConvergence(defaultCtx, "")
+	require.NoError(t, err)
+	assert.Equal(t, "new", report.ExpectedHash)
+	require.Len(t, report.Laggards, 1)
+	assert.Equal(t, "dp3", *report.Laggards[0].ID)
+
+	dataPlanes = `[{"id":"dp1","config_hash":"abc"}]`
+	report, err = client.Clustering.CheckConvergence(defaultCtx, "")
+	require.NoError(t, err)
+	assert.True(t, report.Converged())
+
+	assert.False(t, (&ConvergenceReport{ExpectedHash: "abc"}).Converged())
+
+	client.SetRole(NodeRoleDataPlane)
+	_, err = client.Clustering.ListAllDataPlanes(defaultCtx)
+	assert.True(t, IsUnsupportedOnRoleErr(err))
+}
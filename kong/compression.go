@@ -0,0 +1,102 @@
+package kong
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SetRequestCompression makes the client gzip the bodies of requests of
+// at least minSize bytes, such as pushes of large declarative
+// configurations, sending them with "Content-Encoding: gzip". Kong must be
+// able to decompress request bodies, e.g. when it sits behind a gateway
+// doing so. A minSize of 0 or less disables the compression of requests,
+// which is the default.
+//
+// Responses are always negotiated with "Accept-Encoding: gzip" and
+// decompressed transparently, unless the request sets Accept-Encoding
+// itself, whichever the transport of the HTTP client.
+func (c *Client) SetRequestCompression(minSize int) {
+	c.requestCompression.Store(int64(minSize))
+}
+
+// compressRequest returns a copy of req negotiating the compression of
+// the response, with its body gzipped if it is large enough, see
+// SetRequestCompression, and whether the response is to be decompressed
+// by the client.
+func (c *Client) compressRequest(req *http.Request) (*http.Request, bool, error) {
+	req = req.Clone(req.Context())
+	decompress := false
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+		decompress = true
+	}
+	minSize := c.requestCompression.Load()
+	if minSize <= 0 || req.Body == nil || req.Body == http.NoBody ||
+		req.Header.Get("Content-Encoding") != "" {
+		return req, decompress, nil
+	}
+	if req.ContentLength >= 0 && req.ContentLength < minSize {
+		return req, decompress, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Body.Close()
+	if int64(len(body)) >= minSize {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		if _, err := gz.Write(body); err != nil {
+			return nil, false, err
+		}
+		if err := gz.Close(); err != nil {
+			return nil, false, err
+		}
+		body = buf.Bytes()
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	return req, decompress, nil
+}
+
+// decompressResponse makes the body of resp read decompressed if it is
+// gzipped, as the transport of the standard library does.
+func decompressResponse(resp *http.Response) {
+	if resp == nil || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+}
+
+// gzipBody decompresses a response body, lazily so that empty bodies
+// can be closed without error.
+type gzipBody struct {
+	body io.ReadCloser
+	zr   *gzip.Reader
+	err  error
+}
+
+func (g *gzipBody) Read(p []byte) (int, error) {
+	if g.zr == nil && g.err == nil {
+		g.zr, g.err = gzip.NewReader(g.body)
+	}
+	if g.err != nil {
+		return 0, g.err
+	}
+	return g.zr.Read(p)
+}
+
+func (g *gzipBody) Close() error {
+	return g.body.Close()
+}
@@ -0,0 +1,96 @@
+package kong
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestCompression(t *testing.T) {
+	list := `{"data":[` + strings.Repeat(`{"id":"s1","name":"svc"},`, 99) + `{"id":"s1","name":"svc"}],"next":null}`
+	compressedList := gzipped(t, list)
+	var received []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+		b, err := io.ReadAll(body)
+		require.NoError(t, err)
+		received = append(received, r.Header.Get("Content-Encoding")+" "+string(b))
+
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			_, _ = w.Write([]byte(list))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet:
+			_, _ = w.Write(compressedList)
+		default:
+			_, _ = w.Write(gzipped(t, `{"id":"s1","name":"svc"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	services, _, err := client.Services.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.Len(t, services, 100)
+	assert.Equal(t, int64(len(compressedList)), client.TrafficStats().Total.BytesReceived)
+	require.NoError(t, client.Services.Delete(defaultCtx, String("svc")))
+
+	// the caller negotiates the encoding itself
+	req, err := client.NewRequest(http.MethodGet, "/services", nil, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "identity")
+	var raw bytes.Buffer
+	_, err = client.Do(defaultCtx, req, &raw)
+	require.NoError(t, err)
+	assert.Equal(t, list, raw.String())
+
+	// requests are sent uncompressed by default
+	small := &Service{Name: String("svc")}
+	_, err = client.Services.Create(defaultCtx, small)
+	require.NoError(t, err)
+	large := &Service{Name: String("svc"), Tags: StringSlice(strings.Repeat("a", 100))}
+	client.SetRequestCompression(100)
+	_, err = client.Services.Create(defaultCtx, small)
+	require.NoError(t, err)
+	_, err = client.Services.Create(defaultCtx, large)
+	require.NoError(t, err)
+	smallJSON, err := json.Marshal(small)
+	require.NoError(t, err)
+	largeJSON, err := json.Marshal(large)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		" ",
+		" ",
+		" ",
+		" " + string(smallJSON),
+		" " + string(smallJSON),
+		"gzip " + string(largeJSON),
+	}, received)
+}
@@ -0,0 +1,173 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// pageSize is the size of the pages listed when checking pagination,
+// small enough to page through the entities created by the battery.
+const pageSize = 2
+
+// entityCount is the number of entities created to check pagination.
+const entityCount = 5
+
+// CRUD describes how to exercise the service of entities of type T.
+// All the fields are required, but Name.
+type CRUD[T any] struct {
+	// New returns a new valid entity without ID, distinct for each i, whose
+	// name, if any, starts with prefix.
+	New func(prefix string, i int) *T
+	// ID returns the ID of entity.
+	ID func(entity *T) *string
+	// Name returns the name, or username, entities can be fetched by
+	// besides their ID. Nil when entities have no such name.
+	Name func(entity *T) *string
+	// Modify changes a field of entity, to be updated.
+	Modify func(entity *T)
+
+	Create  func(ctx context.Context, entity *T) (*T, error)
+	Get     func(ctx context.Context, nameOrID *string) (*T, error)
+	Update  func(ctx context.Context, entity *T) (*T, error)
+	Delete  func(ctx context.Context, nameOrID *string) error
+	List    func(ctx context.Context, opt *kong.ListOpt) ([]*T, *kong.ListOpt, error)
+	ListAll func(ctx context.Context) ([]*T, error)
+}
+
+// Run checks that the service described by crud:
+//   - rejects missing IDs and names without calling Kong,
+//   - returns errors satisfying kong.IsNotFoundErr for missing entities,
+//   - creates entities with an ID and fetches them by ID and name,
+//   - updates entities,
+//   - lists all the entities, page by page and at once,
+//   - deletes entities, deleting missing entities being no error, as
+//     with the Admin API.
+func Run[T any](t *testing.T, crud CRUD[T]) {
+	t.Helper()
+	ctx := context.Background()
+	prefix := "conformance-" + uuid.NewString()[:8] + "-"
+
+	var created []*T
+	t.Cleanup(func() {
+		for _, entity := range created {
+			_ = crud.Delete(ctx, crud.ID(entity))
+		}
+	})
+	create := func(t *testing.T, i int) *T {
+		entity, err := crud.Create(ctx, crud.New(prefix, i))
+		require.NoError(t, err)
+		require.NotNil(t, entity)
+		created = append(created, entity)
+		return entity
+	}
+
+	t.Run("rejects missing IDs", func(t *testing.T) {
+		_, err := crud.Get(ctx, nil)
+		assert.Error(t, err)
+		_, err = crud.Get(ctx, kong.String(""))
+		assert.Error(t, err)
+		assert.Error(t, crud.Delete(ctx, nil))
+		assert.Error(t, crud.Delete(ctx, kong.String("")))
+		_, err = crud.Update(ctx, crud.New(prefix, -1))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing entities are not found", func(t *testing.T) {
+		_, err := crud.Get(ctx, kong.String(uuid.NewString()))
+		assert.True(t, kong.IsNotFoundErr(err), "got %v", err)
+		if crud.Name != nil {
+			_, err = crud.Get(ctx, kong.String(prefix+"missing"))
+			assert.True(t, kong.IsNotFoundErr(err), "got %v", err)
+		}
+	})
+
+	t.Run("creates and gets", func(t *testing.T) {
+		entity := create(t, 0)
+		id := crud.ID(entity)
+		require.NotNil(t, id)
+		require.NotEmpty(t, *id)
+
+		fetched, err := crud.Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, entity, fetched)
+		if crud.Name != nil {
+			fetched, err = crud.Get(ctx, crud.Name(entity))
+			require.NoError(t, err)
+			assert.Equal(t, entity, fetched)
+		}
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		entity := create(t, 1)
+		before, err := crud.Get(ctx, crud.ID(entity))
+		require.NoError(t, err)
+		crud.Modify(entity)
+		updated, err := crud.Update(ctx, entity)
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.Equal(t, *crud.ID(before), *crud.ID(updated))
+		assert.NotEqual(t, before, updated)
+
+		fetched, err := crud.Get(ctx, crud.ID(entity))
+		require.NoError(t, err)
+		assert.Equal(t, updated, fetched)
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		for i := len(created); i < entityCount; i++ {
+			create(t, i)
+		}
+		expected := map[string]bool{}
+		for _, entity := range created {
+			expected[*crud.ID(entity)] = true
+		}
+
+		paged := map[string]bool{}
+		opt := &kong.ListOpt{Size: pageSize}
+		for pages := 0; opt != nil; pages++ {
+			require.Less(t, pages, 1000, "pagination doesn't end")
+			entities, next, err := crud.List(ctx, opt)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, len(entities), pageSize)
+			for _, entity := range entities {
+				id := *crud.ID(entity)
+				assert.False(t, paged[id], "%s listed twice", id)
+				paged[id] = true
+			}
+			opt = next
+		}
+		all, err := crud.ListAll(ctx)
+		require.NoError(t, err)
+		listed := map[string]bool{}
+		for _, entity := range all {
+			listed[*crud.ID(entity)] = true
+		}
+		assert.Equal(t, paged, listed)
+		for id := range expected {
+			assert.True(t, listed[id], "%s not listed", id)
+		}
+	})
+
+	t.Run("deletes", func(t *testing.T) {
+		entity := create(t, entityCount)
+		if crud.Name != nil {
+			require.NoError(t, crud.Delete(ctx, crud.Name(entity)))
+		} else {
+			require.NoError(t, crud.Delete(ctx, crud.ID(entity)))
+		}
+		_, err := crud.Get(ctx, crud.ID(entity))
+		assert.True(t, kong.IsNotFoundErr(err), "got %v", err)
+		all, err := crud.ListAll(ctx)
+		require.NoError(t, err)
+		for _, listed := range all {
+			assert.NotEqual(t, *crud.ID(entity), *crud.ID(listed))
+		}
+		assert.NoError(t, crud.Delete(ctx, crud.ID(entity)))
+	})
+}
@@ -0,0 +1,116 @@
+package conformance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// fakeAdminAPI is an in-memory Admin API serving collections of entities
+// named by name, or username for consumers.
+type fakeAdminAPI struct {
+	mu          sync.Mutex
+	collections map[string][]map[string]interface{}
+}
+
+func (f *fakeAdminAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	collection := segments[0]
+	nameKey := "name"
+	if collection == "consumers" {
+		nameKey = "username"
+	}
+	entities := f.collections[collection]
+	index := -1
+	if len(segments) == 2 {
+		for i, entity := range entities {
+			if entity["id"] == segments[1] || entity[nameKey] == segments[1] {
+				index = i
+			}
+		}
+	}
+	write := func(status int, v interface{}) {
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+		if size == 0 {
+			size = 100
+		}
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + size
+		if end > len(entities) {
+			end = len(entities)
+		}
+		list := map[string]interface{}{"data": append([]map[string]interface{}{}, entities[offset:end]...)}
+		if end < len(entities) {
+			list["offset"] = strconv.Itoa(end)
+		}
+		write(http.StatusOK, list)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		var entity map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&entity); err != nil {
+			write(http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		for _, existing := range entities {
+			if entity[nameKey] != nil && existing[nameKey] == entity[nameKey] {
+				write(http.StatusConflict, map[string]string{"message": "unique constraint violation"})
+				return
+			}
+		}
+		entity["id"] = uuid.NewString()
+		f.collections[collection] = append(entities, entity)
+		write(http.StatusCreated, entity)
+	case index < 0 && r.Method == http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+	case index < 0:
+		write(http.StatusNotFound, map[string]string{"message": "Not found"})
+	case r.Method == http.MethodGet && collection == "consumer_groups":
+		write(http.StatusOK, map[string]interface{}{"consumer_group": entities[index]})
+	case r.Method == http.MethodGet:
+		write(http.StatusOK, entities[index])
+	case r.Method == http.MethodPatch:
+		var patch map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			write(http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		for k, v := range patch {
+			entities[index][k] = v
+		}
+		write(http.StatusOK, entities[index])
+	case r.Method == http.MethodDelete:
+		f.collections[collection] = append(entities[:index:index], entities[index+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestConformance(t *testing.T) {
+	srv := httptest.NewServer(&fakeAdminAPI{collections: map[string][]map[string]interface{}{}})
+	defer srv.Close()
+	client, err := kong.NewClient(kong.String(srv.URL), nil)
+	require.NoError(t, err)
+
+	t.Run("services", func(t *testing.T) { RunServices(t, client.Services) })
+	t.Run("routes", func(t *testing.T) { RunRoutes(t, client.Routes) })
+	t.Run("consumers", func(t *testing.T) { RunConsumers(t, client.Consumers) })
+	t.Run("consumer groups", func(t *testing.T) { RunConsumerGroups(t, client.ConsumerGroups) })
+	t.Run("upstreams", func(t *testing.T) { RunUpstreams(t, client.Upstreams) })
+}
@@ -0,0 +1,16 @@
+// Package conformance checks that implementations of the Abstract*Service
+// interfaces of the kong package, be they the clients of the package
+// talking to Kong, mocks or clients of fake Admin API servers, behave the
+// same way, with a standard battery of CRUD, pagination and error cases.
+//
+// Run runs the battery for any entity type, given how to call its service;
+// RunServices, RunRoutes, RunConsumers, RunConsumerGroups and RunUpstreams
+// run it for the corresponding services:
+//
+//	func TestServices(t *testing.T) {
+//		conformance.RunServices(t, newFakeServices())
+//	}
+//
+// The battery creates and deletes entities, which are named after a random
+// prefix, so it can run against a Kong node in use.
+package conformance
@@ -0,0 +1,119 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// RunServices runs the battery of Run against s.
+func RunServices(t *testing.T, s kong.AbstractSvcService) {
+	t.Helper()
+	Run(t, CRUD[kong.Service]{
+		New: func(prefix string, i int) *kong.Service {
+			return &kong.Service{
+				Name: kong.String(fmt.Sprintf("%s%d", prefix, i)),
+				Host: kong.String("example.com"),
+			}
+		},
+		ID:      func(service *kong.Service) *string { return service.ID },
+		Name:    func(service *kong.Service) *string { return service.Name },
+		Modify:  func(service *kong.Service) { service.Host = kong.String("example.org") },
+		Create:  s.Create,
+		Get:     s.Get,
+		Update:  s.Update,
+		Delete:  s.Delete,
+		List:    s.List,
+		ListAll: s.ListAll,
+	})
+}
+
+// RunRoutes runs the battery of Run against s, with routes not attached
+// to services.
+func RunRoutes(t *testing.T, s kong.AbstractRouteService) {
+	t.Helper()
+	Run(t, CRUD[kong.Route]{
+		New: func(prefix string, i int) *kong.Route {
+			return &kong.Route{
+				Name:  kong.String(fmt.Sprintf("%s%d", prefix, i)),
+				Hosts: kong.StringSlice(fmt.Sprintf("%s%d.example.com", prefix, i)),
+			}
+		},
+		ID:      func(route *kong.Route) *string { return route.ID },
+		Name:    func(route *kong.Route) *string { return route.Name },
+		Modify:  func(route *kong.Route) { route.Paths = kong.StringSlice("/conformance") },
+		Create:  s.Create,
+		Get:     s.Get,
+		Update:  s.Update,
+		Delete:  s.Delete,
+		List:    s.List,
+		ListAll: s.ListAll,
+	})
+}
+
+// RunConsumers runs the battery of Run against s.
+func RunConsumers(t *testing.T, s kong.AbstractConsumerService) {
+	t.Helper()
+	Run(t, CRUD[kong.Consumer]{
+		New: func(prefix string, i int) *kong.Consumer {
+			return &kong.Consumer{Username: kong.String(fmt.Sprintf("%s%d", prefix, i))}
+		},
+		ID:   func(consumer *kong.Consumer) *string { return consumer.ID },
+		Name: func(consumer *kong.Consumer) *string { return consumer.Username },
+		Modify: func(consumer *kong.Consumer) {
+			consumer.CustomID = kong.String("custom-" + *consumer.Username)
+		},
+		Create:  s.Create,
+		Get:     s.Get,
+		Update:  s.Update,
+		Delete:  s.Delete,
+		List:    s.List,
+		ListAll: s.ListAll,
+	})
+}
+
+// RunConsumerGroups runs the battery of Run against s.
+func RunConsumerGroups(t *testing.T, s kong.AbstractConsumerGroupService) {
+	t.Helper()
+	Run(t, CRUD[kong.ConsumerGroup]{
+		New: func(prefix string, i int) *kong.ConsumerGroup {
+			return &kong.ConsumerGroup{Name: kong.String(fmt.Sprintf("%s%d", prefix, i))}
+		},
+		ID:     func(group *kong.ConsumerGroup) *string { return group.ID },
+		Name:   func(group *kong.ConsumerGroup) *string { return group.Name },
+		Modify: func(group *kong.ConsumerGroup) { group.Tags = kong.StringSlice("conformance") },
+		Create: s.Create,
+		Get: func(ctx context.Context, nameOrID *string) (*kong.ConsumerGroup, error) {
+			group, err := s.Get(ctx, nameOrID)
+			if err != nil {
+				return nil, err
+			}
+			return group.ConsumerGroup, nil
+		},
+		Update:  s.Update,
+		Delete:  s.Delete,
+		List:    s.List,
+		ListAll: s.ListAll,
+	})
+}
+
+// RunUpstreams runs the battery of Run against s.
+func RunUpstreams(t *testing.T, s kong.AbstractUpstreamService) {
+	t.Helper()
+	Run(t, CRUD[kong.Upstream]{
+		New: func(prefix string, i int) *kong.Upstream {
+			return &kong.Upstream{Name: kong.String(fmt.Sprintf("%s%d.upstream", prefix, i))}
+		},
+		ID:      func(upstream *kong.Upstream) *string { return upstream.ID },
+		Name:    func(upstream *kong.Upstream) *string { return upstream.Name },
+		Modify:  func(upstream *kong.Upstream) { upstream.Slots = kong.Int(100) },
+		Create:  s.Create,
+		Get:     s.Get,
+		Update:  s.Update,
+		Delete:  s.Delete,
+		List:    s.List,
+		ListAll: s.ListAll,
+	})
+}
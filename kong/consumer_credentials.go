@@ -0,0 +1,255 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// ConsumerCredentials are the credentials a consumer should have, see
+// Client.SyncConsumerCredentials. A nil slice leaves the credentials of
+// its type untouched, while an empty slice deletes them all.
+//
+// Credentials are matched by key (key-auth and jwt), username
+// (basic-auth and hmac-auth) or group (acl), which must be set.
+type ConsumerCredentials struct {
+	KeyAuths   []*KeyAuth   `json:"keyauth_credentials,omitempty" yaml:"keyauth_credentials,omitempty"`
+	BasicAuths []*BasicAuth `json:"basicauth_credentials,omitempty" yaml:"basicauth_credentials,omitempty"`
+	JWTAuths   []*JWTAuth   `json:"jwt_secrets,omitempty" yaml:"jwt_secrets,omitempty"`
+	HMACAuths  []*HMACAuth  `json:"hmacauth_credentials,omitempty" yaml:"hmacauth_credentials,omitempty"`
+	ACLGroups  []*ACLGroup  `json:"acls,omitempty" yaml:"acls,omitempty"`
+}
+
+// CredentialChange is a change made by Client.SyncConsumerCredentials.
+type CredentialChange struct {
+	Operation MutationOperation
+	// Type is the type of the credential, e.g. "key-auth" or "acl".
+	Type string
+	// Identifier is the key, username or group of the credential.
+	Identifier string
+}
+
+// CredentialSyncResult summarizes the changes made by
+// Client.SyncConsumerCredentials.
+type CredentialSyncResult struct {
+	Changes   []CredentialChange
+	Unchanged int
+}
+
+// SyncConsumerCredentials creates, updates and deletes the key-auth,
+// basic-auth, jwt, hmac-auth and acl credentials of the consumer with
+// consumerUsernameOrID so that they match desired, e.g. when onboarding
+// or offboarding an API client.
+//
+// Kong only stores a hash of basic-auth passwords, so they are only set
+// when the credentials are created.
+//
+// Credentials are synced one type after the other. If a request fails,
+// the changes made until then are returned along with the error.
+func (c *Client) SyncConsumerCredentials(ctx context.Context,
+	consumerUsernameOrID *string, desired *ConsumerCredentials,
+) (*CredentialSyncResult, error) {
+	if isEmptyString(consumerUsernameOrID) {
+		return nil, fmt.Errorf("consumerUsernameOrID cannot be nil for SyncConsumerCredentials operation")
+	}
+	if desired == nil {
+		return nil, fmt.Errorf("cannot sync nil credentials")
+	}
+
+	result := &CredentialSyncResult{}
+	err := syncCredentials(ctx, consumerUsernameOrID, desired.KeyAuths, result, credentialOps[KeyAuth]{
+		kind: "key-auth",
+		key:  func(k *KeyAuth) *string { return k.Key },
+		id:   func(k *KeyAuth) *string { return k.ID },
+		equal: func(desired, actual *KeyAuth) bool {
+			return sameTags(desired.Tags, actual.Tags)
+		},
+		setID:  func(k *KeyAuth, id *string) { k.ID = id },
+		list:   c.KeyAuths.ListForConsumer,
+		create: c.KeyAuths.Create,
+		update: c.KeyAuths.Update,
+		delete: c.KeyAuths.Delete,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = syncCredentials(ctx, consumerUsernameOrID, desired.BasicAuths, result, credentialOps[BasicAuth]{
+		kind: "basic-auth",
+		key:  func(b *BasicAuth) *string { return b.Username },
+		id:   func(b *BasicAuth) *string { return b.ID },
+		equal: func(desired, actual *BasicAuth) bool {
+			return sameTags(desired.Tags, actual.Tags)
+		},
+		setID: func(b *BasicAuth, id *string) {
+			b.ID = id
+			// the password would be hashed again
+			b.Password = nil
+		},
+		list:   c.BasicAuths.ListForConsumer,
+		create: c.BasicAuths.Create,
+		update: c.BasicAuths.Update,
+		delete: c.BasicAuths.Delete,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = syncCredentials(ctx, consumerUsernameOrID, desired.JWTAuths, result, credentialOps[JWTAuth]{
+		kind: "jwt",
+		key:  func(j *JWTAuth) *string { return j.Key },
+		id:   func(j *JWTAuth) *string { return j.ID },
+		equal: func(desired, actual *JWTAuth) bool {
+			return sameTags(desired.Tags, actual.Tags) &&
+				(desired.Algorithm == nil || derefString(desired.Algorithm) == derefString(actual.Algorithm)) &&
+				(desired.Secret == nil || derefString(desired.Secret) == derefString(actual.Secret)) &&
+				(desired.RSAPublicKey == nil || derefString(desired.RSAPublicKey) == derefString(actual.RSAPublicKey))
+		},
+		setID:  func(j *JWTAuth, id *string) { j.ID = id },
+		list:   c.JWTAuths.ListForConsumer,
+		create: c.JWTAuths.Create,
+		update: c.JWTAuths.Update,
+		delete: c.JWTAuths.Delete,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = syncCredentials(ctx, consumerUsernameOrID, desired.HMACAuths, result, credentialOps[HMACAuth]{
+		kind: "hmac-auth",
+		key:  func(h *HMACAuth) *string { return h.Username },
+		id:   func(h *HMACAuth) *string { return h.ID },
+		equal: func(desired, actual *HMACAuth) bool {
+			return sameTags(desired.Tags, actual.Tags) &&
+				(desired.Secret == nil || derefString(desired.Secret) == derefString(actual.Secret))
+		},
+		setID:  func(h *HMACAuth, id *string) { h.ID = id },
+		list:   c.HMACAuths.ListForConsumer,
+		create: c.HMACAuths.Create,
+		update: c.HMACAuths.Update,
+		delete: c.HMACAuths.Delete,
+	})
+	if err != nil {
+		return result, err
+	}
+	err = syncCredentials(ctx, consumerUsernameOrID, desired.ACLGroups, result, credentialOps[ACLGroup]{
+		kind: "acl",
+		key:  func(a *ACLGroup) *string { return a.Group },
+		id:   func(a *ACLGroup) *string { return a.ID },
+		equal: func(desired, actual *ACLGroup) bool {
+			return sameTags(desired.Tags, actual.Tags)
+		},
+		setID:  func(a *ACLGroup, id *string) { a.ID = id },
+		list:   c.ACLs.ListForConsumer,
+		create: c.ACLs.Create,
+		update: c.ACLs.Update,
+		delete: c.ACLs.Delete,
+	})
+	return result, err
+}
+
+// credentialOps are the operations SyncConsumerCredentials needs on
+// credentials of type T.
+type credentialOps[T any] struct {
+	kind   string
+	key    func(*T) *string
+	id     func(*T) *string
+	equal  func(desired, actual *T) bool
+	setID  func(*T, *string)
+	list   func(ctx context.Context, consumerUsernameOrID *string, opt *ListOpt) ([]*T, *ListOpt, error)
+	create func(ctx context.Context, consumerUsernameOrID *string, credential *T) (*T, error)
+	update func(ctx context.Context, consumerUsernameOrID *string, credential *T) (*T, error)
+	delete func(ctx context.Context, consumerUsernameOrID, id *string) error
+}
+
+func syncCredentials[T any](ctx context.Context, consumerUsernameOrID *string,
+	desired []*T, result *CredentialSyncResult, ops credentialOps[T],
+) error {
+	if desired == nil {
+		return nil
+	}
+	wanted := map[string]*T{}
+	for _, credential := range desired {
+		key := ops.key(credential)
+		if isEmptyString(key) {
+			return fmt.Errorf("%s credentials must have a key, username or group to be synced", ops.kind)
+		}
+		wanted[*key] = credential
+	}
+
+	actual, err := listAllForConsumer(ctx, consumerUsernameOrID, ops.list)
+	if err != nil {
+		return fmt.Errorf("listing %s credentials: %w", ops.kind, err)
+	}
+
+	existing := map[string]bool{}
+	for _, credential := range actual {
+		key := derefString(ops.key(credential))
+		want, ok := wanted[key]
+		if !ok || existing[key] {
+			if err := ops.delete(ctx, consumerUsernameOrID, ops.id(credential)); err != nil {
+				return fmt.Errorf("deleting %s credential %s: %w", ops.kind, key, err)
+			}
+			result.Changes = append(result.Changes, CredentialChange{MutationDelete, ops.kind, key})
+			continue
+		}
+		existing[key] = true
+		if ops.equal(want, credential) {
+			result.Unchanged++
+			continue
+		}
+		update := *want
+		ops.setID(&update, ops.id(credential))
+		if _, err := ops.update(ctx, consumerUsernameOrID, &update); err != nil {
+			return fmt.Errorf("updating %s credential %s: %w", ops.kind, key, err)
+		}
+		result.Changes = append(result.Changes, CredentialChange{MutationUpdate, ops.kind, key})
+	}
+
+	for _, credential := range desired {
+		key := *ops.key(credential)
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+		if _, err := ops.create(ctx, consumerUsernameOrID, credential); err != nil {
+			return fmt.Errorf("creating %s credential %s: %w", ops.kind, key, err)
+		}
+		result.Changes = append(result.Changes, CredentialChange{MutationCreate, ops.kind, key})
+	}
+	return nil
+}
+
+// listAllForConsumer fetches all the credentials of a consumer with
+// list, a ListForConsumer method.
+func listAllForConsumer[T any](ctx context.Context, consumerUsernameOrID *string,
+	list func(context.Context, *string, *ListOpt) ([]*T, *ListOpt, error),
+) ([]*T, error) {
+	var credentials, data []*T
+	var err error
+	opt := &ListOpt{Size: pageSize}
+	for opt != nil {
+		data, opt, err = list(ctx, consumerUsernameOrID, opt)
+		if err != nil {
+			return nil, err
+		}
+		credentials = append(credentials, data...)
+	}
+	return credentials, nil
+}
+
+// sameTags returns true if a and b hold the same tags, in any order.
+func sameTags(a, b []*string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as, bs := make([]string, len(a)), make([]string, len(b))
+	for i := range a {
+		as[i], bs[i] = derefString(a[i]), derefString(b[i])
+	}
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
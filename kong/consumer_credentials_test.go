@@ -0,0 +1,97 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncConsumerCredentials(t *testing.T) {
+	existing := map[string]string{
+		"key-auth": `[{"id":"k1","key":"keep"},{"id":"k2","key":"retag","tags":["old"]},{"id":"k3","key":"revoke"}]`,
+		"acls":     `[{"id":"a1","group":"admins"}]`,
+		"jwt":      `[{"id":"j1","key":"iss","algorithm":"HS256","secret":"s"}]`,
+	}
+	var requests []string
+	bodies := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/consumers/alice/")
+		requests = append(requests, r.Method+" "+path)
+		if r.Method == http.MethodGet {
+			data, ok := existing[path]
+			if !ok {
+				data = `[]`
+			}
+			_, _ = w.Write([]byte(`{"data":` + data + `}`))
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies[r.Method+" "+path] = body
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	result, err := client.SyncConsumerCredentials(defaultCtx, String("alice"), &ConsumerCredentials{
+		KeyAuths: []*KeyAuth{
+			{Key: String("keep")},
+			{Key: String("retag"), Tags: StringSlice("new")},
+			{Key: String("fresh")},
+		},
+		BasicAuths: []*BasicAuth{{Username: String("alice"), Password: String("secret")}},
+		JWTAuths:   []*JWTAuth{{Key: String("iss"), Algorithm: String("HS256"), Secret: String("rotated")}},
+		ACLGroups:  []*ACLGroup{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, []CredentialChange{
+		{MutationUpdate, "key-auth", "retag"},
+		{MutationDelete, "key-auth", "revoke"},
+		{MutationCreate, "key-auth", "fresh"},
+		{MutationCreate, "basic-auth", "alice"},
+		{MutationUpdate, "jwt", "iss"},
+		{MutationDelete, "acl", "admins"},
+	}, result.Changes)
+	assert.Equal(t, []string{
+		"GET key-auth",
+		"PATCH key-auth/k2",
+		"DELETE key-auth/k3",
+		"POST key-auth",
+		"GET basic-auth",
+		"POST basic-auth",
+		"GET jwt",
+		"PATCH jwt/j1",
+		"GET acls",
+		"DELETE acls/a1",
+	}, requests)
+	assert.Equal(t, "secret", bodies["POST basic-auth"]["password"])
+	assert.Equal(t, "rotated", bodies["PATCH jwt/j1"]["secret"])
+
+	// unmanaged credential types are left untouched
+	requests = nil
+	result, err = client.SyncConsumerCredentials(defaultCtx, String("alice"), &ConsumerCredentials{
+		ACLGroups: []*ACLGroup{{Group: String("admins")}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Changes)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Equal(t, []string{"GET acls"}, requests)
+
+	_, err = client.SyncConsumerCredentials(defaultCtx, String("alice"), &ConsumerCredentials{
+		KeyAuths: []*KeyAuth{{}},
+	})
+	assert.Error(t, err)
+	_, err = client.SyncConsumerCredentials(defaultCtx, nil, &ConsumerCredentials{})
+	assert.Error(t, err)
+}
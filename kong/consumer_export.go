@@ -0,0 +1,273 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DocumentFormat is the format of a document exported from Kong.
+type DocumentFormat string
+
+const (
+	// DocumentFormatJSON formats documents as JSON.
+	DocumentFormatJSON DocumentFormat = "json"
+	// DocumentFormatYAML formats documents as YAML.
+	DocumentFormatYAML DocumentFormat = "yaml"
+	// DocumentFormatNDJSON formats documents as newline-delimited JSON,
+	// one EntityRecord per line.
+	DocumentFormatNDJSON DocumentFormat = "ndjson"
+)
+
+// ConsumerDocument is a portable document holding consumers along with
+// their credentials, see Client.ExportConsumers.
+type ConsumerDocument struct {
+	Consumers []*ExportedConsumer `json:"consumers" yaml:"consumers"`
+}
+
+// ExportedConsumer is a consumer in a ConsumerDocument.
+type ExportedConsumer struct {
+	Consumer            `yaml:",inline"`
+	ConsumerCredentials `yaml:",inline"`
+}
+
+// Marshal formats d as JSON, YAML or newline-delimited JSON, with a
+// "consumers" record per consumer.
+func (d *ConsumerDocument) Marshal(format DocumentFormat) ([]byte, error) {
+	switch format {
+	case DocumentFormatJSON:
+		return json.MarshalIndent(d, "", "  ")
+	case DocumentFormatYAML:
+		return yaml.Marshal(d)
+	case DocumentFormatNDJSON:
+		var buf bytes.Buffer
+		for _, consumer := range d.Consumers {
+			record, err := NewEntityRecord("consumers", consumer)
+			if err != nil {
+				return nil, err
+			}
+			if err := writeNDJSON(&buf, record); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, fmt.Errorf("unknown document format: %v", format)
+}
+
+// UnmarshalConsumerDocument parses a ConsumerDocument formatted as JSON,
+// YAML or newline-delimited JSON.
+func UnmarshalConsumerDocument(data []byte) (*ConsumerDocument, error) {
+	var document ConsumerDocument
+	if isNDJSON(data) {
+		document.Consumers = []*ExportedConsumer{}
+		err := readEntityRecords(data, func(record EntityRecord) error {
+			if record.Type != "consumers" {
+				return fmt.Errorf("unexpected entity type %q", record.Type)
+			}
+			var consumer ExportedConsumer
+			if err := yaml.UnmarshalStrict(record.Entity, &consumer); err != nil {
+				return err
+			}
+			document.Consumers = append(document.Consumers, &consumer)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("decoding consumer document: %w", err)
+		}
+		return &document, nil
+	}
+	// YAML is a superset of JSON
+	if err := yaml.UnmarshalStrict(data, &document); err != nil {
+		return nil, fmt.Errorf("decoding consumer document: %w", err)
+	}
+	return &document, nil
+}
+
+// ExportConsumers fetches all consumers in Kong along with their
+// key-auth, basic-auth, jwt, hmac-auth and acl credentials, e.g. to
+// migrate them to another cluster or workspace with ImportConsumers.
+//
+// IDs and creation timestamps are left out of the document, since they
+// must be unique across workspaces. Basic-auth passwords are left out
+// too: Kong only stores their hash, which would be hashed again when
+// imported. They must be added to the document to create basic-auth
+// credentials missing from the target.
+func (c *Client) ExportConsumers(ctx context.Context) (*ConsumerDocument, error) {
+	consumers, err := c.Consumers.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing consumers: %w", err)
+	}
+	document := &ConsumerDocument{Consumers: []*ExportedConsumer{}}
+	byID := map[string]*ExportedConsumer{}
+	for _, consumer := range consumers {
+		exported := &ExportedConsumer{Consumer: *consumer}
+		byID[derefString(consumer.ID)] = exported
+		exported.ID, exported.CreatedAt = nil, nil
+		document.Consumers = append(document.Consumers, exported)
+	}
+
+	// listing each credential type once is cheaper than listing the
+	// credentials of every consumer
+	keyAuths, err := c.KeyAuths.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing key-auth credentials: %w", err)
+	}
+	for _, keyAuth := range keyAuths {
+		if exported := byID[credentialConsumerID(keyAuth.Consumer)]; exported != nil {
+			keyAuth.ID, keyAuth.CreatedAt, keyAuth.Consumer = nil, nil, nil
+			exported.KeyAuths = append(exported.KeyAuths, keyAuth)
+		}
+	}
+	basicAuths, err := c.BasicAuths.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing basic-auth credentials: %w", err)
+	}
+	for _, basicAuth := range basicAuths {
+		if exported := byID[credentialConsumerID(basicAuth.Consumer)]; exported != nil {
+			basicAuth.ID, basicAuth.CreatedAt, basicAuth.Consumer = nil, nil, nil
+			basicAuth.Password = nil
+			exported.BasicAuths = append(exported.BasicAuths, basicAuth)
+		}
+	}
+	jwtAuths, err := c.JWTAuths.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing jwt credentials: %w", err)
+	}
+	for _, jwtAuth := range jwtAuths {
+		if exported := byID[credentialConsumerID(jwtAuth.Consumer)]; exported != nil {
+			jwtAuth.ID, jwtAuth.CreatedAt, jwtAuth.Consumer = nil, nil, nil
+			exported.JWTAuths = append(exported.JWTAuths, jwtAuth)
+		}
+	}
+	hmacAuths, err := c.HMACAuths.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing hmac-auth credentials: %w", err)
+	}
+	for _, hmacAuth := range hmacAuths {
+		if exported := byID[credentialConsumerID(hmacAuth.Consumer)]; exported != nil {
+			hmacAuth.ID, hmacAuth.CreatedAt, hmacAuth.Consumer = nil, nil, nil
+			exported.HMACAuths = append(exported.HMACAuths, hmacAuth)
+		}
+	}
+	aclGroups, err := c.ACLs.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing acls: %w", err)
+	}
+	for _, aclGroup := range aclGroups {
+		if exported := byID[credentialConsumerID(aclGroup.Consumer)]; exported != nil {
+			aclGroup.ID, aclGroup.CreatedAt, aclGroup.Consumer = nil, nil, nil
+			exported.ACLGroups = append(exported.ACLGroups, aclGroup)
+		}
+	}
+	return document, nil
+}
+
+func credentialConsumerID(consumer *Consumer) string {
+	if consumer == nil {
+		return ""
+	}
+	return derefString(consumer.ID)
+}
+
+// ConsumerImportResult summarizes the changes made by
+// Client.ImportConsumers.
+type ConsumerImportResult struct {
+	// Created and Updated hold the usernames, or custom IDs, of the
+	// consumers created and updated.
+	Created []string
+	Updated []string
+	// Unchanged is the number of consumers left as they were.
+	Unchanged int
+	// Credentials maps the usernames, or custom IDs, of consumers to the
+	// changes made to their credentials.
+	Credentials map[string]*CredentialSyncResult
+}
+
+// ImportConsumers creates the consumers of document in Kong, or updates
+// them if they exist, and syncs their credentials with
+// SyncConsumerCredentials. Consumers are matched by username, then by
+// custom ID.
+//
+// Credential types absent from a consumer of document are left
+// untouched, so that importing is never destructive for them.
+//
+// If a request fails, the changes made until then are returned along
+// with the error.
+func (c *Client) ImportConsumers(ctx context.Context,
+	document *ConsumerDocument,
+) (*ConsumerImportResult, error) {
+	if document == nil {
+		return nil, fmt.Errorf("cannot import a nil consumer document")
+	}
+	ctx = withoutIdempotencyKey(ctx)
+	result := &ConsumerImportResult{Credentials: map[string]*CredentialSyncResult{}}
+	for _, imported := range document.Consumers {
+		var name string
+		switch {
+		case !isEmptyString(imported.Username):
+			name = *imported.Username
+		case !isEmptyString(imported.CustomID):
+			name = *imported.CustomID
+		default:
+			return result, fmt.Errorf("consumers must have a username or custom ID to be imported")
+		}
+
+		consumer, err := c.findConsumer(ctx, &imported.Consumer)
+		if err != nil {
+			return result, fmt.Errorf("fetching consumer %s: %w", name, err)
+		}
+		desired := imported.Consumer
+		desired.CreatedAt = nil
+		switch {
+		case consumer == nil:
+			desired.ID = nil
+			consumer, err = c.Consumers.Create(ctx, &desired)
+			if err != nil {
+				return result, fmt.Errorf("creating consumer %s: %w", name, err)
+			}
+			result.Created = append(result.Created, name)
+		case derefString(consumer.Username) != derefString(desired.Username) ||
+			derefString(consumer.CustomID) != derefString(desired.CustomID) ||
+			!sameTags(consumer.Tags, desired.Tags):
+			desired.ID = consumer.ID
+			consumer, err = c.Consumers.Update(ctx, &desired)
+			if err != nil {
+				return result, fmt.Errorf("updating consumer %s: %w", name, err)
+			}
+			result.Updated = append(result.Updated, name)
+		default:
+			result.Unchanged++
+		}
+
+		credentials, err := c.SyncConsumerCredentials(ctx, consumer.ID, &imported.ConsumerCredentials)
+		if credentials != nil {
+			result.Credentials[name] = credentials
+		}
+		if err != nil {
+			return result, fmt.Errorf("syncing credentials of consumer %s: %w", name, err)
+		}
+	}
+	return result, nil
+}
+
+// findConsumer fetches the consumer with the username, or else the
+// custom ID, of consumer. It returns nil if there is none.
+func (c *Client) findConsumer(ctx context.Context, consumer *Consumer) (*Consumer, error) {
+	if !isEmptyString(consumer.Username) {
+		found, err := c.Consumers.Get(ctx, consumer.Username)
+		if err == nil || !IsNotFoundErr(err) {
+			return found, err
+		}
+	}
+	if !isEmptyString(consumer.CustomID) {
+		found, err := c.Consumers.GetByCustomID(ctx, consumer.CustomID)
+		if err == nil || !IsNotFoundErr(err) {
+			return found, err
+		}
+	}
+	return nil, nil
+}
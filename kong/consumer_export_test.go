@@ -0,0 +1,107 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportConsumers(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data := map[string]string{
+			"/consumers": `[{"id":"c1","username":"alice","created_at":1,"tags":["t"]},
+				{"id":"c2","custom_id":"bob-42"}]`,
+			"/key-auths":   `[{"id":"k1","key":"secret","created_at":1,"consumer":{"id":"c1"}}]`,
+			"/basic-auths": `[{"id":"b1","username":"alice","password":"hash","consumer":{"id":"c1"}}]`,
+			"/jwts":        `[{"id":"j1","key":"iss","secret":"s","consumer":{"id":"c2"}}]`,
+			"/hmac-auths":  `[]`,
+			"/acls":        `[{"id":"a1","group":"admins","consumer":{"id":"c1"}},{"id":"a2","group":"x","consumer":{"id":"gone"}}]`,
+		}[r.URL.Path]
+		if data == "" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"data":` + data + `}`))
+	}))
+	defer source.Close()
+	client, err := NewClient(String(source.URL), nil)
+	require.NoError(t, err)
+
+	document, err := client.ExportConsumers(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, document.Consumers, 2)
+	alice := document.Consumers[0]
+	assert.Nil(t, alice.ID)
+	assert.Nil(t, alice.CreatedAt)
+	assert.Equal(t, []*KeyAuth{{Key: String("secret")}}, alice.KeyAuths)
+	assert.Equal(t, []*BasicAuth{{Username: String("alice")}}, alice.BasicAuths)
+	assert.Equal(t, []*ACLGroup{{Group: String("admins")}}, alice.ACLGroups)
+	assert.Equal(t, []*JWTAuth{{Key: String("iss"), Secret: String("s")}}, document.Consumers[1].JWTAuths)
+
+	b, err := document.Marshal(DocumentFormatYAML)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "keyauth_credentials:")
+	parsed, err := UnmarshalConsumerDocument(b)
+	require.NoError(t, err)
+	assert.Equal(t, document, parsed)
+	b, err = document.Marshal(DocumentFormatJSON)
+	require.NoError(t, err)
+	parsed, err = UnmarshalConsumerDocument(b)
+	require.NoError(t, err)
+	assert.Equal(t, document, parsed)
+	b, err = document.Marshal(DocumentFormatNDJSON)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, len(document.Consumers))
+	assert.True(t, strings.HasPrefix(lines[0], `{"type":"consumers","entity":{`))
+	parsed, err = UnmarshalConsumerDocument(b)
+	require.NoError(t, err)
+	assert.Equal(t, document, parsed)
+	_, err = UnmarshalConsumerDocument([]byte(`{"type":"services","entity":{}}`))
+	assert.EqualError(t, err, `decoding consumer document: record 1: unexpected entity type "services"`)
+	_, err = document.Marshal("xml")
+	assert.Error(t, err)
+	_, err = UnmarshalConsumerDocument([]byte(`{"consumers":[{"nope":1}]}`))
+	assert.Error(t, err)
+
+	var requests []string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.Method + " " + r.URL.Path {
+		case "GET /consumers/alice":
+			_, _ = w.Write([]byte(`{"id":"c9","username":"alice","tags":["t"]}`))
+		case "GET /consumers":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "POST /consumers":
+			_, _ = w.Write([]byte(`{"id":"c10","custom_id":"bob-42"}`))
+		case "GET /consumers/c9/basic-auth":
+			_, _ = w.Write([]byte(`{"data":[{"id":"b9","username":"alice"}]}`))
+		case "GET /consumers/c9/key-auth", "GET /consumers/c9/acls", "GET /consumers/c10/jwt":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "POST /consumers/c9/key-auth", "POST /consumers/c9/acls", "POST /consumers/c10/jwt":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		}
+	}))
+	defer target.Close()
+	client, err = NewClient(String(target.URL), nil)
+	require.NoError(t, err)
+
+	result, err := client.ImportConsumers(defaultCtx, parsed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob-42"}, result.Created)
+	assert.Empty(t, result.Updated)
+	assert.Equal(t, 1, result.Unchanged)
+	assert.Len(t, result.Credentials["alice"].Changes, 2)
+	assert.Equal(t, 1, result.Credentials["alice"].Unchanged)
+	assert.Equal(t, []CredentialChange{{MutationCreate, "jwt", "iss"}}, result.Credentials["bob-42"].Changes)
+	assert.NotContains(t, requests, "GET /consumers/c9/jwt")
+
+	_, err = client.ImportConsumers(defaultCtx, &ConsumerDocument{Consumers: []*ExportedConsumer{{}}})
+	assert.Error(t, err)
+}
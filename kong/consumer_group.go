@@ -1,5 +1,7 @@
 package kong
 
+import "fmt"
+
 // ConsumerGroupObject represents a ConsumerGroup in Kong.
 // +k8s:deepcopy-gen=true
 type ConsumerGroupObject struct {
@@ -33,6 +35,50 @@ type ConsumerGroupRLA struct {
 	Plugin        *string       `json:"plugin,omitempty" yaml:"plugin,omitempty"`
 }
 
+// RateLimitingAdvancedConfig is the part of the configuration of the
+// rate-limiting-advanced plugin which can be overridden per ConsumerGroup.
+type RateLimitingAdvancedConfig struct {
+	// Limit holds the number of requests allowed in each window,
+	// matching the windows in WindowSize.
+	Limit []float64 `json:"limit"`
+	// WindowSize holds the sizes of the windows in seconds.
+	WindowSize []int `json:"window_size"`
+	// WindowType is either "sliding" or "fixed".
+	WindowType *string `json:"window_type,omitempty"`
+	// RetryAfterJitterMax is the upper bound, in seconds, of the jitter
+	// added to the Retry-After header of rejected requests.
+	RetryAfterJitterMax *int `json:"retry_after_jitter_max,omitempty"`
+}
+
+// Validate checks that c can be accepted by Kong.
+func (c *RateLimitingAdvancedConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("config cannot be nil")
+	}
+	if len(c.Limit) == 0 {
+		return fmt.Errorf("at least one limit is required")
+	}
+	if len(c.Limit) != len(c.WindowSize) {
+		return fmt.Errorf("limit and window_size must have the same length, got %d and %d",
+			len(c.Limit), len(c.WindowSize))
+	}
+	for i := range c.Limit {
+		if c.Limit[i] <= 0 {
+			return fmt.Errorf("limit must be positive, got %v", c.Limit[i])
+		}
+		if c.WindowSize[i] <= 0 {
+			return fmt.Errorf("window_size must be positive, got %d", c.WindowSize[i])
+		}
+	}
+	if c.WindowType != nil && *c.WindowType != "sliding" && *c.WindowType != "fixed" {
+		return fmt.Errorf("window_type must be sliding or fixed, got %q", *c.WindowType)
+	}
+	if c.RetryAfterJitterMax != nil && *c.RetryAfterJitterMax < 0 {
+		return fmt.Errorf("retry_after_jitter_max must be positive, got %d", *c.RetryAfterJitterMax)
+	}
+	return nil
+}
+
 // ConsumerGroupPlugin represents a ConsumerGroupPlugin in Kong.
 // +k8s:deepcopy-gen=true
 type ConsumerGroupPlugin struct {
@@ -25,6 +25,11 @@ type AbstractConsumerGroupService interface {
 	UpdateRateLimitingAdvancedPlugin(
 		ctx context.Context, nameOrID *string, config map[string]Configuration,
 	) (*ConsumerGroupRLA, error)
+	// UpdateRateLimitingAdvancedConfig overrides the RLA plugin configuration
+	// for a ConsumerGroup in Kong.
+	UpdateRateLimitingAdvancedConfig(
+		ctx context.Context, nameOrID *string, config *RateLimitingAdvancedConfig,
+	) (*ConsumerGroupRLA, error)
 }
 
 // ConsumerGroupService handles ConsumerGroup in Kong.
@@ -32,10 +37,23 @@ type ConsumerGroupService service
 
 // Create creates a ConsumerGroup in Kong.
 // If an ID is specified, it will be used to create a consumer group in Kong,
-// otherwise an ID is auto-generated.
+// otherwise an ID is auto-generated, unless creates are idempotent,
+// see WithIdempotencyKey and SetIdempotentCreates.
 func (s *ConsumerGroupService) Create(ctx context.Context,
 	consumerGroup *ConsumerGroup,
 ) (*ConsumerGroup, error) {
+	if consumerGroup.ID == nil {
+		id, err := s.client.idempotentID(ctx, consumerGroup, consumerGroup.Name)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			withID := *consumerGroup
+			withID.ID = id
+			consumerGroup = &withID
+		}
+	}
+
 	queryPath := "/consumer_groups"
 	method := "POST"
 	if consumerGroup.ID != nil {
@@ -134,7 +152,7 @@ func (s *ConsumerGroupService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var consumer ConsumerGroup
-		err = json.Unmarshal(b, &consumer)
+		err = s.client.decode(b, &consumer)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -183,3 +201,23 @@ func (s *ConsumerGroupService) UpdateRateLimitingAdvancedPlugin(
 	}
 	return &rla, nil
 }
+
+// UpdateRateLimitingAdvancedConfig overrides the configuration of the
+// rate-limiting-advanced plugin for the ConsumerGroup nameOrID in Kong.
+// config is validated before being sent.
+func (s *ConsumerGroupService) UpdateRateLimitingAdvancedConfig(
+	ctx context.Context, nameOrID *string, config *RateLimitingAdvancedConfig,
+) (*ConsumerGroupRLA, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate-limiting-advanced config: %w", err)
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var c Configuration
+	if err := s.client.decode(b, &c); err != nil {
+		return nil, err
+	}
+	return s.UpdateRateLimitingAdvancedPlugin(ctx, nameOrID, map[string]Configuration{"config": c})
+}
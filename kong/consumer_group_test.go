@@ -1,6 +1,9 @@
 package kong
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/uuid"
@@ -242,3 +245,39 @@ func compareConsumerGroups(expected, actual []*ConsumerGroup) bool {
 
 	return (compareSlices(expectedNames, actualNames))
 }
+
+func TestConsumerGroupUpdateRateLimitingAdvancedConfig(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT /consumer_groups/gold/overrides/plugins/rate-limiting-advanced",
+			r.Method+" "+r.URL.Path)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"config": map[string]interface{}{
+				"limit":       []interface{}{float64(10)},
+				"window_size": []interface{}{float64(60)},
+				"window_type": "sliding",
+			},
+		}, body)
+		body["consumer_group"] = "gold"
+		body["plugin"] = "rate-limiting-advanced"
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	override, err := client.ConsumerGroups.UpdateRateLimitingAdvancedConfig(defaultCtx, String("gold"),
+		&RateLimitingAdvancedConfig{
+			Limit:      []float64{10},
+			WindowSize: []int{60},
+			WindowType: String("sliding"),
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "gold", *override.ConsumerGroup)
+
+	_, err = client.ConsumerGroups.UpdateRateLimitingAdvancedConfig(defaultCtx, String("gold"),
+		&RateLimitingAdvancedConfig{Limit: []float64{10, 100}, WindowSize: []int{60}})
+	assert.EqualError(t, err, "invalid rate-limiting-advanced config: "+
+		"limit and window_size must have the same length, got 2 and 1")
+}
@@ -0,0 +1,226 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrConsumerMergeConflict is returned by Client.MergeConsumers when
+// plugins are configured for both consumers with the same scope, in
+// which case Kong can't hold both once merged.
+type ErrConsumerMergeConflict struct {
+	// Plugins holds the names of the conflicting plugins.
+	Plugins []string
+}
+
+func (e *ErrConsumerMergeConflict) Error() string {
+	return fmt.Sprintf("plugins configured for both consumers: %s", strings.Join(e.Plugins, ", "))
+}
+
+// IsConsumerMergeConflictErr returns true if the error or its cause is
+// an ErrConsumerMergeConflict.
+func IsConsumerMergeConflictErr(e error) bool {
+	var conflictErr *ErrConsumerMergeConflict
+	return errors.As(e, &conflictErr)
+}
+
+// ConsumerMergeResult summarizes the changes made by
+// Client.MergeConsumers.
+type ConsumerMergeResult struct {
+	// Target is the consumer the source consumer was merged into.
+	Target *Consumer
+	// Credentials holds the credentials moved to the target consumer,
+	// as updates, and the ACL groups of the source consumer the target
+	// consumer was already in, as deletions.
+	Credentials []CredentialChange
+	// Plugins holds the plugins moved to the target consumer.
+	Plugins []*Plugin
+}
+
+// credentialRef references a credential to move to another consumer.
+type credentialRef struct {
+	kind string
+	// endpoint is the top-level endpoint of the credential type, which
+	// allows changing the consumer of credentials.
+	endpoint   string
+	id         string
+	identifier string
+}
+
+// MergeConsumers merges the consumer with sourceUsernameOrID into the
+// consumer with targetUsernameOrID: the credentials, ACL groups and
+// plugins of the source consumer are moved to the target consumer, then
+// the source consumer is deleted.
+//
+// Credentials are moved rather than recreated, so that their secrets,
+// including hashed basic-auth passwords, are kept. ACL groups the
+// target consumer is already in are deleted instead.
+//
+// If plugins of the same name and scope are configured for both
+// consumers, an ErrConsumerMergeConflict is returned before any change
+// is made. Memberships of consumer groups aren't moved.
+//
+// If a request fails, the changes made until then are returned along
+// with the error, and the source consumer is left in place.
+func (c *Client) MergeConsumers(ctx context.Context,
+	sourceUsernameOrID, targetUsernameOrID *string,
+) (*ConsumerMergeResult, error) {
+	if isEmptyString(sourceUsernameOrID) {
+		return nil, fmt.Errorf("sourceUsernameOrID cannot be nil for MergeConsumers operation")
+	}
+	if isEmptyString(targetUsernameOrID) {
+		return nil, fmt.Errorf("targetUsernameOrID cannot be nil for MergeConsumers operation")
+	}
+	source, err := c.Consumers.Get(ctx, sourceUsernameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching source consumer: %w", err)
+	}
+	target, err := c.Consumers.Get(ctx, targetUsernameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching target consumer: %w", err)
+	}
+	if *source.ID == *target.ID {
+		return nil, fmt.Errorf("cannot merge consumer %s into itself", source.FriendlyName())
+	}
+
+	credentials, err := c.listCredentialRefs(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	targetACLGroups, err := listAllForConsumer(ctx, target.ID, c.ACLs.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing acls of target consumer: %w", err)
+	}
+	groups := map[string]bool{}
+	for _, aclGroup := range targetACLGroups {
+		groups[derefString(aclGroup.Group)] = true
+	}
+
+	plugins, err := c.Plugins.ListAllForConsumer(ctx, source.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins of source consumer: %w", err)
+	}
+	targetPlugins, err := c.Plugins.ListAllForConsumer(ctx, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins of target consumer: %w", err)
+	}
+	scopes := map[string]bool{}
+	for _, plugin := range targetPlugins {
+		scopes[pluginScope(plugin)] = true
+	}
+	var conflicts []string
+	for _, plugin := range plugins {
+		if scopes[pluginScope(plugin)] {
+			conflicts = append(conflicts, plugin.FriendlyName())
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, &ErrConsumerMergeConflict{Plugins: conflicts}
+	}
+
+	result := &ConsumerMergeResult{Target: target}
+	for _, credential := range credentials {
+		endpoint := credential.endpoint + "/" + credential.id
+		method, change := "PATCH", MutationUpdate
+		var body interface{} = map[string]interface{}{"consumer": map[string]string{"id": *target.ID}}
+		if credential.kind == "acl" && groups[credential.identifier] {
+			method, change, body = "DELETE", MutationDelete, nil
+		}
+		req, err := c.NewRequest(method, endpoint, nil, body)
+		if err != nil {
+			return result, err
+		}
+		if _, err := c.Do(ctx, req, nil); err != nil {
+			return result, fmt.Errorf("moving %s credential %s: %w", credential.kind, credential.identifier, err)
+		}
+		result.Credentials = append(result.Credentials, CredentialChange{change, credential.kind, credential.identifier})
+	}
+	for _, plugin := range plugins {
+		moved, err := c.Plugins.Update(ctx, &Plugin{ID: plugin.ID, Consumer: &Consumer{ID: target.ID}})
+		if err != nil {
+			return result, fmt.Errorf("moving plugin %s: %w", plugin.FriendlyName(), err)
+		}
+		result.Plugins = append(result.Plugins, moved)
+	}
+
+	if err := c.Consumers.Delete(ctx, source.ID); err != nil {
+		return result, fmt.Errorf("deleting source consumer: %w", err)
+	}
+	return result, nil
+}
+
+// listCredentialRefs fetches all the credentials of a consumer.
+func (c *Client) listCredentialRefs(ctx context.Context, consumerID *string) ([]credentialRef, error) {
+	var refs []credentialRef
+	add := func(kind, endpoint string, id, identifier *string) {
+		refs = append(refs, credentialRef{kind, endpoint, derefString(id), derefString(identifier)})
+	}
+
+	keyAuths, err := listAllForConsumer(ctx, consumerID, c.KeyAuths.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing key-auth credentials: %w", err)
+	}
+	for _, keyAuth := range keyAuths {
+		add("key-auth", "/key-auths", keyAuth.ID, keyAuth.Key)
+	}
+	basicAuths, err := listAllForConsumer(ctx, consumerID, c.BasicAuths.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing basic-auth credentials: %w", err)
+	}
+	for _, basicAuth := range basicAuths {
+		add("basic-auth", "/basic-auths", basicAuth.ID, basicAuth.Username)
+	}
+	jwtAuths, err := listAllForConsumer(ctx, consumerID, c.JWTAuths.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing jwt credentials: %w", err)
+	}
+	for _, jwtAuth := range jwtAuths {
+		add("jwt", "/jwts", jwtAuth.ID, jwtAuth.Key)
+	}
+	hmacAuths, err := listAllForConsumer(ctx, consumerID, c.HMACAuths.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing hmac-auth credentials: %w", err)
+	}
+	for _, hmacAuth := range hmacAuths {
+		add("hmac-auth", "/hmac-auths", hmacAuth.ID, hmacAuth.Username)
+	}
+	oauth2Credentials, err := listAllForConsumer(ctx, consumerID, c.Oauth2Credentials.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing oauth2 credentials: %w", err)
+	}
+	for _, oauth2Credential := range oauth2Credentials {
+		add("oauth2", "/oauth2", oauth2Credential.ID, oauth2Credential.ClientID)
+	}
+	mtlsAuths, err := listAllForConsumer(ctx, consumerID, c.MTLSAuths.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing mtls-auth credentials: %w", err)
+	}
+	for _, mtlsAuth := range mtlsAuths {
+		add("mtls-auth", "/mtls-auths", mtlsAuth.ID, mtlsAuth.SubjectName)
+	}
+	aclGroups, err := listAllForConsumer(ctx, consumerID, c.ACLs.ListForConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("listing acls: %w", err)
+	}
+	for _, aclGroup := range aclGroups {
+		add("acl", "/acls", aclGroup.ID, aclGroup.Group)
+	}
+	return refs, nil
+}
+
+// pluginScope identifies the plugins Kong allows only once per consumer.
+func pluginScope(plugin *Plugin) string {
+	scope := []string{derefString(plugin.Name)}
+	if plugin.Service != nil {
+		scope = append(scope, "service:"+derefString(plugin.Service.ID))
+	}
+	if plugin.Route != nil {
+		scope = append(scope, "route:"+derefString(plugin.Route.ID))
+	}
+	if plugin.ConsumerGroup != nil {
+		scope = append(scope, "consumer_group:"+derefString(plugin.ConsumerGroup.ID))
+	}
+	return strings.Join(scope, "|")
+}
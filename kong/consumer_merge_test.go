@@ -0,0 +1,88 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConsumers(t *testing.T) {
+	targetPlugins := `[{"id":"p9","name":"rate-limiting","service":{"id":"s1"}}]`
+	var requests []string
+	patched := map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /consumers/old":
+			_, _ = w.Write([]byte(`{"id":"c1","username":"old"}`))
+		case "GET /consumers/new":
+			_, _ = w.Write([]byte(`{"id":"c2","username":"new"}`))
+		case "GET /consumers/c1/key-auth":
+			_, _ = w.Write([]byte(`{"data":[{"id":"k1","key":"secret"}]}`))
+		case "GET /consumers/c1/basic-auth":
+			_, _ = w.Write([]byte(`{"data":[{"id":"b1","username":"old","password":"hash"}]}`))
+		case "GET /consumers/c1/acls":
+			_, _ = w.Write([]byte(`{"data":[{"id":"a1","group":"admins"},{"id":"a2","group":"ops"}]}`))
+		case "GET /consumers/c2/acls":
+			_, _ = w.Write([]byte(`{"data":[{"id":"a9","group":"admins"}]}`))
+		case "GET /consumers/c1/plugins":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"rate-limiting"}]}`))
+		case "GET /consumers/c2/plugins":
+			_, _ = w.Write([]byte(`{"data":` + targetPlugins + `}`))
+		case "GET /consumers/c1/jwt", "GET /consumers/c1/hmac-auth",
+			"GET /consumers/c1/oauth2", "GET /consumers/c1/mtls-auth":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		default:
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodPatch {
+				var body map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				patched[r.URL.Path] = body["consumer"]
+				_, _ = w.Write([]byte(`{"id":"p1","consumer":{"id":"c2"}}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	// rate-limiting on a service doesn't conflict with one on all services
+	result, err := client.MergeConsumers(defaultCtx, String("old"), String("new"))
+	require.NoError(t, err)
+	assert.Equal(t, "c2", *result.Target.ID)
+	assert.Equal(t, []CredentialChange{
+		{MutationUpdate, "key-auth", "secret"},
+		{MutationUpdate, "basic-auth", "old"},
+		{MutationDelete, "acl", "admins"},
+		{MutationUpdate, "acl", "ops"},
+	}, result.Credentials)
+	require.Len(t, result.Plugins, 1)
+	assert.Equal(t, []string{
+		"PATCH /key-auths/k1",
+		"PATCH /basic-auths/b1",
+		"DELETE /acls/a1",
+		"PATCH /acls/a2",
+		"PATCH /plugins/p1",
+		"DELETE /consumers/c1",
+	}, requests)
+	for _, consumer := range patched {
+		assert.Equal(t, map[string]interface{}{"id": "c2"}, consumer)
+	}
+
+	requests = nil
+	targetPlugins = `[{"id":"p9","name":"rate-limiting"}]`
+	_, err = client.MergeConsumers(defaultCtx, String("old"), String("new"))
+	assert.True(t, IsConsumerMergeConflictErr(err))
+	assert.Contains(t, err.Error(), "rate-limiting")
+	assert.Empty(t, requests)
+
+	_, err = client.MergeConsumers(defaultCtx, String("old"), String("old"))
+	assert.Error(t, err)
+	_, err = client.MergeConsumers(defaultCtx, nil, String("new"))
+	assert.Error(t, err)
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -23,6 +22,8 @@ type AbstractConsumerService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Consumer, *ListOpt, error)
 	// ListAll fetches all Consumers in Kong.
 	ListAll(ctx context.Context) ([]*Consumer, error)
+	// DeleteWhere deletes the Consumers in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Consumer) bool) ([]*Consumer, error)
 }
 
 // ConsumerService handles Consumers in Kong.
@@ -31,10 +32,27 @@ type ConsumerService service
 // Create creates a Consumer in Kong.
 // If an ID is specified, it will be used to
 // create a consumer in Kong, otherwise an ID
-// is auto-generated.
+// is auto-generated, unless creates are idempotent,
+// see WithIdempotencyKey and SetIdempotentCreates.
 func (s *ConsumerService) Create(ctx context.Context,
 	consumer *Consumer,
 ) (*Consumer, error) {
+	if err := ValidateTags(consumer.Tags); err != nil {
+		return nil, err
+	}
+
+	if consumer.ID == nil {
+		id, err := s.client.idempotentID(ctx, consumer, consumer.Username)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			withID := *consumer
+			withID.ID = id
+			consumer = &withID
+		}
+	}
+
 	queryPath := "/consumers"
 	method := "POST"
 	if consumer.ID != nil {
@@ -117,6 +135,9 @@ func (s *ConsumerService) Update(ctx context.Context,
 	if isEmptyString(consumer.ID) {
 		return nil, fmt.Errorf("ID cannot be nil for Update operation")
 	}
+	if err := ValidateTags(consumer.Tags); err != nil {
+		return nil, err
+	}
 
 	endpoint := fmt.Sprintf("/consumers/%v", *consumer.ID)
 	req, err := s.client.NewRequest("PATCH", endpoint, nil, consumer)
@@ -167,7 +188,7 @@ func (s *ConsumerService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var consumer Consumer
-		err = json.Unmarshal(b, &consumer)
+		err = s.client.decode(b, &consumer)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -194,3 +215,14 @@ func (s *ConsumerService) ListAll(ctx context.Context) ([]*Consumer, error) {
 	}
 	return consumers, nil
 }
+
+// DeleteWhere deletes the Consumers in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Consumers are deleted from them,
+// so that unlike deleting Consumers while paginating, none are skipped.
+func (s *ConsumerService) DeleteWhere(ctx context.Context,
+	predicate func(*Consumer) bool,
+) ([]*Consumer, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Consumer) *string { return entity.ID }, predicate)
+}
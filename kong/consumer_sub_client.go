@@ -0,0 +1,280 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConsumerSubClient gives access to credentials and plugins
+// of a single Consumer without having to pass the Consumer's
+// username or ID on every call.
+type ConsumerSubClient struct {
+	client   *Client
+	consumer *string
+}
+
+// ConsumerSubClient returns a ConsumerSubClient scoped to the Consumer
+// identified by consumerUsernameOrID.
+func (c *Client) ConsumerSubClient(consumerUsernameOrID *string) *ConsumerSubClient {
+	return &ConsumerSubClient{
+		client:   c,
+		consumer: consumerUsernameOrID,
+	}
+}
+
+// KeyAuths returns the key-auth credentials of the Consumer.
+func (c *ConsumerSubClient) KeyAuths() *ConsumerKeyAuths {
+	return &ConsumerKeyAuths{c}
+}
+
+// BasicAuths returns the basic-auth credentials of the Consumer.
+func (c *ConsumerSubClient) BasicAuths() *ConsumerBasicAuths {
+	return &ConsumerBasicAuths{c}
+}
+
+// ACLs returns the ACL groups of the Consumer.
+func (c *ConsumerSubClient) ACLs() *ConsumerACLs {
+	return &ConsumerACLs{c}
+}
+
+// Plugins returns the Plugins scoped to the Consumer.
+func (c *ConsumerSubClient) Plugins() *ConsumerPlugins {
+	return &ConsumerPlugins{c}
+}
+
+// ConsumerKeyAuths handles key-auth credentials of a single Consumer.
+type ConsumerKeyAuths struct {
+	sub *ConsumerSubClient
+}
+
+// Create creates a key-auth credential for the Consumer.
+func (s *ConsumerKeyAuths) Create(ctx context.Context, keyAuth *KeyAuth) (*KeyAuth, error) {
+	return s.sub.client.KeyAuths.Create(ctx, s.sub.consumer, keyAuth)
+}
+
+// Get fetches a key-auth credential of the Consumer.
+func (s *ConsumerKeyAuths) Get(ctx context.Context, keyOrID *string) (*KeyAuth, error) {
+	return s.sub.client.KeyAuths.Get(ctx, s.sub.consumer, keyOrID)
+}
+
+// Update updates a key-auth credential of the Consumer.
+func (s *ConsumerKeyAuths) Update(ctx context.Context, keyAuth *KeyAuth) (*KeyAuth, error) {
+	return s.sub.client.KeyAuths.Update(ctx, s.sub.consumer, keyAuth)
+}
+
+// Delete deletes a key-auth credential of the Consumer.
+func (s *ConsumerKeyAuths) Delete(ctx context.Context, keyOrID *string) error {
+	return s.sub.client.KeyAuths.Delete(ctx, s.sub.consumer, keyOrID)
+}
+
+// List fetches a list of key-auth credentials of the Consumer.
+// opt can be used to control pagination.
+func (s *ConsumerKeyAuths) List(ctx context.Context, opt *ListOpt) ([]*KeyAuth, *ListOpt, error) {
+	if isEmptyString(s.sub.consumer) {
+		return nil, nil, fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+	return s.sub.client.KeyAuths.ListForConsumer(ctx, s.sub.consumer, opt)
+}
+
+// ListAll fetches all key-auth credentials of the Consumer.
+func (s *ConsumerKeyAuths) ListAll(ctx context.Context) ([]*KeyAuth, error) {
+	var keyAuths, data []*KeyAuth
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		keyAuths = append(keyAuths, data...)
+	}
+	return keyAuths, nil
+}
+
+// ConsumerBasicAuths handles basic-auth credentials of a single Consumer.
+type ConsumerBasicAuths struct {
+	sub *ConsumerSubClient
+}
+
+// Create creates a basic-auth credential for the Consumer.
+func (s *ConsumerBasicAuths) Create(ctx context.Context, basicAuth *BasicAuth) (*BasicAuth, error) {
+	return s.sub.client.BasicAuths.Create(ctx, s.sub.consumer, basicAuth)
+}
+
+// Get fetches a basic-auth credential of the Consumer.
+func (s *ConsumerBasicAuths) Get(ctx context.Context, usernameOrID *string) (*BasicAuth, error) {
+	return s.sub.client.BasicAuths.Get(ctx, s.sub.consumer, usernameOrID)
+}
+
+// Update updates a basic-auth credential of the Consumer.
+func (s *ConsumerBasicAuths) Update(ctx context.Context, basicAuth *BasicAuth) (*BasicAuth, error) {
+	return s.sub.client.BasicAuths.Update(ctx, s.sub.consumer, basicAuth)
+}
+
+// Delete deletes a basic-auth credential of the Consumer.
+func (s *ConsumerBasicAuths) Delete(ctx context.Context, usernameOrID *string) error {
+	return s.sub.client.BasicAuths.Delete(ctx, s.sub.consumer, usernameOrID)
+}
+
+// List fetches a list of basic-auth credentials of the Consumer.
+// opt can be used to control pagination.
+func (s *ConsumerBasicAuths) List(ctx context.Context, opt *ListOpt) ([]*BasicAuth, *ListOpt, error) {
+	if isEmptyString(s.sub.consumer) {
+		return nil, nil, fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+	return s.sub.client.BasicAuths.ListForConsumer(ctx, s.sub.consumer, opt)
+}
+
+// ListAll fetches all basic-auth credentials of the Consumer.
+func (s *ConsumerBasicAuths) ListAll(ctx context.Context) ([]*BasicAuth, error) {
+	var basicAuths, data []*BasicAuth
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		basicAuths = append(basicAuths, data...)
+	}
+	return basicAuths, nil
+}
+
+// ConsumerACLs handles ACL groups of a single Consumer.
+type ConsumerACLs struct {
+	sub *ConsumerSubClient
+}
+
+// Create adds the Consumer to an ACL group.
+func (s *ConsumerACLs) Create(ctx context.Context, aclGroup *ACLGroup) (*ACLGroup, error) {
+	return s.sub.client.ACLs.Create(ctx, s.sub.consumer, aclGroup)
+}
+
+// Get fetches an ACL group of the Consumer.
+func (s *ConsumerACLs) Get(ctx context.Context, groupOrID *string) (*ACLGroup, error) {
+	return s.sub.client.ACLs.Get(ctx, s.sub.consumer, groupOrID)
+}
+
+// Update updates an ACL group of the Consumer.
+func (s *ConsumerACLs) Update(ctx context.Context, aclGroup *ACLGroup) (*ACLGroup, error) {
+	return s.sub.client.ACLs.Update(ctx, s.sub.consumer, aclGroup)
+}
+
+// Delete removes the Consumer from an ACL group.
+func (s *ConsumerACLs) Delete(ctx context.Context, groupOrID *string) error {
+	return s.sub.client.ACLs.Delete(ctx, s.sub.consumer, groupOrID)
+}
+
+// List fetches a list of ACL groups of the Consumer.
+// opt can be used to control pagination.
+func (s *ConsumerACLs) List(ctx context.Context, opt *ListOpt) ([]*ACLGroup, *ListOpt, error) {
+	if isEmptyString(s.sub.consumer) {
+		return nil, nil, fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+	return s.sub.client.ACLs.ListForConsumer(ctx, s.sub.consumer, opt)
+}
+
+// ListAll fetches all ACL groups of the Consumer.
+func (s *ConsumerACLs) ListAll(ctx context.Context) ([]*ACLGroup, error) {
+	var aclGroups, data []*ACLGroup
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		aclGroups = append(aclGroups, data...)
+	}
+	return aclGroups, nil
+}
+
+// ConsumerPlugins handles Plugins scoped to a single Consumer.
+type ConsumerPlugins struct {
+	sub *ConsumerSubClient
+}
+
+// Create creates a Plugin scoped to the Consumer.
+// Any Consumer already set on the plugin is overridden.
+func (s *ConsumerPlugins) Create(ctx context.Context, plugin *Plugin) (*Plugin, error) {
+	if plugin == nil {
+		return nil, fmt.Errorf("plugin cannot be nil")
+	}
+	if isEmptyString(s.sub.consumer) {
+		return nil, fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+	p := plugin.DeepCopy()
+	p.Consumer = nil
+
+	queryPath := "/consumers/" + *s.sub.consumer + "/plugins"
+	method := "POST"
+	if p.ID != nil {
+		queryPath = queryPath + "/" + *p.ID
+		method = "PUT"
+	}
+	req, err := s.sub.client.NewRequest(method, queryPath, nil, p)
+	if err != nil {
+		return nil, err
+	}
+	var createdPlugin Plugin
+	_, err = s.sub.client.Do(ctx, req, &createdPlugin)
+	if err != nil {
+		return nil, err
+	}
+	return &createdPlugin, nil
+}
+
+// Update updates a Plugin scoped to the Consumer.
+// Any Consumer already set on the plugin is overridden.
+func (s *ConsumerPlugins) Update(ctx context.Context, plugin *Plugin) (*Plugin, error) {
+	if plugin == nil {
+		return nil, fmt.Errorf("plugin cannot be nil")
+	}
+	if isEmptyString(plugin.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+	if isEmptyString(s.sub.consumer) {
+		return nil, fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+
+	p := plugin.DeepCopy()
+	p.Consumer = nil
+
+	endpoint := fmt.Sprintf("/consumers/%v/plugins/%v", *s.sub.consumer, *p.ID)
+	req, err := s.sub.client.NewRequest("PATCH", endpoint, nil, p)
+	if err != nil {
+		return nil, err
+	}
+	var updatedPlugin Plugin
+	_, err = s.sub.client.Do(ctx, req, &updatedPlugin)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedPlugin, nil
+}
+
+// Delete deletes a Plugin scoped to the Consumer.
+func (s *ConsumerPlugins) Delete(ctx context.Context, pluginID *string) error {
+	if isEmptyString(pluginID) {
+		return fmt.Errorf("plugin ID cannot be nil for Delete operation")
+	}
+	if isEmptyString(s.sub.consumer) {
+		return fmt.Errorf("consumerUsernameOrID cannot be nil")
+	}
+
+	endpoint := fmt.Sprintf("/consumers/%v/plugins/%v", *s.sub.consumer, *pluginID)
+	req, err := s.sub.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.sub.client.Do(ctx, req, nil)
+	return err
+}
+
+// ListAll fetches all Plugins scoped to the Consumer.
+func (s *ConsumerPlugins) ListAll(ctx context.Context) ([]*Plugin, error) {
+	return s.sub.client.Plugins.ListAllForConsumer(ctx, s.sub.consumer)
+}
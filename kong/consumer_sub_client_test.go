@@ -0,0 +1,69 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerSubClient(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":[{"id":"1","key":"foo"}],"offset":null}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_, hasConsumer := body["consumer"]
+			assert.False(t, hasConsumer, "consumer must be set through the path")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"1"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	consumer := client.ConsumerSubClient(String("alice"))
+
+	keyAuths, err := consumer.KeyAuths().ListAll(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, keyAuths, 1)
+	assert.Equal(t, "foo", *keyAuths[0].Key)
+
+	_, err = consumer.ACLs().Create(defaultCtx, &ACLGroup{Group: String("admins")})
+	require.NoError(t, err)
+
+	_, err = consumer.Plugins().Create(defaultCtx, &Plugin{
+		Name:     String("rate-limiting"),
+		Consumer: &Consumer{ID: String("bob")},
+	})
+	require.NoError(t, err)
+	_, err = consumer.Plugins().Update(defaultCtx, &Plugin{
+		ID:       String("p1"),
+		Consumer: &Consumer{ID: String("bob")},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, consumer.BasicAuths().Delete(defaultCtx, String("alice-creds")))
+
+	assert.Equal(t, []string{
+		"GET /consumers/alice/key-auth",
+		"POST /consumers/alice/acls",
+		"POST /consumers/alice/plugins",
+		"PATCH /consumers/alice/plugins/p1",
+		"DELETE /consumers/alice/basic-auth/alice-creds",
+	}, requests)
+
+	_, err = client.ConsumerSubClient(nil).KeyAuths().ListAll(defaultCtx)
+	assert.Error(t, err)
+}
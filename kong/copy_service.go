@@ -0,0 +1,178 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// CopyServiceOpts controls what CopyService copies along with the Service.
+type CopyServiceOpts struct {
+	// IncludeRoutes copies the Routes of the Service.
+	IncludeRoutes bool
+	// IncludePlugins copies the Plugins scoped to the Service and,
+	// when IncludeRoutes is set, to its Routes.
+	// Plugins which are also scoped to a Consumer or a Consumer Group
+	// are skipped since these entities are not copied, and so are
+	// Plugins scoped to a Route if IncludeRoutes isn't set.
+	IncludePlugins bool
+}
+
+// CopyServiceResult describes the entities created by CopyService.
+type CopyServiceResult struct {
+	Service *Service
+	Routes  []*Route
+	Plugins []*Plugin
+	// SkippedPlugins holds the plugins which were not copied because
+	// they are scoped to a Consumer, a Consumer Group or a Route which
+	// wasn't copied.
+	SkippedPlugins []*Plugin
+	// SkippedCertificates holds the IDs of the client certificate and CA
+	// certificates of the Service, which are not copied: the copy of the
+	// Service is created without them.
+	SkippedCertificates []string
+	// IDs maps IDs of the source entities to IDs of the copies.
+	IDs map[string]string
+}
+
+// CopyService copies the Service identified by nameOrID from the Kong
+// targeted by src to the one targeted by dst. Copies get new IDs and
+// foreign keys between copies are remapped accordingly. Certificates are
+// not copied, so the copy of the Service doesn't refer to any, see
+// CopyServiceResult.SkippedCertificates.
+//
+// src and dst are typically two clients pointing at the same Kong
+// Enterprise cluster with different workspaces set, which allows
+// promoting configuration between environments, but they can point at
+// different clusters as well.
+//
+// Entities are created one at a time: if an error occurs, the entities
+// copied so far are returned along with the error and are left in place.
+func CopyService(ctx context.Context, src, dst *Client, nameOrID *string,
+	opts CopyServiceOpts,
+) (*CopyServiceResult, error) {
+	if src == nil || dst == nil {
+		return nil, fmt.Errorf("source and destination clients cannot be nil")
+	}
+	if isEmptyString(nameOrID) {
+		return nil, fmt.Errorf("nameOrID cannot be nil")
+	}
+
+	service, err := src.Services.Get(ctx, nameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching service %q: %w", *nameOrID, err)
+	}
+	res := &CopyServiceResult{IDs: map[string]string{}}
+
+	newService := service.DeepCopy()
+	newService.ID = nil
+	newService.CreatedAt = nil
+	newService.UpdatedAt = nil
+	// URL is a shorthand returned by some versions of Kong along with
+	// the fields it is made of, sending both is rejected.
+	newService.URL = nil
+	// certificates are not copied, references to them would dangle
+	if service.ClientCertificate != nil && service.ClientCertificate.ID != nil {
+		res.SkippedCertificates = append(res.SkippedCertificates, *service.ClientCertificate.ID)
+	}
+	for _, id := range service.CACertificates {
+		if id != nil {
+			res.SkippedCertificates = append(res.SkippedCertificates, *id)
+		}
+	}
+	newService.ClientCertificate = nil
+	newService.CACertificates = nil
+	res.Service, err = dst.Services.Create(ctx, newService)
+	if err != nil {
+		return res, fmt.Errorf("creating service %q: %w", service.FriendlyName(), err)
+	}
+	res.IDs[*service.ID] = *res.Service.ID
+
+	var routes []*Route
+	if opts.IncludeRoutes {
+		routes, err = listAllRoutesForService(ctx, src, service.ID)
+		if err != nil {
+			return res, err
+		}
+		for _, route := range routes {
+			newRoute := route.DeepCopy()
+			newRoute.ID = nil
+			newRoute.CreatedAt = nil
+			newRoute.UpdatedAt = nil
+			newRoute.Service = &Service{ID: res.Service.ID}
+			createdRoute, err := dst.Routes.Create(ctx, newRoute)
+			if err != nil {
+				return res, fmt.Errorf("creating route %q: %w", route.FriendlyName(), err)
+			}
+			res.Routes = append(res.Routes, createdRoute)
+			res.IDs[*route.ID] = *createdRoute.ID
+		}
+	}
+
+	if !opts.IncludePlugins {
+		return res, nil
+	}
+	plugins, err := src.Plugins.ListAllForService(ctx, service.ID)
+	if err != nil {
+		return res, fmt.Errorf("listing plugins of service %q: %w", service.FriendlyName(), err)
+	}
+	for _, route := range routes {
+		routePlugins, err := src.Plugins.ListAllForRoute(ctx, route.ID)
+		if err != nil {
+			return res, fmt.Errorf("listing plugins of route %q: %w", route.FriendlyName(), err)
+		}
+		plugins = append(plugins, routePlugins...)
+	}
+	skipped := map[string]bool{}
+	skip := func(plugin *Plugin) {
+		skipped[*plugin.ID] = true
+		res.SkippedPlugins = append(res.SkippedPlugins, plugin)
+	}
+	for _, plugin := range plugins {
+		// plugins scoped to both the service and one of its routes
+		// are listed twice
+		if _, done := res.IDs[*plugin.ID]; done || skipped[*plugin.ID] {
+			continue
+		}
+		if plugin.Consumer != nil || plugin.ConsumerGroup != nil {
+			skip(plugin)
+			continue
+		}
+		newPlugin := plugin.DeepCopy()
+		newPlugin.ID = nil
+		newPlugin.CreatedAt = nil
+		if plugin.Service != nil && plugin.Service.ID != nil {
+			newPlugin.Service = &Service{ID: String(res.IDs[*plugin.Service.ID])}
+		}
+		if plugin.Route != nil && plugin.Route.ID != nil {
+			routeID, ok := res.IDs[*plugin.Route.ID]
+			if !ok {
+				// the route wasn't copied
+				skip(plugin)
+				continue
+			}
+			newPlugin.Route = &Route{ID: String(routeID)}
+		}
+		createdPlugin, err := dst.Plugins.Create(ctx, newPlugin)
+		if err != nil {
+			return res, fmt.Errorf("creating plugin %q: %w", plugin.FriendlyName(), err)
+		}
+		res.Plugins = append(res.Plugins, createdPlugin)
+		res.IDs[*plugin.ID] = *createdPlugin.ID
+	}
+	return res, nil
+}
+
+func listAllRoutesForService(ctx context.Context, client *Client, serviceID *string) ([]*Route, error) {
+	var routes, data []*Route
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = client.Routes.ListForService(ctx, serviceID, opt)
+		if err != nil {
+			return nil, fmt.Errorf("listing routes of service %q: %w", *serviceID, err)
+		}
+		routes = append(routes, data...)
+	}
+	return routes, nil
+}
@@ -0,0 +1,111 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyService(t *testing.T) {
+	var created []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /staging/services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","host":"example.com","created_at":1}`))
+		case "GET /staging/services/s1/routes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","name":"bar","paths":["/bar"],"service":{"id":"s1"}}]}`))
+		case "GET /staging/services/s1/plugins":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","name":"cors","service":{"id":"s1"}},
+				{"id":"p2","name":"rate-limiting","service":{"id":"s1"},"consumer":{"id":"c1"}},
+				{"id":"p4","name":"acl","service":{"id":"s1"},"route":{"id":"r1"},"consumer":{"id":"c1"}}
+			]}`))
+		case "GET /staging/routes/r1/plugins":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p3","name":"key-auth","route":{"id":"r1"}},
+				{"id":"p4","name":"acl","service":{"id":"s1"},"route":{"id":"r1"},"consumer":{"id":"c1"}}
+			]}`))
+		case "POST /production/services", "POST /production/routes", "POST /production/plugins":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			_, hasID := body["id"]
+			assert.False(t, hasID)
+			created = append(created, body)
+			body["id"] = "new-" + r.URL.Path[len("/production/"):]
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(body))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	src.SetWorkspace("staging")
+	dst, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	dst.SetWorkspace("production")
+
+	res, err := CopyService(defaultCtx, src, dst, String("foo"), CopyServiceOpts{
+		IncludeRoutes:  true,
+		IncludePlugins: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-services", *res.Service.ID)
+	require.Len(t, res.Routes, 1)
+	require.Len(t, res.Plugins, 2)
+	// plugins listed twice are only skipped once
+	require.Len(t, res.SkippedPlugins, 2)
+	assert.Equal(t, "p2", *res.SkippedPlugins[0].ID)
+	assert.Equal(t, "p4", *res.SkippedPlugins[1].ID)
+	assert.Equal(t, map[string]string{
+		"s1": "new-services",
+		"r1": "new-routes",
+		"p1": "new-plugins",
+		"p3": "new-plugins",
+	}, res.IDs)
+
+	require.Len(t, created, 4)
+	assert.Equal(t, map[string]interface{}{"id": "new-services"}, created[1]["service"])
+	assert.Equal(t, map[string]interface{}{"id": "new-services"}, created[2]["service"])
+	assert.Equal(t, map[string]interface{}{"id": "new-routes"}, created[3]["route"])
+}
+
+func TestCopyServiceWithCertificates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /staging/services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","protocol":"https","host":"example.com",
+				"client_certificate":{"id":"cert1"},"ca_certificates":["ca1","ca2"],"tls_verify":true}`))
+		case "POST /production/services":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.NotContains(t, body, "client_certificate")
+			assert.NotContains(t, body, "ca_certificates")
+			assert.Equal(t, true, body["tls_verify"])
+			body["id"] = "new-s1"
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(body))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	src, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	src.SetWorkspace("staging")
+	dst := src.ForWorkspace("production")
+
+	res, err := CopyService(defaultCtx, src, dst, String("foo"), CopyServiceOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, "new-s1", *res.Service.ID)
+	assert.Equal(t, []string{"cert1", "ca1", "ca2"}, res.SkippedCertificates)
+}
@@ -1,6 +1,9 @@
 package custom
 
-import "fmt"
+import (
+	"fmt"
+	"sync"
+)
 
 // Registry is a store of EntityCRUD objects
 type Registry interface {
@@ -20,8 +23,9 @@ type Registry interface {
 }
 
 // defaultRegistry is an out of the box implementation
-// of Registry object.
+// of Registry object. It is safe for concurrent use.
 type defaultRegistry struct {
+	lock  sync.RWMutex
 	store map[Type]EntityCRUD
 }
 
@@ -36,6 +40,8 @@ func NewDefaultRegistry() Registry {
 // store and returns an error if the entity
 // of Type is already registered.
 func (r *defaultRegistry) Register(typ Type, def EntityCRUD) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 	if _, ok := r.store[typ]; ok {
 		return fmt.Errorf("type already registered")
 	}
@@ -46,6 +52,8 @@ func (r *defaultRegistry) Register(typ Type, def EntityCRUD) error {
 // Lookup returns the EntityCRUD object associated
 // with typ, or nil if one is not Registered yet.
 func (r *defaultRegistry) Lookup(typ Type) EntityCRUD {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	return r.store[typ]
 }
 
@@ -55,6 +63,8 @@ func (r *defaultRegistry) Lookup(typ Type) EntityCRUD {
 // error if the Type was not registered
 // before this call.
 func (r *defaultRegistry) Unregister(typ Type) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 	if _, ok := r.store[typ]; !ok {
 		return fmt.Errorf("type not registered")
 	}
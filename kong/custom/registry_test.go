@@ -1,6 +1,8 @@
 package custom
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,3 +34,19 @@ func TestDefaultRegistry(t *testing.T) {
 	err = r.Unregister(typ)
 	assert.NoError(err)
 }
+
+func TestDefaultRegistryConcurrentUse(t *testing.T) {
+	r := NewDefaultRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		typ := Type(fmt.Sprintf("type-%d", i))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, r.Register(typ, &EntityCRUDDefinition{Name: typ}))
+			assert.NotNil(t, r.Lookup(typ))
+			assert.NoError(t, r.Unregister(typ))
+		}()
+	}
+	wg.Wait()
+}
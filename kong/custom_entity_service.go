@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/kong/go-kong/kong/custom"
@@ -75,8 +74,14 @@ func (s *CustomEntityService) Create(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	// entities whose primary key is set are created with a PUT on the
+	// entity's endpoint
+	primaryKey := "id"
+	if d, ok := def.(*custom.EntityCRUDDefinition); ok && d.PrimaryKey != "" {
+		primaryKey = d.PrimaryKey
+	}
 	if entity.Object() != nil {
-		if _, ok := entity.Object()["id"]; ok {
+		if _, ok := entity.Object()[primaryKey]; ok {
 			queryPath, err = def.PatchEndpoint(entity)
 			if err != nil {
 				return nil, err
@@ -150,7 +155,7 @@ func (s *CustomEntityService) Delete(ctx context.Context,
 			"' not registered")
 	}
 
-	queryPath, err := def.PatchEndpoint(entity)
+	queryPath, err := def.DeleteEndpoint(entity)
 	if err != nil {
 		return err
 	}
@@ -191,7 +196,7 @@ func (s *CustomEntityService) List(ctx context.Context, opt *ListOpt,
 			return nil, nil, err
 		}
 		var object custom.Object
-		err = json.Unmarshal(b, &object)
+		err = s.client.decode(b, &object)
 		if err != nil {
 			return nil, nil, err
 		}
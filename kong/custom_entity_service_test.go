@@ -1,6 +1,8 @@
 package kong
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 
@@ -89,3 +91,38 @@ func TestCustomEntityService(T *testing.T) {
 	// delete fixture consumer
 	assert.NoError(client.Consumers.Delete(defaultCtx, consumer.ID))
 }
+
+func TestCustomEntityServiceCustomPrimaryKey(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"foo","uri":"/foo","service":{"id":"s1"}}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	typ := custom.Type("my-routes")
+	require.NoError(t, client.Register(typ, &custom.EntityCRUDDefinition{
+		Name:       typ,
+		CRUDPath:   "/services/${service_id}/my-routes",
+		PrimaryKey: "name",
+	}))
+
+	e := custom.NewEntityObject(typ)
+	e.AddRelation("service_id", "s1")
+	e.SetObject(custom.Object{"name": "foo", "uri": "/foo"})
+	created, err := client.CustomEntities.Create(defaultCtx, e)
+	require.NoError(t, err)
+	assert.Equal(t, "/foo", created.Object()["uri"])
+
+	require.NoError(t, client.CustomEntities.Delete(defaultCtx, e))
+	assert.Equal(t, []string{
+		"PUT /services/s1/my-routes/foo",
+		"DELETE /services/s1/my-routes/foo",
+	}, requests)
+}
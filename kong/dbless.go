@@ -0,0 +1,96 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrReadOnlyGateway is returned by write operations when the client
+// targets a Kong running in DB-less mode, where entities can only be
+// changed by sending the whole configuration to the /config endpoint,
+// see ReloadDeclarativeRawConfig.
+type ErrReadOnlyGateway struct {
+	Method string
+	Path   string
+}
+
+func (e *ErrReadOnlyGateway) Error() string {
+	return fmt.Sprintf("%s %s: Kong is running in DB-less mode and its Admin API is read-only, "+
+		"use the /config endpoint to update the configuration", e.Method, e.Path)
+}
+
+// IsReadOnlyGatewayErr returns true if the error or its cause is
+// an ErrReadOnlyGateway.
+func IsReadOnlyGatewayErr(e error) bool {
+	var readOnlyErr *ErrReadOnlyGateway
+	return errors.As(e, &readOnlyErr)
+}
+
+// DetectDBLess fetches the configuration of Kong from the root endpoint
+// and records whether it runs in DB-less mode (database=off).
+// Once DB-less mode is detected, write requests for entities fail with an
+// ErrReadOnlyGateway without being sent to Kong.
+func (c *Client) DetectDBLess(ctx context.Context) (bool, error) {
+	info, err := c.Info.Get(ctx)
+	if err != nil {
+		return false, err
+	}
+	dbless := info.Configuration != nil && info.Configuration.Database == "off"
+	c.SetDBLess(dbless)
+	return dbless, nil
+}
+
+// SetDBLess sets whether the client targets a Kong running in DB-less
+// mode, without querying Kong. See DetectDBLess.
+func (c *Client) SetDBLess(dbless bool) {
+	c.dbless.Store(dbless)
+}
+
+// IsDBLess returns whether the client targets a Kong running in DB-less
+// mode, as set by DetectDBLess or SetDBLess.
+func (c *Client) IsDBLess() bool {
+	return c.dbless.Load()
+}
+
+// checkWritable returns an ErrReadOnlyGateway for requests Kong rejects
+// in DB-less mode.
+func (c *Client) checkWritable(req *http.Request) error {
+	if !c.IsDBLess() {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	segments := pathSegments(c.relativePath(req))
+	// these endpoints don't write entities and are available in DB-less mode
+	if (len(segments) == 1 && segments[0] == "config") ||
+		segments[0] == "schemas" ||
+		isHealthPath(segments) {
+		return nil
+	}
+	return &ErrReadOnlyGateway{Method: req.Method, Path: req.URL.Path}
+}
+
+// pathSegments returns the segments of path, relative to the workspace.
+func pathSegments(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// isHealthPath returns whether segments are those of the health endpoints
+// of upstreams, /upstreams/{upstream}/health, or of their targets,
+// /upstreams/{upstream}/targets/{target}[/{address}]/(un)healthy.
+func isHealthPath(segments []string) bool {
+	if len(segments) < 3 || segments[0] != "upstreams" {
+		return false
+	}
+	if len(segments) == 3 {
+		return segments[2] == "health"
+	}
+	last := segments[len(segments)-1]
+	return segments[2] == "targets" && (len(segments) == 5 || len(segments) == 6) &&
+		(last == "healthy" || last == "unhealthy")
+}
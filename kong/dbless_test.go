@@ -0,0 +1,50 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBLessWriteGuard(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /":
+			_, _ = w.Write([]byte(`{"version":"3.4.0","configuration":{"database":"off"}}`))
+		case "GET /services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo"}`))
+		case "POST /upstreams/up/targets/t1/healthy":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	dbless, err := client.DetectDBLess(defaultCtx)
+	require.NoError(t, err)
+	assert.True(t, dbless)
+	assert.True(t, client.IsDBLess())
+
+	_, err = client.Services.Get(defaultCtx, String("foo"))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Targets.MarkHealthy(defaultCtx, String("up"), &Target{ID: String("t1")}))
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("bar")})
+	require.Error(t, err)
+	assert.True(t, IsReadOnlyGatewayErr(err))
+	assert.Contains(t, err.Error(), "POST /services: Kong is running in DB-less mode")
+	// entities may be named like the endpoints available in DB-less mode
+	_, err = client.Services.Update(defaultCtx, &Service{ID: String("config")})
+	assert.True(t, IsReadOnlyGatewayErr(err))
+	assert.True(t, IsReadOnlyGatewayErr(client.Routes.Delete(defaultCtx, String("healthy"))))
+
+	client.SetDBLess(false)
+	assert.False(t, client.IsDBLess())
+}
@@ -0,0 +1,137 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type defaultTagsCtxKey struct{}
+
+// WithDefaultTags returns a context whose requests creating or updating
+// entities add tags to the entities, in addition to the default tags of
+// the client, see SetDefaultTags.
+func WithDefaultTags(ctx context.Context, tags ...string) context.Context {
+	existing, _ := ctx.Value(defaultTagsCtxKey{}).([]string)
+	return context.WithValue(ctx, defaultTagsCtxKey{}, mergeTags(existing, tags))
+}
+
+// SetDefaultTags sets tags, e.g. "managed-by:my-controller", added to all
+// the entities the client creates or updates, so that entities are
+// labeled consistently without every call site having to. Calling it
+// without tags removes the default tags.
+//
+// Tags are added to the JSON bodies of POST, PUT and PATCH requests to
+// entities supporting tags, unless the entity already has them. PATCH
+// requests not setting tags are left as is so that the tags of the
+// entity aren't replaced.
+func (c *Client) SetDefaultTags(tags ...string) {
+	c.defaultTags.Store(mergeTags(nil, tags))
+}
+
+// DefaultTags returns the tags added to the entities the client creates
+// or updates, see SetDefaultTags.
+func (c *Client) DefaultTags() []string {
+	tags, _ := c.defaultTags.Load().([]string)
+	return tags
+}
+
+// taggedEntities are the collections of entities supporting tags.
+var taggedEntities = map[string]bool{
+	"services": true, "routes": true, "consumers": true, "consumer_groups": true,
+	"plugins": true, "upstreams": true, "targets": true, "certificates": true,
+	"ca_certificates": true, "snis": true, "vaults": true, "keys": true,
+	"key-sets": true, "key-auth": true, "key-auths": true, "basic-auth": true,
+	"basic-auths": true, "hmac-auth": true, "hmac-auths": true, "jwt": true,
+	"jwts": true, "acls": true, "oauth2": true, "mtls-auth": true, "mtls-auths": true,
+}
+
+// addDefaultTags adds the default tags of the client and ctx to the
+// entity created or updated by req.
+func (c *Client) addDefaultTags(ctx context.Context, req *http.Request) error {
+	var tags []string
+	if ctx != nil {
+		tags, _ = ctx.Value(defaultTagsCtxKey{}).([]string)
+	}
+	tags = mergeTags(c.DefaultTags(), tags)
+	if len(tags) == 0 || req.Body == nil {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return nil
+	}
+	segments := strings.Split(strings.Trim(c.relativePath(req), "/"), "/")
+	collection := segments[len(segments)-1]
+	if len(segments)%2 == 0 {
+		collection = segments[len(segments)-2]
+	}
+	// adding consumers to consumer groups doesn't create entities
+	membership := len(segments) > 2 &&
+		((segments[0] == "consumer_groups" && collection == "consumers") ||
+			(segments[0] == "consumers" && collection == "consumer_groups"))
+	if !taggedEntities[collection] || membership {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	req.Body.Close()
+	setBody := func(body []byte) {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+		req.ContentLength = int64(len(body))
+	}
+	var entity map[string]json.RawMessage
+	if err := json.Unmarshal(body, &entity); err != nil || entity == nil {
+		// not an entity
+		setBody(body)
+		return nil
+	}
+	var existing []string
+	if raw, ok := entity["tags"]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			setBody(body)
+			return nil
+		}
+	} else if req.Method == http.MethodPatch {
+		setBody(body)
+		return nil
+	}
+	if entity["tags"], err = json.Marshal(mergeTags(existing, tags)); err != nil {
+		return err
+	}
+	if body, err = json.Marshal(entity); err != nil {
+		return err
+	}
+	setBody(body)
+	return nil
+}
+
+// mergeTags returns tags followed by the tags of additional it doesn't
+// have.
+func mergeTags(tags, additional []string) []string {
+	merged := append([]string{}, tags...)
+	has := map[string]bool{}
+	for _, tag := range tags {
+		has[tag] = true
+	}
+	for _, tag := range additional {
+		if !has[tag] {
+			has[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
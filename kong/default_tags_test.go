@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTags(t *testing.T) {
+	var bodies []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(body)), r.ContentLength)
+		var entity map[string]interface{}
+		assert.NoError(t, json.Unmarshal(body, &entity))
+		bodies = append(bodies, entity)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("svc")})
+	require.NoError(t, err)
+	assert.NotContains(t, bodies[0], "tags")
+
+	client.SetDefaultTags("managed-by:ci", "managed-by:ci")
+	assert.Equal(t, []string{"managed-by:ci"}, client.DefaultTags())
+	ctx := WithDefaultTags(defaultCtx, "team:payments")
+
+	bodies = nil
+	_, err = client.Services.Create(ctx, &Service{Name: String("svc"), Tags: StringSlice("team:payments", "x")})
+	require.NoError(t, err)
+	_, err = client.Routes.CreateInService(defaultCtx, String("svc"), &Route{Paths: StringSlice("/")})
+	require.NoError(t, err)
+	_, err = client.Services.Update(ctx, &Service{ID: String("svc"), Tags: StringSlice("x")})
+	require.NoError(t, err)
+	_, err = client.Services.Update(ctx, &Service{ID: String("svc"), Host: String("example.com")})
+	require.NoError(t, err)
+	_, err = client.ConsumerGroupConsumers.Create(ctx, String("gold"), String("alice"))
+	require.NoError(t, err)
+	require.Len(t, bodies, 5)
+	assert.Equal(t, []interface{}{"team:payments", "x", "managed-by:ci"}, bodies[0]["tags"])
+	assert.Equal(t, []interface{}{"managed-by:ci"}, bodies[1]["tags"])
+	assert.Equal(t, []interface{}{"x", "managed-by:ci", "team:payments"}, bodies[2]["tags"])
+	assert.NotContains(t, bodies[3], "tags", "PATCH without tags must not replace tags")
+	assert.NotContains(t, bodies[4], "tags", "memberships aren't entities")
+
+	client.SetDefaultTags()
+	assert.Nil(t, client.DefaultTags())
+	bodies = nil
+	_, err = client.Consumers.Create(defaultCtx, &Consumer{Username: String("bob")})
+	require.NoError(t, err)
+	assert.NotContains(t, bodies[0], "tags")
+}
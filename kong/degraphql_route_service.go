@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -188,7 +187,7 @@ func (s *DegraphqlRouteService) List(
 			return nil, nil, err
 		}
 		var route DegraphqlRoute
-		err = json.Unmarshal(b, &route)
+		err = s.client.decode(b, &route)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,58 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// deleteWhere deletes the entities listed by list which match predicate,
+// and returns the ones it deleted.
+//
+// Deleting entities while paginating shifts the following entities
+// towards the pages already fetched, with page numbers in Konnect mode as
+// with offsets in DB-less mode, so that loops deleting entities over
+// ListAll skip some of them. Instead, a page is fetched again after
+// entities of it were deleted, until it has no more entities to delete.
+// Entities already deleted by others are ignored.
+func deleteWhere[T any](ctx context.Context,
+	list func(context.Context, *ListOpt) ([]*T, *ListOpt, error),
+	del func(context.Context, *string) error,
+	id func(*T) *string,
+	predicate func(*T) bool,
+) ([]*T, error) {
+	if predicate == nil {
+		return nil, fmt.Errorf("predicate cannot be nil for DeleteWhere operation")
+	}
+	var deleted []*T
+	// entities listed again after being deleted, e.g. because of caching,
+	// are deleted only once so that the loop ends
+	seen := map[string]bool{}
+	opt := &ListOpt{Size: pageSize}
+	for opt != nil {
+		entities, next, err := list(ctx, opt)
+		if err != nil {
+			return deleted, err
+		}
+		deletedFromPage := false
+		for _, entity := range entities {
+			entityID := id(entity)
+			if isEmptyString(entityID) || seen[*entityID] || !predicate(entity) {
+				continue
+			}
+			seen[*entityID] = true
+			// the page shifted even if others deleted the entity first
+			deletedFromPage = true
+			if err := del(ctx, entityID); err != nil {
+				if IsNotFoundErr(err) {
+					continue
+				}
+				return deleted, fmt.Errorf("deleting %s: %w", *entityID, err)
+			}
+			deleted = append(deleted, entity)
+		}
+		if !deletedFromPage {
+			opt = next
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,106 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIndexPaginatedRoutesServer serves routes with offsets which are
+// indexes, as in DB-less mode, so that deleting routes shifts the pages.
+func newIndexPaginatedRoutesServer(t *testing.T, count int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var ids []string
+	for i := 0; i < count; i++ {
+		ids = append(ids, fmt.Sprintf("route-%02d", i))
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/routes":
+			size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+			offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+			end := offset + size
+			if end > len(ids) {
+				end = len(ids)
+			}
+			page := map[string]interface{}{"data": []interface{}{}}
+			var data []interface{}
+			for _, id := range ids[offset:end] {
+				data = append(data, map[string]string{"id": id})
+			}
+			if data != nil {
+				page["data"] = data
+			}
+			if end < len(ids) {
+				page["offset"] = strconv.Itoa(end)
+			}
+			_ = json.NewEncoder(w).Encode(page)
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/routes/"):
+			id := strings.TrimPrefix(r.URL.Path, "/routes/")
+			for i := range ids {
+				if ids[i] == id {
+					ids = append(ids[:i], ids[i+1:]...)
+					w.WriteHeader(http.StatusNoContent)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	}))
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string{}, ids...)
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	defer func(size int) { pageSize = size }(pageSize)
+	pageSize = 10
+	srv, remaining := newIndexPaginatedRoutesServer(t, 25)
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	// delete every route but one out of ten
+	deleted, err := client.Routes.DeleteWhere(defaultCtx, func(route *Route) bool {
+		return !strings.HasSuffix(*route.ID, "0")
+	})
+	require.NoError(t, err)
+	assert.Len(t, deleted, 22)
+	for _, id := range remaining() {
+		assert.True(t, strings.HasSuffix(id, "0"), id)
+	}
+
+	deleted, err = client.Routes.DeleteWhere(defaultCtx, func(*Route) bool { return false })
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	// routes deleted by others in the meantime aren't reported
+	deleted, err = client.Routes.DeleteWhere(defaultCtx, func(route *Route) bool {
+		if *route.ID == "route-10" {
+			require.NoError(t, client.Routes.Delete(defaultCtx, route.ID))
+		}
+		return true
+	})
+	require.NoError(t, err)
+	require.Len(t, deleted, 2)
+	assert.Equal(t, "route-00", *deleted[0].ID)
+	assert.Equal(t, "route-20", *deleted[1].ID)
+	assert.Empty(t, remaining())
+
+	_, err = client.Routes.DeleteWhere(defaultCtx, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,179 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Deprecation is a warning of Kong about the use of a deprecated feature,
+// which may break when Kong is upgraded.
+type Deprecation struct {
+	// Method and Path are the method and path, relative to the workspace,
+	// of the request Kong warned about. They aren't set for deprecated
+	// fields found in schemas.
+	Method string
+	Path   string
+	// Field is the path of a deprecated field set in an entity, e.g.
+	// "config.redis_host", found with DeprecatedFields.
+	Field   string
+	Message string
+	// Sunset is the value of the Sunset header, the date after which the
+	// endpoint may stop working.
+	Sunset string
+	// RemovalInVersion is the version of Kong removing a deprecated field.
+	RemovalInVersion string
+}
+
+// SetDeprecationHook sets a function called with the deprecations Kong
+// reports in the Deprecation and Warning headers of its responses, so
+// that users learn about breaking changes before upgrading Kong. A nil
+// hook removes the hook.
+//
+// The hook is called synchronously, before the response is processed.
+// Deprecations are also set on the Response returned by Do.
+func (c *Client) SetDeprecationHook(hook func(Deprecation)) {
+	c.deprecationHook.Store(hook)
+}
+
+// reportDeprecations calls the deprecation hook, if any, with the
+// deprecations of resp.
+func (c *Client) reportDeprecations(req *http.Request, resp *http.Response) {
+	hook, _ := c.deprecationHook.Load().(func(Deprecation))
+	if hook == nil || resp == nil {
+		return
+	}
+	for _, d := range c.deprecations(req, resp) {
+		hook(d)
+	}
+}
+
+// deprecations returns the deprecations reported in the headers of resp.
+func (c *Client) deprecations(req *http.Request, resp *http.Response) []Deprecation {
+	var res []Deprecation
+	path := c.relativePath(req)
+	if deprecation := resp.Header.Get("Deprecation"); deprecation != "" && deprecation != "false" {
+		message := "endpoint is deprecated"
+		if deprecation != "true" {
+			message += " since " + deprecation
+		}
+		res = append(res, Deprecation{
+			Method:  req.Method,
+			Path:    path,
+			Message: message,
+			Sunset:  resp.Header.Get("Sunset"),
+		})
+	}
+	for _, header := range resp.Header.Values("Warning") {
+		for _, text := range parseWarnings(header) {
+			res = append(res, Deprecation{
+				Method:  req.Method,
+				Path:    path,
+				Message: text,
+				Sunset:  resp.Header.Get("Sunset"),
+			})
+		}
+	}
+	return res
+}
+
+// parseWarnings returns the texts of the warnings of a Warning header,
+// formatted as `299 - "text" "date"`, comma separated. Values which don't
+// follow this format are returned as is.
+func parseWarnings(header string) []string {
+	var res []string
+	for rest := strings.TrimSpace(header); rest != ""; {
+		start := strings.IndexByte(rest, '"')
+		if start < 0 {
+			res = append(res, rest)
+			break
+		}
+		text, n := unquoteWarning(rest[start:])
+		res = append(res, text)
+		rest = rest[start+n:]
+		// skip the optional date
+		if rest = strings.TrimSpace(rest); strings.HasPrefix(rest, `"`) {
+			_, n = unquoteWarning(rest)
+			rest = rest[n:]
+		}
+		rest = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), ","))
+	}
+	return res
+}
+
+// unquoteWarning returns the text of the quoted string s starts with and
+// its length.
+func unquoteWarning(s string) (string, int) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			}
+		case '"':
+			return b.String(), i + 1
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String(), len(s)
+}
+
+// DeprecatedFields returns the deprecated fields of schema, as returned by
+// SchemaService.Get or PluginService.GetFullSchema, which are set in
+// entity, sorted by field.
+func DeprecatedFields(schema Schema, entity interface{}) ([]Deprecation, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := convert(entity, &values); err != nil {
+		return nil, err
+	}
+	res := deprecatedFields(gjson.ParseBytes(b), values, "")
+	sort.Slice(res, func(i, j int) bool { return res[i].Field < res[j].Field })
+	return res, nil
+}
+
+func deprecatedFields(record gjson.Result, values map[string]interface{}, prefix string) []Deprecation {
+	var res []Deprecation
+	for _, field := range record.Get("fields").Array() {
+		for name, value := range field.Map() {
+			set, ok := values[name]
+			if !ok || set == nil {
+				continue
+			}
+			if deprecation := value.Get("deprecation"); deprecation.Exists() {
+				res = append(res, Deprecation{
+					Field:            prefix + name,
+					Message:          deprecation.Get("message").String(),
+					RemovalInVersion: deprecation.Get("removal_in_version").String(),
+				})
+			}
+			if nested, ok := set.(map[string]interface{}); ok && value.Get("fields").Exists() {
+				res = append(res, deprecatedFields(value, nested, prefix+name+".")...)
+			}
+		}
+	}
+	return res
+}
+
+// PluginDeprecations returns the deprecated fields set in plugin, using
+// the cached schema of the plugin.
+func (c *SchemaCache) PluginDeprecations(ctx context.Context, plugin *Plugin) ([]Deprecation, error) {
+	if plugin == nil || isEmptyString(plugin.Name) {
+		return nil, nil
+	}
+	schema, err := c.PluginSchema(ctx, *plugin.Name)
+	if err != nil {
+		return nil, err
+	}
+	return DeprecatedFields(schema, plugin)
+}
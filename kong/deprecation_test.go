@@ -0,0 +1,97 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationHeaders(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/foo/services/legacy" {
+			w.Header().Set("Deprecation", "@1688169599")
+			w.Header().Set("Sunset", "Wed, 11 Nov 2026 11:11:11 GMT")
+			w.Header().Add("Warning", `299 - "field \"x\" is deprecated" "Wed, 21 Oct 2015 07:28:00 GMT", `+
+				`299 kong "use paths instead"`)
+		}
+		_, _ = w.Write([]byte(`{"id":"legacy"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("foo")
+	var reported []Deprecation
+	client.SetDeprecationHook(func(d Deprecation) { reported = append(reported, d) })
+
+	req, err := client.NewRequest(http.MethodGet, "/services/legacy", nil, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(defaultCtx, req, nil)
+	require.NoError(t, err)
+	expected := []Deprecation{
+		{
+			Method: "GET", Path: "/services/legacy", Message: "endpoint is deprecated since @1688169599",
+			Sunset: "Wed, 11 Nov 2026 11:11:11 GMT",
+		},
+		{
+			Method: "GET", Path: "/services/legacy", Message: `field "x" is deprecated`,
+			Sunset: "Wed, 11 Nov 2026 11:11:11 GMT",
+		},
+		{
+			Method: "GET", Path: "/services/legacy", Message: "use paths instead",
+			Sunset: "Wed, 11 Nov 2026 11:11:11 GMT",
+		},
+	}
+	assert.Equal(t, expected, resp.Deprecations)
+	assert.Equal(t, expected, reported)
+
+	reported = nil
+	_, err = client.Services.Get(defaultCtx, String("current"))
+	require.NoError(t, err)
+	assert.Empty(t, reported)
+
+	client.SetDeprecationHook(nil)
+	_, err = client.Services.Get(defaultCtx, String("legacy"))
+	require.NoError(t, err)
+	assert.Empty(t, reported)
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	schema := Schema{
+		"fields": []interface{}{
+			map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+			map[string]interface{}{"config": map[string]interface{}{
+				"type": "record",
+				"fields": []interface{}{
+					map[string]interface{}{"redis_host": map[string]interface{}{
+						"type": "string",
+						"deprecation": map[string]interface{}{
+							"message":            "use redis.host instead",
+							"removal_in_version": "4.0",
+						},
+					}},
+					map[string]interface{}{"redis_port": map[string]interface{}{
+						"type":        "integer",
+						"deprecation": map[string]interface{}{"message": "use redis.port instead"},
+					}},
+				},
+			}},
+		},
+	}
+	deprecations, err := DeprecatedFields(schema, &Plugin{
+		Name:   String("rate-limiting"),
+		Config: Configuration{"redis_host": "redis", "redis_port": nil},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Deprecation{{
+		Field:            "config.redis_host",
+		Message:          "use redis.host instead",
+		RemovalInVersion: "4.0",
+	}}, deprecations)
+
+	deprecations, err = DeprecatedFields(schema, &Plugin{Name: String("rate-limiting")})
+	require.NoError(t, err)
+	assert.Empty(t, deprecations)
+}
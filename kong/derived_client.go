@@ -0,0 +1,56 @@
+package kong
+
+import "sync/atomic"
+
+// ForWorkspace returns a new client targeting workspace, leaving c
+// untouched, so that goroutines sharing c can each work on their own
+// workspace without racing on SetWorkspace. An empty workspace targets the
+// default workspace.
+//
+// The derived client starts with a snapshot of the settings of c and
+// shares its HTTP client, custom entity registry, plugin cache, latency
+// and traffic statistics and adaptive pacing, as they all relate to the
+// same Kong node. Writes to the logger of c are serialized across both
+// clients until a new logger is set on either.
+// Settings changed afterwards on either client don't affect the other.
+// Services replaced on c are not carried over.
+func (c *Client) ForWorkspace(workspace string) *Client {
+	d := &Client{
+		client:      c.client,
+		baseRootURL: c.baseRootURL,
+		workspace:   workspace,
+		latency:     c.latency,
+		traffic:     c.traffic,
+		plugins:     c.plugins,
+		pacer:       c.pacer,
+		Registry:    c.Registry,
+	}
+	d.initServices()
+
+	d.debug.Store(c.debug.Load())
+	d.skipCertificateValidation.Store(c.skipCertificateValidation.Load())
+	d.rejectExpiredCertificates.Store(c.rejectExpiredCertificates.Load())
+	d.dbless.Store(c.dbless.Load())
+	d.readOnly.Store(c.readOnly.Load())
+	d.konnectMode.Store(c.konnectMode.Load())
+	d.idempotentCreates.Store(c.idempotentCreates.Load())
+	d.requestCompression.Store(c.requestCompression.Load())
+	for _, v := range []struct{ dst, src *atomic.Value }{
+		{&d.logger, &c.logger},
+		{&d.role, &c.role},
+		{&d.dryRun, &c.dryRun},
+		{&d.mutationHook, &c.mutationHook},
+		{&d.defaultTags, &c.defaultTags},
+		{&d.protectedTags, &c.protectedTags},
+		{&d.slowRequest, &c.slowRequest},
+		{&d.deprecationHook, &c.deprecationHook},
+		{&d.strictDecoding, &c.strictDecoding},
+		{&d.jsonCodec, &c.jsonCodec},
+		{&d.redactor, &c.redactor},
+	} {
+		if value := v.src.Load(); value != nil {
+			v.dst.Store(value)
+		}
+	}
+	return d
+}
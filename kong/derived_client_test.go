@@ -0,0 +1,139 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForWorkspace(t *testing.T) {
+	var lock sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		paths = append(paths, r.URL.Path)
+		lock.Unlock()
+		_, _ = w.Write([]byte(`{"id":"s1","name":"svc"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("default")
+	client.SetDefaultTags("managed")
+	client.SetReadOnly(true)
+
+	teamA := client.ForWorkspace("team-a")
+	assert.Equal(t, "team-a", teamA.Workspace())
+	assert.Equal(t, "default", client.Workspace())
+	assert.Equal(t, []string{"managed"}, teamA.DefaultTags())
+
+	// settings are a snapshot: changes on one client don't leak to the other
+	_, err = teamA.Services.Create(defaultCtx, &Service{Name: String("svc")})
+	assert.True(t, IsReadOnlyClientErr(err))
+	teamA.SetReadOnly(false)
+	client.SetDefaultTags("other")
+	assert.Equal(t, []string{"managed"}, teamA.DefaultTags())
+
+	_, err = teamA.Services.Get(defaultCtx, String("svc"))
+	require.NoError(t, err)
+	_, err = client.Services.Get(defaultCtx, String("svc"))
+	require.NoError(t, err)
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("svc")})
+	assert.True(t, IsReadOnlyClientErr(err))
+	assert.Equal(t, []string{"/team-a/services/svc", "/default/services/svc"}, paths)
+}
+
+// TestClientConcurrentUse exercises the client from many goroutines at once;
+// it is meant to be run with -race.
+func TestClientConcurrentUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"s1","name":"svc","data":[],"next":null}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	logs := &lockedWriter{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			client.SetWorkspace(fmt.Sprintf("ws-%d", i))
+			client.SetDebugMode(i%2 == 0)
+			client.SetLogger(logs)
+			client.SetSkipCertificateValidation(i%2 == 0)
+			client.SetDefaultTags(fmt.Sprintf("tag-%d", i))
+			client.SetRedactor(NewRedactor())
+			client.SetAdaptivePacing(i%2 == 0)
+		}()
+		go func() {
+			defer wg.Done()
+			ctx := WithWorkspace(context.Background(), fmt.Sprintf("ctx-%d", i))
+			_, err := client.Services.Get(ctx, String("svc"))
+			assert.NoError(t, err)
+			_, err = client.Services.ListAll(ctx)
+			assert.NoError(t, err)
+			_ = client.Lookup("foo")
+		}()
+		go func() {
+			defer wg.Done()
+			derived := client.ForWorkspace(fmt.Sprintf("derived-%d", i))
+			derived.SetDebugMode(false)
+			_, err := derived.Services.Get(defaultCtx, String("svc"))
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("derived-%d", i), derived.Workspace())
+		}()
+	}
+	wg.Wait()
+	assert.True(t, strings.HasPrefix(client.Workspace(), "ws-"))
+}
+
+// TestForWorkspaceSharedLogger logs from a client and a derived client at
+// the same time to a writer which isn't safe for concurrent use; it is
+// meant to be run with -race.
+func TestForWorkspaceSharedLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[],"next":null}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	var logs bytes.Buffer
+	client.SetLogger(&logs)
+	client.SetDebugMode(true)
+	derived := client.ForWorkspace("team-a")
+
+	var wg sync.WaitGroup
+	for _, c := range []*Client{client, derived, client, derived} {
+		c := c
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := c.Services.List(defaultCtx, nil)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Contains(t, logs.String(), "/team-a/services")
+}
+
+type lockedWriter struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -130,7 +129,7 @@ func (s *DeveloperRoleService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var role DeveloperRole
-		err = json.Unmarshal(b, &role)
+		err = s.client.decode(b, &role)
 		if err != nil {
 			return nil, nil, err
 		}
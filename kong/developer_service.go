@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -169,7 +168,7 @@ func (s *DeveloperService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var developer Developer
-		err = json.Unmarshal(b, &developer)
+		err = s.client.decode(b, &developer)
 		if err != nil {
 			return nil, nil, err
 		}
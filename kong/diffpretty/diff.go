@@ -0,0 +1,252 @@
+package diffpretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultContext = 3
+
+	colorReset = "\x1b[0m"
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+)
+
+// Options controls how a diff is rendered.
+type Options struct {
+	// OldName and NewName are used in the header of the diff.
+	// They default to "old" and "new".
+	OldName string
+	NewName string
+	// Context is the number of unchanged lines shown around each change.
+	// It defaults to 3, a negative value disables context lines.
+	Context int
+	// Color enables ANSI colors, which is useful when printing to a terminal.
+	Color bool
+}
+
+func (o Options) context() int {
+	switch {
+	case o.Context < 0:
+		return 0
+	case o.Context == 0:
+		return defaultContext
+	default:
+		return o.Context
+	}
+}
+
+// Entities renders a unified diff between the JSON representations
+// of oldEntity and newEntity. Either of them can be nil to render
+// a creation or a deletion.
+// An empty string is returned if both entities are equal.
+func Entities(oldEntity, newEntity interface{}, opts Options) (string, error) {
+	oldText, err := render(oldEntity)
+	if err != nil {
+		return "", fmt.Errorf("rendering old entity: %w", err)
+	}
+	newText, err := render(newEntity)
+	if err != nil {
+		return "", fmt.Errorf("rendering new entity: %w", err)
+	}
+	return Text(oldText, newText, opts), nil
+}
+
+// render returns an indented JSON representation of entity
+// with keys sorted, so that field order never shows up in diffs.
+func render(entity interface{}) (string, error) {
+	if entity == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return "", err
+	}
+	if bytes.Equal(b, []byte("null")) {
+		return "", nil
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return "", err
+	}
+	b, err = json.MarshalIndent(generic, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Text renders a unified diff between oldText and newText.
+// An empty string is returned if both are equal.
+func Text(oldText, newText string, opts Options) string {
+	if oldText == newText {
+		return ""
+	}
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+	ops := diffLines(oldLines, newLines)
+
+	oldName, newName := opts.OldName, opts.NewName
+	if oldName == "" {
+		oldName = "old"
+	}
+	if newName == "" {
+		newName = "new"
+	}
+
+	var out strings.Builder
+	writeLine(&out, opts, colorRed, "--- "+oldName)
+	writeLine(&out, opts, colorGreen, "+++ "+newName)
+	for _, h := range hunks(ops, opts.context()) {
+		writeLine(&out, opts, colorCyan, h.header())
+		for _, op := range h.ops {
+			switch op.kind {
+			case opEqual:
+				writeLine(&out, opts, "", " "+op.line)
+			case opDelete:
+				writeLine(&out, opts, colorRed, "-"+op.line)
+			case opInsert:
+				writeLine(&out, opts, colorGreen, "+"+op.line)
+			}
+		}
+	}
+	return out.String()
+}
+
+func writeLine(out *strings.Builder, opts Options, color, line string) {
+	if opts.Color && color != "" {
+		out.WriteString(color)
+		out.WriteString(line)
+		out.WriteString(colorReset)
+	} else {
+		out.WriteString(line)
+	}
+	out.WriteByte('\n')
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+	// oldLine and newLine are 1-based line numbers of the line
+	// in the old and new text respectively.
+	oldLine, newLine int
+}
+
+// diffLines computes a line-based edit script using
+// the longest common subsequence of a and b.
+func diffLines(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], oldLine: i + 1, newLine: j + 1})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, op{kind: opInsert, line: b[j], oldLine: i, newLine: j + 1})
+			j++
+		default:
+			ops = append(ops, op{kind: opDelete, line: a[i], oldLine: i + 1, newLine: j})
+			i++
+		}
+	}
+	return ops
+}
+
+type hunk struct {
+	ops []op
+}
+
+func (h hunk) header() string {
+	var oldStart, oldCount, newStart, newCount int
+	for _, op := range h.ops {
+		if op.kind != opInsert {
+			if oldCount == 0 {
+				oldStart = op.oldLine
+			}
+			oldCount++
+		}
+		if op.kind != opDelete {
+			if newCount == 0 {
+				newStart = op.newLine
+			}
+			newCount++
+		}
+	}
+	// unified diffs refer to the line preceding an empty range
+	if oldCount == 0 {
+		oldStart = h.ops[0].oldLine
+	}
+	if newCount == 0 {
+		newStart = h.ops[0].newLine
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+}
+
+// hunks groups ops into hunks of changes surrounded by
+// at most context unchanged lines.
+func hunks(ops []op, context int) []hunk {
+	var res []hunk
+	start := -1
+	lastChange := -1
+	for i, op := range ops {
+		if op.kind == opEqual {
+			continue
+		}
+		if start >= 0 && i-lastChange-1 > 2*context {
+			res = append(res, hunk{ops: ops[start : lastChange+context+1]})
+			start = -1
+		}
+		if start < 0 {
+			start = i - context
+			if start < 0 {
+				start = 0
+			}
+		}
+		lastChange = i
+	}
+	if start >= 0 {
+		end := lastChange + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		res = append(res, hunk{ops: ops[start:end]})
+	}
+	return res
+}
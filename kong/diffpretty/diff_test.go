@@ -0,0 +1,81 @@
+package diffpretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/go-kong/kong"
+)
+
+func TestEntities(t *testing.T) {
+	oldService := &kong.Service{
+		Name: kong.String("foo"),
+		Host: kong.String("example.com"),
+		Port: kong.Int(80),
+	}
+	newService := &kong.Service{
+		Name: kong.String("foo"),
+		Host: kong.String("example.org"),
+		Port: kong.Int(80),
+	}
+
+	t.Run("equal entities", func(t *testing.T) {
+		out, err := Entities(oldService, oldService, Options{})
+		require.NoError(t, err)
+		assert.Empty(t, out)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		out, err := Entities(oldService, newService, Options{OldName: "live", NewName: "desired"})
+		require.NoError(t, err)
+		assert.Equal(t, `--- live
++++ desired
+@@ -1,5 +1,5 @@
+ {
+-  "host": "example.com",
++  "host": "example.org",
+   "name": "foo",
+   "port": 80
+ }
+`, out)
+	})
+
+	t.Run("creation", func(t *testing.T) {
+		out, err := Entities(nil, newService, Options{})
+		require.NoError(t, err)
+		assert.Contains(t, out, "@@ -0,0 +1,5 @@\n")
+		assert.Contains(t, out, "+  \"host\": \"example.org\",\n")
+	})
+
+	t.Run("typed nil is a deletion", func(t *testing.T) {
+		out, err := Entities(oldService, (*kong.Service)(nil), Options{})
+		require.NoError(t, err)
+		assert.Contains(t, out, "@@ -1,5 +0,0 @@\n")
+	})
+
+	t.Run("color", func(t *testing.T) {
+		out, err := Entities(oldService, newService, Options{Color: true})
+		require.NoError(t, err)
+		assert.Contains(t, out, colorRed+`-  "host": "example.com",`+colorReset)
+		assert.Contains(t, out, colorGreen+`+  "host": "example.org",`+colorReset)
+	})
+}
+
+func TestTextHunks(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 0; i < 20; i++ {
+		line := strings.Repeat("x", i)
+		oldLines = append(oldLines, line)
+		newLines = append(newLines, line)
+	}
+	newLines[1] = "changed"
+	newLines[18] = "changed"
+
+	out := Text(strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), Options{Context: 1})
+	assert.Equal(t, 2, strings.Count(out, "@@ -"))
+	assert.Contains(t, out, "@@ -1,3 +1,3 @@\n")
+	assert.Contains(t, out, "@@ -18,3 +18,3 @@\n")
+}
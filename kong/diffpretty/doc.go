@@ -0,0 +1,3 @@
+// Package diffpretty renders human-readable differences
+// between two Kong entities.
+package diffpretty
@@ -0,0 +1,232 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// DriftKind is the kind of difference reported by a Watcher.
+type DriftKind string
+
+const (
+	// DriftAdded is reported for entities which are in Kong but not in
+	// the reference state.
+	DriftAdded DriftKind = "added"
+	// DriftRemoved is reported for entities which are in the reference
+	// state but not in Kong.
+	DriftRemoved DriftKind = "removed"
+	// DriftChanged is reported for entities which differ between Kong and
+	// the reference state.
+	DriftChanged DriftKind = "changed"
+)
+
+// DriftEvent is a difference between the configuration of Kong and a
+// reference state, found by a Watcher.
+type DriftEvent struct {
+	Kind DriftKind
+	// EntityType is the name of the entities in the Admin API,
+	// e.g. "services".
+	EntityType string
+	ID         string
+	// Expected and Actual are the entity in the reference state and in
+	// Kong, when they exist.
+	Expected json.RawMessage
+	Actual   json.RawMessage
+}
+
+// WatcherOpts configures a Watcher.
+type WatcherOpts struct {
+	// Interval is the time between two checks done by Run.
+	Interval time.Duration
+	// Tags and MatchAllTags restrict the watched entities, see BackupOpts.
+	Tags         []string
+	MatchAllTags bool
+	// Desired is the state Kong is compared to, usually read from an
+	// archive written by Backup. If nil, each check compares Kong to the
+	// state of the previous check, the first check reporting no drift.
+	Desired *BackupArchive
+	// OnDrift is called by Run with the events of checks finding drift.
+	OnDrift func([]DriftEvent)
+	// Store, if set and Desired isn't, persists the state of the last
+	// check, so that the first check after a restart compares Kong to it.
+	Store SnapshotStore
+	// StoreKey is the key of the state in Store, "drift/" followed by
+	// the workspace targeted by the context of checks if empty, see
+	// WithWorkspace.
+	StoreKey string
+}
+
+// Watcher periodically compares the entities of a Kong with a reference
+// state to detect changes made outside of the processes managing them,
+// e.g. by hand. Entities are compared by ID, ignoring timestamps.
+type Watcher struct {
+	client   *Client
+	opts     WatcherOpts
+	previous map[string]map[string]json.RawMessage
+	loaded   bool
+}
+
+// NewWatcher returns a Watcher checking the Kong (and workspace)
+// targeted by client.
+func NewWatcher(client *Client, opts WatcherOpts) (*Watcher, error) {
+	w := &Watcher{client: client, opts: opts}
+	if opts.Desired != nil {
+		var err error
+		w.previous, err = archiveEntities(opts.Desired)
+		if err != nil {
+			return nil, fmt.Errorf("reading desired state: %w", err)
+		}
+	}
+	return w, nil
+}
+
+// Check takes a snapshot of Kong with Client.Backup and returns its
+// differences with the reference state, sorted by entity type and ID.
+func (w *Watcher) Check(ctx context.Context) ([]DriftEvent, error) {
+	var buf bytes.Buffer
+	err := w.client.Backup(ctx, &buf, BackupOpts{Tags: w.opts.Tags, MatchAllTags: w.opts.MatchAllTags})
+	if err != nil {
+		return nil, fmt.Errorf("taking snapshot: %w", err)
+	}
+	var archive BackupArchive
+	if err := json.Unmarshal(buf.Bytes(), &archive); err != nil {
+		return nil, err
+	}
+	current, err := archiveEntities(&archive)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.opts.Desired == nil && w.opts.Store != nil && !w.loaded {
+		snapshot, err := w.opts.Store.Load(ctx, w.storeKey(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("loading snapshot: %w", err)
+		}
+		w.previous, w.loaded = snapshot, true
+	}
+
+	previous := w.previous
+	if w.opts.Desired == nil {
+		if w.opts.Store != nil {
+			if err := w.opts.Store.Save(ctx, w.storeKey(ctx), current); err != nil {
+				return nil, fmt.Errorf("saving snapshot: %w", err)
+			}
+		}
+		w.previous = current
+		if previous == nil {
+			return nil, nil
+		}
+	}
+	return diffEntities(previous, current), nil
+}
+
+func (w *Watcher) storeKey(ctx context.Context) string {
+	if w.opts.StoreKey != "" {
+		return w.opts.StoreKey
+	}
+	return "drift/" + w.client.contextWorkspace(ctx)
+}
+
+// Run checks Kong every opts.Interval, passing drift to opts.OnDrift,
+// until ctx is done or a check fails.
+func (w *Watcher) Run(ctx context.Context) error {
+	if w.opts.Interval <= 0 {
+		return fmt.Errorf("interval must be positive")
+	}
+	ticker := time.NewTicker(w.opts.Interval)
+	defer ticker.Stop()
+	for {
+		events, err := w.Check(ctx)
+		if err != nil {
+			return err
+		}
+		if len(events) > 0 && w.opts.OnDrift != nil {
+			w.opts.OnDrift(events)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// archiveEntities indexes the entities of archive by type and ID,
+// without their timestamps.
+func archiveEntities(archive *BackupArchive) (map[string]map[string]json.RawMessage, error) {
+	b, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "version")
+	delete(fields, "workspace")
+	delete(fields, "tags")
+
+	res := map[string]map[string]json.RawMessage{}
+	for entityType, raw := range fields {
+		var entities []map[string]interface{}
+		if err := json.Unmarshal(raw, &entities); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", entityType, err)
+		}
+		res[entityType] = map[string]json.RawMessage{}
+		for _, entity := range entities {
+			id, _ := entity["id"].(string)
+			delete(entity, "created_at")
+			delete(entity, "updated_at")
+			b, err := json.Marshal(entity)
+			if err != nil {
+				return nil, err
+			}
+			res[entityType][id] = b
+		}
+	}
+	return res, nil
+}
+
+func diffEntities(expected, actual map[string]map[string]json.RawMessage) []DriftEvent {
+	var events []DriftEvent
+	for entityType, entities := range expected {
+		for id, e := range entities {
+			a, ok := actual[entityType][id]
+			switch {
+			case !ok:
+				events = append(events, DriftEvent{Kind: DriftRemoved, EntityType: entityType, ID: id, Expected: e})
+			case !jsonEqual(e, a):
+				events = append(events, DriftEvent{
+					Kind: DriftChanged, EntityType: entityType, ID: id, Expected: e, Actual: a,
+				})
+			}
+		}
+	}
+	for entityType, entities := range actual {
+		for id, a := range entities {
+			if _, ok := expected[entityType][id]; !ok {
+				events = append(events, DriftEvent{Kind: DriftAdded, EntityType: entityType, ID: id, Actual: a})
+			}
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].EntityType != events[j].EntityType {
+			return events[i].EntityType < events[j].EntityType
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events
+}
+
+func jsonEqual(a, b json.RawMessage) bool {
+	var x, y interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	return reflect.DeepEqual(x, y)
+}
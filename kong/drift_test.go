@@ -0,0 +1,110 @@
+package kong
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	var lock sync.Mutex
+	services := `[{"id":"s1","name":"foo","port":80,"updated_at":1}]`
+	setServices := func(s string) {
+		lock.Lock()
+		defer lock.Unlock()
+		services = s
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.URL.Path == "/services" {
+			_, _ = w.Write([]byte(`{"data":` + services + `}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	watcher, err := NewWatcher(client, WatcherOpts{})
+	require.NoError(t, err)
+	events, err := watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	// timestamps are ignored
+	setServices(`[{"id":"s1","name":"foo","port":80,"updated_at":2}]`)
+	events, err = watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	setServices(`[{"id":"s1","name":"foo","port":8080},{"id":"s2","name":"bar"}]`)
+	events, err = watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, DriftChanged, events[0].Kind)
+	assert.Equal(t, "services", events[0].EntityType)
+	assert.Equal(t, "s1", events[0].ID)
+	assert.JSONEq(t, `{"id":"s1","name":"foo","port":80}`, string(events[0].Expected))
+	assert.JSONEq(t, `{"id":"s1","name":"foo","port":8080}`, string(events[0].Actual))
+	assert.Equal(t, DriftAdded, events[1].Kind)
+	assert.Equal(t, "s2", events[1].ID)
+
+	// compared to a desired state, drift is reported until fixed
+	watcher, err = NewWatcher(client, WatcherOpts{
+		Desired: &BackupArchive{Services: []*Service{{ID: String("s1"), Name: String("foo"), Port: Int(8080)}}},
+	})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		events, err = watcher.Check(defaultCtx)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, DriftAdded, events[0].Kind)
+	}
+	setServices(`[]`)
+	events, err = watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, DriftRemoved, events[0].Kind)
+	assert.Equal(t, "s1", events[0].ID)
+
+	drift := make(chan []DriftEvent, 1)
+	watcher, err = NewWatcher(client, WatcherOpts{
+		Interval: time.Millisecond,
+		OnDrift:  func(events []DriftEvent) { drift <- events },
+	})
+	require.NoError(t, err)
+	_, err = watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	setServices(`[{"id":"s3","name":"baz"}]`)
+	ctx, cancel := context.WithCancel(defaultCtx)
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- watcher.Run(ctx) }()
+	select {
+	case events := <-drift:
+		assert.Equal(t, "s3", events[0].ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no drift reported")
+	}
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	watcher, err = NewWatcher(client, WatcherOpts{})
+	require.NoError(t, err)
+	assert.Error(t, watcher.Run(defaultCtx))
+
+	// desired states which can't be encoded are rejected
+	_, err = NewWatcher(client, WatcherOpts{
+		Desired: &BackupArchive{Plugins: []*Plugin{{Config: Configuration{"ratio": math.Inf(1)}}}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DryRunRequest is a write request skipped by a client in dry-run mode.
+type DryRunRequest struct {
+	Method string
+	// Path is the path of the request, relative to the workspace.
+	Path string
+	Body []byte
+}
+
+type dryRunConfig struct {
+	report func(DryRunRequest)
+}
+
+// SetDryRun enables or disables the dry-run mode of the client.
+//
+// In dry-run mode, requests creating, updating or deleting entities are
+// not sent to Kong. Instead, they are passed to report, or written to the
+// logger set by SetLogger if report is nil, and succeed with the request
+// body as response. The Create, Update and Delete methods of services
+// thus return the entities as they were sent, enabling "plan" runs of
+// code built on them.
+//
+// Entities created or replaced at the top level of the Admin API
+// (e.g. POST /services or PUT /plugins/{id}) are validated against their
+// schemas first, and the request fails with the error of Kong if they are
+// not valid. Partial updates (PATCH) and nested entities
+// (e.g. POST /services/{service}/routes) are not validated.
+func (c *Client) SetDryRun(dryRun bool, report func(DryRunRequest)) {
+	if !dryRun {
+		c.dryRun.Store((*dryRunConfig)(nil))
+		return
+	}
+	c.dryRun.Store(&dryRunConfig{report: report})
+}
+
+// IsDryRun returns whether the client is in dry-run mode, see SetDryRun.
+func (c *Client) IsDryRun() bool {
+	config, _ := c.dryRun.Load().(*dryRunConfig)
+	return config != nil
+}
+
+// doDryRun returns a response for write requests in dry-run mode, without
+// sending them to Kong. It returns a nil response for other requests.
+func (c *Client) doDryRun(ctx context.Context, req *http.Request) (*http.Response, error) {
+	config, _ := c.dryRun.Load().(*dryRunConfig)
+	if config == nil {
+		return nil, nil
+	}
+	status := http.StatusOK
+	switch req.Method {
+	case http.MethodPost:
+		status = http.StatusCreated
+	case http.MethodPut, http.MethodPatch:
+	case http.MethodDelete:
+		status = http.StatusNoContent
+	default:
+		return nil, nil
+	}
+	path := c.relativePath(req)
+	// validation endpoints don't write anything
+	if strings.HasPrefix(path, "/schemas/") {
+		return nil, nil
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %w", err)
+		}
+	}
+
+	if resp, err := c.validateDryRun(ctx, req.Method, path, body); resp != nil || err != nil {
+		return resp, err
+	}
+
+	r := DryRunRequest{Method: req.Method, Path: path, Body: body}
+	if config.report != nil {
+		config.report(r)
+	} else {
+		if err := c.writeLog([]byte(fmt.Sprintf("dry-run: %s %s %s\n", r.Method, r.Path, r.Body))); err != nil {
+			return nil, err
+		}
+	}
+
+	if status == http.StatusNoContent {
+		body = nil
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// validateDryRun validates top-level entities created or replaced in
+// dry-run mode. It returns the response of Kong if the entity is invalid.
+func (c *Client) validateDryRun(ctx context.Context, method, path string,
+	body []byte,
+) (*http.Response, error) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(body) == 0 ||
+		!(method == http.MethodPost && len(segments) == 1 ||
+			method == http.MethodPut && len(segments) == 2) {
+		return nil, nil
+	}
+	entity := segments[0]
+	req, err := c.NewRequest(http.MethodPost, "/schemas/"+entity+"/validate", nil, body)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.DoRAW(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		// no schema to validate against, e.g. for the /config endpoint
+		resp.Body.Close()
+		return nil, nil
+	case resp.StatusCode >= http.StatusBadRequest:
+		return resp, nil
+	}
+	resp.Body.Close()
+	return nil, nil
+}
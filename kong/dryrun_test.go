@@ -0,0 +1,86 @@
+package kong
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /ws/services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","host":"example.com"}`))
+		case "POST /ws/schemas/services/validate":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			if !bytes.Contains(body, []byte(`"host"`)) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"message":"schema violation (host: required field missing)"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"message":"schema validation successful"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("ws")
+
+	var skipped []DryRunRequest
+	client.SetDryRun(true, func(r DryRunRequest) { skipped = append(skipped, r) })
+	assert.True(t, client.IsDryRun())
+
+	service, err := client.Services.Get(defaultCtx, String("foo"))
+	require.NoError(t, err)
+	assert.Equal(t, "foo", *service.Name)
+
+	created, err := client.Services.Create(defaultCtx, &Service{Name: String("bar"), Host: String("example.com")})
+	require.NoError(t, err)
+	assert.Equal(t, "bar", *created.Name)
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("baz")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "host: required field missing")
+
+	updated, err := client.Services.Update(defaultCtx, &Service{ID: String("s1"), Port: Int(8080)})
+	require.NoError(t, err)
+	assert.Equal(t, 8080, *updated.Port)
+
+	require.NoError(t, client.Services.Delete(defaultCtx, String("s1")))
+	_, err = client.Targets.Create(defaultCtx, String("up"), &Target{Target: String("10.0.0.1:80")})
+	require.NoError(t, err)
+
+	require.Len(t, skipped, 4)
+	assert.Equal(t, "POST", skipped[0].Method)
+	assert.Equal(t, "/services", skipped[0].Path)
+	assert.JSONEq(t, `{"name":"bar","host":"example.com"}`, string(skipped[0].Body))
+	assert.Equal(t, "PATCH /services/s1", skipped[1].Method+" "+skipped[1].Path)
+	assert.Equal(t, "DELETE /services/s1", skipped[2].Method+" "+skipped[2].Path)
+	assert.Equal(t, "POST /upstreams/up/targets", skipped[3].Method+" "+skipped[3].Path)
+	assert.Equal(t, []string{
+		"GET /ws/services/foo",
+		"POST /ws/schemas/services/validate",
+		"POST /ws/schemas/services/validate",
+	}, requests)
+
+	// without a report function, requests are logged
+	var log bytes.Buffer
+	client.SetLogger(&log)
+	client.SetDryRun(true, nil)
+	require.NoError(t, client.Services.Delete(defaultCtx, String("s1")))
+	assert.Equal(t, "dry-run: DELETE /services/s1 \n", log.String())
+
+	client.SetDryRun(false, nil)
+	assert.False(t, client.IsDryRun())
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -155,7 +154,7 @@ func (s *RBACEndpointPermissionService) ListAllForRole(ctx context.Context,
 			return nil, err
 		}
 		var ep RBACEndpointPermission
-		err = json.Unmarshal(b, &ep)
+		err = s.client.decode(b, &ep)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,270 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tidwall/gjson"
+)
+
+const generatorAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// EntityGenerator generates random entities which are valid according to
+// an entity or plugin schema, as returned by SchemaService.Get or
+// PluginService.GetFullSchema, for fuzz and property-based testing.
+//
+// Required fields are always generated and optional ones with probability
+// OptionalFieldProbability; fields filled by Kong (auto) are left out.
+// Enumerations (one_of), numeric ranges (between, gt), length constraints
+// (len_min, len_max), prefixes (starts_with), UUIDs and the entity checks
+// at_least_one_of, only_one_of, mutually_exclusive and mutually_required
+// are honored. Lua patterns (match, not_match), custom validators and
+// conditional entity checks are not, so Kong may still reject a
+// generated entity.
+type EntityGenerator struct {
+	// OptionalFieldProbability is the probability for an optional field
+	// to be generated, 0.5 by default.
+	OptionalFieldProbability float64
+	// MaxLength bounds the length of strings, arrays, sets and maps
+	// without len_max, 8 by default.
+	MaxLength int
+
+	rand *rand.Rand
+}
+
+// NewEntityGenerator returns a generator whose entities are determined by
+// seed, so that failing fuzz inputs can be reproduced.
+func NewEntityGenerator(seed int64) *EntityGenerator {
+	return &EntityGenerator{
+		OptionalFieldProbability: 0.5,
+		MaxLength:                8,
+		rand:                     rand.New(rand.NewSource(seed)), //nolint:gosec // reproducibility matters, not security
+	}
+}
+
+// Generate returns a random entity valid according to schema.
+func (g *EntityGenerator) Generate(schema Schema) (map[string]interface{}, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	root := gjson.ParseBytes(b)
+	if !root.Get("fields").Exists() {
+		return nil, fmt.Errorf("only Lua schemas with fields are supported")
+	}
+	return g.record(root)
+}
+
+// GenerateInto generates a random entity valid according to schema into
+// entity, e.g. a *Service.
+func (g *EntityGenerator) GenerateInto(schema Schema, entity interface{}) error {
+	values, err := g.Generate(schema)
+	if err != nil {
+		return err
+	}
+	return convert(values, entity)
+}
+
+func (g *EntityGenerator) record(record gjson.Result) (map[string]interface{}, error) {
+	res := map[string]interface{}{}
+	fields := map[string]gjson.Result{}
+	for _, field := range record.Get("fields").Array() {
+		for name, schema := range field.Map() {
+			fields[name] = schema
+			if schema.Get("auto").Bool() && !schema.Get("required").Bool() {
+				continue
+			}
+			if !schema.Get("required").Bool() && g.rand.Float64() >= g.OptionalFieldProbability {
+				continue
+			}
+			value, err := g.field(schema)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			res[name] = value
+		}
+	}
+	for _, check := range record.Get("entity_checks").Array() {
+		if err := g.entityCheck(check, fields, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// entityCheck adjusts values to satisfy check, an entity check of a
+// record with fields.
+func (g *EntityGenerator) entityCheck(check gjson.Result, fields map[string]gjson.Result,
+	values map[string]interface{},
+) error {
+	set := func(names []string) error {
+		for _, name := range names {
+			if _, ok := values[name]; ok {
+				continue
+			}
+			value, err := g.field(fields[name])
+			if err != nil {
+				return fmt.Errorf("field %q: %w", name, err)
+			}
+			values[name] = value
+		}
+		return nil
+	}
+	for kind, args := range check.Map() {
+		var names []string
+		for _, name := range args.Array() {
+			if _, ok := fields[name.String()]; !ok {
+				// nested fields and unknown arguments
+				return nil
+			}
+			names = append(names, name.String())
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		var present []string
+		for _, name := range names {
+			if _, ok := values[name]; ok {
+				present = append(present, name)
+			}
+		}
+		switch kind {
+		case "at_least_one_of":
+			if len(present) == 0 {
+				return set(names[g.rand.Intn(len(names)):][:1])
+			}
+		case "only_one_of":
+			if len(present) == 0 {
+				return set(names[g.rand.Intn(len(names)):][:1])
+			}
+			for _, name := range present[1:] {
+				delete(values, name)
+			}
+		case "mutually_exclusive":
+			for i := 1; i < len(present); i++ {
+				delete(values, present[i])
+			}
+		case "mutually_required":
+			if len(present) > 0 {
+				return set(names)
+			}
+		}
+	}
+	return nil
+}
+
+func (g *EntityGenerator) field(field gjson.Result) (interface{}, error) {
+	if oneOf := field.Get("one_of").Array(); len(oneOf) > 0 {
+		return oneOf[g.rand.Intn(len(oneOf))].Value(), nil
+	}
+	switch t := field.Get("type").String(); t {
+	case "string":
+		if field.Get("uuid").Bool() {
+			id, err := uuid.NewRandomFromReader(g.rand)
+			if err != nil {
+				return nil, err
+			}
+			return id.String(), nil
+		}
+		prefix := field.Get("starts_with").String()
+		n := g.length(field, 1) - len(prefix)
+		if n < 0 {
+			n = 0
+		}
+		var b strings.Builder
+		b.WriteString(prefix)
+		for i := 0; i < n; i++ {
+			b.WriteByte(generatorAlphabet[g.rand.Intn(len(generatorAlphabet))])
+		}
+		return b.String(), nil
+	case "integer", "number":
+		min, max := 0.0, 1000.0
+		if between := field.Get("between").Array(); len(between) == 2 {
+			min, max = between[0].Float(), between[1].Float()
+		}
+		if gt := field.Get("gt"); gt.Exists() {
+			if t == "integer" {
+				min = math.Max(min, math.Floor(gt.Float())+1)
+			} else {
+				min = math.Max(min, math.Nextafter(gt.Float(), math.Inf(1)))
+			}
+			if max < min {
+				max = min + 1000
+			}
+		}
+		if t == "integer" {
+			min, max = math.Ceil(min), math.Floor(max)
+			return int64(min) + g.rand.Int63n(int64(max-min)+1), nil
+		}
+		return min + g.rand.Float64()*(max-min), nil
+	case "boolean":
+		return g.rand.Intn(2) == 1, nil
+	case "array", "set":
+		n := g.length(field, 0)
+		elements := make([]interface{}, 0, n)
+		seen := map[string]bool{}
+		// sets can't always hold n distinct elements, e.g. of small enums
+		for attempts := 0; len(elements) < n && attempts < 10*n; attempts++ {
+			element, err := g.field(field.Get("elements"))
+			if err != nil {
+				return nil, err
+			}
+			if t == "set" {
+				key := fmt.Sprint(element)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			elements = append(elements, element)
+		}
+		return elements, nil
+	case "map":
+		n := g.length(field, 0)
+		res := make(map[string]interface{}, n)
+		for i := 0; i < n; i++ {
+			key, err := g.field(field.Get("keys"))
+			if err != nil {
+				return nil, err
+			}
+			value, err := g.field(field.Get("values"))
+			if err != nil {
+				return nil, err
+			}
+			res[fmt.Sprint(key)] = value
+		}
+		return res, nil
+	case "record":
+		return g.record(field)
+	case "foreign":
+		id, err := uuid.NewRandomFromReader(g.rand)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"id": id.String()}, nil
+	case "json", "any":
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported field type %q", t)
+	}
+}
+
+// length returns a random length for field, within its len_min and
+// len_max, at least min.
+func (g *EntityGenerator) length(field gjson.Result, min int) int {
+	if lenMin := field.Get("len_min"); lenMin.Exists() {
+		min = int(lenMin.Int())
+	}
+	max := min + g.MaxLength
+	if lenMax := field.Get("len_max"); lenMax.Exists() {
+		max = int(lenMax.Int())
+	}
+	if max <= min {
+		return min
+	}
+	return min + g.rand.Intn(max-min+1)
+}
@@ -0,0 +1,114 @@
+package kong
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const generatorTestSchema = `{"fields": [
+	{"id": {"type": "string", "uuid": true, "auto": true}},
+	{"created_at": {"type": "integer", "timestamp": true, "auto": true}},
+	{"name": {"type": "string", "len_min": 1, "len_max": 4}},
+	{"protocol": {"type": "string", "one_of": ["http", "https"], "required": true}},
+	{"port": {"type": "integer", "between": [1, 3], "required": true}},
+	{"weight": {"type": "number", "gt": 0.5}},
+	{"retries": {"type": "integer", "gt": 10}},
+	{"path": {"type": "string", "starts_with": "/", "required": true}},
+	{"methods": {"type": "set", "elements": {"type": "string", "one_of": ["GET", "POST"]}, "len_min": 2}},
+	{"headers": {"type": "map", "keys": {"type": "string"}, "values": {"type": "array", "elements": {"type": "string"}}}},
+	{"service": {"type": "foreign", "reference": "services", "required": true}},
+	{"hosts": {"type": "array", "elements": {"type": "string"}}},
+	{"snis": {"type": "array", "elements": {"type": "string"}}},
+	{"cert": {"type": "string"}},
+	{"key": {"type": "string"}},
+	{"config": {"type": "record", "required": true, "fields": [
+		{"enabled": {"type": "boolean", "required": true}}
+	]}}
+], "entity_checks": [
+	{"at_least_one_of": ["hosts", "snis"]},
+	{"mutually_required": ["cert", "key"]},
+	{"at_least_one_of": ["config.enabled"]}
+]}`
+
+func TestEntityGenerator(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(generatorTestSchema), &schema))
+
+	for seed := int64(0); seed < 200; seed++ {
+		entity, err := NewEntityGenerator(seed).Generate(schema)
+		require.NoError(t, err)
+
+		assert.NotContains(t, entity, "id")
+		assert.NotContains(t, entity, "created_at")
+		if name, ok := entity["name"].(string); ok {
+			assert.GreaterOrEqual(t, len(name), 1)
+			assert.LessOrEqual(t, len(name), 4)
+		}
+		assert.Contains(t, []interface{}{"http", "https"}, entity["protocol"])
+		assert.Contains(t, []interface{}{int64(1), int64(2), int64(3)}, entity["port"])
+		if weight, ok := entity["weight"]; ok {
+			assert.Greater(t, weight, 0.5)
+		}
+		if retries, ok := entity["retries"]; ok {
+			assert.Greater(t, retries, int64(10))
+		}
+		assert.True(t, strings.HasPrefix(entity["path"].(string), "/"))
+		if methods, ok := entity["methods"]; ok {
+			assert.ElementsMatch(t, []interface{}{"GET", "POST"}, methods)
+		}
+		assert.Len(t, entity["service"].(map[string]interface{})["id"], 36)
+		assert.True(t, entity["hosts"] != nil || entity["snis"] != nil)
+		_, cert := entity["cert"]
+		_, key := entity["key"]
+		assert.Equal(t, cert, key)
+		assert.IsType(t, true, entity["config"].(map[string]interface{})["enabled"])
+	}
+
+	// entities are determined by the seed
+	a, err := NewEntityGenerator(42).Generate(schema)
+	require.NoError(t, err)
+	b, err := NewEntityGenerator(42).Generate(schema)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	var route Route
+	generator := NewEntityGenerator(1)
+	generator.OptionalFieldProbability = 1
+	require.NoError(t, generator.GenerateInto(Schema{"fields": []interface{}{
+		map[string]interface{}{"name": map[string]interface{}{"type": "string"}},
+		map[string]interface{}{"paths": map[string]interface{}{
+			"type": "array", "elements": map[string]interface{}{"type": "string", "starts_with": "/"},
+		}},
+		map[string]interface{}{"strip_path": map[string]interface{}{"type": "boolean"}},
+	}}, &route))
+	assert.NotNil(t, route.Name)
+	assert.NotNil(t, route.StripPath)
+	for _, path := range route.Paths {
+		assert.True(t, strings.HasPrefix(*path, "/"))
+	}
+
+	_, err = NewEntityGenerator(0).Generate(Schema{"properties": map[string]interface{}{}})
+	assert.Error(t, err)
+	_, err = NewEntityGenerator(0).Generate(Schema{"fields": []interface{}{
+		map[string]interface{}{"f": map[string]interface{}{"type": "function", "required": true}},
+	}})
+	assert.EqualError(t, err, `field "f": unsupported field type "function"`)
+}
+
+func FuzzEntityGenerator(f *testing.F) {
+	var schema Schema
+	require.NoError(f, json.Unmarshal([]byte(generatorTestSchema), &schema))
+	for _, seed := range []int64{0, 1, 42} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		entity, err := NewEntityGenerator(seed).Generate(schema)
+		require.NoError(t, err)
+		_, err = json.Marshal(entity)
+		require.NoError(t, err)
+	})
+}
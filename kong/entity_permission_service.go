@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -141,7 +140,7 @@ func (s *RBACEntityPermissionService) ListAllForRole(ctx context.Context,
 			return nil, err
 		}
 		var ep RBACEntityPermission
-		err = json.Unmarshal(b, &ep)
+		err = s.client.decode(b, &ep)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,250 @@
+package kong
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RouteProtocol is a protocol which can be set on a Route.
+type RouteProtocol string
+
+const (
+	RouteProtocolHTTP           RouteProtocol = "http"
+	RouteProtocolHTTPS          RouteProtocol = "https"
+	RouteProtocolGRPC           RouteProtocol = "grpc"
+	RouteProtocolGRPCS          RouteProtocol = "grpcs"
+	RouteProtocolTCP            RouteProtocol = "tcp"
+	RouteProtocolTLS            RouteProtocol = "tls"
+	RouteProtocolTLSPassthrough RouteProtocol = "tls_passthrough"
+	RouteProtocolUDP            RouteProtocol = "udp"
+	// RouteProtocolWS and RouteProtocolWSS require Kong Enterprise 3.0+.
+	RouteProtocolWS  RouteProtocol = "ws"
+	RouteProtocolWSS RouteProtocol = "wss"
+)
+
+var validRouteProtocols = map[RouteProtocol]struct{}{
+	RouteProtocolHTTP:           {},
+	RouteProtocolHTTPS:          {},
+	RouteProtocolGRPC:           {},
+	RouteProtocolGRPCS:          {},
+	RouteProtocolTCP:            {},
+	RouteProtocolTLS:            {},
+	RouteProtocolTLSPassthrough: {},
+	RouteProtocolUDP:            {},
+	RouteProtocolWS:             {},
+	RouteProtocolWSS:            {},
+}
+
+// IsValid returns true if p is a protocol accepted by Kong on Routes.
+func (p RouteProtocol) IsValid() bool {
+	_, ok := validRouteProtocols[p]
+	return ok
+}
+
+// RouteProtocols converts protocols into a slice of *string
+// which can be used to populate Route.Protocols.
+func RouteProtocols(protocols ...RouteProtocol) []*string {
+	res := make([]*string, 0, len(protocols))
+	for _, p := range protocols {
+		res = append(res, String(string(p)))
+	}
+	return res
+}
+
+// ServiceProtocol is a protocol which can be set on a Service.
+type ServiceProtocol string
+
+const (
+	ServiceProtocolHTTP  ServiceProtocol = "http"
+	ServiceProtocolHTTPS ServiceProtocol = "https"
+	ServiceProtocolGRPC  ServiceProtocol = "grpc"
+	ServiceProtocolGRPCS ServiceProtocol = "grpcs"
+	ServiceProtocolTCP   ServiceProtocol = "tcp"
+	ServiceProtocolTLS   ServiceProtocol = "tls"
+	ServiceProtocolUDP   ServiceProtocol = "udp"
+	// ServiceProtocolWS and ServiceProtocolWSS require Kong Enterprise 3.0+.
+	ServiceProtocolWS  ServiceProtocol = "ws"
+	ServiceProtocolWSS ServiceProtocol = "wss"
+)
+
+var validServiceProtocols = map[ServiceProtocol]struct{}{
+	ServiceProtocolHTTP:  {},
+	ServiceProtocolHTTPS: {},
+	ServiceProtocolGRPC:  {},
+	ServiceProtocolGRPCS: {},
+	ServiceProtocolTCP:   {},
+	ServiceProtocolTLS:   {},
+	ServiceProtocolUDP:   {},
+	ServiceProtocolWS:    {},
+	ServiceProtocolWSS:   {},
+}
+
+// IsValid returns true if p is a protocol accepted by Kong on Services.
+func (p ServiceProtocol) IsValid() bool {
+	_, ok := validServiceProtocols[p]
+	return ok
+}
+
+// HTTPMethod is an HTTP method which can be matched by a Route.
+type HTTPMethod string
+
+const (
+	HTTPMethodGet     HTTPMethod = "GET"
+	HTTPMethodHead    HTTPMethod = "HEAD"
+	HTTPMethodPost    HTTPMethod = "POST"
+	HTTPMethodPut     HTTPMethod = "PUT"
+	HTTPMethodPatch   HTTPMethod = "PATCH"
+	HTTPMethodDelete  HTTPMethod = "DELETE"
+	HTTPMethodConnect HTTPMethod = "CONNECT"
+	HTTPMethodOptions HTTPMethod = "OPTIONS"
+	HTTPMethodTrace   HTTPMethod = "TRACE"
+)
+
+// Kong accepts any method made of uppercase letters, which allows
+// matching on non-standard methods such as PURGE.
+var httpMethodRegex = regexp.MustCompile(`^[A-Z]+$`)
+
+// IsValid returns true if m is a method accepted by Kong on Routes.
+func (m HTTPMethod) IsValid() bool {
+	return httpMethodRegex.MatchString(string(m))
+}
+
+// HTTPMethods converts methods into a slice of *string
+// which can be used to populate Route.Methods.
+func HTTPMethods(methods ...HTTPMethod) []*string {
+	res := make([]*string, 0, len(methods))
+	for _, m := range methods {
+		res = append(res, String(string(m)))
+	}
+	return res
+}
+
+// UpstreamAlgorithm is a load-balancing algorithm of an Upstream.
+type UpstreamAlgorithm string
+
+const (
+	UpstreamAlgorithmRoundRobin        UpstreamAlgorithm = "round-robin"
+	UpstreamAlgorithmConsistentHashing UpstreamAlgorithm = "consistent-hashing"
+	UpstreamAlgorithmLeastConnections  UpstreamAlgorithm = "least-connections"
+	UpstreamAlgorithmLatency           UpstreamAlgorithm = "latency"
+)
+
+var validUpstreamAlgorithms = map[UpstreamAlgorithm]struct{}{
+	UpstreamAlgorithmRoundRobin:        {},
+	UpstreamAlgorithmConsistentHashing: {},
+	UpstreamAlgorithmLeastConnections:  {},
+	UpstreamAlgorithmLatency:           {},
+}
+
+// IsValid returns true if a is an algorithm accepted by Kong on Upstreams.
+func (a UpstreamAlgorithm) IsValid() bool {
+	_, ok := validUpstreamAlgorithms[a]
+	return ok
+}
+
+// HashOn is an input used by an Upstream to compute hashes
+// with the consistent-hashing algorithm.
+type HashOn string
+
+const (
+	HashOnNone       HashOn = "none"
+	HashOnConsumer   HashOn = "consumer"
+	HashOnIP         HashOn = "ip"
+	HashOnHeader     HashOn = "header"
+	HashOnCookie     HashOn = "cookie"
+	HashOnPath       HashOn = "path"
+	HashOnQueryArg   HashOn = "query_arg"
+	HashOnURICapture HashOn = "uri_capture"
+)
+
+var validHashOns = map[HashOn]struct{}{
+	HashOnNone:       {},
+	HashOnConsumer:   {},
+	HashOnIP:         {},
+	HashOnHeader:     {},
+	HashOnCookie:     {},
+	HashOnPath:       {},
+	HashOnQueryArg:   {},
+	HashOnURICapture: {},
+}
+
+// IsValid returns true if h is a hash input accepted by Kong on Upstreams.
+func (h HashOn) IsValid() bool {
+	_, ok := validHashOns[h]
+	return ok
+}
+
+// PathHandling is the path handling algorithm of a Route.
+type PathHandling string
+
+const (
+	PathHandlingV0 PathHandling = "v0"
+	PathHandlingV1 PathHandling = "v1"
+)
+
+// IsValid returns true if p is a path handling algorithm accepted by Kong.
+func (p PathHandling) IsValid() bool {
+	return p == PathHandlingV0 || p == PathHandlingV1
+}
+
+// ValidateEnums checks that the protocols, methods and path handling of
+// the Route only contain values accepted by Kong.
+func (r *Route) ValidateEnums() error {
+	if r == nil {
+		return fmt.Errorf("route is nil")
+	}
+	for _, p := range r.Protocols {
+		if p == nil || !RouteProtocol(*p).IsValid() {
+			return fmt.Errorf("invalid route protocol: %q", derefString(p))
+		}
+	}
+	for _, m := range r.Methods {
+		if m == nil || !HTTPMethod(*m).IsValid() {
+			return fmt.Errorf("invalid route method: %q", derefString(m))
+		}
+	}
+	if r.PathHandling != nil && !PathHandling(*r.PathHandling).IsValid() {
+		return fmt.Errorf("invalid route path_handling: %q", *r.PathHandling)
+	}
+	return nil
+}
+
+// ValidateEnums checks that the protocol of the Service is accepted by Kong.
+func (s *Service) ValidateEnums() error {
+	if s == nil {
+		return fmt.Errorf("service is nil")
+	}
+	if s.Protocol != nil && !ServiceProtocol(*s.Protocol).IsValid() {
+		return fmt.Errorf("invalid service protocol: %q", *s.Protocol)
+	}
+	return nil
+}
+
+// ValidateEnums checks that the algorithm and hashing inputs
+// of the Upstream are accepted by Kong.
+func (u *Upstream) ValidateEnums() error {
+	if u == nil {
+		return fmt.Errorf("upstream is nil")
+	}
+	if u.Algorithm != nil && !UpstreamAlgorithm(*u.Algorithm).IsValid() {
+		return fmt.Errorf("invalid upstream algorithm: %q", *u.Algorithm)
+	}
+	if u.HashOn != nil && !HashOn(*u.HashOn).IsValid() {
+		return fmt.Errorf("invalid upstream hash_on: %q", *u.HashOn)
+	}
+	if u.HashFallback != nil {
+		fallback := HashOn(*u.HashFallback)
+		// Kong doesn't allow falling back to cookie-based hashing.
+		if !fallback.IsValid() || fallback == HashOnCookie {
+			return fmt.Errorf("invalid upstream hash_fallback: %q", *u.HashFallback)
+		}
+	}
+	return nil
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return "<nil>"
+	}
+	return *s
+}
@@ -0,0 +1,99 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteValidateEnums(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		route   *Route
+		wantErr bool
+	}{
+		{
+			name: "valid route",
+			route: &Route{
+				Protocols:    RouteProtocols(RouteProtocolHTTP, RouteProtocolHTTPS),
+				Methods:      HTTPMethods(HTTPMethodGet, HTTPMethodPost),
+				PathHandling: String(string(PathHandlingV1)),
+			},
+		},
+		{
+			name:  "custom uppercase method is valid",
+			route: &Route{Methods: StringSlice("PURGE")},
+		},
+		{
+			name:    "protocol with trailing space",
+			route:   &Route{Protocols: StringSlice("http ")},
+			wantErr: true,
+		},
+		{
+			name:    "lowercase method",
+			route:   &Route{Methods: StringSlice("get")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid path handling",
+			route:   &Route{PathHandling: String("v2")},
+			wantErr: true,
+		},
+		{
+			name:    "nil route",
+			wantErr: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.route.ValidateEnums()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpstreamValidateEnums(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		upstream *Upstream
+		wantErr  bool
+	}{
+		{
+			name: "valid upstream",
+			upstream: &Upstream{
+				Algorithm:    String(string(UpstreamAlgorithmConsistentHashing)),
+				HashOn:       String(string(HashOnHeader)),
+				HashFallback: String(string(HashOnIP)),
+			},
+		},
+		{
+			name:     "unknown algorithm",
+			upstream: &Upstream{Algorithm: String("leastconn")},
+			wantErr:  true,
+		},
+		{
+			name:     "cookie fallback",
+			upstream: &Upstream{HashFallback: String(string(HashOnCookie))},
+			wantErr:  true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.upstream.ValidateEnums()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServiceValidateEnums(t *testing.T) {
+	require.NoError(t, (&Service{Protocol: String(string(ServiceProtocolGRPCS))}).ValidateEnums())
+	require.NoError(t, (&Service{}).ValidateEnums())
+	require.Error(t, (&Service{Protocol: String("tls_passthrough")}).ValidateEnums())
+}
@@ -0,0 +1,3 @@
+// Package eventhooks helps receiving the payloads sent by the webhook
+// handlers of Kong Enterprise event hooks.
+package eventhooks
@@ -0,0 +1,111 @@
+package eventhooks
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Sources of events Kong can send.
+const (
+	SourceCRUD       = "crud"
+	SourceDAOCRUD    = "dao:crud"
+	SourceBalancer   = "balancer"
+	SourceRateLimits = "rate-limiting-advanced"
+)
+
+// Event is a payload sent by a Kong event hook. Its typed content is
+// returned by the method matching its Source.
+type Event struct {
+	Source string `json:"source"`
+	Event  string `json:"event"`
+	// Raw is the whole payload.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Parse parses the payload of an event.
+func Parse(payload []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decoding event: %w", err)
+	}
+	if event.Source == "" {
+		return nil, fmt.Errorf("event has no source")
+	}
+	event.Raw = payload
+	return &event, nil
+}
+
+// CRUDEvent is sent when an entity is created, updated or deleted,
+// by the crud and dao:crud sources.
+type CRUDEvent struct {
+	// Operation is one of create, update and delete.
+	Operation string `json:"operation"`
+	// Schema is the type of the entity, e.g. "consumers".
+	Schema string `json:"schema"`
+	// Entity is the entity after the change, or the deleted entity.
+	Entity json.RawMessage `json:"entity"`
+	// OldEntity is the entity before an update.
+	OldEntity json.RawMessage `json:"old_entity,omitempty"`
+}
+
+// BalancerHealthEvent is sent by the balancer source when the health of
+// a target changes.
+type BalancerHealthEvent struct {
+	UpstreamID string `json:"upstream_id"`
+	IP         string `json:"ip"`
+	Port       int    `json:"port"`
+	Hostname   string `json:"hostname"`
+	Health     string `json:"health"`
+}
+
+// RateLimitExceededEvent is sent by the rate-limiting-advanced source
+// when a limit is exceeded.
+type RateLimitExceededEvent struct {
+	Consumer map[string]interface{} `json:"consumer,omitempty"`
+	IP       string                 `json:"ip,omitempty"`
+	Service  map[string]interface{} `json:"service,omitempty"`
+	Rate     int                    `json:"rate"`
+	Limit    int                    `json:"limit"`
+	Window   string                 `json:"window"`
+}
+
+func (e *Event) decode(v interface{}, sources ...string) error {
+	for _, source := range sources {
+		if e.Source == source {
+			return json.Unmarshal(e.Raw, v)
+		}
+	}
+	return fmt.Errorf("event from source %q is not a %s event", e.Source, sources[0])
+}
+
+// CRUD returns the content of an event of the crud or dao:crud source.
+func (e *Event) CRUD() (*CRUDEvent, error) {
+	var res CRUDEvent
+	if err := e.decode(&res, SourceCRUD, SourceDAOCRUD); err != nil {
+		return nil, err
+	}
+	if res.Operation == "" {
+		// dao:crud events carry the operation as event
+		res.Operation = e.Event
+	}
+	return &res, nil
+}
+
+// BalancerHealth returns the content of an event of the balancer source.
+func (e *Event) BalancerHealth() (*BalancerHealthEvent, error) {
+	var res BalancerHealthEvent
+	if err := e.decode(&res, SourceBalancer); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RateLimitExceeded returns the content of an event of the
+// rate-limiting-advanced source.
+func (e *Event) RateLimitExceeded() (*RateLimitExceededEvent, error) {
+	var res RateLimitExceededEvent
+	if err := e.decode(&res, SourceRateLimits); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,83 @@
+package eventhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec // used by Kong to sign payloads
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SignatureHeader is the header holding the signature of payloads sent
+// by event hooks configured with a secret.
+const SignatureHeader = "X-Kong-Signature"
+
+// maxPayloadSize bounds the payloads read by Handler.
+const maxPayloadSize = 1 << 20
+
+// Sign returns the signature of payload with secret, as sent by Kong in
+// the SignatureHeader: the hex-encoded HMAC-SHA1 of the payload, prefixed
+// with "sha1=".
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(payload)
+	return "sha1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks that signature, the value of the
+// SignatureHeader, is the signature of payload with secret.
+func VerifySignature(payload []byte, signature, secret string) error {
+	if signature == "" {
+		return fmt.Errorf("missing signature")
+	}
+	if !strings.HasPrefix(signature, "sha1=") {
+		signature = "sha1=" + signature
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(payload, secret))) {
+		return fmt.Errorf("invalid signature")
+	}
+	return nil
+}
+
+// Handler returns an http.Handler receiving events from Kong and passing
+// them to handle. If secret is set, payloads without a valid signature
+// are rejected with a 401. Invalid payloads are rejected with a 400,
+// payloads larger than 1 MiB with a 413, and errors returned by handle
+// result in a 500, which makes Kong log the failed delivery.
+func Handler(secret string, handle func(ctx context.Context, event *Event) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		// read one byte past the limit to tell large payloads apart
+		payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize+1))
+		if err != nil {
+			http.Error(w, "reading payload: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(payload) > maxPayloadSize {
+			http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if secret != "" {
+			if err := VerifySignature(payload, r.Header.Get(SignatureHeader), secret); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+		}
+		event, err := Parse(payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := handle(r.Context(), event); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,95 @@
+package eventhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	payload := []byte(`{"source":"crud"}`)
+	signature := Sign(payload, "s3cr3t")
+	assert.True(t, strings.HasPrefix(signature, "sha1="))
+	assert.NoError(t, VerifySignature(payload, signature, "s3cr3t"))
+	assert.NoError(t, VerifySignature(payload, strings.TrimPrefix(signature, "sha1="), "s3cr3t"))
+	assert.EqualError(t, VerifySignature(payload, signature, "other"), "invalid signature")
+	assert.EqualError(t, VerifySignature(payload, "", "s3cr3t"), "missing signature")
+}
+
+func TestEvents(t *testing.T) {
+	event, err := Parse([]byte(`{"source":"crud","event":"consumers","operation":"update",
+		"schema":"consumers","entity":{"id":"c1","username":"bob"},"old_entity":{"id":"c1","username":"alice"}}`))
+	require.NoError(t, err)
+	crud, err := event.CRUD()
+	require.NoError(t, err)
+	assert.Equal(t, "update", crud.Operation)
+	assert.JSONEq(t, `{"id":"c1","username":"bob"}`, string(crud.Entity))
+	assert.JSONEq(t, `{"id":"c1","username":"alice"}`, string(crud.OldEntity))
+	_, err = event.BalancerHealth()
+	assert.EqualError(t, err, `event from source "crud" is not a balancer event`)
+
+	event, err = Parse([]byte(`{"source":"dao:crud","event":"delete","schema":"routes","entity":{"id":"r1"}}`))
+	require.NoError(t, err)
+	crud, err = event.CRUD()
+	require.NoError(t, err)
+	assert.Equal(t, "delete", crud.Operation)
+
+	event, err = Parse([]byte(`{"source":"balancer","event":"health","upstream_id":"u1",
+		"ip":"10.0.0.1","port":80,"hostname":"a","health":"unhealthy"}`))
+	require.NoError(t, err)
+	health, err := event.BalancerHealth()
+	require.NoError(t, err)
+	assert.Equal(t, BalancerHealthEvent{
+		UpstreamID: "u1", IP: "10.0.0.1", Port: 80, Hostname: "a", Health: "unhealthy",
+	}, *health)
+
+	event, err = Parse([]byte(`{"source":"rate-limiting-advanced","event":"rate-limit-exceeded",
+		"ip":"10.0.0.2","rate":11,"limit":10,"window":"1 minute"}`))
+	require.NoError(t, err)
+	exceeded, err := event.RateLimitExceeded()
+	require.NoError(t, err)
+	assert.Equal(t, 11, exceeded.Rate)
+
+	_, err = Parse([]byte(`{}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{`))
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	var received []*Event
+	handler := Handler("s3cr3t", func(_ context.Context, event *Event) error {
+		if event.Event == "fail" {
+			return fmt.Errorf("failed")
+		}
+		received = append(received, event)
+		return nil
+	})
+	send := func(payload, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+		if signature != "" {
+			req.Header.Set(SignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	payload := `{"source":"balancer","event":"health"}`
+	assert.Equal(t, http.StatusOK, send(payload, Sign([]byte(payload), "s3cr3t")))
+	assert.Equal(t, http.StatusUnauthorized, send(payload, ""))
+	assert.Equal(t, http.StatusUnauthorized, send(payload, Sign([]byte(payload), "other")))
+	assert.Equal(t, http.StatusBadRequest, send(`{}`, Sign([]byte(`{}`), "s3cr3t")))
+	failing := `{"source":"crud","event":"fail"}`
+	assert.Equal(t, http.StatusInternalServerError, send(failing, Sign([]byte(failing), "s3cr3t")))
+	large := `{"source":"crud","event":"create","data":"` + strings.Repeat("x", maxPayloadSize) + `"}`
+	assert.Equal(t, http.StatusRequestEntityTooLarge, send(large, Sign([]byte(large), "s3cr3t")))
+	require.Len(t, received, 1)
+	assert.Equal(t, SourceBalancer, received[0].Source)
+}
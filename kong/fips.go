@@ -0,0 +1,29 @@
+package kong
+
+// Edition is the edition of Kong Gateway.
+type Edition string
+
+const (
+	// EditionOSS is the open-source edition of Kong Gateway.
+	EditionOSS Edition = "oss"
+	// EditionEnterprise is Kong Gateway Enterprise.
+	EditionEnterprise Edition = "enterprise"
+)
+
+// Edition returns the edition of Kong, found from its version.
+func (i *Info) Edition() (Edition, error) {
+	version, err := NewVersion(i.Version)
+	if err != nil {
+		return "", err
+	}
+	if version.IsKongGatewayEnterprise() {
+		return EditionEnterprise, nil
+	}
+	return EditionOSS, nil
+}
+
+// IsFIPS returns true if Kong runs in FIPS mode (fips=on, Kong Gateway
+// Enterprise 3.0+).
+func (i *Info) IsFIPS() bool {
+	return i.Configuration != nil && i.Configuration.FIPS
+}
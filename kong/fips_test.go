@@ -0,0 +1,25 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoEditionAndFIPS(t *testing.T) {
+	info := &Info{Version: "3.4.1.0-enterprise-edition", Configuration: &RuntimeConfiguration{FIPS: true}}
+	edition, err := info.Edition()
+	require.NoError(t, err)
+	assert.Equal(t, EditionEnterprise, edition)
+	assert.True(t, info.IsFIPS())
+
+	info = &Info{Version: "3.4.1"}
+	edition, err = info.Edition()
+	require.NoError(t, err)
+	assert.Equal(t, EditionOSS, edition)
+	assert.False(t, info.IsFIPS())
+
+	_, err = (&Info{Version: "not a version"}).Edition()
+	assert.Error(t, err)
+}
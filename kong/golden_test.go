@@ -0,0 +1,151 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenEntities are the entities round-tripped against the responses of
+// Kong in testdata/golden/<version>/<entity>.json. Each entity needs a
+// fixture for at least one version; dropping a fixture in a version
+// directory is enough to test it.
+var goldenEntities = map[string]func() interface{}{
+	"services":        func() interface{} { return &Service{} },
+	"routes":          func() interface{} { return &Route{} },
+	"consumers":       func() interface{} { return &Consumer{} },
+	"plugins":         func() interface{} { return &Plugin{} },
+	"upstreams":       func() interface{} { return &Upstream{} },
+	"targets":         func() interface{} { return &Target{} },
+	"certificates":    func() interface{} { return &Certificate{} },
+	"ca_certificates": func() interface{} { return &CACertificate{} },
+	"snis":            func() interface{} { return &SNI{} },
+	"vaults":          func() interface{} { return &Vault{} },
+	"keys":            func() interface{} { return &Key{} },
+	"key_sets":        func() interface{} { return &KeySet{} },
+
+	"consumer_groups":    func() interface{} { return &ConsumerGroup{} },
+	"key_auths":          func() interface{} { return &KeyAuth{} },
+	"basic_auths":        func() interface{} { return &BasicAuth{} },
+	"jwts":               func() interface{} { return &JWTAuth{} },
+	"hmac_auths":         func() interface{} { return &HMACAuth{} },
+	"acls":               func() interface{} { return &ACLGroup{} },
+	"mtls_auths":         func() interface{} { return &MTLSAuth{} },
+	"oauth2_credentials": func() interface{} { return &Oauth2Credential{} },
+
+	"workspaces":                func() interface{} { return &Workspace{} },
+	"rbac_users":                func() interface{} { return &RBACUser{} },
+	"rbac_roles":                func() interface{} { return &RBACRole{} },
+	"rbac_endpoint_permissions": func() interface{} { return &RBACEndpointPermission{} },
+	"rbac_entity_permissions":   func() interface{} { return &RBACEntityPermission{} },
+	"licenses":                  func() interface{} { return &License{} },
+	"vitals_cluster":            func() interface{} { return &vitalsResponse[[]*float64]{} },
+	"vitals_status_codes":       func() interface{} { return &vitalsResponse[map[string]int]{} },
+}
+
+// goldenRequestForms turn the responses of Kong into the documents entities
+// encode to, for entities encoding to the form the Admin API expects in
+// requests rather than the one of its responses.
+var goldenRequestForms = map[string]func(map[string]interface{}){
+	"rbac_endpoint_permissions": rbacPermissionRequestForm,
+	"rbac_entity_permissions":   rbacPermissionRequestForm,
+}
+
+// rbacPermissionRequestForm joins the actions of RBAC permissions, which
+// are sent as a comma-separated list, and drops their role, which is
+// given by the path.
+func rbacPermissionRequestForm(doc map[string]interface{}) {
+	var actions []string
+	for _, action := range doc["actions"].([]interface{}) {
+		actions = append(actions, action.(string))
+	}
+	doc["actions"] = strings.Join(actions, ",")
+	delete(doc, "role")
+}
+
+// TestGoldenRoundTrip checks that entities decode the responses of Kong
+// without unknown fields, catching renamed or added fields, and encode
+// them back to the same document, minus the null fields, catching
+// retyped fields, byte-stably. Entities listed in goldenRequestForms are
+// checked against the request form of the document instead.
+func TestGoldenRoundTrip(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "golden", "*", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	covered := map[string]bool{}
+	for _, fixture := range fixtures {
+		fixture := fixture
+		version := filepath.Base(filepath.Dir(fixture))
+		entityType := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		covered[entityType] = true
+		t.Run(version+"/"+entityType, func(t *testing.T) {
+			newEntity, ok := goldenEntities[entityType]
+			require.True(t, ok, "no entity registered for %s", fixture)
+			b, err := os.ReadFile(fixture)
+			require.NoError(t, err)
+
+			entity := newEntity()
+			dec := json.NewDecoder(bytes.NewReader(b))
+			dec.DisallowUnknownFields()
+			require.NoError(t, dec.Decode(entity))
+			out, err := json.Marshal(entity)
+			require.NoError(t, err)
+
+			var response interface{}
+			require.NoError(t, json.Unmarshal(b, &response))
+			response = dropNulls(response)
+			requestForm, hasRequestForm := goldenRequestForms[entityType]
+			if hasRequestForm {
+				requestForm(response.(map[string]interface{}))
+			}
+			expected, err := json.Marshal(response)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(out))
+
+			// request forms can't be decoded again
+			again := newEntity()
+			if hasRequestForm {
+				require.NoError(t, json.Unmarshal(b, again))
+			} else {
+				require.NoError(t, json.Unmarshal(out, again))
+			}
+			stable, err := json.Marshal(again)
+			require.NoError(t, err)
+			assert.Equal(t, string(out), string(stable))
+		})
+	}
+	for entityType := range goldenEntities {
+		assert.True(t, covered[entityType], "no fixture for %s", entityType)
+	}
+}
+
+// dropNulls removes the null fields of the objects in v, as entities don't
+// encode unset fields, except in maps of free-form values, such as plugin
+// configurations and workspace metadata.
+func dropNulls(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			switch {
+			case value == nil:
+				delete(v, key)
+			case key == "config", key == "meta":
+				// free-form values keep their nulls
+			default:
+				v[key] = dropNulls(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = dropNulls(value)
+		}
+	}
+	return v
+}
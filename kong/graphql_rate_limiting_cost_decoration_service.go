@@ -2,8 +2,8 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
 )
 
 type AbstractGraphqlRateLimitingCostDecorationService interface {
@@ -19,6 +19,8 @@ type AbstractGraphqlRateLimitingCostDecorationService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*GraphqlRateLimitingCostDecoration, *ListOpt, error)
 	// Retrieves all decorations for the GraphQL rate-limiting plugin in Kong.
 	ListAll(ctx context.Context) ([]*GraphqlRateLimitingCostDecoration, error)
+	// Fetches the cost decoration of a GraphQL type or field from Kong.
+	GetByTypePath(ctx context.Context, typePath *string) (*GraphqlRateLimitingCostDecoration, error)
 }
 
 type GraphqlRateLimitingCostDecorationService service
@@ -133,7 +135,7 @@ func (s *GraphqlRateLimitingCostDecorationService) List(
 			return nil, nil, err
 		}
 		var deco GraphqlRateLimitingCostDecoration
-		err = json.Unmarshal(b, &deco)
+		err = s.client.decode(b, &deco)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -164,3 +166,27 @@ func (s *GraphqlRateLimitingCostDecorationService) ListAll(
 	}
 	return decos, nil
 }
+
+// GetByTypePath fetches the CostDecoration item of a GraphQL type or
+// field, e.g. "Vehicle.name", from Kong.
+// An APIError with a 404 status code is returned if there is none.
+func (s *GraphqlRateLimitingCostDecorationService) GetByTypePath(
+	ctx context.Context,
+	typePath *string,
+) (*GraphqlRateLimitingCostDecoration, error) {
+	if isEmptyString(typePath) {
+		return nil, fmt.Errorf("typePath cannot be nil for GetByTypePath operation")
+	}
+
+	decos, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, deco := range decos {
+		if deco.TypePath != nil && *deco.TypePath == *typePath {
+			return deco, nil
+		}
+	}
+	return nil, NewAPIError(http.StatusNotFound,
+		fmt.Sprintf("no cost decoration for type path %q", *typePath))
+}
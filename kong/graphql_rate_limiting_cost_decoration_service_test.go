@@ -1,9 +1,12 @@
 package kong
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +57,24 @@ func TestGraphqlRateLimitingCostDecorationService(t *testing.T) {
 		require.Nil(t, createdDeco)
 	})
 }
+
+func TestGraphqlRateLimitingCostDecorationGetByTypePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql-rate-limiting-advanced/costs", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"d1","type_path":"Vehicle"},
+			{"id":"d2","type_path":"Vehicle.name","add_constant":8}
+		]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	deco, err := client.GraphqlRateLimitingCostDecorations.GetByTypePath(defaultCtx, String("Vehicle.name"))
+	require.NoError(t, err)
+	assert.Equal(t, "d2", *deco.ID)
+	assert.Equal(t, float64(8), *deco.AddConstant)
+
+	_, err = client.GraphqlRateLimitingCostDecorations.GetByTypePath(defaultCtx, String("Vehicle.id"))
+	assert.True(t, IsNotFoundErr(err))
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -169,7 +168,7 @@ func (s *GroupService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var Group Group
-		err = json.Unmarshal(b, &Group)
+		err = s.client.decode(b, &Group)
 		if err != nil {
 			return nil, nil, err
 		}
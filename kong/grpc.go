@@ -0,0 +1,102 @@
+package kong
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewGRPCService returns a Service proxying gRPC traffic to host:port,
+// using the grpcs protocol if useTLS is set and grpc otherwise.
+// To verify the certificate presented by the upstream over grpcs, set
+// TLSVerify and CACertificates on the returned Service.
+func NewGRPCService(name, host string, port int, useTLS bool) *Service {
+	protocol := ServiceProtocolGRPC
+	if useTLS {
+		protocol = ServiceProtocolGRPCS
+	}
+	return &Service{
+		Name:     String(name),
+		Host:     String(host),
+		Port:     Int(port),
+		Protocol: String(string(protocol)),
+	}
+}
+
+// NewGRPCRoute returns a Route of service accepting both grpc and grpcs
+// requests. paths are usually gRPC service or method names,
+// e.g. "/helloworld.Greeter/" or "/helloworld.Greeter/SayHello".
+func NewGRPCRoute(name string, service *Service, paths ...string) *Route {
+	route := &Route{
+		Name:      String(name),
+		Protocols: RouteProtocols(RouteProtocolGRPC, RouteProtocolGRPCS),
+		Paths:     StringSlice(paths...),
+		// Kong rejects strip_path=true on gRPC routes and defaults it to true.
+		StripPath: Bool(false),
+	}
+	if service != nil {
+		route.Service = &Service{ID: service.ID}
+	}
+	return route
+}
+
+// ValidateGRPC checks s against the constraints Kong puts on gRPC
+// Services: the protocol must be grpc or grpcs, path is not supported and
+// TLS settings only apply to grpcs.
+func (s *Service) ValidateGRPC() error {
+	if s == nil {
+		return fmt.Errorf("service is nil")
+	}
+	protocol := ServiceProtocol(derefString(s.Protocol))
+	if protocol != ServiceProtocolGRPC && protocol != ServiceProtocolGRPCS {
+		return fmt.Errorf("invalid gRPC service protocol: %q", derefString(s.Protocol))
+	}
+	if s.Path != nil {
+		return fmt.Errorf("path cannot be set on a gRPC service")
+	}
+	if protocol == ServiceProtocolGRPC &&
+		(s.TLSVerify != nil || s.TLSVerifyDepth != nil || len(s.CACertificates) > 0 || s.ClientCertificate != nil) {
+		return fmt.Errorf("TLS settings require the grpcs protocol")
+	}
+	return nil
+}
+
+// ValidateGRPC checks r against the constraints Kong puts on gRPC Routes:
+// protocols must be grpc or grpcs, methods and strip_path=true are not
+// supported, paths must be absolute and at least one of hosts, headers,
+// paths or, for grpcs, snis must be set.
+func (r *Route) ValidateGRPC() error {
+	if r == nil {
+		return fmt.Errorf("route is nil")
+	}
+	if len(r.Protocols) == 0 {
+		return fmt.Errorf("protocols must be set on a gRPC route, it defaults to http and https")
+	}
+	grpcs := false
+	for _, p := range r.Protocols {
+		switch RouteProtocol(derefString(p)) {
+		case RouteProtocolGRPC:
+		case RouteProtocolGRPCS:
+			grpcs = true
+		default:
+			return fmt.Errorf("invalid gRPC route protocol: %q", derefString(p))
+		}
+	}
+	if len(r.Methods) > 0 {
+		return fmt.Errorf("methods cannot be set on a gRPC route")
+	}
+	if r.StripPath == nil || *r.StripPath {
+		return fmt.Errorf("strip_path must be set to false on a gRPC route")
+	}
+	for _, path := range r.Paths {
+		if !strings.HasPrefix(derefString(path), "/") {
+			return fmt.Errorf("invalid gRPC route path: %q must start with '/'", derefString(path))
+		}
+	}
+	if len(r.Hosts) == 0 && len(r.Headers) == 0 && len(r.Paths) == 0 && (!grpcs || len(r.SNIs) == 0) {
+		if grpcs {
+			return fmt.Errorf("one of hosts, headers, paths or snis must be set on a gRPC route")
+		}
+		return fmt.Errorf("one of hosts, headers or paths must be set on a gRPC route")
+	}
+	return nil
+}
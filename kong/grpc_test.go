@@ -0,0 +1,110 @@
+package kong
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGRPCServiceValidate(t *testing.T) {
+	assert.NoError(t, NewGRPCService("foo", "grpc.example.com", 50051, false).ValidateGRPC())
+
+	service := NewGRPCService("foo", "grpc.example.com", 50051, true)
+	service.TLSVerify = Bool(true)
+	assert.NoError(t, service.ValidateGRPC())
+
+	service = NewGRPCService("foo", "grpc.example.com", 50051, false)
+	service.TLSVerify = Bool(true)
+	assert.EqualError(t, service.ValidateGRPC(), "TLS settings require the grpcs protocol")
+
+	service = NewGRPCService("foo", "grpc.example.com", 50051, false)
+	service.Path = String("/foo")
+	assert.EqualError(t, service.ValidateGRPC(), "path cannot be set on a gRPC service")
+
+	assert.EqualError(t, (&Service{Protocol: String("http")}).ValidateGRPC(),
+		`invalid gRPC service protocol: "http"`)
+}
+
+func TestGRPCRouteValidate(t *testing.T) {
+	service := &Service{ID: String("s1")}
+	route := NewGRPCRoute("foo", service, "/helloworld.Greeter/")
+	assert.NoError(t, route.ValidateGRPC())
+	assert.Equal(t, "s1", *route.Service.ID)
+
+	for _, tt := range []struct {
+		name    string
+		modify  func(r *Route)
+		wantErr string
+	}{
+		{
+			name:    "default protocols",
+			modify:  func(r *Route) { r.Protocols = nil },
+			wantErr: "protocols must be set on a gRPC route",
+		},
+		{
+			name:    "mixed protocols",
+			modify:  func(r *Route) { r.Protocols = RouteProtocols(RouteProtocolGRPC, RouteProtocolHTTP) },
+			wantErr: `invalid gRPC route protocol: "http"`,
+		},
+		{
+			name:    "methods",
+			modify:  func(r *Route) { r.Methods = HTTPMethods(HTTPMethodPost) },
+			wantErr: "methods cannot be set on a gRPC route",
+		},
+		{
+			name:    "strip_path",
+			modify:  func(r *Route) { r.StripPath = nil },
+			wantErr: "strip_path must be set to false on a gRPC route",
+		},
+		{
+			name:    "relative path",
+			modify:  func(r *Route) { r.Paths = StringSlice("helloworld.Greeter") },
+			wantErr: `invalid gRPC route path: "helloworld.Greeter" must start with '/'`,
+		},
+		{
+			name:    "no matching criteria",
+			modify:  func(r *Route) { r.Paths = nil },
+			wantErr: "one of hosts, headers, paths or snis must be set on a gRPC route",
+		},
+		{
+			name: "snis only",
+			modify: func(r *Route) {
+				r.Paths = nil
+				r.SNIs = StringSlice("grpc.example.com")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewGRPCRoute("foo", service, "/helloworld.Greeter/")
+			tt.modify(r)
+			err := r.ValidateGRPC()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func ExampleNewGRPCService() {
+	service := NewGRPCService("greeter", "greeter.internal", 50051, true)
+	service.TLSVerify = Bool(true)
+	fmt.Println(*service.Protocol, service.ValidateGRPC())
+	// Output: grpcs <nil>
+}
+
+func ExampleNewGRPCRoute() {
+	service := NewGRPCService("greeter", "greeter.internal", 50051, false)
+	service.ID = String("9b1c6e2c-6a6b-4a42-9b55-8a1d4a7bd1a5")
+	route := NewGRPCRoute("say-hello", service, "/helloworld.Greeter/SayHello")
+	fmt.Println(route.ValidateGRPC())
+
+	// methods are matched on HTTP routes only
+	route.Methods = HTTPMethods(HTTPMethodPost)
+	fmt.Println(route.ValidateGRPC())
+	// Output:
+	// <nil>
+	// methods cannot be set on a gRPC route
+}
@@ -0,0 +1,267 @@
+package kong
+
+import "fmt"
+
+// HealthcheckType is the protocol used by active health checks, or the
+// kind of traffic observed by passive health checks.
+type HealthcheckType string
+
+const (
+	HealthcheckTypeHTTP  HealthcheckType = "http"
+	HealthcheckTypeHTTPS HealthcheckType = "https"
+	HealthcheckTypeTCP   HealthcheckType = "tcp"
+	HealthcheckTypeGRPC  HealthcheckType = "grpc"
+	HealthcheckTypeGRPCS HealthcheckType = "grpcs"
+)
+
+// IsValid reports whether t is a healthcheck type supported by Kong.
+func (t HealthcheckType) IsValid() bool {
+	switch t {
+	case HealthcheckTypeHTTP, HealthcheckTypeHTTPS, HealthcheckTypeTCP,
+		HealthcheckTypeGRPC, HealthcheckTypeGRPCS:
+		return true
+	}
+	return false
+}
+
+// Limits enforced by Kong on healthcheck configuration.
+const (
+	maxHealthcheckInterval  = 65535
+	maxHealthcheckCounter   = 255
+	maxHealthcheckThreshold = 100
+)
+
+// NewActiveHealthcheck returns an active health check probing targets
+// every interval seconds with checkType, on httpPath for HTTP-based
+// types. Unlike Kong's defaults, which leave probing disabled, targets
+// are marked healthy after 2 successful probes and unhealthy after 2
+// failures or 3 timeouts.
+func NewActiveHealthcheck(checkType HealthcheckType, httpPath string, interval int) *ActiveHealthcheck {
+	active := &ActiveHealthcheck{
+		Type:        String(string(checkType)),
+		Concurrency: Int(10),
+		Timeout:     Int(1),
+		Healthy: &Healthy{
+			Interval:  Int(interval),
+			Successes: Int(2),
+		},
+		Unhealthy: &Unhealthy{
+			Interval:    Int(interval),
+			TCPFailures: Int(2),
+			Timeouts:    Int(3),
+		},
+	}
+	if checkType != HealthcheckTypeTCP {
+		active.HTTPPath = String(httpPath)
+		active.Healthy.HTTPStatuses = []int{200, 302}
+		active.Unhealthy.HTTPFailures = Int(2)
+		active.Unhealthy.HTTPStatuses = []int{429, 404, 500, 501, 502, 503, 504, 505}
+	}
+	return active
+}
+
+// NewPassiveHealthcheck returns a passive health check observing
+// proxied traffic of checkType. Targets are marked unhealthy after 5 HTTP
+// failures, 2 TCP failures or 3 timeouts. Since passive checks never mark
+// targets healthy again by themselves, they should be paired with an
+// active health check.
+func NewPassiveHealthcheck(checkType HealthcheckType) *PassiveHealthcheck {
+	passive := &PassiveHealthcheck{
+		Type: String(string(checkType)),
+		Healthy: &Healthy{
+			Successes: Int(5),
+		},
+		Unhealthy: &Unhealthy{
+			TCPFailures: Int(2),
+			Timeouts:    Int(3),
+		},
+	}
+	if checkType != HealthcheckTypeTCP {
+		passive.Healthy.HTTPStatuses = []int{
+			200, 201, 202, 203, 204, 205, 206, 207, 208, 226,
+			300, 301, 302, 303, 304, 305, 306, 307, 308,
+		}
+		passive.Unhealthy.HTTPFailures = Int(5)
+		passive.Unhealthy.HTTPStatuses = []int{429, 500, 503}
+	}
+	return passive
+}
+
+// Validate checks h for values Kong rejects and for combinations Kong
+// accepts but which silently leave health checks ineffective, such as
+// thresholds set without a probing interval or passive checks which can
+// mark targets unhealthy without active checks to bring them back.
+func (h *Healthcheck) Validate() error {
+	if h == nil {
+		return nil
+	}
+	if h.Threshold != nil && (*h.Threshold < 0 || *h.Threshold > maxHealthcheckThreshold) {
+		return fmt.Errorf("healthchecks.threshold must be between 0 and %d, got %v",
+			maxHealthcheckThreshold, *h.Threshold)
+	}
+	if err := h.Active.validate(); err != nil {
+		return fmt.Errorf("healthchecks.active: %w", err)
+	}
+	if err := h.Passive.validate(); err != nil {
+		return fmt.Errorf("healthchecks.passive: %w", err)
+	}
+	if h.Passive.marksUnhealthy() && !h.Active.marksHealthy() {
+		return fmt.Errorf("healthchecks.passive can mark targets unhealthy but " +
+			"healthchecks.active.healthy.interval is not set: unhealthy targets will never recover")
+	}
+	return nil
+}
+
+func (a *ActiveHealthcheck) validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := validateHealthcheckType(a.Type); err != nil {
+		return err
+	}
+	if a.Concurrency != nil && *a.Concurrency < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", *a.Concurrency)
+	}
+	if a.Timeout != nil && *a.Timeout < 0 {
+		return fmt.Errorf("timeout must be positive, got %d", *a.Timeout)
+	}
+	if a.HTTPPath != nil && (*a.HTTPPath == "" || (*a.HTTPPath)[0] != '/') {
+		return fmt.Errorf("http_path must start with '/', got %q", *a.HTTPPath)
+	}
+	if err := a.Healthy.validate(true); err != nil {
+		return fmt.Errorf("healthy: %w", err)
+	}
+	if err := a.Unhealthy.validate(true); err != nil {
+		return fmt.Errorf("unhealthy: %w", err)
+	}
+	if err := validateDisjointStatuses(a.Healthy, a.Unhealthy); err != nil {
+		return err
+	}
+	if a.Healthy != nil && intValue(a.Healthy.Successes) > 0 && intValue(a.Healthy.Interval) == 0 {
+		return fmt.Errorf("healthy.successes is set but healthy.interval is 0: " +
+			"targets will never be marked healthy")
+	}
+	if u := a.Unhealthy; u != nil && intValue(u.Interval) == 0 &&
+		intValue(u.HTTPFailures)+intValue(u.TCPFailures)+intValue(u.Timeouts) > 0 {
+		return fmt.Errorf("unhealthy thresholds are set but unhealthy.interval is 0: " +
+			"targets will never be marked unhealthy")
+	}
+	return nil
+}
+
+func (a *ActiveHealthcheck) marksHealthy() bool {
+	return a != nil && a.Healthy != nil &&
+		intValue(a.Healthy.Interval) > 0 && intValue(a.Healthy.Successes) > 0
+}
+
+func (p *PassiveHealthcheck) validate() error {
+	if p == nil {
+		return nil
+	}
+	if err := validateHealthcheckType(p.Type); err != nil {
+		return err
+	}
+	if p.Healthy != nil && p.Healthy.Interval != nil {
+		return fmt.Errorf("healthy.interval is not supported by passive health checks")
+	}
+	if p.Unhealthy != nil && p.Unhealthy.Interval != nil {
+		return fmt.Errorf("unhealthy.interval is not supported by passive health checks")
+	}
+	if err := p.Healthy.validate(false); err != nil {
+		return fmt.Errorf("healthy: %w", err)
+	}
+	if err := p.Unhealthy.validate(false); err != nil {
+		return fmt.Errorf("unhealthy: %w", err)
+	}
+	return validateDisjointStatuses(p.Healthy, p.Unhealthy)
+}
+
+func (p *PassiveHealthcheck) marksUnhealthy() bool {
+	return p != nil && p.Unhealthy != nil &&
+		intValue(p.Unhealthy.HTTPFailures)+intValue(p.Unhealthy.TCPFailures)+intValue(p.Unhealthy.Timeouts) > 0
+}
+
+func (h *Healthy) validate(active bool) error {
+	if h == nil {
+		return nil
+	}
+	if active {
+		if err := validateHealthcheckRange("interval", h.Interval, maxHealthcheckInterval); err != nil {
+			return err
+		}
+	}
+	if err := validateHealthcheckRange("successes", h.Successes, maxHealthcheckCounter); err != nil {
+		return err
+	}
+	return validateHTTPStatuses(h.HTTPStatuses)
+}
+
+func (u *Unhealthy) validate(active bool) error {
+	if u == nil {
+		return nil
+	}
+	if active {
+		if err := validateHealthcheckRange("interval", u.Interval, maxHealthcheckInterval); err != nil {
+			return err
+		}
+	}
+	if err := validateHealthcheckRange("http_failures", u.HTTPFailures, maxHealthcheckCounter); err != nil {
+		return err
+	}
+	if err := validateHealthcheckRange("tcp_failures", u.TCPFailures, maxHealthcheckCounter); err != nil {
+		return err
+	}
+	if err := validateHealthcheckRange("timeouts", u.Timeouts, maxHealthcheckCounter); err != nil {
+		return err
+	}
+	return validateHTTPStatuses(u.HTTPStatuses)
+}
+
+func validateHealthcheckType(t *string) error {
+	if t != nil && !HealthcheckType(*t).IsValid() {
+		return fmt.Errorf("invalid type: %q", *t)
+	}
+	return nil
+}
+
+func validateHealthcheckRange(name string, value *int, max int) error {
+	if value != nil && (*value < 0 || *value > max) {
+		return fmt.Errorf("%s must be between 0 and %d, got %d", name, max, *value)
+	}
+	return nil
+}
+
+func validateHTTPStatuses(statuses []int) error {
+	seen := map[int]bool{}
+	for _, status := range statuses {
+		if status < 100 || status > 999 {
+			return fmt.Errorf("invalid HTTP status: %d", status)
+		}
+		if seen[status] {
+			return fmt.Errorf("duplicate HTTP status: %d", status)
+		}
+		seen[status] = true
+	}
+	return nil
+}
+
+func validateDisjointStatuses(healthy *Healthy, unhealthy *Unhealthy) error {
+	if healthy == nil || unhealthy == nil {
+		return nil
+	}
+	for _, h := range healthy.HTTPStatuses {
+		for _, u := range unhealthy.HTTPStatuses {
+			if h == u {
+				return fmt.Errorf("HTTP status %d is listed as both healthy and unhealthy", h)
+			}
+		}
+	}
+	return nil
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,97 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthcheckConstructors(t *testing.T) {
+	for _, checkType := range []HealthcheckType{HealthcheckTypeHTTP, HealthcheckTypeTCP, HealthcheckTypeGRPCS} {
+		h := &Healthcheck{
+			Active:  NewActiveHealthcheck(checkType, "/status", 5),
+			Passive: NewPassiveHealthcheck(checkType),
+		}
+		assert.NoError(t, h.Validate(), checkType)
+	}
+	assert.Nil(t, NewActiveHealthcheck(HealthcheckTypeTCP, "/status", 5).HTTPPath)
+}
+
+func TestHealthcheckValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		modify  func(h *Healthcheck)
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			modify: func(h *Healthcheck) {},
+		},
+		{
+			name:    "threshold out of range",
+			modify:  func(h *Healthcheck) { h.Threshold = Float64(101) },
+			wantErr: "healthchecks.threshold must be between 0 and 100, got 101",
+		},
+		{
+			name:    "invalid type",
+			modify:  func(h *Healthcheck) { h.Active.Type = String("udp") },
+			wantErr: `healthchecks.active: invalid type: "udp"`,
+		},
+		{
+			name:    "relative http_path",
+			modify:  func(h *Healthcheck) { h.Active.HTTPPath = String("status") },
+			wantErr: `healthchecks.active: http_path must start with '/', got "status"`,
+		},
+		{
+			name:    "counter out of range",
+			modify:  func(h *Healthcheck) { h.Active.Unhealthy.Timeouts = Int(256) },
+			wantErr: "healthchecks.active: unhealthy: timeouts must be between 0 and 255, got 256",
+		},
+		{
+			name:    "invalid status",
+			modify:  func(h *Healthcheck) { h.Passive.Unhealthy.HTTPStatuses = []int{42} },
+			wantErr: "healthchecks.passive: unhealthy: invalid HTTP status: 42",
+		},
+		{
+			name:    "duplicate status",
+			modify:  func(h *Healthcheck) { h.Active.Healthy.HTTPStatuses = []int{200, 200} },
+			wantErr: "healthchecks.active: healthy: duplicate HTTP status: 200",
+		},
+		{
+			name:    "status both healthy and unhealthy",
+			modify:  func(h *Healthcheck) { h.Active.Unhealthy.HTTPStatuses = []int{302} },
+			wantErr: "healthchecks.active: HTTP status 302 is listed as both healthy and unhealthy",
+		},
+		{
+			name:    "thresholds without interval",
+			modify:  func(h *Healthcheck) { h.Active.Unhealthy.Interval = Int(0) },
+			wantErr: "unhealthy thresholds are set but unhealthy.interval is 0",
+		},
+		{
+			name:    "interval in passive check",
+			modify:  func(h *Healthcheck) { h.Passive.Healthy.Interval = Int(5) },
+			wantErr: "healthchecks.passive: healthy.interval is not supported by passive health checks",
+		},
+		{
+			name:    "passive without active recovery",
+			modify:  func(h *Healthcheck) { h.Active = nil },
+			wantErr: "unhealthy targets will never recover",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Healthcheck{
+				Active:  NewActiveHealthcheck(HealthcheckTypeHTTP, "/status", 5),
+				Passive: NewPassiveHealthcheck(HealthcheckTypeHTTP),
+			}
+			tt.modify(h)
+			err := h.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractHMACAuthService handles hmac-auth credentials in Kong.
@@ -41,7 +40,7 @@ func (s *HMACAuthService) Create(ctx context.Context,
 	}
 
 	var createdHMACAuth HMACAuth
-	err = json.Unmarshal(cred, &createdHMACAuth)
+	err = s.client.decode(cred, &createdHMACAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +59,7 @@ func (s *HMACAuthService) Get(ctx context.Context,
 	}
 
 	var hmacAuth HMACAuth
-	err = json.Unmarshal(cred, &hmacAuth)
+	err = s.client.decode(cred, &hmacAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +78,7 @@ func (s *HMACAuthService) Update(ctx context.Context,
 	}
 
 	var updatedHMACAuth HMACAuth
-	err = json.Unmarshal(cred, &updatedHMACAuth)
+	err = s.client.decode(cred, &updatedHMACAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +110,7 @@ func (s *HMACAuthService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var hmacAuth HMACAuth
-		err = json.Unmarshal(b, &hmacAuth)
+		err = s.client.decode(b, &hmacAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -157,7 +156,7 @@ func (s *HMACAuthService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var hmacAuth HMACAuth
-		err = json.Unmarshal(b, &hmacAuth)
+		err = s.client.decode(b, &hmacAuth)
 		if err != nil {
 			return nil, nil, err
 		}
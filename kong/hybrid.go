@@ -0,0 +1,102 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// NodeRole is the role of a Kong node, as set by its role configuration.
+type NodeRole string
+
+const (
+	// NodeRoleTraditional is a node which both serves the Admin API and
+	// proxies traffic, with or without a database.
+	NodeRoleTraditional NodeRole = "traditional"
+	// NodeRoleControlPlane is a hybrid mode node which serves the Admin
+	// API and pushes configuration to data planes but doesn't proxy
+	// traffic.
+	NodeRoleControlPlane NodeRole = "control_plane"
+	// NodeRoleDataPlane is a hybrid mode node which proxies traffic
+	// using the configuration received from a control plane. Its Admin
+	// API is read-only.
+	NodeRoleDataPlane NodeRole = "data_plane"
+)
+
+// ErrUnsupportedOnRole is returned by operations which are not supported
+// by the role of the targeted Kong node, e.g. writing entities on a data
+// plane or fetching upstream health from a control plane.
+type ErrUnsupportedOnRole struct {
+	Role   NodeRole
+	Method string
+	Path   string
+}
+
+func (e *ErrUnsupportedOnRole) Error() string {
+	return fmt.Sprintf("%s %s: not supported by a Kong node with role %s", e.Method, e.Path, e.Role)
+}
+
+// IsUnsupportedOnRoleErr returns true if the error or its cause is
+// an ErrUnsupportedOnRole.
+func IsUnsupportedOnRoleErr(e error) bool {
+	var roleErr *ErrUnsupportedOnRole
+	return errors.As(e, &roleErr)
+}
+
+// DetectRole fetches the configuration of Kong from the root endpoint and
+// records the role of the node. Once a role is recorded, operations the
+// role doesn't support fail with an ErrUnsupportedOnRole without being
+// sent to Kong.
+func (c *Client) DetectRole(ctx context.Context) (NodeRole, error) {
+	info, err := c.Info.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+	role := NodeRoleTraditional
+	if info.Configuration != nil && info.Configuration.Role != "" {
+		role = NodeRole(info.Configuration.Role)
+	}
+	c.SetRole(role)
+	return role, nil
+}
+
+// SetRole sets the role of the Kong node targeted by the client, without
+// querying Kong. See DetectRole.
+func (c *Client) SetRole(role NodeRole) {
+	c.role.Store(role)
+}
+
+// Role returns the role of the Kong node targeted by the client as set by
+// DetectRole or SetRole, or an empty string if it's unknown.
+func (c *Client) Role() NodeRole {
+	role, _ := c.role.Load().(NodeRole)
+	return role
+}
+
+// checkRole returns an ErrUnsupportedOnRole for requests the role of the
+// targeted node doesn't support.
+func (c *Client) checkRole(req *http.Request) error {
+	role := c.Role()
+	segments := pathSegments(c.relativePath(req))
+	unsupported := false
+	switch role {
+	case NodeRoleControlPlane:
+		// control planes have no load balancer
+		unsupported = isHealthPath(segments)
+	case NodeRoleDataPlane:
+		// configuration is pushed by the control plane, which is the
+		// only one to know about the cluster, but the health of targets
+		// is local to each node
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			unsupported = segments[0] == "clustering"
+		default:
+			unsupported = segments[0] != "schemas" && !isHealthPath(segments)
+		}
+	}
+	if unsupported {
+		return &ErrUnsupportedOnRole{Role: role, Method: req.Method, Path: req.URL.Path}
+	}
+	return nil
+}
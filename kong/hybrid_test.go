@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeRole(t *testing.T) {
+	role := "control_plane"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /":
+			_, _ = w.Write([]byte(`{"version":"3.4.0","configuration":{"role":"` + role + `"}}`))
+		case "GET /upstreams/up/targets":
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		case "GET /services/health":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"health"}`))
+		case "POST /upstreams/up/targets/t1/healthy":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /schemas/plugins/validate":
+			_, _ = w.Write([]byte(`{"message":"schema validation successful"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	assert.Equal(t, NodeRole(""), client.Role())
+
+	got, err := client.DetectRole(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, NodeRoleControlPlane, got)
+	_, err = client.Targets.ListAll(defaultCtx, String("up"))
+	assert.NoError(t, err)
+	_, err = client.UpstreamNodeHealth.ListAll(defaultCtx, String("up"))
+	assert.True(t, IsUnsupportedOnRoleErr(err))
+	assert.EqualError(t, err, "GET /upstreams/up/health: not supported by a Kong node with role control_plane")
+	err = client.Targets.MarkHealthy(defaultCtx, String("up"), &Target{ID: String("t1")})
+	assert.True(t, IsUnsupportedOnRoleErr(err))
+	// entities may be named like health endpoints
+	_, err = client.Services.Get(defaultCtx, String("health"))
+	assert.NoError(t, err)
+
+	role = "data_plane"
+	got, err = client.DetectRole(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, NodeRoleDataPlane, got)
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("foo")})
+	assert.True(t, IsUnsupportedOnRoleErr(err))
+	valid, _, err := client.Plugins.Validate(defaultCtx, &Plugin{Name: String("cors")})
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	// health of targets is local to each data plane
+	err = client.Targets.MarkHealthy(defaultCtx, String("up"), &Target{ID: String("t1")})
+	assert.NoError(t, err)
+
+	role = ""
+	got, err = client.DetectRole(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, NodeRoleTraditional, got)
+}
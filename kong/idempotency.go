@@ -0,0 +1,69 @@
+package kong
+
+import "context"
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey returns a context making Create methods of services,
+// routes, consumers and consumer groups derive the ID of the entity they
+// create from key and its name, when it has no ID, so that retrying a
+// creation after e.g. a timeout doesn't create a duplicate entity. The ID
+// is generated with UUIDv5, in a namespace specific to the entity type,
+// and the entity is created with PUT, which replaces it if it was already
+// created. Unnamed entities created with the same key get the same ID.
+//
+// Bulk operations such as Restore and ImportConsumers ignore the key.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// withoutIdempotencyKey returns ctx without the idempotency key set with
+// WithIdempotencyKey, for operations creating many entities.
+func withoutIdempotencyKey(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = defaultCtx
+	}
+	if _, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, "")
+}
+
+// SetIdempotentCreates makes Create methods of services, routes, consumers
+// and consumer groups idempotent, or not anymore. Entities created
+// without an ID get an ID derived from the idempotency key of the
+// context, see WithIdempotencyKey, or else from their name (username for
+// consumers) as FillID does. Entities without ID, key nor name are
+// created with POST as usual.
+func (c *Client) SetIdempotentCreates(idempotent bool) {
+	c.idempotentCreates.Store(idempotent)
+}
+
+// IsIdempotentCreates returns whether Create methods are idempotent, see
+// SetIdempotentCreates.
+func (c *Client) IsIdempotentCreates() bool {
+	return c.idempotentCreates.Load()
+}
+
+// idempotentID returns the ID to create entity with, named name, or nil
+// if it has no idempotency key and creates aren't idempotent.
+func (c *Client) idempotentID(ctx context.Context, entity IDFillable, name *string) (*string, error) {
+	if ctx == nil {
+		ctx = defaultCtx
+	}
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	if !ok || key == "" {
+		if !c.IsIdempotentCreates() || isEmptyString(name) {
+			return nil, nil
+		}
+		key = *name
+	} else if !isEmptyString(name) {
+		// distinct entities created under the same key get distinct IDs
+		key += "\x00" + *name
+	}
+	gen, err := idGeneratorFor(entity)
+	if err != nil {
+		return nil, err
+	}
+	return gen.buildIDFor(key), nil
+}
@@ -0,0 +1,116 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotentCreates(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	service := &Service{Name: String("billing"), Host: String("billing.internal")}
+	_, err = client.Services.Create(defaultCtx, service)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /services"}, requests)
+	// a nil context has no idempotency key
+	requests = nil
+	_, err = client.Services.Create(nil, service) //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /services"}, requests)
+
+	requests = nil
+	ctx := WithIdempotencyKey(defaultCtx, "request-1")
+	for i := 0; i < 2; i++ {
+		_, err = client.Services.Create(ctx, service)
+		require.NoError(t, err)
+	}
+	require.Len(t, requests, 2)
+	assert.Equal(t, requests[0], requests[1])
+	assert.Regexp(t, `^PUT /services/[0-9a-f-]{36}$`, requests[0])
+	assert.Nil(t, service.ID, "the entity to create must not be changed")
+
+	// keys are scoped to entity types
+	serviceID := strings.TrimPrefix(requests[0], "PUT /services/")
+	requests = nil
+	_, err = client.Routes.Create(ctx, &Route{})
+	require.NoError(t, err)
+	assert.Regexp(t, `^PUT /routes/`, requests[0])
+	assert.NotEqual(t, "PUT /routes/"+serviceID, requests[0])
+
+	// distinct entities created under the same key get distinct IDs
+	requests = nil
+	_, err = client.Services.Create(ctx, &Service{Name: String("orders"), Host: String("orders.internal")})
+	require.NoError(t, err)
+	_, err = client.Services.Create(ctx, &Service{Name: String("payments"), Host: String("payments.internal")})
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.NotEqual(t, requests[0], requests[1])
+
+	client.SetIdempotentCreates(true)
+	assert.True(t, client.IsIdempotentCreates())
+	expected := service.DeepCopy()
+	require.NoError(t, expected.FillID())
+	consumer := &Consumer{Username: String("alice")}
+	expectedConsumer := consumer.DeepCopy()
+	require.NoError(t, expectedConsumer.FillID())
+	group := &ConsumerGroup{Name: String("gold")}
+	expectedGroup := group.DeepCopy()
+	require.NoError(t, expectedGroup.FillID())
+
+	requests = nil
+	_, err = client.Services.Create(defaultCtx, service)
+	require.NoError(t, err)
+	_, err = client.Consumers.Create(defaultCtx, consumer)
+	require.NoError(t, err)
+	_, err = client.ConsumerGroups.Create(defaultCtx, group)
+	require.NoError(t, err)
+	_, err = client.Routes.Create(defaultCtx, &Route{Paths: StringSlice("/")})
+	require.NoError(t, err)
+	_, err = client.Services.Create(defaultCtx, &Service{ID: String("explicit"), Name: String("billing")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"PUT /services/" + *expected.ID,
+		"PUT /consumers/" + *expectedConsumer.ID,
+		"PUT /consumer_groups/" + *expectedGroup.ID,
+		"POST /routes",
+		"PUT /services/explicit",
+	}, requests)
+}
+
+func TestIdempotencyKeyBulkOperations(t *testing.T) {
+	var creates []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		creates = append(creates, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	ctx := WithIdempotencyKey(defaultCtx, "import-1")
+	_, err = client.ImportConsumers(ctx, &ConsumerDocument{Consumers: []*ExportedConsumer{
+		{Consumer: Consumer{Username: String("alice")}},
+		{Consumer: Consumer{Username: String("bob")}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /consumers", "POST /consumers"}, creates)
+}
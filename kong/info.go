@@ -4,6 +4,7 @@ package kong
 type Info struct {
 	Version       string                `json:"version,omitempty" yaml:"version,omitempty"`
 	Configuration *RuntimeConfiguration `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	Plugins       *PluginsInfo          `json:"plugins,omitempty" yaml:"plugins,omitempty"`
 }
 
 // RuntimeConfiguration represents the runtime configuration of Kong.
@@ -11,4 +12,39 @@ type RuntimeConfiguration struct {
 	Database string `json:"database,omitempty" yaml:"database,omitempty"`
 	Portal   bool   `json:"portal,omitempty" yaml:"portal,omitempty"`
 	RBAC     string `json:"rbac,omitempty" yaml:"rbac,omitempty"`
+	Role     string `json:"role,omitempty" yaml:"role,omitempty"`
+	FIPS     bool   `json:"fips,omitempty" yaml:"fips,omitempty"`
+
+	RouterFlavor RouterFlavor `json:"router_flavor,omitempty" yaml:"router_flavor,omitempty"`
+	// Plugins and Vaults are the plugins and vaults set in the
+	// configuration of Kong, e.g. "bundled".
+	Plugins []string `json:"plugins,omitempty" yaml:"plugins,omitempty"`
+	Vaults  []string `json:"vaults,omitempty" yaml:"vaults,omitempty"`
+	// LoadedPlugins and LoadedVaults are the plugins and vaults loaded by
+	// Kong, by name.
+	LoadedPlugins map[string]bool `json:"loaded_plugins,omitempty" yaml:"loaded_plugins,omitempty"`
+	LoadedVaults  map[string]bool `json:"loaded_vaults,omitempty" yaml:"loaded_vaults,omitempty"`
+}
+
+// RouterFlavor is the flavor of the router of Kong.
+type RouterFlavor string
+
+const (
+	// RouterFlavorTraditional is the router of Kong before 3.0.
+	RouterFlavorTraditional RouterFlavor = "traditional"
+	// RouterFlavorTraditionalCompatible is the default router of Kong 3.x,
+	// matching traditional routes with the expressions engine.
+	RouterFlavorTraditionalCompatible RouterFlavor = "traditional_compatible"
+	// RouterFlavorExpressions is the router matching expression routes.
+	RouterFlavorExpressions RouterFlavor = "expressions"
+)
+
+// PluginsInfo represents the plugins of Kong.
+type PluginsInfo struct {
+	// AvailableOnServer holds the plugins available on the node, by name,
+	// along with their version and priority on Kong 3.x.
+	AvailableOnServer map[string]interface{} `json:"available_on_server,omitempty" yaml:"available_on_server,omitempty"`
+	// EnabledInCluster holds the names of the plugins configured in the
+	// cluster.
+	EnabledInCluster []string `json:"enabled_in_cluster,omitempty" yaml:"enabled_in_cluster,omitempty"`
 }
@@ -23,6 +23,39 @@ func (r *RuntimeConfiguration) IsRBACEnabled() bool {
 	return r.RBAC == "on"
 }
 
+// IsVaultEnabled returns true if the vault with name is loaded by Kong.
+func (r *RuntimeConfiguration) IsVaultEnabled(name string) bool {
+	return r.LoadedVaults[name]
+}
+
+// IsPluginLoaded returns true if the plugin with name is loaded by Kong.
+func (r *RuntimeConfiguration) IsPluginLoaded(name string) bool {
+	return r.LoadedPlugins[name]
+}
+
+// IsExpressionsRouter returns true if Kong matches expression routes.
+func (r *RuntimeConfiguration) IsExpressionsRouter() bool {
+	return r.RouterFlavor == RouterFlavorExpressions
+}
+
+// IsAvailable returns true if the plugin with name is available on the
+// node.
+func (p *PluginsInfo) IsAvailable(name string) bool {
+	_, ok := p.AvailableOnServer[name]
+	return ok
+}
+
+// IsEnabled returns true if the plugin with name is configured in the
+// cluster.
+func (p *PluginsInfo) IsEnabled(name string) bool {
+	for _, enabled := range p.EnabledInCluster {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
+}
+
 // convert convert an object to another through json marshalling
 // unmarshalling
 func convert(from, to interface{}) error {
@@ -1,6 +1,7 @@
 package kong
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -49,3 +50,42 @@ func TestConvert(T *testing.T) {
 	assert.False(actual.Configuration.IsInMemory())
 	assert.True(actual.Configuration.IsRBACEnabled())
 }
+
+func TestInfoCapabilities(t *testing.T) {
+	var info Info
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"version": "3.4.1",
+		"configuration": {
+			"router_flavor": "expressions",
+			"portal": false,
+			"plugins": ["bundled", "custom"],
+			"loaded_plugins": {"key-auth": true, "custom": true},
+			"vaults": ["bundled"],
+			"loaded_vaults": {"env": true}
+		},
+		"plugins": {
+			"available_on_server": {
+				"key-auth": {"version": "3.4.1", "priority": 1250},
+				"custom": true
+			},
+			"enabled_in_cluster": ["key-auth"]
+		}
+	}`), &info))
+
+	require.NotNil(t, info.Configuration)
+	assert.Equal(t, RouterFlavorExpressions, info.Configuration.RouterFlavor)
+	assert.True(t, info.Configuration.IsExpressionsRouter())
+	assert.False(t, info.Configuration.Portal)
+	assert.Equal(t, []string{"bundled", "custom"}, info.Configuration.Plugins)
+	assert.True(t, info.Configuration.IsPluginLoaded("custom"))
+	assert.False(t, info.Configuration.IsPluginLoaded("acl"))
+	assert.True(t, info.Configuration.IsVaultEnabled("env"))
+	assert.False(t, info.Configuration.IsVaultEnabled("aws"))
+
+	require.NotNil(t, info.Plugins)
+	assert.True(t, info.Plugins.IsAvailable("key-auth"))
+	assert.True(t, info.Plugins.IsAvailable("custom"))
+	assert.False(t, info.Plugins.IsAvailable("acl"))
+	assert.True(t, info.Plugins.IsEnabled("key-auth"))
+	assert.False(t, info.Plugins.IsEnabled("custom"))
+}
@@ -0,0 +1,44 @@
+package kong
+
+import "encoding/json"
+
+// JSONCodec encodes and decodes JSON. Implementations must behave like
+// encoding/json, honoring its struct tags and the json.Marshaler and
+// json.Unmarshaler interfaces, e.g. jsoniter configured compatible with
+// the standard library or sonic.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodecHolder struct {
+	codec JSONCodec
+}
+
+// SetJSONCodec sets the codec encoding the bodies of requests and
+// decoding the responses of Kong, e.g. for a faster implementation when
+// syncing many entities. A nil codec restores encoding/json, the
+// default.
+func (c *Client) SetJSONCodec(codec JSONCodec) {
+	c.jsonCodec.Store(jsonCodecHolder{codec: codec})
+}
+
+// customJSONCodec returns the codec set with SetJSONCodec, or nil.
+func (c *Client) customJSONCodec() JSONCodec {
+	holder, _ := c.jsonCodec.Load().(jsonCodecHolder)
+	return holder.codec
+}
+
+func (c *Client) marshalJSON(v interface{}) ([]byte, error) {
+	if codec := c.customJSONCodec(); codec != nil {
+		return codec.Marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+func (c *Client) unmarshalJSON(data []byte, v interface{}) error {
+	if codec := c.customJSONCodec(); codec != nil {
+		return codec.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
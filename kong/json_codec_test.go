@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingCodec struct {
+	marshaled, unmarshaled int
+}
+
+func (c *countingCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshaled++
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshaled++
+	return json.Unmarshal(data, v)
+}
+
+func TestSetJSONCodec(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"foo","host":"foo.internal"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"s1"},{"id":"s2"}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	codec := &countingCodec{}
+	client.SetJSONCodec(codec)
+
+	service, err := client.Services.Create(defaultCtx, &Service{Name: String("foo"), Host: String("foo.internal")})
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *service.ID)
+	assert.Equal(t, 1, codec.marshaled)
+	assert.Equal(t, 1, codec.unmarshaled)
+
+	services, _, err := client.Services.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.Len(t, services, 2)
+	// the page, then each service
+	assert.Equal(t, 4, codec.unmarshaled)
+
+	client.SetJSONCodec(nil)
+	_, _, err = client.Services.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 4, codec.unmarshaled)
+}
@@ -0,0 +1,177 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect of the documents returned by
+// ToJSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ToJSONSchema converts an entity or plugin schema, as returned by
+// SchemaService.Get or PluginService.GetFullSchema, into a standard JSON
+// Schema document titled title. This lets tools which don't know about
+// Kong, such as linters of declarative configuration, validate entities.
+//
+// Types, required fields, defaults, enumerations (one_of), numeric ranges
+// (between, gt) and length constraints are translated. Lua patterns (match,
+// not_match) and entity checks involving several fields have no JSON
+// Schema counterpart and are left out, so a document passing the
+// resulting JSON Schema can still be rejected by Kong.
+//
+// Fields which aren't required are nullable, as Kong returns null for
+// unset fields. At the top level, properties the schema doesn't define are
+// allowed if they are arrays of objects, which are the entities nested in
+// declarative configuration, such as the routes of a service.
+//
+// Schemas which are already JSON Schemas, as used by some Kong
+// Enterprise entities, are returned as is.
+func ToJSONSchema(title string, schema Schema) (map[string]interface{}, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	root := gjson.ParseBytes(b)
+
+	var res map[string]interface{}
+	if root.Get("properties").Exists() {
+		if err := json.Unmarshal(b, &res); err != nil {
+			return nil, err
+		}
+	} else {
+		res, err = luaRecordToJSONSchema(root)
+		if err != nil {
+			return nil, err
+		}
+		res["additionalProperties"] = map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "object"},
+		}
+	}
+	res["$schema"] = JSONSchemaDraft
+	res["title"] = title
+	return res, nil
+}
+
+func luaRecordToJSONSchema(record gjson.Result) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+	for _, field := range record.Get("fields").Array() {
+		for name, value := range field.Map() {
+			property, err := luaFieldToJSONSchema(value)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			properties[name] = property
+			if !value.Get("required").Bool() {
+				setNullable(property)
+				continue
+			}
+			// Kong fills fields with defaults, they can be omitted
+			if !value.Get("default").Exists() {
+				required = append(required, name)
+			}
+		}
+	}
+	res := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		res["required"] = required
+	}
+	return res, nil
+}
+
+func luaFieldToJSONSchema(field gjson.Result) (map[string]interface{}, error) {
+	var res map[string]interface{}
+	switch t := field.Get("type").String(); t {
+	case "string":
+		res = map[string]interface{}{"type": "string"}
+		if field.Get("uuid").Bool() {
+			res["format"] = "uuid"
+		}
+		setIfExists(res, "minLength", field.Get("len_min"))
+		setIfExists(res, "maxLength", field.Get("len_max"))
+	case "integer", "number":
+		res = map[string]interface{}{"type": t}
+		if between := field.Get("between").Array(); len(between) == 2 {
+			res["minimum"] = between[0].Value()
+			res["maximum"] = between[1].Value()
+		}
+		setIfExists(res, "exclusiveMinimum", field.Get("gt"))
+	case "boolean":
+		res = map[string]interface{}{"type": "boolean"}
+	case "array", "set":
+		items, err := luaFieldToJSONSchema(field.Get("elements"))
+		if err != nil {
+			return nil, err
+		}
+		res = map[string]interface{}{"type": "array", "items": items}
+		if t == "set" {
+			res["uniqueItems"] = true
+		}
+		setIfExists(res, "minItems", field.Get("len_min"))
+		setIfExists(res, "maxItems", field.Get("len_max"))
+	case "map":
+		values, err := luaFieldToJSONSchema(field.Get("values"))
+		if err != nil {
+			return nil, err
+		}
+		res = map[string]interface{}{"type": "object", "additionalProperties": values}
+		if keys, err := luaFieldToJSONSchema(field.Get("keys")); err == nil {
+			res["propertyNames"] = keys
+		}
+	case "record":
+		var err error
+		res, err = luaRecordToJSONSchema(field)
+		if err != nil {
+			return nil, err
+		}
+	case "foreign":
+		// foreign keys are given as {"id": "..."} or, in declarative
+		// configuration, by the name of the entity
+		res = map[string]interface{}{
+			"type": []string{"object", "string"},
+			"properties": map[string]interface{}{
+				"id": map[string]interface{}{"type": "string"},
+			},
+		}
+	case "json", "any":
+		res = map[string]interface{}{}
+	default:
+		return nil, fmt.Errorf("unsupported field type %q", t)
+	}
+
+	if oneOf := field.Get("one_of"); oneOf.Exists() {
+		res["enum"] = oneOf.Value()
+	}
+	setIfExists(res, "default", field.Get("default"))
+	if field.Get("description").Exists() {
+		res["description"] = field.Get("description").String()
+	}
+	return res, nil
+}
+
+// setNullable makes the JSON Schema of a field accept null as well.
+func setNullable(property map[string]interface{}) {
+	switch t := property["type"].(type) {
+	case string:
+		property["type"] = []string{t, "null"}
+	case []string:
+		property["type"] = append(t, "null")
+	}
+	if enum, ok := property["enum"].([]interface{}); ok {
+		property["enum"] = append(enum, nil)
+	}
+}
+
+func setIfExists(m map[string]interface{}, key string, value gjson.Result) {
+	if value.Exists() {
+		m[key] = value.Value()
+	}
+}
@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToJSONSchema(T *testing.T) {
+	var schema Schema
+	require.NoError(T, json.Unmarshal([]byte(`{"fields": [
+		{"id": {"type": "string", "uuid": true, "auto": true}},
+		{"name": {"type": "string", "len_min": 1}},
+		{"port": {"type": "integer", "default": 80, "between": [0, 65535], "required": true}},
+		{"host": {"type": "string", "required": true}},
+		{"protocol": {"type": "string", "one_of": ["http", "https"]}},
+		{"tags": {"type": "set", "elements": {"type": "string"}}},
+		{"headers": {"type": "map", "keys": {"type": "string"}, "values": {"type": "array", "elements": {"type": "string"}}}},
+		{"client_certificate": {"type": "foreign", "reference": "certificates"}},
+		{"config": {"type": "record", "required": true, "fields": [
+			{"minute": {"type": "number", "gt": 0}}
+		]}}
+	]}`), &schema))
+
+	res, err := ToJSONSchema("service", schema)
+	require.NoError(T, err)
+	b, err := json.Marshal(res)
+	require.NoError(T, err)
+	assert.JSONEq(T, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "service",
+		"type": "object",
+		"additionalProperties": {"type": "array", "items": {"type": "object"}},
+		"required": ["host", "config"],
+		"properties": {
+			"id": {"type": ["string", "null"], "format": "uuid"},
+			"name": {"type": ["string", "null"], "minLength": 1},
+			"port": {"type": "integer", "default": 80, "minimum": 0, "maximum": 65535},
+			"host": {"type": "string"},
+			"protocol": {"type": ["string", "null"], "enum": ["http", "https", null]},
+			"tags": {"type": ["array", "null"], "uniqueItems": true, "items": {"type": "string"}},
+			"headers": {
+				"type": ["object", "null"],
+				"propertyNames": {"type": "string"},
+				"additionalProperties": {"type": "array", "items": {"type": "string"}}
+			},
+			"client_certificate": {"type": ["object", "string", "null"], "properties": {"id": {"type": "string"}}},
+			"config": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {"minute": {"type": ["number", "null"], "exclusiveMinimum": 0}}
+			}
+		}
+	}`, string(b))
+
+	_, err = ToJSONSchema("broken", Schema{"fields": []interface{}{
+		map[string]interface{}{"f": map[string]interface{}{"type": "function"}},
+	}})
+	assert.EqualError(T, err, `field "f": unsupported field type "function"`)
+
+	res, err = ToJSONSchema("key_set", Schema{"type": "object", "properties": map[string]interface{}{}})
+	require.NoError(T, err)
+	assert.Equal(T, "object", res["type"])
+	assert.Equal(T, "key_set", res["title"])
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractJWTAuthService handles JWT credentials in Kong.
@@ -41,7 +40,7 @@ func (s *JWTAuthService) Create(ctx context.Context,
 	}
 
 	var createdJWT JWTAuth
-	err = json.Unmarshal(cred, &createdJWT)
+	err = s.client.decode(cred, &createdJWT)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +59,7 @@ func (s *JWTAuthService) Get(ctx context.Context,
 	}
 
 	var jwtAuth JWTAuth
-	err = json.Unmarshal(cred, &jwtAuth)
+	err = s.client.decode(cred, &jwtAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +78,7 @@ func (s *JWTAuthService) Update(ctx context.Context,
 	}
 
 	var updatedJWT JWTAuth
-	err = json.Unmarshal(cred, &updatedJWT)
+	err = s.client.decode(cred, &updatedJWT)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +110,7 @@ func (s *JWTAuthService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var jwtAuth JWTAuth
-		err = json.Unmarshal(b, &jwtAuth)
+		err = s.client.decode(b, &jwtAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -157,7 +156,7 @@ func (s *JWTAuthService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var jwtAuth JWTAuth
-		err = json.Unmarshal(b, &jwtAuth)
+		err = s.client.decode(b, &jwtAuth)
 		if err != nil {
 			return nil, nil, err
 		}
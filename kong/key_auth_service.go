@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractKeyAuthService handles key-auth credentials in Kong.
@@ -40,7 +39,7 @@ func (s *KeyAuthService) Create(ctx context.Context,
 	}
 
 	var createdKeyAuth KeyAuth
-	err = json.Unmarshal(cred, &createdKeyAuth)
+	err = s.client.decode(cred, &createdKeyAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +58,7 @@ func (s *KeyAuthService) Get(ctx context.Context,
 	}
 
 	var keyAuth KeyAuth
-	err = json.Unmarshal(cred, &keyAuth)
+	err = s.client.decode(cred, &keyAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +77,7 @@ func (s *KeyAuthService) Update(ctx context.Context,
 	}
 
 	var updatedKeyAuth KeyAuth
-	err = json.Unmarshal(cred, &updatedKeyAuth)
+	err = s.client.decode(cred, &updatedKeyAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -110,7 +109,7 @@ func (s *KeyAuthService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var keyAuth KeyAuth
-		err = json.Unmarshal(b, &keyAuth)
+		err = s.client.decode(b, &keyAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -156,7 +155,7 @@ func (s *KeyAuthService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var keyAuth KeyAuth
-		err = json.Unmarshal(b, &keyAuth)
+		err = s.client.decode(b, &keyAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -128,7 +127,7 @@ func (s *KeyService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var key Key
-		err = json.Unmarshal(b, &key)
+		err = s.client.decode(b, &key)
 		if err != nil {
 			return nil, nil, err
 		}
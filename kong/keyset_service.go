@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -128,7 +127,7 @@ func (s *KeySetService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var keyset KeySet
-		err = json.Unmarshal(b, &keyset)
+		err = s.client.decode(b, &keyset)
 		if err != nil {
 			return nil, nil, err
 		}
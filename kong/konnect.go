@@ -0,0 +1,16 @@
+package kong
+
+// SetKonnectMode tells the client whether it talks to the Admin API
+// compatible endpoints of a Konnect control plane instead of Kong.
+// In Konnect mode, lists are paginated with page numbers
+// (page[size] and page[number]) instead of offsets. The ListOpt returned
+// by List methods are used the same way with both backends.
+func (c *Client) SetKonnectMode(konnect bool) {
+	c.konnectMode.Store(konnect)
+}
+
+// IsKonnectMode returns whether the client is in Konnect mode, see
+// SetKonnectMode.
+func (c *Client) IsKonnectMode() bool {
+	return c.konnectMode.Load()
+}
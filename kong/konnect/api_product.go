@@ -0,0 +1,65 @@
+package konnect
+
+// APIProduct represents an API product in Konnect, a bundle of APIs
+// which can be published to dev portals.
+type APIProduct struct {
+	ID           *string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Name         *string           `json:"name,omitempty" yaml:"name,omitempty"`
+	Description  *string           `json:"description,omitempty" yaml:"description,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	PortalIDs    []*string         `json:"portal_ids,omitempty" yaml:"portal_ids,omitempty"`
+	VersionCount *int              `json:"version_count,omitempty" yaml:"version_count,omitempty"`
+	CreatedAt    *string           `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt    *string           `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// FriendlyName returns the endpoint key name or ID.
+func (p *APIProduct) FriendlyName() string {
+	if p.Name != nil {
+		return *p.Name
+	}
+	if p.ID != nil {
+		return *p.ID
+	}
+	return ""
+}
+
+// APIProductVersion represents a version of an API product in Konnect,
+// optionally linked to a Gateway service of a control plane.
+type APIProductVersion struct {
+	ID             *string                   `json:"id,omitempty" yaml:"id,omitempty"`
+	Name           *string                   `json:"name,omitempty" yaml:"name,omitempty"`
+	GatewayService *APIProductGatewayService `json:"gateway_service,omitempty" yaml:"gateway_service,omitempty"`
+	PublishStatus  *string                   `json:"publish_status,omitempty" yaml:"publish_status,omitempty"`
+	Deprecated     *bool                     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	CreatedAt      *string                   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt      *string                   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// APIProductGatewayService links an APIProductVersion to a Kong service.
+type APIProductGatewayService struct {
+	ControlPlaneID *string `json:"control_plane_id,omitempty" yaml:"control_plane_id,omitempty"`
+	ID             *string `json:"id,omitempty" yaml:"id,omitempty"`
+}
+
+const (
+	// PublishStatusPublished is the status of product versions visible in
+	// a dev portal.
+	PublishStatusPublished = "published"
+	// PublishStatusUnpublished is the status of product versions hidden
+	// from a dev portal.
+	PublishStatusUnpublished = "unpublished"
+)
+
+// PortalProductVersion represents the publication of an
+// APIProductVersion in a dev portal.
+type PortalProductVersion struct {
+	ProductVersionID               *string   `json:"product_version_id,omitempty" yaml:"product_version_id,omitempty"`
+	PublishStatus                  *string   `json:"publish_status,omitempty" yaml:"publish_status,omitempty"`
+	Deprecated                     *bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	ApplicationRegistrationEnabled *bool     `json:"application_registration_enabled,omitempty" yaml:"application_registration_enabled,omitempty"`
+	AutoApproveRegistration        *bool     `json:"auto_approve_registration,omitempty" yaml:"auto_approve_registration,omitempty"`
+	AuthStrategyIDs                []*string `json:"auth_strategy_ids,omitempty" yaml:"auth_strategy_ids,omitempty"`
+	CreatedAt                      *string   `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt                      *string   `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
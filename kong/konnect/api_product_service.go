@@ -0,0 +1,150 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractAPIProductService handles API products in Konnect.
+type AbstractAPIProductService interface {
+	// Create creates an APIProduct in Konnect.
+	Create(ctx context.Context, product *APIProduct) (*APIProduct, error)
+	// Get fetches an APIProduct in Konnect.
+	Get(ctx context.Context, id *string) (*APIProduct, error)
+	// Update updates an APIProduct in Konnect.
+	Update(ctx context.Context, product *APIProduct) (*APIProduct, error)
+	// Delete deletes an APIProduct in Konnect.
+	Delete(ctx context.Context, id *string) error
+	// List fetches a list of APIProducts in Konnect.
+	List(ctx context.Context, opt *ListOpt) ([]*APIProduct, *ListOpt, error)
+	// ListAll fetches all APIProducts in Konnect.
+	ListAll(ctx context.Context) ([]*APIProduct, error)
+}
+
+// APIProductService handles API products in Konnect.
+type APIProductService service
+
+const apiProductsEndpoint = "/v2/api-products"
+
+// Create creates an APIProduct in Konnect.
+func (s *APIProductService) Create(ctx context.Context,
+	product *APIProduct,
+) (*APIProduct, error) {
+	if product == nil {
+		return nil, fmt.Errorf("cannot create a nil API product")
+	}
+	if isEmptyString(product.Name) {
+		return nil, fmt.Errorf("name cannot be nil for Create operation")
+	}
+
+	req, err := s.client.NewRequest("POST", apiProductsEndpoint, nil, product)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdProduct APIProduct
+	_, err = s.client.Do(ctx, req, &createdProduct)
+	if err != nil {
+		return nil, err
+	}
+	return &createdProduct, nil
+}
+
+// Get fetches an APIProduct in Konnect.
+func (s *APIProductService) Get(ctx context.Context,
+	id *string,
+) (*APIProduct, error) {
+	if isEmptyString(id) {
+		return nil, fmt.Errorf("id cannot be nil for Get operation")
+	}
+
+	req, err := s.client.NewRequest("GET", apiProductsEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var product APIProduct
+	_, err = s.client.Do(ctx, req, &product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// Update updates an APIProduct in Konnect.
+// Setting PortalIDs publishes the product to these portals.
+func (s *APIProductService) Update(ctx context.Context,
+	product *APIProduct,
+) (*APIProduct, error) {
+	if product == nil {
+		return nil, fmt.Errorf("cannot update a nil API product")
+	}
+	if isEmptyString(product.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	// the ID and the read-only fields are rejected by Konnect
+	update := APIProduct{
+		Name:        product.Name,
+		Description: product.Description,
+		Labels:      product.Labels,
+		PortalIDs:   product.PortalIDs,
+	}
+	req, err := s.client.NewRequest("PATCH", apiProductsEndpoint+"/"+*product.ID, nil, update)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProduct APIProduct
+	_, err = s.client.Do(ctx, req, &updatedProduct)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedProduct, nil
+}
+
+// Delete deletes an APIProduct in Konnect, along with its versions.
+func (s *APIProductService) Delete(ctx context.Context,
+	id *string,
+) error {
+	if isEmptyString(id) {
+		return fmt.Errorf("id cannot be nil for Delete operation")
+	}
+
+	req, err := s.client.NewRequest("DELETE", apiProductsEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of APIProducts in Konnect.
+// opt can be used to control pagination and filter API products.
+func (s *APIProductService) List(ctx context.Context,
+	opt *ListOpt,
+) ([]*APIProduct, *ListOpt, error) {
+	var products []*APIProduct
+	next, err := s.client.list(ctx, apiProductsEndpoint, opt, &products)
+	if err != nil {
+		return nil, nil, err
+	}
+	return products, next, nil
+}
+
+// ListAll fetches all APIProducts in Konnect.
+func (s *APIProductService) ListAll(ctx context.Context) ([]*APIProduct, error) {
+	var products, data []*APIProduct
+	var err error
+	opt := &ListOpt{PageSize: defaultPageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, data...)
+	}
+	return products, nil
+}
@@ -0,0 +1,118 @@
+package konnect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIProductServices(t *testing.T) {
+	var productUpdate, versionCreate, publication, publicationUpdate map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/api-products":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"p1","name":"billing"}`))
+		case "GET /v2/api-products/p1":
+			_, _ = w.Write([]byte(`{"id":"p1","name":"billing","version_count":1}`))
+		case "PATCH /v2/api-products/p1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&productUpdate))
+			_, _ = w.Write([]byte(`{"id":"p1","name":"billing","portal_ids":["portal1"]}`))
+		case "GET /v2/api-products":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1"}],"meta":{"page":{"number":1,"size":100,"total":1}}}`))
+		case "DELETE /v2/api-products/p1":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /v2/api-products/p1/product-versions":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&versionCreate))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"v1","name":"v1","gateway_service":{"control_plane_id":"cp1","id":"s1"}}`))
+		case "GET /v2/api-products/p1/product-versions":
+			_, _ = w.Write([]byte(`{"data":[{"id":"v1"},{"id":"v2"}],"meta":{"page":{"number":1,"size":100,"total":2}}}`))
+		case "PATCH /v2/api-products/p1/product-versions/v1":
+			_, _ = w.Write([]byte(`{"id":"v1","name":"v1","deprecated":true}`))
+		case "DELETE /v2/api-products/p1/product-versions/v1":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /v2/portals/portal1/product-versions":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&publication))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"product_version_id":"v1","publish_status":"published"}`))
+		case "GET /v2/portals/portal1/product-versions/v1":
+			_, _ = w.Write([]byte(`{"product_version_id":"v1","publish_status":"published"}`))
+		case "PATCH /v2/portals/portal1/product-versions/v1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&publicationUpdate))
+			_, _ = w.Write([]byte(`{"product_version_id":"v1","publish_status":"unpublished"}`))
+		case "GET /v2/portals/portal1/product-versions":
+			_, _ = w.Write([]byte(`{"data":[{"product_version_id":"v1"}],"meta":{"page":{"number":1,"size":100,"total":1}}}`))
+		case "DELETE /v2/portals/portal1/product-versions/v1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(kong.String(srv.URL), "kpat_test", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	product, err := client.APIProducts.Create(ctx, &APIProduct{Name: kong.String("billing")})
+	require.NoError(t, err)
+	assert.Equal(t, "p1", *product.ID)
+	product, err = client.APIProducts.Get(ctx, kong.String("p1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, *product.VersionCount)
+	product, err = client.APIProducts.Update(ctx, &APIProduct{
+		ID: kong.String("p1"), PortalIDs: kong.StringSlice("portal1"), VersionCount: kong.Int(1),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"portal_ids": []interface{}{"portal1"}}, productUpdate)
+	assert.Equal(t, "portal1", *product.PortalIDs[0])
+	products, err := client.APIProducts.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	version, err := client.APIProductVersions.Create(ctx, kong.String("p1"), &APIProductVersion{
+		Name:           kong.String("v1"),
+		GatewayService: &APIProductGatewayService{ControlPlaneID: kong.String("cp1"), ID: kong.String("s1")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *version.GatewayService.ID)
+	assert.Equal(t, map[string]interface{}{"control_plane_id": "cp1", "id": "s1"}, versionCreate["gateway_service"])
+	_, err = client.APIProductVersions.Create(ctx, nil, version)
+	assert.Error(t, err)
+	versions, err := client.APIProductVersions.ListAll(ctx, kong.String("p1"))
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+	version, err = client.APIProductVersions.Update(ctx, kong.String("p1"), &APIProductVersion{
+		ID: kong.String("v1"), Deprecated: kong.Bool(true),
+	})
+	require.NoError(t, err)
+	assert.True(t, *version.Deprecated)
+
+	publishedVersion, err := client.PortalProductVersions.Publish(ctx, kong.String("portal1"),
+		&PortalProductVersion{ProductVersionID: kong.String("v1")})
+	require.NoError(t, err)
+	assert.Equal(t, PublishStatusPublished, *publishedVersion.PublishStatus)
+	assert.Equal(t, PublishStatusPublished, publication["publish_status"])
+	publishedVersion, err = client.PortalProductVersions.Get(ctx, kong.String("portal1"), kong.String("v1"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", *publishedVersion.ProductVersionID)
+	publishedVersion, err = client.PortalProductVersions.Update(ctx, kong.String("portal1"), &PortalProductVersion{
+		ProductVersionID: kong.String("v1"), PublishStatus: kong.String(PublishStatusUnpublished),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"publish_status": "unpublished"}, publicationUpdate)
+	assert.Equal(t, PublishStatusUnpublished, *publishedVersion.PublishStatus)
+	publishedVersions, err := client.PortalProductVersions.ListAll(ctx, kong.String("portal1"))
+	require.NoError(t, err)
+	assert.Len(t, publishedVersions, 1)
+
+	require.NoError(t, client.PortalProductVersions.Unpublish(ctx, kong.String("portal1"), kong.String("v1")))
+	require.NoError(t, client.APIProductVersions.Delete(ctx, kong.String("p1"), kong.String("v1")))
+	require.NoError(t, client.APIProducts.Delete(ctx, kong.String("p1")))
+}
@@ -0,0 +1,168 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractAPIProductVersionService handles versions of API products in
+// Konnect.
+type AbstractAPIProductVersionService interface {
+	// Create creates an APIProductVersion in Konnect.
+	Create(ctx context.Context, productID *string, version *APIProductVersion) (*APIProductVersion, error)
+	// Get fetches an APIProductVersion in Konnect.
+	Get(ctx context.Context, productID, id *string) (*APIProductVersion, error)
+	// Update updates an APIProductVersion in Konnect.
+	Update(ctx context.Context, productID *string, version *APIProductVersion) (*APIProductVersion, error)
+	// Delete deletes an APIProductVersion in Konnect.
+	Delete(ctx context.Context, productID, id *string) error
+	// List fetches a list of APIProductVersions of an APIProduct in Konnect.
+	List(ctx context.Context, productID *string, opt *ListOpt) ([]*APIProductVersion, *ListOpt, error)
+	// ListAll fetches all APIProductVersions of an APIProduct in Konnect.
+	ListAll(ctx context.Context, productID *string) ([]*APIProductVersion, error)
+}
+
+// APIProductVersionService handles versions of API products in Konnect.
+type APIProductVersionService service
+
+func apiProductVersionsEndpoint(productID string) string {
+	return apiProductsEndpoint + "/" + productID + "/product-versions"
+}
+
+// Create creates an APIProductVersion of the APIProduct with productID.
+// Setting version.GatewayService links the version to a Kong service.
+func (s *APIProductVersionService) Create(ctx context.Context,
+	productID *string, version *APIProductVersion,
+) (*APIProductVersion, error) {
+	if isEmptyString(productID) {
+		return nil, fmt.Errorf("productID cannot be nil for Create operation")
+	}
+	if version == nil {
+		return nil, fmt.Errorf("cannot create a nil API product version")
+	}
+	if isEmptyString(version.Name) {
+		return nil, fmt.Errorf("name cannot be nil for Create operation")
+	}
+
+	req, err := s.client.NewRequest("POST", apiProductVersionsEndpoint(*productID), nil, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdVersion APIProductVersion
+	_, err = s.client.Do(ctx, req, &createdVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &createdVersion, nil
+}
+
+// Get fetches the APIProductVersion with id of the APIProduct with
+// productID.
+func (s *APIProductVersionService) Get(ctx context.Context,
+	productID, id *string,
+) (*APIProductVersion, error) {
+	if isEmptyString(productID) || isEmptyString(id) {
+		return nil, fmt.Errorf("productID and id cannot be nil for Get operation")
+	}
+
+	req, err := s.client.NewRequest("GET", apiProductVersionsEndpoint(*productID)+"/"+*id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var version APIProductVersion
+	_, err = s.client.Do(ctx, req, &version)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// Update updates an APIProductVersion of the APIProduct with productID.
+func (s *APIProductVersionService) Update(ctx context.Context,
+	productID *string, version *APIProductVersion,
+) (*APIProductVersion, error) {
+	if isEmptyString(productID) {
+		return nil, fmt.Errorf("productID cannot be nil for Update operation")
+	}
+	if version == nil {
+		return nil, fmt.Errorf("cannot update a nil API product version")
+	}
+	if isEmptyString(version.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	// the ID and the read-only fields are rejected by Konnect
+	update := APIProductVersion{
+		Name:           version.Name,
+		GatewayService: version.GatewayService,
+		PublishStatus:  version.PublishStatus,
+		Deprecated:     version.Deprecated,
+	}
+	req, err := s.client.NewRequest("PATCH", apiProductVersionsEndpoint(*productID)+"/"+*version.ID, nil, update)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedVersion APIProductVersion
+	_, err = s.client.Do(ctx, req, &updatedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedVersion, nil
+}
+
+// Delete deletes the APIProductVersion with id of the APIProduct with
+// productID.
+func (s *APIProductVersionService) Delete(ctx context.Context,
+	productID, id *string,
+) error {
+	if isEmptyString(productID) || isEmptyString(id) {
+		return fmt.Errorf("productID and id cannot be nil for Delete operation")
+	}
+
+	req, err := s.client.NewRequest("DELETE", apiProductVersionsEndpoint(*productID)+"/"+*id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of APIProductVersions of the APIProduct with
+// productID. opt can be used to control pagination and filter versions.
+func (s *APIProductVersionService) List(ctx context.Context,
+	productID *string, opt *ListOpt,
+) ([]*APIProductVersion, *ListOpt, error) {
+	if isEmptyString(productID) {
+		return nil, nil, fmt.Errorf("productID cannot be nil for List operation")
+	}
+
+	var versions []*APIProductVersion
+	next, err := s.client.list(ctx, apiProductVersionsEndpoint(*productID), opt, &versions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions, next, nil
+}
+
+// ListAll fetches all APIProductVersions of the APIProduct with
+// productID.
+func (s *APIProductVersionService) ListAll(ctx context.Context,
+	productID *string,
+) ([]*APIProductVersion, error) {
+	var versions, data []*APIProductVersion
+	var err error
+	opt := &ListOpt{PageSize: defaultPageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, productID, opt)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, data...)
+	}
+	return versions, nil
+}
@@ -0,0 +1,178 @@
+package konnect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+)
+
+const defaultBaseURL = "https://us.api.konghq.com"
+
+type service struct {
+	client *Client
+}
+
+// Client talks to the Konnect API of a region.
+type Client struct {
+	client  *http.Client
+	baseURL string
+	token   string
+	common  service
+
+	APIProducts           AbstractAPIProductService
+	APIProductVersions    AbstractAPIProductVersionService
+	ControlPlanes         AbstractControlPlaneService
+	DataPlaneCertificates AbstractDataPlaneCertificateService
+	PortalProductVersions AbstractPortalProductVersionService
+	SystemAccounts        AbstractSystemAccountService
+}
+
+// NewClient returns a Client which talks to the Konnect API at baseURL
+// (e.g. https://eu.api.konghq.com, defaults to the US region),
+// authenticating with token, a personal or system account access token.
+func NewClient(baseURL *string, token string, client *http.Client) (*Client, error) {
+	if client == nil {
+		client = &http.Client{Timeout: kong.DefaultTimeout}
+	}
+	rootURL := defaultBaseURL
+	if baseURL != nil {
+		rootURL = *baseURL
+	}
+	rootURL = strings.TrimSuffix(rootURL, "/")
+	if _, err := url.ParseRequestURI(rootURL); err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+	if token == "" {
+		return nil, fmt.Errorf("token cannot be empty")
+	}
+
+	konnect := &Client{
+		client:  client,
+		baseURL: rootURL,
+		token:   token,
+	}
+	konnect.common.client = konnect
+	konnect.APIProducts = (*APIProductService)(&konnect.common)
+	konnect.APIProductVersions = (*APIProductVersionService)(&konnect.common)
+	konnect.ControlPlanes = (*ControlPlaneService)(&konnect.common)
+	konnect.DataPlaneCertificates = (*DataPlaneCertificateService)(&konnect.common)
+	konnect.PortalProductVersions = (*PortalProductVersionService)(&konnect.common)
+	konnect.SystemAccounts = (*SystemAccountService)(&konnect.common)
+	return konnect, nil
+}
+
+// BaseURL returns the URL of the Konnect API the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// NewRequest creates a request for endpoint, relative to the base URL,
+// with the query string qs and the JSON encoding of body, if not nil.
+func (c *Client) NewRequest(method, endpoint string, qs url.Values,
+	body interface{},
+) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+	u := c.baseURL + endpoint
+	if len(qs) > 0 {
+		u += "?" + qs.Encode()
+	}
+	req, err := http.NewRequest(method, u, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	return req, nil
+}
+
+// Do sends req and decodes the JSON response body into v, if not nil.
+// Error responses are returned as a *kong.APIError, so that helpers such
+// as kong.IsNotFoundErr work with Konnect errors too.
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("making HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("reading response body: %w", err)
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, kong.NewAPIErrorWithRaw(resp.StatusCode, errorMessage(body), body)
+	}
+	if v != nil && len(body) > 0 {
+		if err := json.Unmarshal(body, v); err != nil {
+			return resp, fmt.Errorf("failed decoding response body: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// errorMessage extracts the message of a Konnect error response, which
+// follows RFC 7807 (problem details).
+func errorMessage(body []byte) string {
+	var problem struct {
+		Title   string `json:"title"`
+		Detail  string `json:"detail"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return string(body)
+	}
+	switch {
+	case problem.Detail != "":
+		return problem.Detail
+	case problem.Message != "":
+		return problem.Message
+	}
+	return problem.Title
+}
+
+// CoreEntitiesURL returns the base URL of the Admin API compatible
+// endpoints of the control plane with controlPlaneID, to be used with
+// kong.NewClient.
+func (c *Client) CoreEntitiesURL(controlPlaneID string) string {
+	return c.baseURL + "/v2/control-planes/" + controlPlaneID + "/core-entities"
+}
+
+// CoreClient returns a kong.Client managing the Kong entities (services,
+// routes, plugins...) of the control plane with controlPlaneID, using the
+// token of c.
+func (c *Client) CoreClient(controlPlaneID string) (*kong.Client, error) {
+	if controlPlaneID == "" {
+		return nil, fmt.Errorf("controlPlaneID cannot be empty")
+	}
+	// HTTPClientWithHeaders wraps the transport of the client it is given
+	httpClient := *c.client
+	coreHTTPClient := kong.HTTPClientWithHeaders(&httpClient, http.Header{
+		"Authorization": []string{"Bearer " + c.token},
+	})
+	client, err := kong.NewClient(kong.String(c.CoreEntitiesURL(controlPlaneID)), coreHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+	client.SetKonnectMode(true)
+	return client, nil
+}
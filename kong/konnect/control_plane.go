@@ -0,0 +1,34 @@
+package konnect
+
+// ControlPlane represents a control plane (formerly runtime group) in
+// Konnect.
+type ControlPlane struct {
+	ID          *string             `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        *string             `json:"name,omitempty" yaml:"name,omitempty"`
+	Description *string             `json:"description,omitempty" yaml:"description,omitempty"`
+	Labels      map[string]string   `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ClusterType *string             `json:"cluster_type,omitempty" yaml:"cluster_type,omitempty"`
+	AuthType    *string             `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+	Config      *ControlPlaneConfig `json:"config,omitempty" yaml:"config,omitempty"`
+	CreatedAt   *string             `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt   *string             `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// ControlPlaneConfig is the read-only configuration of a control plane.
+type ControlPlaneConfig struct {
+	ControlPlaneEndpoint *string `json:"control_plane_endpoint,omitempty" yaml:"control_plane_endpoint,omitempty"`
+	TelemetryEndpoint    *string `json:"telemetry_endpoint,omitempty" yaml:"telemetry_endpoint,omitempty"`
+	ClusterType          *string `json:"cluster_type,omitempty" yaml:"cluster_type,omitempty"`
+	AuthType             *string `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+}
+
+// FriendlyName returns the endpoint key name or ID.
+func (c *ControlPlane) FriendlyName() string {
+	if c.Name != nil {
+		return *c.Name
+	}
+	if c.ID != nil {
+		return *c.ID
+	}
+	return ""
+}
@@ -0,0 +1,180 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// AbstractControlPlaneService handles control planes in Konnect.
+type AbstractControlPlaneService interface {
+	// Create creates a ControlPlane in Konnect.
+	Create(ctx context.Context, controlPlane *ControlPlane) (*ControlPlane, error)
+	// Get fetches a ControlPlane in Konnect.
+	Get(ctx context.Context, id *string) (*ControlPlane, error)
+	// GetByName fetches a ControlPlane in Konnect by name.
+	GetByName(ctx context.Context, name *string) (*ControlPlane, error)
+	// Update updates a ControlPlane in Konnect.
+	Update(ctx context.Context, controlPlane *ControlPlane) (*ControlPlane, error)
+	// Delete deletes a ControlPlane in Konnect.
+	Delete(ctx context.Context, id *string) error
+	// List fetches a list of ControlPlanes in Konnect.
+	List(ctx context.Context, opt *ListOpt) ([]*ControlPlane, *ListOpt, error)
+	// ListAll fetches all ControlPlanes in Konnect.
+	ListAll(ctx context.Context) ([]*ControlPlane, error)
+}
+
+// ControlPlaneService handles control planes in Konnect.
+type ControlPlaneService service
+
+const controlPlanesEndpoint = "/v2/control-planes"
+
+func isEmptyString(s *string) bool {
+	return s == nil || *s == ""
+}
+
+// Create creates a ControlPlane in Konnect.
+// Konnect generates the ID of the control plane.
+func (s *ControlPlaneService) Create(ctx context.Context,
+	controlPlane *ControlPlane,
+) (*ControlPlane, error) {
+	if controlPlane == nil {
+		return nil, fmt.Errorf("cannot create a nil control plane")
+	}
+	if isEmptyString(controlPlane.Name) {
+		return nil, fmt.Errorf("name cannot be nil for Create operation")
+	}
+
+	req, err := s.client.NewRequest("POST", controlPlanesEndpoint, nil, controlPlane)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdControlPlane ControlPlane
+	_, err = s.client.Do(ctx, req, &createdControlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return &createdControlPlane, nil
+}
+
+// Get fetches a ControlPlane in Konnect.
+func (s *ControlPlaneService) Get(ctx context.Context,
+	id *string,
+) (*ControlPlane, error) {
+	if isEmptyString(id) {
+		return nil, fmt.Errorf("id cannot be nil for Get operation")
+	}
+
+	req, err := s.client.NewRequest("GET", controlPlanesEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var controlPlane ControlPlane
+	_, err = s.client.Do(ctx, req, &controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return &controlPlane, nil
+}
+
+// GetByName fetches the ControlPlane named name in Konnect.
+// It returns a 404 kong.APIError if there is none.
+func (s *ControlPlaneService) GetByName(ctx context.Context,
+	name *string,
+) (*ControlPlane, error) {
+	if isEmptyString(name) {
+		return nil, fmt.Errorf("name cannot be nil for GetByName operation")
+	}
+
+	controlPlanes, _, err := s.List(ctx, &ListOpt{Filters: map[string]string{"name": *name}})
+	if err != nil {
+		return nil, err
+	}
+	for _, controlPlane := range controlPlanes {
+		if controlPlane.Name != nil && *controlPlane.Name == *name {
+			return controlPlane, nil
+		}
+	}
+	return nil, kong.NewAPIError(http.StatusNotFound, fmt.Sprintf("control plane %q not found", *name))
+}
+
+// Update updates a ControlPlane in Konnect.
+func (s *ControlPlaneService) Update(ctx context.Context,
+	controlPlane *ControlPlane,
+) (*ControlPlane, error) {
+	if controlPlane == nil {
+		return nil, fmt.Errorf("cannot update a nil control plane")
+	}
+	if isEmptyString(controlPlane.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	// the ID and the read-only fields are rejected by Konnect
+	update := ControlPlane{
+		Name:        controlPlane.Name,
+		Description: controlPlane.Description,
+		Labels:      controlPlane.Labels,
+		AuthType:    controlPlane.AuthType,
+	}
+	req, err := s.client.NewRequest("PATCH", controlPlanesEndpoint+"/"+*controlPlane.ID, nil, update)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedControlPlane ControlPlane
+	_, err = s.client.Do(ctx, req, &updatedControlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedControlPlane, nil
+}
+
+// Delete deletes a ControlPlane in Konnect, along with its entities.
+func (s *ControlPlaneService) Delete(ctx context.Context,
+	id *string,
+) error {
+	if isEmptyString(id) {
+		return fmt.Errorf("id cannot be nil for Delete operation")
+	}
+
+	req, err := s.client.NewRequest("DELETE", controlPlanesEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of ControlPlanes in Konnect.
+// opt can be used to control pagination and filter control planes.
+func (s *ControlPlaneService) List(ctx context.Context,
+	opt *ListOpt,
+) ([]*ControlPlane, *ListOpt, error) {
+	var controlPlanes []*ControlPlane
+	next, err := s.client.list(ctx, controlPlanesEndpoint, opt, &controlPlanes)
+	if err != nil {
+		return nil, nil, err
+	}
+	return controlPlanes, next, nil
+}
+
+// ListAll fetches all ControlPlanes in Konnect.
+func (s *ControlPlaneService) ListAll(ctx context.Context) ([]*ControlPlane, error) {
+	var controlPlanes, data []*ControlPlane
+	var err error
+	opt := &ListOpt{PageSize: defaultPageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		controlPlanes = append(controlPlanes, data...)
+	}
+	return controlPlanes, nil
+}
@@ -0,0 +1,119 @@
+package konnect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControlPlaneService(t *testing.T) {
+	var patched map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer kpat_test", r.Header.Get("Authorization"))
+		q := r.URL.Query()
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/control-planes":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"cp1","name":"prod","config":{"control_plane_endpoint":"https://cp1.example.com"}}`))
+		case "GET /v2/control-planes/cp1":
+			_, _ = w.Write([]byte(`{"id":"cp1","name":"prod"}`))
+		case "GET /v2/control-planes/nope":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"title":"Not Found","detail":"control plane not found"}`))
+		case "PATCH /v2/control-planes/cp1":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			_, _ = w.Write([]byte(`{"id":"cp1","name":"prod","description":"new"}`))
+		case "DELETE /v2/control-planes/cp1":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /v2/control-planes":
+			if name := q.Get("filter[name][eq]"); name != "" {
+				data := `[]`
+				if name == "prod" {
+					data = `[{"id":"cp1","name":"prod"}]`
+				}
+				_, _ = w.Write([]byte(`{"data":` + data + `,"meta":{"page":{"number":1,"size":10,"total":1}}}`))
+				return
+			}
+			switch q.Get("page[number]") {
+			case "", "1":
+				assert.Equal(t, "100", q.Get("page[size]"))
+				_, _ = w.Write([]byte(`{"data":[{"id":"cp1"},{"id":"cp2"}],"meta":{"page":{"number":1,"size":2,"total":3}}}`))
+			case "2":
+				_, _ = w.Write([]byte(`{"data":[{"id":"cp3"}],"meta":{"page":{"number":2,"size":2,"total":3}}}`))
+			default:
+				t.Errorf("unexpected page %q", q.Get("page[number]"))
+			}
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(kong.String(srv.URL), "kpat_test", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	controlPlane, err := client.ControlPlanes.Create(ctx, &ControlPlane{Name: kong.String("prod")})
+	require.NoError(t, err)
+	assert.Equal(t, "cp1", *controlPlane.ID)
+	assert.Equal(t, "https://cp1.example.com", *controlPlane.Config.ControlPlaneEndpoint)
+	_, err = client.ControlPlanes.Create(ctx, &ControlPlane{})
+	assert.Error(t, err)
+
+	controlPlane, err = client.ControlPlanes.Get(ctx, kong.String("cp1"))
+	require.NoError(t, err)
+	assert.Equal(t, "prod", controlPlane.FriendlyName())
+	_, err = client.ControlPlanes.Get(ctx, kong.String("nope"))
+	assert.True(t, kong.IsNotFoundErr(err))
+	assert.Contains(t, err.Error(), "control plane not found")
+
+	controlPlane, err = client.ControlPlanes.GetByName(ctx, kong.String("prod"))
+	require.NoError(t, err)
+	assert.Equal(t, "cp1", *controlPlane.ID)
+	_, err = client.ControlPlanes.GetByName(ctx, kong.String("staging"))
+	assert.True(t, kong.IsNotFoundErr(err))
+
+	controlPlane.Description = kong.String("new")
+	controlPlane.Config = &ControlPlaneConfig{ClusterType: kong.String("CLUSTER_TYPE_HYBRID")}
+	controlPlane, err = client.ControlPlanes.Update(ctx, controlPlane)
+	require.NoError(t, err)
+	assert.Equal(t, "new", *controlPlane.Description)
+	assert.Equal(t, map[string]interface{}{"name": "prod", "description": "new"}, patched)
+
+	require.NoError(t, client.ControlPlanes.Delete(ctx, kong.String("cp1")))
+
+	controlPlanes, err := client.ControlPlanes.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, controlPlanes, 3)
+}
+
+func TestCoreClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer kpat_test", r.Header.Get("Authorization"))
+		assert.Equal(t, "/v2/control-planes/cp1/core-entities/services/foo", r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"s1","name":"foo"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(kong.String(srv.URL), "kpat_test", nil)
+	require.NoError(t, err)
+
+	core, err := client.CoreClient("cp1")
+	require.NoError(t, err)
+	assert.True(t, core.IsKonnectMode())
+	service, err := core.Services.Get(context.Background(), kong.String("foo"))
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *service.ID)
+
+	// the client of c is left untouched
+	assert.Nil(t, client.client.Transport)
+
+	_, err = client.CoreClient("")
+	assert.Error(t, err)
+	_, err = NewClient(nil, "", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,20 @@
+package konnect
+
+// DataPlaneCertificate represents a client certificate that data planes
+// of a Konnect control plane can authenticate with (pinned certificate
+// mode).
+type DataPlaneCertificate struct {
+	ID        *string `json:"id,omitempty" yaml:"id,omitempty"`
+	Cert      *string `json:"cert,omitempty" yaml:"cert,omitempty"`
+	CreatedAt *int    `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt *int    `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// DataPlaneCredentials are the certificate and private key a data plane
+// needs to connect to a Konnect control plane, in PEM format, as the
+// cluster_cert and cluster_cert_key settings of Kong.
+type DataPlaneCredentials struct {
+	Certificate *DataPlaneCertificate
+	CertPEM     []byte
+	KeyPEM      []byte
+}
@@ -0,0 +1,194 @@
+package konnect
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// AbstractDataPlaneCertificateService handles data plane client
+// certificates of control planes in Konnect.
+type AbstractDataPlaneCertificateService interface {
+	// Create registers a DataPlaneCertificate for a control plane.
+	Create(ctx context.Context, controlPlaneID *string, certPEM []byte) (*DataPlaneCertificate, error)
+	// Generate creates and registers new DataPlaneCredentials for a control plane.
+	Generate(ctx context.Context, controlPlaneID *string, commonName string,
+		validity time.Duration) (*DataPlaneCredentials, error)
+	// Get fetches a DataPlaneCertificate of a control plane.
+	Get(ctx context.Context, controlPlaneID, id *string) (*DataPlaneCertificate, error)
+	// List fetches all DataPlaneCertificates of a control plane.
+	List(ctx context.Context, controlPlaneID *string) ([]*DataPlaneCertificate, error)
+	// Delete deletes a DataPlaneCertificate of a control plane.
+	Delete(ctx context.Context, controlPlaneID, id *string) error
+}
+
+// DataPlaneCertificateService handles data plane client certificates of
+// control planes in Konnect.
+type DataPlaneCertificateService service
+
+func dataPlaneCertificatesEndpoint(controlPlaneID string) string {
+	return controlPlanesEndpoint + "/" + controlPlaneID + "/dp-client-certificates"
+}
+
+// Create registers the PEM encoded certificate certPEM for the control
+// plane with controlPlaneID. Data planes presenting it are then allowed
+// to connect to the control plane.
+func (s *DataPlaneCertificateService) Create(ctx context.Context,
+	controlPlaneID *string, certPEM []byte,
+) (*DataPlaneCertificate, error) {
+	if isEmptyString(controlPlaneID) {
+		return nil, fmt.Errorf("controlPlaneID cannot be nil for Create operation")
+	}
+	if len(certPEM) == 0 {
+		return nil, fmt.Errorf("cert cannot be empty for Create operation")
+	}
+
+	body := DataPlaneCertificate{Cert: kong.String(string(certPEM))}
+	req, err := s.client.NewRequest("POST", dataPlaneCertificatesEndpoint(*controlPlaneID), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var created struct {
+		Item *DataPlaneCertificate `json:"item"`
+	}
+	_, err = s.client.Do(ctx, req, &created)
+	if err != nil {
+		return nil, err
+	}
+	return created.Item, nil
+}
+
+// Generate creates a self-signed certificate for commonName, valid for
+// validity, with an ECDSA P-256 key, and registers it for the control
+// plane with controlPlaneID. The returned credentials hold the only copy
+// of the private key.
+func (s *DataPlaneCertificateService) Generate(ctx context.Context,
+	controlPlaneID *string, commonName string, validity time.Duration,
+) (*DataPlaneCredentials, error) {
+	if validity <= 0 {
+		return nil, fmt.Errorf("validity must be positive")
+	}
+	certPEM, keyPEM, err := generateCertificate(commonName, validity)
+	if err != nil {
+		return nil, fmt.Errorf("generating certificate: %w", err)
+	}
+	certificate, err := s.Create(ctx, controlPlaneID, certPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &DataPlaneCredentials{
+		Certificate: certificate,
+		CertPEM:     certPEM,
+		KeyPEM:      keyPEM,
+	}, nil
+}
+
+// Get fetches the DataPlaneCertificate with id of the control plane
+// with controlPlaneID.
+func (s *DataPlaneCertificateService) Get(ctx context.Context,
+	controlPlaneID, id *string,
+) (*DataPlaneCertificate, error) {
+	if isEmptyString(controlPlaneID) || isEmptyString(id) {
+		return nil, fmt.Errorf("controlPlaneID and id cannot be nil for Get operation")
+	}
+
+	req, err := s.client.NewRequest("GET", dataPlaneCertificatesEndpoint(*controlPlaneID)+"/"+*id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificate struct {
+		Item *DataPlaneCertificate `json:"item"`
+	}
+	_, err = s.client.Do(ctx, req, &certificate)
+	if err != nil {
+		return nil, err
+	}
+	return certificate.Item, nil
+}
+
+// List fetches all DataPlaneCertificates of the control plane with
+// controlPlaneID. Konnect doesn't paginate them.
+func (s *DataPlaneCertificateService) List(ctx context.Context,
+	controlPlaneID *string,
+) ([]*DataPlaneCertificate, error) {
+	if isEmptyString(controlPlaneID) {
+		return nil, fmt.Errorf("controlPlaneID cannot be nil for List operation")
+	}
+
+	req, err := s.client.NewRequest("GET", dataPlaneCertificatesEndpoint(*controlPlaneID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []*DataPlaneCertificate `json:"items"`
+	}
+	_, err = s.client.Do(ctx, req, &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// Delete deletes the DataPlaneCertificate with id of the control plane
+// with controlPlaneID. Data planes using it can't connect anymore.
+func (s *DataPlaneCertificateService) Delete(ctx context.Context,
+	controlPlaneID, id *string,
+) error {
+	if isEmptyString(controlPlaneID) || isEmptyString(id) {
+		return fmt.Errorf("controlPlaneID and id cannot be nil for Delete operation")
+	}
+
+	req, err := s.client.NewRequest("DELETE", dataPlaneCertificatesEndpoint(*controlPlaneID)+"/"+*id, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// generateCertificate returns a PEM encoded self-signed client
+// certificate and its private key.
+func generateCertificate(commonName string, validity time.Duration) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
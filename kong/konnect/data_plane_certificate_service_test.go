@@ -0,0 +1,70 @@
+package konnect
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataPlaneCertificateService(t *testing.T) {
+	var registered string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v2/control-planes/cp1/dp-client-certificates":
+			var body DataPlaneCertificate
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			registered = *body.Cert
+			w.WriteHeader(http.StatusCreated)
+			b, _ := json.Marshal(map[string]interface{}{"item": map[string]interface{}{"id": "c1", "cert": registered}})
+			_, _ = w.Write(b)
+		case "GET /v2/control-planes/cp1/dp-client-certificates/c1":
+			_, _ = w.Write([]byte(`{"item":{"id":"c1","cert":"pem"}}`))
+		case "GET /v2/control-planes/cp1/dp-client-certificates":
+			_, _ = w.Write([]byte(`{"items":[{"id":"c1"},{"id":"c2"}]}`))
+		case "DELETE /v2/control-planes/cp1/dp-client-certificates/c1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(kong.String(srv.URL), "kpat_test", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	credentials, err := client.DataPlaneCertificates.Generate(ctx, kong.String("cp1"), "dp-1", 24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "c1", *credentials.Certificate.ID)
+	assert.Equal(t, string(credentials.CertPEM), registered)
+	_, err = tls.X509KeyPair(credentials.CertPEM, credentials.KeyPEM)
+	require.NoError(t, err)
+	block, _ := pem.Decode(credentials.CertPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "dp-1", cert.Subject.CommonName)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+
+	_, err = client.DataPlaneCertificates.Create(ctx, kong.String("cp1"), nil)
+	assert.Error(t, err)
+
+	certificate, err := client.DataPlaneCertificates.Get(ctx, kong.String("cp1"), kong.String("c1"))
+	require.NoError(t, err)
+	assert.Equal(t, "pem", *certificate.Cert)
+
+	certificates, err := client.DataPlaneCertificates.List(ctx, kong.String("cp1"))
+	require.NoError(t, err)
+	assert.Len(t, certificates, 2)
+
+	require.NoError(t, client.DataPlaneCertificates.Delete(ctx, kong.String("cp1"), kong.String("c1")))
+	assert.Error(t, client.DataPlaneCertificates.Delete(ctx, kong.String("cp1"), nil))
+}
@@ -0,0 +1,4 @@
+// Package konnect provides a client for the Konnect API, to manage the
+// Konnect resources around the Kong entities managed with the kong
+// package, e.g. control planes.
+package konnect
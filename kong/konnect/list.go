@@ -0,0 +1,89 @@
+package konnect
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+const defaultPageSize = 100
+
+// ListOpt aids in paginating through lists of Konnect resources.
+type ListOpt struct {
+	// PageSize is the number of resources per page.
+	PageSize int
+	// PageNumber is the number of the page to fetch, starting at 1.
+	PageNumber int
+	// Filters are added as filter[field][eq]=value query parameters.
+	Filters map[string]string
+}
+
+func (opt *ListOpt) values() url.Values {
+	q := url.Values{}
+	if opt == nil {
+		return q
+	}
+	if opt.PageSize > 0 {
+		q.Set("page[size]", strconv.Itoa(opt.PageSize))
+	}
+	if opt.PageNumber > 0 {
+		q.Set("page[number]", strconv.Itoa(opt.PageNumber))
+	}
+	for field, value := range opt.Filters {
+		q.Set("filter["+field+"][eq]", value)
+	}
+	return q
+}
+
+// PageMeta is the pagination information returned with lists.
+type PageMeta struct {
+	Number int `json:"number"`
+	Size   int `json:"size"`
+	Total  int `json:"total"`
+}
+
+type listMeta struct {
+	Page PageMeta `json:"page"`
+}
+
+// next returns the options to fetch the page following meta, or nil if
+// it was the last one.
+func (opt *ListOpt) next(meta PageMeta, count int) *ListOpt {
+	if count == 0 || meta.Size <= 0 || meta.Number*meta.Size >= meta.Total {
+		return nil
+	}
+	next := &ListOpt{PageSize: meta.Size, PageNumber: meta.Number + 1}
+	if opt != nil {
+		next.Filters = opt.Filters
+	}
+	return next
+}
+
+// list fetches the page of endpoint selected by opt, decoding its
+// resources into v, and returns the options to fetch the next page.
+func (c *Client) list(ctx context.Context, endpoint string, opt *ListOpt,
+	v interface{},
+) (*ListOpt, error) {
+	req, err := c.NewRequest("GET", endpoint, opt.values(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Data []json.RawMessage `json:"data"`
+		Meta listMeta          `json:"meta"`
+	}
+	_, err = c.Do(ctx, req, &list)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(list.Data)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, err
+	}
+	return opt.next(list.Meta.Page, len(list.Data)), nil
+}
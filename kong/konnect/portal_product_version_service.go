@@ -0,0 +1,173 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+)
+
+// AbstractPortalProductVersionService handles the publication of API
+// product versions in Konnect dev portals.
+type AbstractPortalProductVersionService interface {
+	// Publish publishes an APIProductVersion in a portal.
+	Publish(ctx context.Context, portalID *string, version *PortalProductVersion) (*PortalProductVersion, error)
+	// Get fetches the publication of an APIProductVersion in a portal.
+	Get(ctx context.Context, portalID, productVersionID *string) (*PortalProductVersion, error)
+	// Update updates the publication of an APIProductVersion in a portal.
+	Update(ctx context.Context, portalID *string, version *PortalProductVersion) (*PortalProductVersion, error)
+	// Unpublish removes an APIProductVersion from a portal.
+	Unpublish(ctx context.Context, portalID, productVersionID *string) error
+	// List fetches a list of the APIProductVersions published in a portal.
+	List(ctx context.Context, portalID *string, opt *ListOpt) ([]*PortalProductVersion, *ListOpt, error)
+	// ListAll fetches all the APIProductVersions published in a portal.
+	ListAll(ctx context.Context, portalID *string) ([]*PortalProductVersion, error)
+}
+
+// PortalProductVersionService handles the publication of API product
+// versions in Konnect dev portals.
+type PortalProductVersionService service
+
+func portalProductVersionsEndpoint(portalID string) string {
+	return "/v2/portals/" + portalID + "/product-versions"
+}
+
+// Publish publishes the APIProductVersion with version.ProductVersionID
+// in the portal with portalID. The product of the version must be
+// published to the portal, see APIProduct.PortalIDs.
+// PublishStatus defaults to PublishStatusPublished.
+func (s *PortalProductVersionService) Publish(ctx context.Context,
+	portalID *string, version *PortalProductVersion,
+) (*PortalProductVersion, error) {
+	if isEmptyString(portalID) {
+		return nil, fmt.Errorf("portalID cannot be nil for Publish operation")
+	}
+	if version == nil || isEmptyString(version.ProductVersionID) {
+		return nil, fmt.Errorf("product_version_id cannot be nil for Publish operation")
+	}
+
+	body := *version
+	if body.PublishStatus == nil {
+		publishStatus := PublishStatusPublished
+		body.PublishStatus = &publishStatus
+	}
+	req, err := s.client.NewRequest("POST", portalProductVersionsEndpoint(*portalID), nil, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var publishedVersion PortalProductVersion
+	_, err = s.client.Do(ctx, req, &publishedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &publishedVersion, nil
+}
+
+// Get fetches the publication of the APIProductVersion with
+// productVersionID in the portal with portalID.
+func (s *PortalProductVersionService) Get(ctx context.Context,
+	portalID, productVersionID *string,
+) (*PortalProductVersion, error) {
+	if isEmptyString(portalID) || isEmptyString(productVersionID) {
+		return nil, fmt.Errorf("portalID and productVersionID cannot be nil for Get operation")
+	}
+
+	endpoint := portalProductVersionsEndpoint(*portalID) + "/" + *productVersionID
+	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var version PortalProductVersion
+	_, err = s.client.Do(ctx, req, &version)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// Update updates the publication of the APIProductVersion with
+// version.ProductVersionID in the portal with portalID, e.g. to
+// deprecate it or to unpublish it while keeping its settings.
+func (s *PortalProductVersionService) Update(ctx context.Context,
+	portalID *string, version *PortalProductVersion,
+) (*PortalProductVersion, error) {
+	if isEmptyString(portalID) {
+		return nil, fmt.Errorf("portalID cannot be nil for Update operation")
+	}
+	if version == nil || isEmptyString(version.ProductVersionID) {
+		return nil, fmt.Errorf("product_version_id cannot be nil for Update operation")
+	}
+
+	// the product version ID and the read-only fields are rejected by Konnect
+	update := *version
+	update.ProductVersionID = nil
+	update.CreatedAt = nil
+	update.UpdatedAt = nil
+	endpoint := portalProductVersionsEndpoint(*portalID) + "/" + *version.ProductVersionID
+	req, err := s.client.NewRequest("PATCH", endpoint, nil, update)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedVersion PortalProductVersion
+	_, err = s.client.Do(ctx, req, &updatedVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedVersion, nil
+}
+
+// Unpublish removes the APIProductVersion with productVersionID from the
+// portal with portalID, along with its publication settings.
+func (s *PortalProductVersionService) Unpublish(ctx context.Context,
+	portalID, productVersionID *string,
+) error {
+	if isEmptyString(portalID) || isEmptyString(productVersionID) {
+		return fmt.Errorf("portalID and productVersionID cannot be nil for Unpublish operation")
+	}
+
+	endpoint := portalProductVersionsEndpoint(*portalID) + "/" + *productVersionID
+	req, err := s.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of the APIProductVersions published in the portal
+// with portalID. opt can be used to control pagination.
+func (s *PortalProductVersionService) List(ctx context.Context,
+	portalID *string, opt *ListOpt,
+) ([]*PortalProductVersion, *ListOpt, error) {
+	if isEmptyString(portalID) {
+		return nil, nil, fmt.Errorf("portalID cannot be nil for List operation")
+	}
+
+	var versions []*PortalProductVersion
+	next, err := s.client.list(ctx, portalProductVersionsEndpoint(*portalID), opt, &versions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions, next, nil
+}
+
+// ListAll fetches all the APIProductVersions published in the portal
+// with portalID.
+func (s *PortalProductVersionService) ListAll(ctx context.Context,
+	portalID *string,
+) ([]*PortalProductVersion, error) {
+	var versions, data []*PortalProductVersion
+	var err error
+	opt := &ListOpt{PageSize: defaultPageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, portalID, opt)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, data...)
+	}
+	return versions, nil
+}
@@ -0,0 +1,45 @@
+package konnect
+
+// SystemAccount represents a system account in Konnect, an identity for
+// machines such as CI pipelines.
+type SystemAccount struct {
+	ID             *string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name           *string `json:"name,omitempty" yaml:"name,omitempty"`
+	Description    *string `json:"description,omitempty" yaml:"description,omitempty"`
+	KonnectManaged *bool   `json:"konnect_managed,omitempty" yaml:"konnect_managed,omitempty"`
+	CreatedAt      *string `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt      *string `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// FriendlyName returns the endpoint key name or ID.
+func (s *SystemAccount) FriendlyName() string {
+	if s.Name != nil {
+		return *s.Name
+	}
+	if s.ID != nil {
+		return *s.ID
+	}
+	return ""
+}
+
+// SystemAccountAccessToken represents an access token of a system
+// account in Konnect. Token is only returned when the token is created.
+type SystemAccountAccessToken struct {
+	ID         *string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name       *string `json:"name,omitempty" yaml:"name,omitempty"`
+	ExpiresAt  *string `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	LastUsedAt *string `json:"last_used_at,omitempty" yaml:"last_used_at,omitempty"`
+	Token      *string `json:"token,omitempty" yaml:"token,omitempty"`
+	CreatedAt  *string `json:"created_at,omitempty" yaml:"created_at,omitempty"`
+	UpdatedAt  *string `json:"updated_at,omitempty" yaml:"updated_at,omitempty"`
+}
+
+// AssignedRole represents a role assigned to a system account in Konnect,
+// e.g. the "Admin" role on the "Control Planes" entity type.
+type AssignedRole struct {
+	ID             *string `json:"id,omitempty" yaml:"id,omitempty"`
+	RoleName       *string `json:"role_name,omitempty" yaml:"role_name,omitempty"`
+	EntityID       *string `json:"entity_id,omitempty" yaml:"entity_id,omitempty"`
+	EntityTypeName *string `json:"entity_type_name,omitempty" yaml:"entity_type_name,omitempty"`
+	EntityRegion   *string `json:"entity_region,omitempty" yaml:"entity_region,omitempty"`
+}
@@ -0,0 +1,324 @@
+package konnect
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AbstractSystemAccountService handles system accounts, their roles and
+// their access tokens in Konnect.
+type AbstractSystemAccountService interface {
+	// Create creates a SystemAccount in Konnect.
+	Create(ctx context.Context, account *SystemAccount) (*SystemAccount, error)
+	// Get fetches a SystemAccount in Konnect.
+	Get(ctx context.Context, id *string) (*SystemAccount, error)
+	// Update updates a SystemAccount in Konnect.
+	Update(ctx context.Context, account *SystemAccount) (*SystemAccount, error)
+	// Delete deletes a SystemAccount in Konnect.
+	Delete(ctx context.Context, id *string) error
+	// List fetches a list of SystemAccounts in Konnect.
+	List(ctx context.Context, opt *ListOpt) ([]*SystemAccount, *ListOpt, error)
+	// ListAll fetches all SystemAccounts in Konnect.
+	ListAll(ctx context.Context) ([]*SystemAccount, error)
+
+	// AssignRole assigns a role to a SystemAccount.
+	AssignRole(ctx context.Context, accountID *string, role *AssignedRole) (*AssignedRole, error)
+	// ListRoles fetches the roles assigned to a SystemAccount.
+	ListRoles(ctx context.Context, accountID *string) ([]*AssignedRole, error)
+	// RemoveRole removes a role from a SystemAccount.
+	RemoveRole(ctx context.Context, accountID, roleID *string) error
+
+	// CreateAccessToken creates an access token for a SystemAccount.
+	CreateAccessToken(ctx context.Context, accountID *string,
+		token *SystemAccountAccessToken) (*SystemAccountAccessToken, error)
+	// ListAccessTokens fetches the access tokens of a SystemAccount.
+	ListAccessTokens(ctx context.Context, accountID *string) ([]*SystemAccountAccessToken, error)
+	// DeleteAccessToken revokes an access token of a SystemAccount.
+	DeleteAccessToken(ctx context.Context, accountID, tokenID *string) error
+	// RotateAccessToken replaces an access token of a SystemAccount.
+	RotateAccessToken(ctx context.Context, accountID, tokenID *string,
+		ttl time.Duration) (*SystemAccountAccessToken, error)
+}
+
+// SystemAccountService handles system accounts, their roles and their
+// access tokens in Konnect.
+type SystemAccountService service
+
+const systemAccountsEndpoint = "/v3/system-accounts"
+
+// Create creates a SystemAccount in Konnect.
+func (s *SystemAccountService) Create(ctx context.Context,
+	account *SystemAccount,
+) (*SystemAccount, error) {
+	if account == nil {
+		return nil, fmt.Errorf("cannot create a nil system account")
+	}
+	if isEmptyString(account.Name) {
+		return nil, fmt.Errorf("name cannot be nil for Create operation")
+	}
+
+	var createdAccount SystemAccount
+	req, err := s.client.NewRequest("POST", systemAccountsEndpoint, nil, account)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &createdAccount); err != nil {
+		return nil, err
+	}
+	return &createdAccount, nil
+}
+
+// Get fetches a SystemAccount in Konnect.
+func (s *SystemAccountService) Get(ctx context.Context,
+	id *string,
+) (*SystemAccount, error) {
+	if isEmptyString(id) {
+		return nil, fmt.Errorf("id cannot be nil for Get operation")
+	}
+
+	var account SystemAccount
+	req, err := s.client.NewRequest("GET", systemAccountsEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// Update updates a SystemAccount in Konnect.
+func (s *SystemAccountService) Update(ctx context.Context,
+	account *SystemAccount,
+) (*SystemAccount, error) {
+	if account == nil {
+		return nil, fmt.Errorf("cannot update a nil system account")
+	}
+	if isEmptyString(account.ID) {
+		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	}
+
+	update := SystemAccount{Name: account.Name, Description: account.Description}
+	var updatedAccount SystemAccount
+	req, err := s.client.NewRequest("PATCH", systemAccountsEndpoint+"/"+*account.ID, nil, update)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &updatedAccount); err != nil {
+		return nil, err
+	}
+	return &updatedAccount, nil
+}
+
+// Delete deletes a SystemAccount in Konnect, revoking its access tokens.
+func (s *SystemAccountService) Delete(ctx context.Context,
+	id *string,
+) error {
+	if isEmptyString(id) {
+		return fmt.Errorf("id cannot be nil for Delete operation")
+	}
+	req, err := s.client.NewRequest("DELETE", systemAccountsEndpoint+"/"+*id, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// List fetches a list of SystemAccounts in Konnect.
+// opt can be used to control pagination and filter system accounts.
+func (s *SystemAccountService) List(ctx context.Context,
+	opt *ListOpt,
+) ([]*SystemAccount, *ListOpt, error) {
+	var accounts []*SystemAccount
+	next, err := s.client.list(ctx, systemAccountsEndpoint, opt, &accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accounts, next, nil
+}
+
+// ListAll fetches all SystemAccounts in Konnect.
+func (s *SystemAccountService) ListAll(ctx context.Context) ([]*SystemAccount, error) {
+	var accounts, data []*SystemAccount
+	var err error
+	opt := &ListOpt{PageSize: defaultPageSize}
+
+	for opt != nil {
+		data, opt, err = s.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, data...)
+	}
+	return accounts, nil
+}
+
+// AssignRole assigns role to the SystemAccount with accountID.
+func (s *SystemAccountService) AssignRole(ctx context.Context,
+	accountID *string, role *AssignedRole,
+) (*AssignedRole, error) {
+	if isEmptyString(accountID) {
+		return nil, fmt.Errorf("accountID cannot be nil for AssignRole operation")
+	}
+	if role == nil || isEmptyString(role.RoleName) || isEmptyString(role.EntityTypeName) {
+		return nil, fmt.Errorf("role name and entity type name cannot be nil for AssignRole operation")
+	}
+
+	var assignedRole AssignedRole
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/assigned-roles"
+	req, err := s.client.NewRequest("POST", endpoint, nil, role)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &assignedRole); err != nil {
+		return nil, err
+	}
+	return &assignedRole, nil
+}
+
+// ListRoles fetches all the roles assigned to the SystemAccount with
+// accountID.
+func (s *SystemAccountService) ListRoles(ctx context.Context,
+	accountID *string,
+) ([]*AssignedRole, error) {
+	if isEmptyString(accountID) {
+		return nil, fmt.Errorf("accountID cannot be nil for ListRoles operation")
+	}
+
+	var roles, data []*AssignedRole
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/assigned-roles"
+	opt := &ListOpt{PageSize: defaultPageSize}
+	for opt != nil {
+		var err error
+		data = nil
+		opt, err = s.client.list(ctx, endpoint, opt, &data)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, data...)
+	}
+	return roles, nil
+}
+
+// RemoveRole removes the role with roleID from the SystemAccount with
+// accountID.
+func (s *SystemAccountService) RemoveRole(ctx context.Context,
+	accountID, roleID *string,
+) error {
+	if isEmptyString(accountID) || isEmptyString(roleID) {
+		return fmt.Errorf("accountID and roleID cannot be nil for RemoveRole operation")
+	}
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/assigned-roles/" + *roleID
+	req, err := s.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// CreateAccessToken creates an access token for the SystemAccount with
+// accountID. The returned token is the only one holding the secret
+// (Token), Konnect doesn't return it afterwards.
+func (s *SystemAccountService) CreateAccessToken(ctx context.Context,
+	accountID *string, token *SystemAccountAccessToken,
+) (*SystemAccountAccessToken, error) {
+	if isEmptyString(accountID) {
+		return nil, fmt.Errorf("accountID cannot be nil for CreateAccessToken operation")
+	}
+	if token == nil || isEmptyString(token.Name) || isEmptyString(token.ExpiresAt) {
+		return nil, fmt.Errorf("name and expires_at cannot be nil for CreateAccessToken operation")
+	}
+
+	var createdToken SystemAccountAccessToken
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/access-tokens"
+	body := SystemAccountAccessToken{Name: token.Name, ExpiresAt: token.ExpiresAt}
+	req, err := s.client.NewRequest("POST", endpoint, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &createdToken); err != nil {
+		return nil, err
+	}
+	return &createdToken, nil
+}
+
+// ListAccessTokens fetches all the access tokens of the SystemAccount with
+// accountID, without their secrets.
+func (s *SystemAccountService) ListAccessTokens(ctx context.Context,
+	accountID *string,
+) ([]*SystemAccountAccessToken, error) {
+	if isEmptyString(accountID) {
+		return nil, fmt.Errorf("accountID cannot be nil for ListAccessTokens operation")
+	}
+
+	var tokens, data []*SystemAccountAccessToken
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/access-tokens"
+	opt := &ListOpt{PageSize: defaultPageSize}
+	for opt != nil {
+		var err error
+		data = nil
+		opt, err = s.client.list(ctx, endpoint, opt, &data)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, data...)
+	}
+	return tokens, nil
+}
+
+// DeleteAccessToken revokes the access token with tokenID of the
+// SystemAccount with accountID.
+func (s *SystemAccountService) DeleteAccessToken(ctx context.Context,
+	accountID, tokenID *string,
+) error {
+	if isEmptyString(accountID) || isEmptyString(tokenID) {
+		return fmt.Errorf("accountID and tokenID cannot be nil for DeleteAccessToken operation")
+	}
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/access-tokens/" + *tokenID
+	req, err := s.client.NewRequest("DELETE", endpoint, nil, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// RotateAccessToken creates a new access token with the name of the
+// token with tokenID, valid for ttl, then revokes the old token.
+// If the old token can't be revoked, the new token is returned along
+// with the error, so that it isn't lost.
+func (s *SystemAccountService) RotateAccessToken(ctx context.Context,
+	accountID, tokenID *string, ttl time.Duration,
+) (*SystemAccountAccessToken, error) {
+	if isEmptyString(accountID) || isEmptyString(tokenID) {
+		return nil, fmt.Errorf("accountID and tokenID cannot be nil for RotateAccessToken operation")
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl must be positive")
+	}
+
+	var old SystemAccountAccessToken
+	endpoint := systemAccountsEndpoint + "/" + *accountID + "/access-tokens/" + *tokenID
+	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.client.Do(ctx, req, &old); err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ttl).UTC().Format(time.RFC3339)
+	token, err := s.CreateAccessToken(ctx, accountID, &SystemAccountAccessToken{
+		Name:      old.Name,
+		ExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.DeleteAccessToken(ctx, accountID, tokenID); err != nil {
+		return token, fmt.Errorf("revoking access token %s: %w", *tokenID, err)
+	}
+	return token, nil
+}
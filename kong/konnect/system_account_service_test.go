@@ -0,0 +1,114 @@
+package konnect
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemAccountService(t *testing.T) {
+	var assigned, createdToken map[string]interface{}
+	deletedTokens := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /v3/system-accounts":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"sa1","name":"ci","konnect_managed":false}`))
+		case "GET /v3/system-accounts/sa1":
+			_, _ = w.Write([]byte(`{"id":"sa1","name":"ci"}`))
+		case "PATCH /v3/system-accounts/sa1":
+			_, _ = w.Write([]byte(`{"id":"sa1","name":"ci","description":"pipeline"}`))
+		case "DELETE /v3/system-accounts/sa1":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /v3/system-accounts":
+			_, _ = w.Write([]byte(`{"data":[{"id":"sa1"},{"id":"sa2"}],"meta":{"page":{"number":1,"size":100,"total":2}}}`))
+		case "POST /v3/system-accounts/sa1/assigned-roles":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&assigned))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"r1","role_name":"Admin","entity_id":"cp1","entity_type_name":"Control Planes"}`))
+		case "GET /v3/system-accounts/sa1/assigned-roles":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","role_name":"Admin"}],"meta":{"page":{"number":1,"size":100,"total":1}}}`))
+		case "DELETE /v3/system-accounts/sa1/assigned-roles/r1":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /v3/system-accounts/sa1/access-tokens/t1":
+			_, _ = w.Write([]byte(`{"id":"t1","name":"deploy"}`))
+		case "POST /v3/system-accounts/sa1/access-tokens":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&createdToken))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"t2","name":"deploy","token":"spat_secret"}`))
+		case "GET /v3/system-accounts/sa1/access-tokens":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t2","name":"deploy"}],"meta":{"page":{"number":1,"size":100,"total":1}}}`))
+		case "DELETE /v3/system-accounts/sa1/access-tokens/t1":
+			deletedTokens = append(deletedTokens, "t1")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(kong.String(srv.URL), "kpat_test", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	account, err := client.SystemAccounts.Create(ctx, &SystemAccount{Name: kong.String("ci")})
+	require.NoError(t, err)
+	assert.Equal(t, "sa1", *account.ID)
+	_, err = client.SystemAccounts.Create(ctx, &SystemAccount{})
+	assert.Error(t, err)
+
+	account, err = client.SystemAccounts.Get(ctx, kong.String("sa1"))
+	require.NoError(t, err)
+	assert.Equal(t, "ci", account.FriendlyName())
+
+	account, err = client.SystemAccounts.Update(ctx, &SystemAccount{
+		ID: kong.String("sa1"), Description: kong.String("pipeline"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pipeline", *account.Description)
+
+	accounts, err := client.SystemAccounts.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	role, err := client.SystemAccounts.AssignRole(ctx, kong.String("sa1"), &AssignedRole{
+		RoleName:       kong.String("Admin"),
+		EntityID:       kong.String("cp1"),
+		EntityTypeName: kong.String("Control Planes"),
+		EntityRegion:   kong.String("us"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "r1", *role.ID)
+	assert.Equal(t, "Control Planes", assigned["entity_type_name"])
+	roles, err := client.SystemAccounts.ListRoles(ctx, kong.String("sa1"))
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "Admin", *roles[0].RoleName)
+	require.NoError(t, client.SystemAccounts.RemoveRole(ctx, kong.String("sa1"), kong.String("r1")))
+
+	_, err = client.SystemAccounts.CreateAccessToken(ctx, kong.String("sa1"),
+		&SystemAccountAccessToken{Name: kong.String("deploy")})
+	assert.Error(t, err)
+
+	token, err := client.SystemAccounts.RotateAccessToken(ctx, kong.String("sa1"), kong.String("t1"), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "spat_secret", *token.Token)
+	assert.Equal(t, "deploy", createdToken["name"])
+	expiresAt, err := time.Parse(time.RFC3339, createdToken["expires_at"].(string))
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+	assert.Equal(t, []string{"t1"}, deletedTokens)
+
+	tokens, err := client.SystemAccounts.ListAccessTokens(ctx, kong.String("sa1"))
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Nil(t, tokens[0].Token)
+
+	require.NoError(t, client.SystemAccounts.Delete(ctx, kong.String("sa1")))
+}
@@ -0,0 +1,192 @@
+package kong
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the latency histograms kept by
+// the client. Slower requests fall in an implicit +Inf bucket.
+var latencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// EndpointLatency is the latency distribution of the requests made by
+// a client to an endpoint of the Admin API, measured until the response
+// headers are received.
+type EndpointLatency struct {
+	Method string
+	// Path is the path of the endpoint, relative to the workspace, with
+	// the IDs and names of entities replaced by "{}",
+	// e.g. "/services/{}/routes".
+	Path  string
+	Count int
+	Total time.Duration
+	Max   time.Duration
+	// Buckets are the number of requests which took up to the latency
+	// of the bucket with the same index in LatencyBucketBounds, the last
+	// bucket counting slower requests.
+	Buckets []int
+}
+
+// LatencyBucketBounds returns the upper bounds of the buckets of
+// EndpointLatency.Buckets.
+func LatencyBucketBounds() []time.Duration {
+	return append([]time.Duration(nil), latencyBuckets...)
+}
+
+// Mean returns the mean latency of the requests.
+func (e *EndpointLatency) Mean() time.Duration {
+	if e.Count == 0 {
+		return 0
+	}
+	return e.Total / time.Duration(e.Count)
+}
+
+// Quantile returns an upper bound of the q-quantile (e.g. 0.99) of the
+// latency of the requests, the bound of the bucket it falls in, or Max
+// if it falls in the last bucket.
+func (e *EndpointLatency) Quantile(q float64) time.Duration {
+	if e.Count == 0 {
+		return 0
+	}
+	rank := int(q * float64(e.Count))
+	if rank >= e.Count {
+		rank = e.Count - 1
+	}
+	seen := 0
+	for i, count := range e.Buckets {
+		seen += count
+		if seen > rank {
+			if i < len(latencyBuckets) {
+				return latencyBuckets[i]
+			}
+			break
+		}
+	}
+	return e.Max
+}
+
+// SlowRequest is a request which took longer than the threshold set
+// with SetSlowRequestHook.
+type SlowRequest struct {
+	Method string
+	// Path is the path of the request, relative to the workspace.
+	Path     string
+	Duration time.Duration
+	// StatusCode is the status of the response, or 0 if the request
+	// failed.
+	StatusCode int
+}
+
+type slowRequestConfig struct {
+	threshold time.Duration
+	hook      func(SlowRequest)
+}
+
+type latencyRecorder struct {
+	mu        sync.Mutex
+	endpoints map[[2]string]*EndpointLatency
+}
+
+// SetSlowRequestHook sets a function called after each request taking
+// longer than threshold, e.g. to log the Admin API calls slowing down a
+// reconciliation loop. A nil hook removes the hook.
+// The hook is called synchronously, before the response is processed.
+func (c *Client) SetSlowRequestHook(threshold time.Duration, hook func(SlowRequest)) {
+	if hook == nil {
+		c.slowRequest.Store((*slowRequestConfig)(nil))
+		return
+	}
+	c.slowRequest.Store(&slowRequestConfig{threshold: threshold, hook: hook})
+}
+
+// LatencyStats returns a snapshot of the latency distributions of the
+// requests made by the client, per method and endpoint, sorted by
+// decreasing total time, so that the endpoints dominating the time spent
+// talking to Kong come first.
+func (c *Client) LatencyStats() []EndpointLatency {
+	c.latency.mu.Lock()
+	defer c.latency.mu.Unlock()
+	stats := make([]EndpointLatency, 0, len(c.latency.endpoints))
+	for _, e := range c.latency.endpoints {
+		stat := *e
+		stat.Buckets = append([]int(nil), e.Buckets...)
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Total != stats[j].Total {
+			return stats[i].Total > stats[j].Total
+		}
+		return stats[i].Method+stats[i].Path < stats[j].Method+stats[j].Path
+	})
+	return stats
+}
+
+// ResetLatencyStats clears the latency distributions of the client.
+func (c *Client) ResetLatencyStats() {
+	c.latency.mu.Lock()
+	defer c.latency.mu.Unlock()
+	c.latency.endpoints = nil
+}
+
+// recordLatency records the latency of req, answered with resp (nil if
+// the request failed), and reports it if it is slow.
+func (c *Client) recordLatency(req *http.Request, resp *http.Response, d time.Duration) {
+	path := c.relativePath(req)
+	key := [2]string{req.Method, endpointTemplate(path)}
+
+	c.latency.mu.Lock()
+	if c.latency.endpoints == nil {
+		c.latency.endpoints = map[[2]string]*EndpointLatency{}
+	}
+	e := c.latency.endpoints[key]
+	if e == nil {
+		e = &EndpointLatency{
+			Method:  key[0],
+			Path:    key[1],
+			Buckets: make([]int, len(latencyBuckets)+1),
+		}
+		c.latency.endpoints[key] = e
+	}
+	e.Count++
+	e.Total += d
+	if d > e.Max {
+		e.Max = d
+	}
+	e.Buckets[sort.Search(len(latencyBuckets), func(i int) bool { return d <= latencyBuckets[i] })]++
+	c.latency.mu.Unlock()
+
+	config, _ := c.slowRequest.Load().(*slowRequestConfig)
+	if config == nil || d <= config.threshold {
+		return
+	}
+	slow := SlowRequest{Method: req.Method, Path: path, Duration: d}
+	if resp != nil {
+		slow.StatusCode = resp.StatusCode
+	}
+	config.hook(slow)
+}
+
+// endpointTemplate replaces the IDs and names of entities in path, found
+// after collection names, with "{}".
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 1; i < len(segments); i += 2 {
+		segments[i] = "{}"
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/team-a/services/slow" {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"id":"s1"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team-a")
+
+	var slow []SlowRequest
+	client.SetSlowRequestHook(20*time.Millisecond, func(r SlowRequest) {
+		slow = append(slow, r)
+	})
+
+	for _, id := range []string{"a", "b", "slow"} {
+		_, err := client.Services.Get(defaultCtx, String(id))
+		require.NoError(t, err)
+	}
+	_, err = client.Routes.Get(defaultCtx, String("r1"))
+	require.NoError(t, err)
+
+	require.Len(t, slow, 1)
+	assert.Equal(t, "GET", slow[0].Method)
+	assert.Equal(t, "/services/slow", slow[0].Path)
+	assert.Equal(t, http.StatusOK, slow[0].StatusCode)
+	assert.GreaterOrEqual(t, slow[0].Duration, 30*time.Millisecond)
+
+	stats := client.LatencyStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "/services/{}", stats[0].Path)
+	assert.Equal(t, 3, stats[0].Count)
+	assert.GreaterOrEqual(t, stats[0].Max, 30*time.Millisecond)
+	assert.Equal(t, stats[0].Total/3, stats[0].Mean())
+	assert.Len(t, stats[0].Buckets, len(LatencyBucketBounds())+1)
+	assert.GreaterOrEqual(t, stats[0].Quantile(0.99), 50*time.Millisecond)
+	assert.Equal(t, "/routes/{}", stats[1].Path)
+
+	client.ResetLatencyStats()
+	assert.Empty(t, client.LatencyStats())
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	assert.Equal(t, "/services", endpointTemplate("/services"))
+	assert.Equal(t, "/services/{}/routes/{}", endpointTemplate("/services/foo/routes/bar"))
+	assert.Equal(t, "/", endpointTemplate("/"))
+}
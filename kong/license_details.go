@@ -0,0 +1,144 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const licenseDateLayout = "2006-01-02"
+
+// LicenseDetails is the content of the payload of a Kong Enterprise
+// license.
+type LicenseDetails struct {
+	Customer            string
+	LicenseKey          string
+	ProductSubscription string
+	SupportPlan         string
+	// AdminSeats and DataPlanes are the numbers of admins and data planes
+	// the license allows, zero if unlimited or not set.
+	AdminSeats int
+	DataPlanes int
+	CreatedAt  time.Time
+	// ExpiresAt is the end of the last day the license is valid, in UTC.
+	ExpiresAt time.Time
+}
+
+// ExpiresWithin returns true if the license expires before now+d,
+// including when it is already expired.
+func (d *LicenseDetails) ExpiresWithin(now time.Time, within time.Duration) bool {
+	return !d.ExpiresAt.After(now.Add(within))
+}
+
+// licenseCount decodes the seat counts of licenses, which Kong sends as
+// strings.
+type licenseCount int
+
+func (c *licenseCount) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n int
+		if err := json.Unmarshal(b, &n); err != nil {
+			return err
+		}
+		*c = licenseCount(n)
+		return nil
+	}
+	if s == "" {
+		*c = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*c = licenseCount(n)
+	return nil
+}
+
+// Details decodes the payload of the license.
+func (l *License) Details() (*LicenseDetails, error) {
+	if l == nil || isEmptyString(l.Payload) {
+		return nil, fmt.Errorf("license has no payload")
+	}
+	var data struct {
+		License struct {
+			Payload struct {
+				AdminSeats          licenseCount `json:"admin_seats"`
+				Customer            string       `json:"customer"`
+				DataPlanes          licenseCount `json:"dataplanes"`
+				CreationDate        string       `json:"license_creation_date"`
+				ExpirationDate      string       `json:"license_expiration_date"`
+				LicenseKey          string       `json:"license_key"`
+				ProductSubscription string       `json:"product_subscription"`
+				SupportPlan         string       `json:"support_plan"`
+			} `json:"payload"`
+		} `json:"license"`
+	}
+	if err := json.Unmarshal([]byte(*l.Payload), &data); err != nil {
+		return nil, fmt.Errorf("decoding license payload: %w", err)
+	}
+	payload := data.License.Payload
+	expiration, err := time.Parse(licenseDateLayout, payload.ExpirationDate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing license expiration date: %w", err)
+	}
+	details := &LicenseDetails{
+		Customer:            payload.Customer,
+		LicenseKey:          payload.LicenseKey,
+		ProductSubscription: payload.ProductSubscription,
+		SupportPlan:         payload.SupportPlan,
+		AdminSeats:          int(payload.AdminSeats),
+		DataPlanes:          int(payload.DataPlanes),
+		ExpiresAt:           expiration.AddDate(0, 0, 1),
+	}
+	if payload.CreationDate != "" {
+		details.CreatedAt, err = time.Parse(licenseDateLayout, payload.CreationDate)
+		if err != nil {
+			return nil, fmt.Errorf("parsing license creation date: %w", err)
+		}
+	}
+	return details, nil
+}
+
+// GetActive fetches the license Kong uses, the one expiring last, along
+// with its details. It returns a 404 APIError if there is no license.
+func (s *LicenseService) GetActive(ctx context.Context) (*License, *LicenseDetails, error) {
+	licenses, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		active  *License
+		details *LicenseDetails
+	)
+	for _, license := range licenses {
+		d, err := license.Details()
+		if err != nil {
+			return nil, nil, fmt.Errorf("license %s: %w", license.FriendlyName(), err)
+		}
+		if details == nil || d.ExpiresAt.After(details.ExpiresAt) {
+			active, details = license, d
+		}
+	}
+	if active == nil {
+		return nil, nil, NewAPIError(http.StatusNotFound, "no license found")
+	}
+	return active, details, nil
+}
+
+// CheckExpiring returns true, along with the details of the active
+// license, if the license expires within the given duration or is
+// already expired.
+func (s *LicenseService) CheckExpiring(ctx context.Context,
+	within time.Duration,
+) (bool, *LicenseDetails, error) {
+	_, details, err := s.GetActive(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	return details.ExpiresWithin(time.Now(), within), details, nil
+}
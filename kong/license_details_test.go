@@ -0,0 +1,79 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLicensePayload(expiration string) string {
+	return `{"license":{"version":1,"signature":"sig","payload":{"admin_seats":"5",` +
+		`"customer":"Acme","dataplanes":"10","license_creation_date":"2023-01-01",` +
+		`"license_expiration_date":"` + expiration + `","license_key":"key",` +
+		`"product_subscription":"Konnect Enterprise","support_plan":"None"}}}`
+}
+
+func TestLicenseDetails(t *testing.T) {
+	license := &License{Payload: String(testLicensePayload("2024-01-31"))}
+	details, err := license.Details()
+	require.NoError(t, err)
+	assert.Equal(t, &LicenseDetails{
+		Customer:            "Acme",
+		LicenseKey:          "key",
+		ProductSubscription: "Konnect Enterprise",
+		SupportPlan:         "None",
+		AdminSeats:          5,
+		DataPlanes:          10,
+		CreatedAt:           time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		ExpiresAt:           time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, details)
+
+	lastDay := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	assert.False(t, details.ExpiresWithin(lastDay, time.Hour))
+	assert.True(t, details.ExpiresWithin(lastDay, 24*time.Hour))
+	assert.True(t, details.ExpiresWithin(lastDay.AddDate(0, 1, 0), 0))
+
+	_, err = (&License{}).Details()
+	assert.Error(t, err)
+	_, err = (&License{Payload: String(`{"license":{"payload":{}}}`)}).Details()
+	assert.Error(t, err)
+}
+
+func TestLicenseServiceCheckExpiring(t *testing.T) {
+	licenses := []*License{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/licenses", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": licenses})
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, _, err = client.Licenses.GetActive(defaultCtx)
+	assert.True(t, IsNotFoundErr(err))
+
+	soon := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	later := time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+	licenses = []*License{
+		{ID: String("l1"), Payload: String(testLicensePayload(soon))},
+		{ID: String("l2"), Payload: String(testLicensePayload(later))},
+	}
+	license, _, err := client.Licenses.GetActive(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "l2", *license.ID)
+
+	expiring, details, err := client.Licenses.CheckExpiring(defaultCtx, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.False(t, expiring)
+	assert.Equal(t, "Acme", details.Customer)
+
+	licenses = licenses[:1]
+	expiring, _, err = client.Licenses.CheckExpiring(defaultCtx, 30*24*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, expiring)
+}
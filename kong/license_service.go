@@ -2,8 +2,8 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 )
 
 // AbstractLicenseService handles Licenses in Kong.
@@ -20,6 +20,10 @@ type AbstractLicenseService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*License, *ListOpt, error)
 	// ListAll fetches all Licenses in Kong.
 	ListAll(ctx context.Context) ([]*License, error)
+	// GetActive fetches the License used by Kong along with its details.
+	GetActive(ctx context.Context) (*License, *LicenseDetails, error)
+	// CheckExpiring tells whether the License used by Kong expires soon.
+	CheckExpiring(ctx context.Context, within time.Duration) (bool, *LicenseDetails, error)
 }
 
 // LicenseService handles Licenses in Kong.
@@ -138,7 +142,7 @@ func (s *LicenseService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var license License
-		err = json.Unmarshal(b, &license)
+		err = s.client.decode(b, &license)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"net/url"
+	"strconv"
 )
 
 // ListOpt aids in paginating through list endpoints
 type ListOpt struct {
 	// Size of the page
 	Size int `url:"size,omitempty"`
-	// Offset for the current page
+	// Offset for the current page. In Konnect mode, it is the number of
+	// the page, see Client.SetKonnectMode.
 	Offset string `url:"offset,omitempty"`
 
 	// Tags to use for filtering the list.
@@ -29,19 +32,47 @@ type qs struct {
 	Tags   string `url:"tags,omitempty"`
 }
 
+// konnectQS is used to construct query string for list endpoints
+// in Konnect mode
+type konnectQS struct {
+	PageSize   int    `url:"page[size],omitempty"`
+	PageNumber string `url:"page[number],omitempty"`
+	Tags       string `url:"tags,omitempty"`
+}
+
+// konnectPage is the pagination information of lists in Konnect mode
+type konnectPage struct {
+	Number int `json:"number"`
+	Size   int `json:"size"`
+	Total  int `json:"total"`
+}
+
 // list fetches a list of an entity in Kong.
 // opt can be used to control pagination.
 func (c *Client) list(ctx context.Context,
 	endpoint string, opt *ListOpt,
 ) ([]json.RawMessage, *ListOpt, error) {
-	q := constructQueryString(opt)
-	req, err := c.NewRequest("GET", endpoint, &q, nil)
+	var q interface{}
+	if c.IsKonnectMode() {
+		konnectQ := constructKonnectQueryString(opt)
+		q = &konnectQ
+	} else {
+		kongQ := constructQueryString(opt)
+		q = &kongQ
+	}
+	req, err := c.NewRequest("GET", endpoint, q, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 	var list struct {
 		Data []json.RawMessage `json:"data"`
 		Next *string           `json:"offset"`
+		// NextPage is the path of the next page, carrying the offset
+		// when some endpoints leave the offset out
+		NextPage *string `json:"next"`
+		Meta     struct {
+			Page *konnectPage `json:"page"`
+		} `json:"meta"`
 	}
 
 	_, err = c.Do(ctx, req, &list)
@@ -49,6 +80,22 @@ func (c *Client) list(ctx context.Context,
 		return nil, nil, err
 	}
 
+	if list.Next == nil && list.NextPage != nil {
+		if next, err := url.Parse(*list.NextPage); err == nil && next.Query().Get("offset") != "" {
+			offset := next.Query().Get("offset")
+			list.Next = &offset
+		}
+	}
+
+	// Konnect paginates with page numbers, which are passed around as
+	// offsets so that callers don't have to care
+	page := list.Meta.Page
+	if list.Next == nil && page != nil && len(list.Data) > 0 &&
+		page.Size > 0 && page.Number*page.Size < page.Total {
+		nextPage := strconv.Itoa(page.Number + 1)
+		list.Next = &nextPage
+	}
+
 	// convinient for end user to use this opt till it's nil
 	var next *ListOpt
 	if list.Next != nil {
@@ -65,6 +112,11 @@ func (c *Client) list(ctx context.Context,
 	return list.Data, next, nil
 }
 
+func constructKonnectQueryString(opt *ListOpt) konnectQS {
+	q := constructQueryString(opt)
+	return konnectQS{PageSize: q.Size, PageNumber: q.Offset, Tags: q.Tags}
+}
+
 func constructQueryString(opt *ListOpt) qs {
 	var q qs
 	if opt == nil {
@@ -1,8 +1,13 @@
 package kong
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_constructQueryString(t *testing.T) {
@@ -54,3 +59,58 @@ func Test_constructQueryString(t *testing.T) {
 		})
 	}
 }
+
+func TestListKonnectMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Empty(t, q.Get("offset"))
+		assert.Equal(t, "2", q.Get("page[size]"))
+		switch q.Get("page[number]") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s1"},{"id":"s2"}],"meta":{"page":{"number":1,"size":2,"total":3}}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s3"}],"meta":{"page":{"number":2,"size":2,"total":3}}}`))
+		default:
+			t.Errorf("unexpected page %q", q.Get("page[number]"))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetKonnectMode(true)
+	assert.True(t, client.IsKonnectMode())
+
+	services, next, err := client.Services.List(defaultCtx, &ListOpt{Size: 2})
+	require.NoError(t, err)
+	assert.Len(t, services, 2)
+	require.NotNil(t, next)
+	assert.Equal(t, "2", next.Offset)
+	assert.Equal(t, 2, next.Size)
+
+	services, next, err = client.Services.List(defaultCtx, next)
+	require.NoError(t, err)
+	assert.Len(t, services, 1)
+	assert.Nil(t, next)
+}
+
+func TestListNextPage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s1"}],"next":"/services?offset=abc%3D&size=1"}`))
+		case "abc=":
+			_, _ = w.Write([]byte(`{"data":[{"id":"s2"}],"next":null}`))
+		default:
+			t.Errorf("unexpected offset %q", r.URL.Query().Get("offset"))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetStrictDecoding(true)
+
+	services, err := client.Services.ListAll(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	assert.Equal(t, "s2", *services[1].ID)
+}
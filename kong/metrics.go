@@ -0,0 +1,200 @@
+package kong
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MetricSample is a sample of the Prometheus metrics exposed by Kong.
+type MetricSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Metrics are the Prometheus metrics exposed by a Kong node.
+type Metrics struct {
+	Samples []MetricSample
+}
+
+// SharedDictUsage is the memory usage of a Lua shared dictionary of Kong.
+type SharedDictUsage struct {
+	Name       string
+	Subsystem  string
+	UsedBytes  float64
+	TotalBytes float64
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format.
+// Comments, including HELP and TYPE lines, are ignored.
+func ParseMetrics(r io.Reader) (*Metrics, error) {
+	metrics := &Metrics{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sample, err := parseMetricSample(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		metrics.Samples = append(metrics.Samples, sample)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return metrics, nil
+}
+
+func parseMetricSample(text string) (MetricSample, error) {
+	sample := MetricSample{Labels: map[string]string{}}
+	end := strings.IndexAny(text, "{ \t")
+	if end <= 0 {
+		return sample, fmt.Errorf("invalid sample %q", text)
+	}
+	sample.Name, text = text[:end], text[end:]
+
+	if strings.HasPrefix(text, "{") {
+		text = text[1:]
+		for {
+			text = strings.TrimLeft(text, " \t,")
+			if strings.HasPrefix(text, "}") {
+				text = text[1:]
+				break
+			}
+			eq := strings.Index(text, "=\"")
+			if eq <= 0 {
+				return sample, fmt.Errorf("invalid labels of %s", sample.Name)
+			}
+			name := strings.TrimSpace(text[:eq])
+			value, rest, err := parseLabelValue(text[eq+2:])
+			if err != nil {
+				return sample, fmt.Errorf("label %s of %s: %w", name, sample.Name, err)
+			}
+			sample.Labels[name] = value
+			text = rest
+		}
+	}
+
+	// the value can be followed by a timestamp
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return sample, fmt.Errorf("missing value of %s", sample.Name)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, fmt.Errorf("invalid value of %s: %w", sample.Name, err)
+	}
+	sample.Value = value
+	return sample, nil
+}
+
+// parseLabelValue parses a label value up to its closing quote and
+// returns the remaining text.
+func parseLabelValue(text string) (string, string, error) {
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"':
+			return b.String(), text[i+1:], nil
+		case '\\':
+			i++
+			if i == len(text) {
+				break
+			}
+			switch text[i] {
+			case 'n':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(text[i])
+			}
+		default:
+			b.WriteByte(text[i])
+		}
+	}
+	return "", "", fmt.Errorf("unterminated value")
+}
+
+// Find returns the samples of the metric name which have all the labels.
+func (m *Metrics) Find(name string, labels map[string]string) []MetricSample {
+	var samples []MetricSample
+	for _, sample := range m.Samples {
+		if sample.Name != name {
+			continue
+		}
+		match := true
+		for k, v := range labels {
+			if sample.Labels[k] != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// Value returns the sum of the values of the samples Find returns, and
+// false if there are none.
+func (m *Metrics) Value(name string, labels map[string]string) (float64, bool) {
+	samples := m.Find(name, labels)
+	var value float64
+	for _, sample := range samples {
+		value += sample.Value
+	}
+	return value, len(samples) > 0
+}
+
+// Connections returns the number of connections of Nginx by state
+// (e.g. "active", "reading", "writing", "waiting", "accepted",
+// "handled", "total"), from kong_nginx_connections_total or, for Kong
+// 2.x, kong_nginx_http_current_connections.
+func (m *Metrics) Connections() map[string]float64 {
+	connections := map[string]float64{}
+	for _, name := range []string{"kong_nginx_connections_total", "kong_nginx_http_current_connections"} {
+		for _, sample := range m.Find(name, nil) {
+			connections[sample.Labels["state"]] += sample.Value
+		}
+		if len(connections) > 0 {
+			break
+		}
+	}
+	return connections
+}
+
+// DatastoreReachable returns whether Kong can reach its database, and
+// false as second value if Kong doesn't report it (e.g. in DB-less mode).
+func (m *Metrics) DatastoreReachable() (bool, bool) {
+	value, ok := m.Value("kong_datastore_reachable", nil)
+	return value == 1, ok
+}
+
+// SharedDicts returns the usage of the Lua shared dictionaries of Kong.
+func (m *Metrics) SharedDicts() []SharedDictUsage {
+	var usages []SharedDictUsage
+	index := map[[2]string]int{}
+	usage := func(sample MetricSample) *SharedDictUsage {
+		key := [2]string{sample.Labels["shared_dict"], sample.Labels["kong_subsystem"]}
+		i, ok := index[key]
+		if !ok {
+			i = len(usages)
+			index[key] = i
+			usages = append(usages, SharedDictUsage{Name: key[0], Subsystem: key[1]})
+		}
+		return &usages[i]
+	}
+	for _, sample := range m.Find("kong_memory_lua_shared_dict_bytes", nil) {
+		usage(sample).UsedBytes = sample.Value
+	}
+	for _, sample := range m.Find("kong_memory_lua_shared_dict_total_bytes", nil) {
+		usage(sample).TotalBytes = sample.Value
+	}
+	return usages
+}
@@ -0,0 +1,87 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP kong_datastore_reachable Datastore reachable from Kong, 0 is unreachable
+# TYPE kong_datastore_reachable gauge
+kong_datastore_reachable 1
+# HELP kong_memory_lua_shared_dict_bytes Allocated slabs in bytes in a shared_dict
+# TYPE kong_memory_lua_shared_dict_bytes gauge
+kong_memory_lua_shared_dict_bytes{node_id="n1",shared_dict="kong",kong_subsystem="http"} 40960
+kong_memory_lua_shared_dict_bytes{node_id="n1",shared_dict="kong_db_cache",kong_subsystem="http"} 802816
+kong_memory_lua_shared_dict_total_bytes{node_id="n1",shared_dict="kong",kong_subsystem="http"} 5242880
+kong_memory_lua_shared_dict_total_bytes{node_id="n1",shared_dict="kong_db_cache",kong_subsystem="http"} 134217728
+# TYPE kong_nginx_connections_total gauge
+kong_nginx_connections_total{node_id="n1",subsystem="http",state="active"} 3
+kong_nginx_connections_total{node_id="n1",subsystem="http",state="accepted"} 120 1700000000000
+kong_nginx_connections_total{node_id="n1",subsystem="stream",state="active"} 1
+kong_http_requests_total{service="a \"quoted\" name",route="r\\1",code="200"} 7
+kong_latency_bucket{type="kong",le="+Inf"} NaN
+`
+
+func TestParseMetrics(t *testing.T) {
+	metrics, err := ParseMetrics(strings.NewReader(testMetrics))
+	require.NoError(t, err)
+	require.Len(t, metrics.Samples, 10)
+
+	reachable, ok := metrics.DatastoreReachable()
+	assert.True(t, ok)
+	assert.True(t, reachable)
+
+	assert.Equal(t, map[string]float64{"active": 4, "accepted": 120}, metrics.Connections())
+
+	assert.Equal(t, []SharedDictUsage{
+		{Name: "kong", Subsystem: "http", UsedBytes: 40960, TotalBytes: 5242880},
+		{Name: "kong_db_cache", Subsystem: "http", UsedBytes: 802816, TotalBytes: 134217728},
+	}, metrics.SharedDicts())
+
+	samples := metrics.Find("kong_http_requests_total", map[string]string{"code": "200"})
+	require.Len(t, samples, 1)
+	assert.Equal(t, `a "quoted" name`, samples[0].Labels["service"])
+	assert.Equal(t, `r\1`, samples[0].Labels["route"])
+	value, ok := metrics.Value("kong_nginx_connections_total", map[string]string{"subsystem": "http"})
+	assert.True(t, ok)
+	assert.Equal(t, float64(123), value)
+	_, ok = metrics.Value("kong_missing", nil)
+	assert.False(t, ok)
+
+	_, err = ParseMetrics(strings.NewReader(`kong_broken{label="value} 1`))
+	assert.Error(t, err)
+	_, err = ParseMetrics(strings.NewReader(`kong_broken one`))
+	assert.Error(t, err)
+}
+
+func TestStatusServiceMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/metrics", r.URL.Path)
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String("http://localhost:1"), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team-a")
+	status, err := NewStatusService(client, srv.URL)
+	require.NoError(t, err)
+
+	metrics, err := status.Metrics(defaultCtx)
+	require.NoError(t, err)
+	assert.Len(t, metrics.Samples, 10)
+}
+
+func TestNewStatusService(t *testing.T) {
+	client, err := NewClient(nil, nil)
+	require.NoError(t, err)
+	_, err = NewStatusService(client, "localhost")
+	assert.Error(t, err)
+	_, err = NewStatusService(nil, "http://localhost:8007")
+	assert.Error(t, err)
+}
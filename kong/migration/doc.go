@@ -0,0 +1,12 @@
+// Package migration copies the entities of a Kong to another one, e.g.
+// from a cluster to another, from Kong OSS to Kong Enterprise or from a
+// self-managed Kong to a Konnect control plane, with the clients of the
+// kong package.
+//
+// Migrations are paced, report the entities the destination rejects and
+// can be resumed from checkpoints after being interrupted.
+//
+// MigratePlugin rewrites the configuration of plugins across the breaking
+// changes made by versions of Kong, so that plugins dumped from a version
+// can be applied to another one.
+package migration
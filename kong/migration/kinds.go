@@ -0,0 +1,271 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// kind is a kind of entities copied by Migrate. Entities are handled in
+// their JSON form so that references can be rewritten generically.
+type kind struct {
+	name string
+	list func(ctx context.Context, c *kong.Client,
+		opt *kong.ListOpt) ([]map[string]interface{}, *kong.ListOpt, error)
+	// create creates an entity and returns its ID.
+	create func(ctx context.Context, c *kong.Client, entity map[string]interface{}) (string, error)
+	// prepare, if set, adapts entities before they are created.
+	prepare func(entity map[string]interface{})
+	// after, if set, copies the entities depending on a copied entity.
+	after func(ctx context.Context, m *migrator, sourceID, destinationID string) error
+	// unsupported, if set, is why entities of the kind aren't copied.
+	unsupported string
+}
+
+// kinds are the kinds of entities copied by Migrate, in dependency order.
+var kinds = []kind{
+	newKind("certificates",
+		func(c *kong.Client) lister[kong.Certificate] { return c.Certificates.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Certificate) (*kong.Certificate, error) {
+			return c.Certificates.Create(ctx, e)
+		}).
+		// SNIs are copied as entities of their own
+		withPrepare(func(entity map[string]interface{}) { delete(entity, "snis") }),
+	newKind("ca_certificates",
+		func(c *kong.Client) lister[kong.CACertificate] { return c.CACertificates.List },
+		func(ctx context.Context, c *kong.Client, e *kong.CACertificate) (*kong.CACertificate, error) {
+			return c.CACertificates.Create(ctx, e)
+		}),
+	newKind("snis",
+		func(c *kong.Client) lister[kong.SNI] { return c.SNIs.List },
+		func(ctx context.Context, c *kong.Client, e *kong.SNI) (*kong.SNI, error) {
+			return c.SNIs.Create(ctx, e)
+		}),
+	newKind("services",
+		func(c *kong.Client) lister[kong.Service] { return c.Services.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Service) (*kong.Service, error) {
+			return c.Services.Create(ctx, e)
+		}),
+	newKind("routes",
+		func(c *kong.Client) lister[kong.Route] { return c.Routes.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Route) (*kong.Route, error) {
+			return c.Routes.Create(ctx, e)
+		}),
+	newKind("upstreams",
+		func(c *kong.Client) lister[kong.Upstream] { return c.Upstreams.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Upstream) (*kong.Upstream, error) {
+			return c.Upstreams.Create(ctx, e)
+		}).
+		withAfter(copyTargets),
+	newKind("consumers",
+		func(c *kong.Client) lister[kong.Consumer] { return c.Consumers.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Consumer) (*kong.Consumer, error) {
+			return c.Consumers.Create(ctx, e)
+		}),
+	newKind("consumer_groups",
+		func(c *kong.Client) lister[kong.ConsumerGroup] { return c.ConsumerGroups.List },
+		func(ctx context.Context, c *kong.Client, e *kong.ConsumerGroup) (*kong.ConsumerGroup, error) {
+			return c.ConsumerGroups.Create(ctx, e)
+		}).
+		withAfter(copyConsumerGroupConsumers),
+	newKind("plugins",
+		func(c *kong.Client) lister[kong.Plugin] { return c.Plugins.List },
+		func(ctx context.Context, c *kong.Client, e *kong.Plugin) (*kong.Plugin, error) {
+			return c.Plugins.Create(ctx, e)
+		}),
+	newCredentialKind("key-auths",
+		func(c *kong.Client) lister[kong.KeyAuth] { return c.KeyAuths.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.KeyAuth) (*kong.KeyAuth, error) {
+			return c.KeyAuths.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("jwts",
+		func(c *kong.Client) lister[kong.JWTAuth] { return c.JWTAuths.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.JWTAuth) (*kong.JWTAuth, error) {
+			return c.JWTAuths.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("hmac-auths",
+		func(c *kong.Client) lister[kong.HMACAuth] { return c.HMACAuths.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.HMACAuth) (*kong.HMACAuth, error) {
+			return c.HMACAuths.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("acls",
+		func(c *kong.Client) lister[kong.ACLGroup] { return c.ACLs.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.ACLGroup) (*kong.ACLGroup, error) {
+			return c.ACLs.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("oauth2",
+		func(c *kong.Client) lister[kong.Oauth2Credential] { return c.Oauth2Credentials.List },
+		func(ctx context.Context, c *kong.Client, consumer *string,
+			e *kong.Oauth2Credential,
+		) (*kong.Oauth2Credential, error) {
+			return c.Oauth2Credentials.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("mtls-auths",
+		func(c *kong.Client) lister[kong.MTLSAuth] { return c.MTLSAuths.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.MTLSAuth) (*kong.MTLSAuth, error) {
+			return c.MTLSAuths.Create(ctx, consumer, e)
+		}),
+	newCredentialKind("basic-auths",
+		func(c *kong.Client) lister[kong.BasicAuth] { return c.BasicAuths.List },
+		func(ctx context.Context, c *kong.Client, consumer *string, e *kong.BasicAuth) (*kong.BasicAuth, error) {
+			return c.BasicAuths.Create(ctx, consumer, e)
+		}).
+		withUnsupported("basic-auth passwords are hashed by Kong and can't be copied"),
+}
+
+type lister[T any] func(ctx context.Context, opt *kong.ListOpt) ([]*T, *kong.ListOpt, error)
+
+func newKind[T any](name string, list func(c *kong.Client) lister[T],
+	create func(ctx context.Context, c *kong.Client, entity *T) (*T, error),
+) kind {
+	return kind{
+		name: name,
+		list: func(ctx context.Context, c *kong.Client,
+			opt *kong.ListOpt,
+		) ([]map[string]interface{}, *kong.ListOpt, error) {
+			entities, next, err := list(c)(ctx, opt)
+			if err != nil {
+				return nil, nil, err
+			}
+			var converted []map[string]interface{}
+			if err := convert(entities, &converted); err != nil {
+				return nil, nil, err
+			}
+			return converted, next, nil
+		},
+		create: func(ctx context.Context, c *kong.Client, entity map[string]interface{}) (string, error) {
+			var e T
+			if err := convert(entity, &e); err != nil {
+				return "", err
+			}
+			created, err := create(ctx, c, &e)
+			if err != nil {
+				return "", err
+			}
+			return createdID(created)
+		},
+	}
+}
+
+// newCredentialKind returns the kind of credentials, created for their
+// consumer.
+func newCredentialKind[T any](name string, list func(c *kong.Client) lister[T],
+	create func(ctx context.Context, c *kong.Client, consumer *string, entity *T) (*T, error),
+) kind {
+	return newKind(name, list, func(ctx context.Context, c *kong.Client, e *T) (*T, error) {
+		var credential struct {
+			Consumer *kong.Consumer `json:"consumer"`
+		}
+		if err := convert(e, &credential); err != nil {
+			return nil, err
+		}
+		if credential.Consumer == nil || credential.Consumer.ID == nil {
+			return nil, fmt.Errorf("credential has no consumer")
+		}
+		return create(ctx, c, credential.Consumer.ID, e)
+	})
+}
+
+func (k kind) withPrepare(prepare func(entity map[string]interface{})) kind {
+	k.prepare = prepare
+	return k
+}
+
+func (k kind) withAfter(after func(ctx context.Context, m *migrator, sourceID, destinationID string) error) kind {
+	k.after = after
+	return k
+}
+
+func (k kind) withUnsupported(reason string) kind {
+	k.unsupported = reason
+	return k
+}
+
+func createdID(created interface{}) (string, error) {
+	var entity struct {
+		ID *string `json:"id"`
+	}
+	if err := convert(created, &entity); err != nil {
+		return "", err
+	}
+	if entity.ID == nil {
+		return "", fmt.Errorf("created entity has no ID")
+	}
+	return *entity.ID, nil
+}
+
+var targets = kind{
+	name: "targets",
+	create: func(ctx context.Context, c *kong.Client, entity map[string]interface{}) (string, error) {
+		var target kong.Target
+		if err := convert(entity, &target); err != nil {
+			return "", err
+		}
+		if target.Upstream == nil || target.Upstream.ID == nil {
+			return "", fmt.Errorf("target has no upstream")
+		}
+		created, err := c.Targets.Create(ctx, target.Upstream.ID, &target)
+		if err != nil {
+			return "", err
+		}
+		return createdID(created)
+	},
+}
+
+// copyTargets copies the targets of an upstream.
+func copyTargets(ctx context.Context, m *migrator, sourceID, _ string) error {
+	if err := m.wait(ctx); err != nil {
+		return err
+	}
+	sourceTargets, err := m.source.Targets.ListAll(ctx, &sourceID)
+	if err != nil {
+		return fmt.Errorf("listing targets of upstream %s: %w", sourceID, err)
+	}
+	var entities []map[string]interface{}
+	if err := convert(sourceTargets, &entities); err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		if err := m.copyEntity(ctx, targets, entity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyConsumerGroupConsumers adds the consumers of a consumer group to
+// the copied group.
+func copyConsumerGroupConsumers(ctx context.Context, m *migrator, sourceID, destinationID string) error {
+	const name = "consumer_group_consumers"
+	if err := m.wait(ctx); err != nil {
+		return err
+	}
+	group, err := m.source.ConsumerGroups.Get(ctx, &sourceID)
+	if err != nil {
+		return fmt.Errorf("fetching consumer group %s: %w", sourceID, err)
+	}
+	for _, consumer := range group.Consumers {
+		if consumer == nil || consumer.ID == nil {
+			continue
+		}
+		if err := m.wait(ctx); err != nil {
+			return err
+		}
+		consumerID := m.destinationID(*consumer.ID)
+		if _, err := m.destination.ConsumerGroupConsumers.Create(ctx, &destinationID, &consumerID); err != nil {
+			if !isRejection(err) {
+				return fmt.Errorf("adding consumer %s to consumer group %s: %w", consumerID, destinationID, err)
+			}
+			m.report.Skipped = append(m.report.Skipped, SkippedEntity{
+				Kind:   name,
+				ID:     *consumer.ID,
+				Name:   consumer.FriendlyName(),
+				Reason: err.Error(),
+			})
+			continue
+		}
+		m.report.Copied[name]++
+	}
+	return nil
+}
@@ -0,0 +1,327 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+)
+
+const defaultPageSize = 1000
+
+// Options configures Migrate.
+type Options struct {
+	// PreserveIDs creates the entities with their IDs in the destination.
+	// Otherwise, the destination generates new IDs and references between
+	// entities are rewritten accordingly. Preserving IDs makes resuming
+	// migrations idempotent, as entities are created with PUT.
+	PreserveIDs bool
+	// RequestsPerSecond limits the rate of the requests made to the source
+	// and the destination. Zero means no limit.
+	RequestsPerSecond float64
+	// PageSize is the size of the pages listed from the source, 1000 if
+	// zero.
+	PageSize int
+	// Checkpoint, if set, resumes the migration from a checkpoint reported
+	// to OnCheckpoint by a previous migration.
+	Checkpoint *Checkpoint
+	// OnCheckpoint, if set, is called with the progress of the migration
+	// after every page of entities, e.g. to save it to resume the
+	// migration later. The migration stops if it returns an error.
+	OnCheckpoint func(Checkpoint) error
+}
+
+// Checkpoint is the progress of a migration.
+type Checkpoint struct {
+	// Kind is the kind of entities being copied, e.g. "services".
+	Kind string `json:"kind,omitempty"`
+	// Offset is the offset of the next page of entities of Kind to copy.
+	Offset string `json:"offset,omitempty"`
+	// IDs maps the IDs of copied entities in the source to their IDs in
+	// the destination, when IDs aren't preserved.
+	IDs map[string]string `json:"ids,omitempty"`
+	// Done is true once all entities were copied.
+	Done bool `json:"done,omitempty"`
+}
+
+// SkippedEntity is an entity which wasn't copied.
+type SkippedEntity struct {
+	Kind string `json:"kind"`
+	// ID is the ID of the entity in the source.
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// Report is the outcome of Migrate.
+type Report struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Copied counts the entities copied by kind.
+	Copied map[string]int `json:"copied"`
+	// Skipped holds the entities the destination rejected, e.g. plugins it
+	// doesn't have, and those which can't be copied.
+	Skipped []SkippedEntity `json:"skipped,omitempty"`
+	// Unsupported holds the kinds of entities the source doesn't support,
+	// e.g. consumer groups in Kong OSS.
+	Unsupported []string `json:"unsupported,omitempty"`
+	// Checkpoint is the progress of the migration when it ended.
+	Checkpoint Checkpoint `json:"checkpoint"`
+}
+
+// Migrate copies the entities of source to destination, in dependency
+// order: certificates, CA certificates, SNIs, services, routes,
+// upstreams and their targets, consumers, consumer groups and their
+// consumers, plugins and the key-auth, JWT, HMAC, ACL, OAuth2 and mTLS
+// credentials of consumers. Basic-auth credentials are skipped since
+// their passwords can't be read back. Only the workspaces set on the
+// clients are migrated.
+//
+// Entities the destination rejects with a client error, e.g. because it
+// doesn't support them, are reported as skipped. Other errors stop the
+// migration, which can then be resumed from the checkpoint of the
+// returned report. The report is returned even if an error is.
+func Migrate(ctx context.Context, source, destination *kong.Client, opts Options) (*Report, error) {
+	m := &migrator{
+		source:      source,
+		destination: destination,
+		opts:        opts,
+		report:      &Report{StartedAt: time.Now(), Copied: map[string]int{}},
+	}
+	defer func() { m.report.FinishedAt = time.Now() }()
+	if opts.PageSize <= 0 {
+		m.opts.PageSize = defaultPageSize
+	}
+	checkpoint := &m.report.Checkpoint
+	if opts.Checkpoint != nil {
+		*checkpoint = *opts.Checkpoint
+		checkpoint.IDs = map[string]string{}
+		for sourceID, destinationID := range opts.Checkpoint.IDs {
+			checkpoint.IDs[sourceID] = destinationID
+		}
+	}
+	if checkpoint.IDs == nil {
+		checkpoint.IDs = map[string]string{}
+	}
+	if checkpoint.Done {
+		return m.report, nil
+	}
+
+	start := 0
+	if checkpoint.Kind != "" {
+		start = -1
+		for i, k := range kinds {
+			if k.name == checkpoint.Kind {
+				start = i
+			}
+		}
+		if start < 0 {
+			return m.report, fmt.Errorf("unknown kind in checkpoint: %q", checkpoint.Kind)
+		}
+	}
+	for _, k := range kinds[start:] {
+		if checkpoint.Kind != k.name {
+			checkpoint.Kind, checkpoint.Offset = k.name, ""
+			if err := m.saveCheckpoint(); err != nil {
+				return m.report, err
+			}
+		}
+		if err := m.copyKind(ctx, k); err != nil {
+			return m.report, err
+		}
+	}
+	*checkpoint = Checkpoint{IDs: checkpoint.IDs, Done: true}
+	return m.report, m.saveCheckpoint()
+}
+
+type migrator struct {
+	source, destination *kong.Client
+	opts                Options
+	report              *Report
+	lastRequest         time.Time
+}
+
+func (m *migrator) saveCheckpoint() error {
+	if m.opts.OnCheckpoint == nil {
+		return nil
+	}
+	checkpoint := m.report.Checkpoint
+	checkpoint.IDs = map[string]string{}
+	for sourceID, destinationID := range m.report.Checkpoint.IDs {
+		checkpoint.IDs[sourceID] = destinationID
+	}
+	if err := m.opts.OnCheckpoint(checkpoint); err != nil {
+		return fmt.Errorf("saving checkpoint: %w", err)
+	}
+	return nil
+}
+
+// wait paces requests according to RequestsPerSecond.
+func (m *migrator) wait(ctx context.Context) error {
+	if m.opts.RequestsPerSecond <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / m.opts.RequestsPerSecond)
+	if d := time.Until(m.lastRequest.Add(interval)); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	m.lastRequest = time.Now()
+	return nil
+}
+
+func (m *migrator) copyKind(ctx context.Context, k kind) error {
+	checkpoint := &m.report.Checkpoint
+	opt := &kong.ListOpt{Size: m.opts.PageSize, Offset: checkpoint.Offset}
+	for first := true; ; first = false {
+		if err := m.wait(ctx); err != nil {
+			return err
+		}
+		entities, next, err := k.list(ctx, m.source, opt)
+		if err != nil {
+			if first && kong.IsNotFoundErr(err) {
+				m.report.Unsupported = append(m.report.Unsupported, k.name)
+				return nil
+			}
+			return fmt.Errorf("listing %s: %w", k.name, err)
+		}
+		for _, entity := range entities {
+			if err := m.copyEntity(ctx, k, entity); err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		checkpoint.Offset = next.Offset
+		if err := m.saveCheckpoint(); err != nil {
+			return err
+		}
+		opt = next
+	}
+}
+
+// copyEntity creates entity, as listed from the source, in the
+// destination.
+func (m *migrator) copyEntity(ctx context.Context, k kind, entity map[string]interface{}) error {
+	sourceID, _ := entity["id"].(string)
+	skip := func(reason string) {
+		m.report.Skipped = append(m.report.Skipped, SkippedEntity{
+			Kind:   k.name,
+			ID:     sourceID,
+			Name:   entityName(entity),
+			Reason: reason,
+		})
+	}
+	if k.unsupported != "" {
+		skip(k.unsupported)
+		return nil
+	}
+
+	delete(entity, "created_at")
+	delete(entity, "updated_at")
+	if k.prepare != nil {
+		k.prepare(entity)
+	}
+	if !m.opts.PreserveIDs {
+		delete(entity, "id")
+		remapIDs(entity, m.report.Checkpoint.IDs)
+	}
+	if err := m.wait(ctx); err != nil {
+		return err
+	}
+	destinationID, err := k.create(ctx, m.destination, entity)
+	if err != nil {
+		if isRejection(err) {
+			skip(err.Error())
+			return nil
+		}
+		return fmt.Errorf("copying %s %s: %w", k.name, sourceID, err)
+	}
+	if !m.opts.PreserveIDs && sourceID != "" {
+		m.report.Checkpoint.IDs[sourceID] = destinationID
+	}
+	m.report.Copied[k.name]++
+	if k.after != nil {
+		return k.after(ctx, m, sourceID, destinationID)
+	}
+	return nil
+}
+
+// destinationID returns the ID in the destination of the entity with
+// sourceID in the source.
+func (m *migrator) destinationID(sourceID string) string {
+	if id, ok := m.report.Checkpoint.IDs[sourceID]; ok {
+		return id
+	}
+	return sourceID
+}
+
+// isRejection returns true if err is a client error of the destination,
+// which retrying won't fix.
+func isRejection(err error) bool {
+	var apiErr *kong.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch code := apiErr.Code(); code {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	default:
+		return code >= 400 && code < 500
+	}
+}
+
+// remapIDs rewrites the references to entities of entity, i.e. objects
+// with an ID and CA certificate IDs, to their IDs in the destination.
+func remapIDs(entity map[string]interface{}, ids map[string]string) {
+	for key, value := range entity {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			if id, ok := value["id"].(string); ok {
+				if mapped, ok := ids[id]; ok {
+					value["id"] = mapped
+				}
+			}
+			remapIDs(value, ids)
+		case []interface{}:
+			if key != "ca_certificates" {
+				continue
+			}
+			for i, id := range value {
+				if id, ok := id.(string); ok {
+					if mapped, ok := ids[id]; ok {
+						value[i] = mapped
+					}
+				}
+			}
+		}
+	}
+}
+
+func entityName(entity map[string]interface{}) string {
+	for _, key := range []string{"name", "username", "key", "group", "client_id", "target", "subject_name", "id"} {
+		if name, ok := entity[key].(string); ok && name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// convert converts from into to through JSON.
+func convert(from, to interface{}) error {
+	b, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
@@ -0,0 +1,207 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/go-kong/kong"
+)
+
+func newSource(t *testing.T) *kong.Client {
+	responses := map[string]string{
+		"/services": `{"data":[{"id":"s1","name":"one","host":"one.internal","created_at":1}],
+			"offset":"page2"}`,
+		"/services?page2": `{"data":[{"id":"s2","name":"two","host":"two.internal"}]}`,
+		"/routes":         `{"data":[{"id":"r1","name":"r","paths":["/"],"service":{"id":"s2"}}]}`,
+		"/upstreams":      `{"data":[{"id":"u1","name":"up"}]}`,
+		"/upstreams/u1/targets": `{"data":[{"id":"t1","target":"10.0.0.1:80",
+			"upstream":{"id":"u1"}}]}`,
+		"/consumers": `{"data":[{"id":"c1","username":"alice"}]}`,
+		"/plugins": `{"data":[{"id":"p1","name":"key-auth","service":{"id":"s1"}},
+			{"id":"p2","name":"enterprise-only","consumer":{"id":"c1"}}]}`,
+		"/key-auths":   `{"data":[{"id":"k1","key":"secret","consumer":{"id":"c1"}}]}`,
+		"/basic-auths": `{"data":[{"id":"b1","username":"alice","password":"hash","consumer":{"id":"c1"}}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request to source: %s %s", r.Method, r.URL)
+			return
+		}
+		key := r.URL.Path
+		if offset := r.URL.Query().Get("offset"); offset != "" {
+			key += "?" + offset
+		}
+		if r.URL.Path == "/consumer_groups" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		response, ok := responses[key]
+		if !ok {
+			response = `{"data":[]}`
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := kong.NewClient(kong.String(srv.URL), nil)
+	require.NoError(t, err)
+	return client
+}
+
+type destination struct {
+	client *kong.Client
+	mu     sync.Mutex
+	writes []string
+	bodies map[string]map[string]interface{}
+	// fail makes requests fail with a server error until reset.
+	fail string
+}
+
+func newDestination(t *testing.T) *destination {
+	d := &destination{bodies: map[string]map[string]interface{}{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		var entity map[string]interface{}
+		_ = json.Unmarshal(body, &entity)
+		if d.fail != "" && strings.HasPrefix(r.URL.Path, d.fail) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if entity["name"] == "enterprise-only" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"plugin 'enterprise-only' not enabled"}`))
+			return
+		}
+		d.writes = append(d.writes, r.Method+" "+r.URL.Path)
+		if _, ok := entity["id"]; !ok {
+			entity["id"] = fmt.Sprintf("new-%d", len(d.writes))
+		}
+		d.bodies[r.URL.Path] = entity
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(entity)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := kong.NewClient(kong.String(srv.URL), nil)
+	require.NoError(t, err)
+	d.client = client
+	return d
+}
+
+func TestMigratePreservingIDs(t *testing.T) {
+	dst := newDestination(t)
+	var checkpoints []Checkpoint
+	report, err := Migrate(context.Background(), newSource(t), dst.client, Options{
+		PreserveIDs:  true,
+		OnCheckpoint: func(c Checkpoint) error { checkpoints = append(checkpoints, c); return nil },
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"PUT /services/s1",
+		"PUT /services/s2",
+		"PUT /routes/r1",
+		"PUT /upstreams/u1",
+		"POST /upstreams/u1/targets",
+		"PUT /consumers/c1",
+		"PUT /plugins/p1",
+		"PUT /consumers/c1/key-auth/k1",
+	}, dst.writes)
+	assert.NotContains(t, dst.bodies["/services/s1"], "created_at")
+	assert.Equal(t, map[string]int{
+		"services": 2, "routes": 1, "upstreams": 1, "targets": 1,
+		"consumers": 1, "plugins": 1, "key-auths": 1,
+	}, report.Copied)
+	assert.Equal(t, []string{"consumer_groups"}, report.Unsupported)
+	require.Len(t, report.Skipped, 2)
+	assert.Equal(t, "plugins", report.Skipped[0].Kind)
+	assert.Equal(t, "p2", report.Skipped[0].ID)
+	assert.Equal(t, "enterprise-only", report.Skipped[0].Name)
+	assert.Contains(t, report.Skipped[0].Reason, "not enabled")
+	assert.Equal(t, "basic-auths", report.Skipped[1].Kind)
+
+	assert.True(t, report.Checkpoint.Done)
+	assert.Equal(t, Checkpoint{Kind: "services", Offset: "page2", IDs: map[string]string{}},
+		checkpoints[4])
+	assert.True(t, checkpoints[len(checkpoints)-1].Done)
+}
+
+func TestMigrateRemappingIDs(t *testing.T) {
+	dst := newDestination(t)
+	report, err := Migrate(context.Background(), newSource(t), dst.client, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /services",
+		"POST /services",
+		"POST /routes",
+		"POST /upstreams",
+		"POST /upstreams/new-4/targets",
+		"POST /consumers",
+		"POST /plugins",
+		"POST /consumers/new-6/key-auth",
+	}, dst.writes)
+	assert.Equal(t, map[string]interface{}{"id": "new-2"}, dst.bodies["/routes"]["service"])
+	assert.Equal(t, map[string]interface{}{"id": "new-1"}, dst.bodies["/plugins"]["service"])
+	assert.Equal(t, "new-2", report.Checkpoint.IDs["s2"])
+	assert.Equal(t, "new-6", report.Checkpoint.IDs["c1"])
+}
+
+func TestMigrateResume(t *testing.T) {
+	source := newSource(t)
+	dst := newDestination(t)
+	dst.fail = "/routes"
+	var saved Checkpoint
+	report, err := Migrate(context.Background(), source, dst.client, Options{
+		OnCheckpoint: func(c Checkpoint) error { saved = c; return nil },
+	})
+	require.Error(t, err)
+	assert.Equal(t, "routes", report.Checkpoint.Kind)
+	assert.Equal(t, "routes", saved.Kind)
+	assert.Equal(t, []string{"POST /services", "POST /services"}, dst.writes)
+
+	dst.fail = ""
+	report, err = Migrate(context.Background(), source, dst.client, Options{Checkpoint: &saved})
+	require.NoError(t, err)
+	assert.Equal(t, "POST /routes", dst.writes[2])
+	assert.Equal(t, map[string]interface{}{"id": "new-2"}, dst.bodies["/routes"]["service"])
+	assert.Zero(t, report.Copied["services"])
+
+	report, err = Migrate(context.Background(), source, dst.client, Options{Checkpoint: &report.Checkpoint})
+	require.NoError(t, err)
+	assert.Empty(t, report.Copied)
+
+	_, err = Migrate(context.Background(), source, dst.client, Options{Checkpoint: &Checkpoint{Kind: "nope"}})
+	assert.Error(t, err)
+}
+
+func TestMigratePacing(t *testing.T) {
+	dst := newDestination(t)
+	start := time.Now()
+	_, err := Migrate(context.Background(), newSource(t), dst.client, Options{
+		PreserveIDs:       true,
+		RequestsPerSecond: 200,
+	})
+	require.NoError(t, err)
+	// the source is listed for every kind, plus the second page of
+	// services and targets, and every entity is written, including the
+	// rejected plugin
+	requests := len(kinds) + 2 + len(dst.writes) + 1
+	assert.GreaterOrEqual(t, time.Since(start), time.Duration(requests-1)*5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Migrate(ctx, newSource(t), newDestination(t).client, Options{RequestsPerSecond: 1})
+	assert.ErrorIs(t, err, context.Canceled)
+}
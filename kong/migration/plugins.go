@@ -0,0 +1,240 @@
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// pluginChange is a breaking change made to the configuration of plugins
+// by a version of Kong.
+type pluginChange struct {
+	plugins []string
+	// version is the version of Kong making the change.
+	version string
+	// renames maps the paths of fields before the change to their paths
+	// after it.
+	renames map[string]string
+	// removed are the paths of the fields removed by the change.
+	removed []string
+	// introduced are the paths of the fields introduced by the change.
+	introduced []string
+}
+
+var redisShorthands = map[string]string{
+	"redis_host":        "redis.host",
+	"redis_port":        "redis.port",
+	"redis_username":    "redis.username",
+	"redis_password":    "redis.password",
+	"redis_ssl":         "redis.ssl",
+	"redis_ssl_verify":  "redis.ssl_verify",
+	"redis_server_name": "redis.server_name",
+	"redis_timeout":     "redis.timeout",
+	"redis_database":    "redis.database",
+}
+
+// pluginChanges are the known breaking changes to the configuration of
+// bundled plugins.
+var pluginChanges = []pluginChange{
+	{
+		// serverless functions run in the access phase since 2.3
+		plugins: []string{"pre-function", "post-function"},
+		version: "3.0.0",
+		renames: map[string]string{"functions": "access"},
+	},
+	{
+		plugins: []string{"aws-lambda"},
+		version: "3.0.0",
+		removed: []string{"proxy_scheme"},
+	},
+	{
+		plugins: []string{"session"},
+		version: "3.2.0",
+		renames: map[string]string{
+			"cookie_lifetime":   "rolling_timeout",
+			"cookie_idletime":   "idling_timeout",
+			"cookie_httponly":   "cookie_http_only",
+			"cookie_discard":    "stale_ttl",
+			"cookie_persistent": "remember",
+		},
+		removed: []string{"cookie_renew"},
+		introduced: []string{
+			"absolute_timeout", "remember_cookie_name", "remember_rolling_timeout",
+			"remember_absolute_timeout", "request_headers", "response_headers",
+			"hash_subject", "store_metadata", "audience", "read_body_for_logout",
+		},
+	},
+	{
+		plugins:    []string{"rate-limiting", "response-ratelimiting"},
+		version:    "3.6.0",
+		renames:    redisShorthands,
+		introduced: []string{"redis"},
+	},
+	{
+		plugins: []string{"acme"},
+		version: "3.6.0",
+		renames: map[string]string{
+			"storage_config.redis.auth":            "storage_config.redis.password",
+			"storage_config.redis.ssl_server_name": "storage_config.redis.server_name",
+			"storage_config.redis.namespace":       "storage_config.redis.extra_options.namespace",
+			"storage_config.redis.scan_count":      "storage_config.redis.extra_options.scan_count",
+		},
+	},
+}
+
+// MigratePlugin returns a copy of plugin, configured for Kong version from,
+// with its configuration rewritten across the known breaking changes made
+// between from and to, so that plugins dumped from a version of Kong can be
+// applied to another one, whether it is newer or older. It also returns a
+// description of the changes made to the configuration.
+//
+// Fields are renamed and moved, e.g. the redis_* fields of the
+// rate-limiting plugin moved to a redis record in Kong 3.6. Fields removed
+// by an upgrade are dropped, as are the known fields introduced by an
+// upgrade when downgrading. Other fields are left as they are, for Kong to
+// reject them.
+func MigratePlugin(plugin *kong.Plugin, from, to kong.Version) (*kong.Plugin, []string) {
+	if plugin == nil {
+		return nil, nil
+	}
+	migrated := plugin.DeepCopy()
+	if migrated.Name == nil || migrated.Config == nil {
+		return migrated, nil
+	}
+	upgrade := kong.MustNewRange("<" + to.String())(from)
+	var changes []pluginChange
+	for _, change := range pluginChanges {
+		if !containsString(change.plugins, *migrated.Name) {
+			continue
+		}
+		before := kong.MustNewRange("<" + change.version)
+		if before(from) != before(to) {
+			changes = append(changes, change)
+		}
+	}
+
+	var applied []string
+	config := map[string]interface{}(migrated.Config)
+	if upgrade {
+		for _, change := range changes {
+			applied = append(applied, change.upgrade(config)...)
+		}
+	} else {
+		for i := len(changes) - 1; i >= 0; i-- {
+			applied = append(applied, changes[i].downgrade(config)...)
+		}
+	}
+	return migrated, applied
+}
+
+func (c pluginChange) upgrade(config map[string]interface{}) []string {
+	var applied []string
+	for _, old := range sortedKeys(c.renames) {
+		if moveField(config, old, c.renames[old]) {
+			applied = append(applied, fmt.Sprintf("config.%s moved to config.%s in Kong %s",
+				old, c.renames[old], c.version))
+		}
+	}
+	for _, removed := range c.removed {
+		if _, ok := deleteField(config, removed); ok {
+			applied = append(applied, fmt.Sprintf("config.%s removed in Kong %s", removed, c.version))
+		}
+	}
+	return applied
+}
+
+func (c pluginChange) downgrade(config map[string]interface{}) []string {
+	var applied []string
+	for _, old := range sortedKeys(c.renames) {
+		if moveField(config, c.renames[old], old) {
+			applied = append(applied, fmt.Sprintf("config.%s moved to config.%s before Kong %s",
+				c.renames[old], old, c.version))
+		}
+	}
+	for _, introduced := range c.introduced {
+		if _, ok := deleteField(config, introduced); ok {
+			applied = append(applied, fmt.Sprintf("config.%s removed before Kong %s", introduced, c.version))
+		}
+	}
+	return applied
+}
+
+// moveField moves the field at path from to path to, unless the field
+// isn't set or to is set already, and removes the records left empty.
+// It returns true if the field was moved.
+func moveField(config map[string]interface{}, from, to string) bool {
+	if value, ok := getField(config, to); ok && value != nil {
+		deleteField(config, from)
+		return false
+	}
+	value, ok := deleteField(config, from)
+	if !ok || value == nil {
+		return false
+	}
+	parts := strings.Split(to, ".")
+	record := config
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := record[part].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			record[part] = next
+		}
+		record = next
+	}
+	record[parts[len(parts)-1]] = value
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func getField(config map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.Split(path, ".")
+	record := config
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := record[part].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		record = next
+	}
+	value, ok := record[parts[len(parts)-1]]
+	return value, ok
+}
+
+// deleteField deletes the field at path and returns its value, removing
+// the records left empty.
+func deleteField(config map[string]interface{}, path string) (interface{}, bool) {
+	parts := strings.SplitN(path, ".", 2)
+	if len(parts) == 1 {
+		value, ok := config[path]
+		delete(config, path)
+		return value, ok
+	}
+	record, ok := config[parts[0]].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	value, ok := deleteField(record, parts[1])
+	if len(record) == 0 {
+		delete(config, parts[0])
+	}
+	return value, ok
+}
@@ -0,0 +1,113 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kong/go-kong/kong"
+)
+
+func TestMigratePlugin(t *testing.T) {
+	v35 := kong.MustNewVersion("3.5.0.3")
+	v36 := kong.MustNewVersion("3.6.0")
+	plugin := &kong.Plugin{
+		Name: kong.String("rate-limiting"),
+		Config: kong.Configuration{
+			"minute":         float64(10),
+			"redis_host":     "redis.internal",
+			"redis_port":     float64(6379),
+			"redis_password": nil,
+		},
+	}
+
+	upgraded, changes := MigratePlugin(plugin, v35, v36)
+	assert.Equal(t, kong.Configuration{
+		"minute": float64(10),
+		"redis":  map[string]interface{}{"host": "redis.internal", "port": float64(6379)},
+	}, upgraded.Config)
+	assert.Equal(t, []string{
+		"config.redis_host moved to config.redis.host in Kong 3.6.0",
+		"config.redis_port moved to config.redis.port in Kong 3.6.0",
+	}, changes)
+	assert.Contains(t, plugin.Config, "redis_host")
+
+	upgraded.Config["redis"].(map[string]interface{})["sentinel_master"] = nil
+	downgraded, changes := MigratePlugin(upgraded, v36, v35)
+	assert.Equal(t, kong.Configuration{
+		"minute":     float64(10),
+		"redis_host": "redis.internal",
+		"redis_port": float64(6379),
+	}, downgraded.Config)
+	assert.Equal(t, []string{
+		"config.redis.host moved to config.redis_host before Kong 3.6.0",
+		"config.redis.port moved to config.redis_port before Kong 3.6.0",
+		"config.redis removed before Kong 3.6.0",
+	}, changes)
+
+	same, changes := MigratePlugin(plugin, kong.MustNewVersion("3.4.3.5"), v35)
+	assert.Equal(t, plugin, same)
+	assert.Empty(t, changes)
+	same, changes = MigratePlugin(upgraded, v36, kong.MustNewVersion("3.8.0"))
+	assert.Equal(t, upgraded, same)
+	assert.Empty(t, changes)
+}
+
+func TestMigratePluginAcrossSeveralVersions(t *testing.T) {
+	session := &kong.Plugin{
+		Name: kong.String("session"),
+		Config: kong.Configuration{
+			"cookie_lifetime": float64(3600),
+			"cookie_renew":    float64(600),
+			"rolling_timeout": nil,
+		},
+	}
+	upgraded, changes := MigratePlugin(session, kong.MustNewVersion("2.8.4"), kong.MustNewVersion("3.9.0"))
+	assert.Equal(t, kong.Configuration{"rolling_timeout": float64(3600)}, upgraded.Config)
+	assert.Len(t, changes, 2)
+
+	acme := &kong.Plugin{
+		Name: kong.String("acme"),
+		Config: kong.Configuration{
+			"storage_config": map[string]interface{}{
+				"redis": map[string]interface{}{
+					"extra_options": map[string]interface{}{"namespace": "certs"},
+					"password":      "secret",
+				},
+			},
+		},
+	}
+	downgraded, _ := MigratePlugin(acme, kong.MustNewVersion("3.8.0"), kong.MustNewVersion("3.0.0"))
+	assert.Equal(t, kong.Configuration{
+		"storage_config": map[string]interface{}{
+			"redis": map[string]interface{}{"namespace": "certs", "auth": "secret"},
+		},
+	}, downgraded.Config)
+
+	acme = &kong.Plugin{
+		Name: kong.String("acme"),
+		Config: kong.Configuration{
+			"storage_config": map[string]interface{}{
+				"redis": map[string]interface{}{"auth": "secret", "ssl_server_name": "redis.internal"},
+			},
+		},
+	}
+	upgraded, changes = MigratePlugin(acme, kong.MustNewVersion("3.5.0"), kong.MustNewVersion("3.6.1"))
+	assert.Equal(t, kong.Configuration{
+		"storage_config": map[string]interface{}{
+			"redis": map[string]interface{}{"password": "secret", "server_name": "redis.internal"},
+		},
+	}, upgraded.Config)
+	assert.Len(t, changes, 2)
+
+	functions := &kong.Plugin{
+		Name:   kong.String("pre-function"),
+		Config: kong.Configuration{"functions": []interface{}{"return"}},
+	}
+	upgraded, _ = MigratePlugin(functions, kong.MustNewVersion("2.8.0"), kong.MustNewVersion("3.0.0"))
+	assert.Equal(t, kong.Configuration{"access": []interface{}{"return"}}, upgraded.Config)
+
+	none, changes := MigratePlugin(nil, kong.MustNewVersion("2.8.0"), kong.MustNewVersion("3.0.0"))
+	assert.Nil(t, none)
+	assert.Empty(t, changes)
+}
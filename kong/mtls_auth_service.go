@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractMTLSAuthService handles MTLS credentials in Kong.
@@ -41,7 +40,7 @@ func (s *MTLSAuthService) Create(ctx context.Context,
 	}
 
 	var createdMTLS MTLSAuth
-	err = json.Unmarshal(cred, &createdMTLS)
+	err = s.client.decode(cred, &createdMTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +59,7 @@ func (s *MTLSAuthService) Get(ctx context.Context,
 	}
 
 	var mtlsAuth MTLSAuth
-	err = json.Unmarshal(cred, &mtlsAuth)
+	err = s.client.decode(cred, &mtlsAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +78,7 @@ func (s *MTLSAuthService) Update(ctx context.Context,
 	}
 
 	var updatedMTLS MTLSAuth
-	err = json.Unmarshal(cred, &updatedMTLS)
+	err = s.client.decode(cred, &updatedMTLS)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +110,7 @@ func (s *MTLSAuthService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var mtlsAuth MTLSAuth
-		err = json.Unmarshal(b, &mtlsAuth)
+		err = s.client.decode(b, &mtlsAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -157,7 +156,7 @@ func (s *MTLSAuthService) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var mtlsAuth MTLSAuth
-		err = json.Unmarshal(b, &mtlsAuth)
+		err = s.client.decode(b, &mtlsAuth)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,137 @@
+package kong
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// MutationOperation is the kind of change reported to a mutation hook.
+type MutationOperation string
+
+const (
+	// MutationCreate is reported for entities created with POST, or with
+	// PUT if the entity didn't exist before.
+	MutationCreate MutationOperation = "create"
+	// MutationUpdate is reported for entities updated with PATCH or PUT.
+	MutationUpdate MutationOperation = "update"
+	// MutationDelete is reported for deleted entities.
+	MutationDelete MutationOperation = "delete"
+)
+
+// Mutation is a change made to an entity through the client.
+type Mutation struct {
+	Operation MutationOperation
+	// EntityType is the name of the entity collection in the Admin API,
+	// e.g. "services" or "key-auth".
+	EntityType string
+	// ID is the ID of the entity if Kong returned it, or the name or ID
+	// it was referred to by otherwise.
+	ID string
+	// Before is the entity before the change. It is only set if the
+	// hook was set with fetchBefore, for updates and deletions.
+	Before json.RawMessage
+	// After is the entity as returned by Kong after the change. It is
+	// not set for deletions.
+	After json.RawMessage
+}
+
+type mutationHookConfig struct {
+	hook        func(context.Context, Mutation)
+	fetchBefore bool
+}
+
+// SetMutationHook sets a function called after every successful request
+// creating, updating or deleting an entity, e.g. to keep an audit trail
+// of the changes made with the client. A nil hook removes the hook.
+//
+// If fetchBefore is set, entities are fetched before being updated or
+// deleted so that the hook gets their previous state, at the cost of an
+// additional request per change.
+//
+// The hook is called synchronously and is not called in dry-run mode.
+func (c *Client) SetMutationHook(hook func(ctx context.Context, m Mutation), fetchBefore bool) {
+	if hook == nil {
+		c.mutationHook.Store((*mutationHookConfig)(nil))
+		return
+	}
+	c.mutationHook.Store(&mutationHookConfig{hook: hook, fetchBefore: fetchBefore})
+}
+
+// prepareMutation returns the Mutation to report for req once it
+// succeeds, or nil if req doesn't change an entity or no hook is set.
+func (c *Client) prepareMutation(ctx context.Context, req *http.Request) *Mutation {
+	config, _ := c.mutationHook.Load().(*mutationHookConfig)
+	if config == nil || c.IsDryRun() {
+		return nil
+	}
+	path := c.relativePath(req)
+	segments := pathSegments(path)
+	// these endpoints don't write entities
+	if segments[0] == "schemas" || segments[0] == "config" || isHealthPath(segments) {
+		return nil
+	}
+
+	m := &Mutation{}
+	switch req.Method {
+	case http.MethodPost:
+		m.Operation = MutationCreate
+	case http.MethodPut, http.MethodPatch:
+		m.Operation = MutationUpdate
+	case http.MethodDelete:
+		m.Operation = MutationDelete
+	default:
+		return nil
+	}
+	if len(segments)%2 == 1 {
+		m.EntityType = segments[len(segments)-1]
+		return m
+	}
+	m.EntityType = segments[len(segments)-2]
+	m.ID = segments[len(segments)-1]
+
+	if config.fetchBefore && req.Method != http.MethodPost {
+		before, err := c.NewRequestRaw(http.MethodGet, c.workspacedBaseURL(c.requestWorkspace(req)), path, nil, nil)
+		if err != nil {
+			return m
+		}
+		var entity json.RawMessage
+		_, err = c.Do(ctx, before, &entity)
+		switch {
+		case err == nil:
+			m.Before = entity
+		case IsNotFoundErr(err) && req.Method == http.MethodPut:
+			m.Operation = MutationCreate
+		}
+	}
+	return m
+}
+
+// reportMutation calls the mutation hook for m, once the request changing
+// the entity succeeded with resp. The body of resp is kept readable.
+func (c *Client) reportMutation(ctx context.Context, m *Mutation, resp *http.Response) error {
+	config, _ := c.mutationHook.Load().(*mutationHookConfig)
+	if config == nil {
+		return nil
+	}
+	if m.Operation != MutationDelete {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if json.Valid(body) {
+			m.After = body
+			var entity struct {
+				ID *string `json:"id"`
+			}
+			if err := json.Unmarshal(body, &entity); err == nil && entity.ID != nil {
+				m.ID = *entity.ID
+			}
+		}
+	}
+	config.hook(ctx, *m)
+	return nil
+}
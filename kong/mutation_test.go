@@ -0,0 +1,103 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutationHook(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /services":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","port":80}`))
+		case "GET /services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","port":80}`))
+		case "PATCH /services/foo":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","port":8080}`))
+		case "GET /services/bar":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		case "PUT /services/bar":
+			_, _ = w.Write([]byte(`{"id":"s2","name":"bar"}`))
+		case "DELETE /services/foo":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /upstreams/up/targets/t1/healthy", "DELETE /services/healthy":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /upstreams/up/targets":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid target"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	var mutations []Mutation
+	client.SetMutationHook(func(_ context.Context, m Mutation) {
+		mutations = append(mutations, m)
+	}, false)
+
+	service, err := client.Services.Create(defaultCtx, &Service{Name: String("foo")})
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *service.ID)
+	// failed requests are not reported
+	_, err = client.Targets.Create(defaultCtx, String("up"), &Target{Target: String("x")})
+	require.Error(t, err)
+	require.Len(t, mutations, 1)
+	assert.Equal(t, MutationCreate, mutations[0].Operation)
+	assert.Equal(t, "services", mutations[0].EntityType)
+	assert.Equal(t, "s1", mutations[0].ID)
+	assert.Nil(t, mutations[0].Before)
+	assert.JSONEq(t, `{"id":"s1","name":"foo","port":80}`, string(mutations[0].After))
+
+	mutations = nil
+	client.SetMutationHook(func(_ context.Context, m Mutation) {
+		mutations = append(mutations, m)
+	}, true)
+	service, err = client.Services.Update(defaultCtx, &Service{ID: String("foo"), Port: Int(8080)})
+	require.NoError(t, err)
+	assert.Equal(t, 8080, *service.Port)
+	_, err = client.Services.Create(defaultCtx, &Service{ID: String("bar"), Name: String("bar")})
+	require.NoError(t, err)
+	require.NoError(t, client.Services.Delete(defaultCtx, String("foo")))
+
+	require.Len(t, mutations, 3)
+	assert.Equal(t, MutationUpdate, mutations[0].Operation)
+	assert.Equal(t, "s1", mutations[0].ID)
+	assert.JSONEq(t, `{"id":"s1","name":"foo","port":80}`, string(mutations[0].Before))
+	assert.JSONEq(t, `{"id":"s1","name":"foo","port":8080}`, string(mutations[0].After))
+	assert.Equal(t, MutationCreate, mutations[1].Operation)
+	assert.Equal(t, "s2", mutations[1].ID)
+	assert.Nil(t, mutations[1].Before)
+	assert.Equal(t, MutationDelete, mutations[2].Operation)
+	assert.Equal(t, "foo", mutations[2].ID)
+	assert.NotNil(t, mutations[2].Before)
+	assert.Nil(t, mutations[2].After)
+
+	// health endpoints don't change entities, which may be named like them
+	mutations = nil
+	client.SetMutationHook(func(_ context.Context, m Mutation) {
+		mutations = append(mutations, m)
+	}, false)
+	require.NoError(t, client.Targets.MarkHealthy(defaultCtx, String("up"), &Target{ID: String("t1")}))
+	require.NoError(t, client.Services.Delete(defaultCtx, String("healthy")))
+	require.Len(t, mutations, 1)
+	assert.Equal(t, MutationDelete, mutations[0].Operation)
+	assert.Equal(t, "services", mutations[0].EntityType)
+	assert.Equal(t, "healthy", mutations[0].ID)
+
+	// the hook is not called in dry-run mode
+	mutations = nil
+	client.SetDryRun(true, func(DryRunRequest) {})
+	require.NoError(t, client.Services.Delete(defaultCtx, String("foo")))
+	assert.Empty(t, mutations)
+}
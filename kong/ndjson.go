@@ -0,0 +1,64 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// EntityRecord is an entity in newline-delimited JSON documents, see
+// DocumentFormatNDJSON, one per line with its type as an envelope, e.g.
+// {"type":"services","entity":{...}}, which makes them easy to process
+// with line-oriented tools such as jq.
+type EntityRecord struct {
+	// Type is the type of the entity, e.g. "services".
+	Type   string          `json:"type"`
+	Entity json.RawMessage `json:"entity"`
+}
+
+// NewEntityRecord returns the EntityRecord of entity.
+func NewEntityRecord(entityType string, entity interface{}) (EntityRecord, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return EntityRecord{}, err
+	}
+	return EntityRecord{Type: entityType, Entity: b}, nil
+}
+
+// writeNDJSON writes v to w as a line of JSON.
+func writeNDJSON(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// isNDJSON returns whether data starts with an EntityRecord.
+func isNDJSON(data []byte) bool {
+	var first map[string]json.RawMessage
+	if json.NewDecoder(bytes.NewReader(data)).Decode(&first) != nil {
+		return false
+	}
+	_, hasType := first["type"]
+	_, hasEntity := first["entity"]
+	return hasType && hasEntity
+}
+
+// readEntityRecords calls fn with the EntityRecords of data, in order.
+func readEntityRecords(data []byte, fn func(EntityRecord) error) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for line := 1; ; line++ {
+		var record EntityRecord
+		if err := dec.Decode(&record); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("record %d: %w", line, err)
+		}
+		if err := fn(record); err != nil {
+			return fmt.Errorf("record %d: %w", line, err)
+		}
+	}
+}
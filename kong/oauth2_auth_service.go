@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AbstractOauth2Service handles oauth2 credentials in Kong.
@@ -42,7 +41,7 @@ func (s *Oauth2Service) Create(ctx context.Context,
 	}
 
 	var createdOauth2Cred Oauth2Credential
-	err = json.Unmarshal(cred, &createdOauth2Cred)
+	err = s.client.decode(cred, &createdOauth2Cred)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +60,7 @@ func (s *Oauth2Service) Get(ctx context.Context,
 	}
 
 	var oauth2Cred Oauth2Credential
-	err = json.Unmarshal(cred, &oauth2Cred)
+	err = s.client.decode(cred, &oauth2Cred)
 	if err != nil {
 		return nil, err
 	}
@@ -81,7 +80,7 @@ func (s *Oauth2Service) Update(ctx context.Context,
 	}
 
 	var updatedHMACAuth Oauth2Credential
-	err = json.Unmarshal(cred, &updatedHMACAuth)
+	err = s.client.decode(cred, &updatedHMACAuth)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +112,7 @@ func (s *Oauth2Service) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var oauth2Cred Oauth2Credential
-		err = json.Unmarshal(b, &oauth2Cred)
+		err = s.client.decode(b, &oauth2Cred)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -162,7 +161,7 @@ func (s *Oauth2Service) ListForConsumer(ctx context.Context,
 			return nil, nil, err
 		}
 		var oauth2Cred Oauth2Credential
-		err = json.Unmarshal(b, &oauth2Cred)
+		err = s.client.decode(b, &oauth2Cred)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,176 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tokenExpiryMargin is how long before they expire access tokens are
+// refreshed.
+const tokenExpiryMargin = 30 * time.Second
+
+// ClientCredentialsAuth is a RequestSigner obtaining OAuth2 access tokens
+// with the client credentials flow and setting them in the Authorization
+// header of requests, for Admin APIs protected by an OIDC enforcing proxy
+// or by the openid-connect plugin of Kong. Use it with
+// HTTPClientWithSigner.
+//
+// Tokens are cached and refreshed shortly before they expire.
+// A ClientCredentialsAuth is safe for concurrent use.
+type ClientCredentialsAuth struct {
+	// TokenURL is the token endpoint of the authorization server. If
+	// empty, it is discovered from the OpenID configuration of Issuer.
+	TokenURL string
+	Issuer   string
+
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// Audience, if set, is sent as the audience parameter, which some
+	// providers require.
+	Audience string
+
+	// HTTPClient makes the requests to the authorization server,
+	// http.DefaultClient if nil.
+	HTTPClient *http.Client
+
+	lock     sync.Mutex
+	tokenURL string
+	token    string
+	expires  time.Time
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Sign satisfies the RequestSigner interface.
+func (a *ClientCredentialsAuth) Sign(req *http.Request, _ []byte, now time.Time) error {
+	token, err := a.Token(req.Context(), now)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns the cached access token, or obtains a new one if it
+// expires before now plus a margin.
+func (a *ClientCredentialsAuth) Token(ctx context.Context, now time.Time) (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if a.token != "" && (a.expires.IsZero() || now.Add(tokenExpiryMargin).Before(a.expires)) {
+		return a.token, nil
+	}
+	tokenURL := a.TokenURL
+	if tokenURL == "" {
+		if a.Issuer == "" {
+			return "", fmt.Errorf("token URL or issuer is required")
+		}
+		if a.tokenURL == "" {
+			discovered, err := a.discoverTokenURL(ctx)
+			if err != nil {
+				return "", err
+			}
+			a.tokenURL = discovered
+		}
+		tokenURL = a.tokenURL
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(a.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.Scopes, " "))
+	}
+	if a.Audience != "" {
+		form.Set("audience", a.Audience)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+
+	var token tokenResponse
+	if err := a.do(req, &token); err != nil {
+		return "", fmt.Errorf("obtaining access token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("obtaining access token: no access token in response")
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return "", fmt.Errorf("obtaining access token: unsupported token type %q", token.TokenType)
+	}
+	a.token = token.AccessToken
+	a.expires = time.Time{}
+	if token.ExpiresIn > 0 {
+		a.expires = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return a.token, nil
+}
+
+// Invalidate drops the cached access token, e.g. after it was revoked, so
+// that the next request obtains a new one.
+func (a *ClientCredentialsAuth) Invalidate() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.token = ""
+	a.expires = time.Time{}
+}
+
+func (a *ClientCredentialsAuth) discoverTokenURL(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(a.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return "", err
+	}
+	var configuration struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := a.do(req, &configuration); err != nil {
+		return "", fmt.Errorf("discovering token endpoint: %w", err)
+	}
+	if configuration.TokenEndpoint == "" {
+		return "", fmt.Errorf("discovering token endpoint: no token endpoint in OpenID configuration")
+	}
+	return configuration.TokenEndpoint, nil
+}
+
+func (a *ClientCredentialsAuth) do(req *http.Request, v interface{}) error {
+	client := a.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var token tokenResponse
+		if json.Unmarshal(body, &token) == nil && token.Error != "" {
+			if token.ErrorDescription != "" {
+				return fmt.Errorf("%s: %s: %s", resp.Status, token.Error, token.ErrorDescription)
+			}
+			return fmt.Errorf("%s: %s", resp.Status, token.Error)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return json.Unmarshal(body, v)
+}
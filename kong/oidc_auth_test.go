@@ -0,0 +1,75 @@
+package kong
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCredentialsAuth(t *testing.T) {
+	issued := 0
+	var idp *httptest.Server
+	idp = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			_, _ = fmt.Fprintf(w, `{"token_endpoint":"%s/token"}`, idp.URL)
+		case "/token":
+			require.NoError(t, r.ParseForm())
+			user, password, _ := r.BasicAuth()
+			if user != "kong-admin" || password != "s3cret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad secret"}`))
+				return
+			}
+			assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+			assert.Equal(t, "admin read", r.PostForm.Get("scope"))
+			issued++
+			_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":60}`, issued)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer idp.Close()
+
+	var authorization string
+	admin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"id":"s1"}`))
+	}))
+	defer admin.Close()
+
+	auth := &ClientCredentialsAuth{
+		Issuer:       idp.URL,
+		ClientID:     "kong-admin",
+		ClientSecret: "s3cret",
+		Scopes:       []string{"admin", "read"},
+	}
+	client, err := NewClient(String(admin.URL), HTTPClientWithSigner(nil, auth))
+	require.NoError(t, err)
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", authorization)
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, issued)
+
+	// refreshed shortly before expiring
+	token, err := auth.Token(defaultCtx, time.Now().Add(45*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	auth.Invalidate()
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-3", authorization)
+
+	auth = &ClientCredentialsAuth{TokenURL: idp.URL + "/token", ClientID: "kong-admin", ClientSecret: "nope"}
+	_, err = auth.Token(defaultCtx, time.Now())
+	assert.ErrorContains(t, err, "invalid_client: bad secret")
+	_, err = (&ClientCredentialsAuth{}).Token(defaultCtx, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// minPacingInterval is the interval between requests set by the
+	// first 429 response.
+	minPacingInterval = 50 * time.Millisecond
+	// maxPacingInterval caps the interval between requests.
+	maxPacingInterval = 10 * time.Second
+)
+
+// PacingState is the state of the adaptive pacing of a client, see
+// SetAdaptivePacing.
+type PacingState struct {
+	// Enabled is true if adaptive pacing is enabled.
+	Enabled bool
+	// Interval is the current minimum time between two requests.
+	Interval time.Duration
+	// PausedUntil is set while requests are paused, after Kong
+	// answered with a Retry-After header or ran out of rate limit quota.
+	PausedUntil time.Time
+	// Throttled is the number of 429 responses received.
+	Throttled int
+}
+
+type pacer struct {
+	mu          sync.Mutex
+	enabled     bool
+	interval    time.Duration
+	pausedUntil time.Time
+	last        time.Time
+	throttled   int
+}
+
+// SetAdaptivePacing enables or disables the adaptive pacing of the
+// requests of the client. When enabled, the client slows down when Kong,
+// or a proxy in front of it, answers with 429 responses or announces
+// that the rate limit quota is running out with RateLimit-Remaining and
+// RateLimit-Reset headers, and speeds up again as requests succeed.
+// Requests wait for their turn, or until their context is done.
+func (c *Client) SetAdaptivePacing(enabled bool) {
+	c.pacer.mu.Lock()
+	defer c.pacer.mu.Unlock()
+	c.pacer.enabled = enabled
+	if !enabled {
+		c.pacer.interval = 0
+		c.pacer.pausedUntil = time.Time{}
+		c.pacer.last = time.Time{}
+	}
+}
+
+// PacingState returns the state of the adaptive pacing of the client.
+func (c *Client) PacingState() PacingState {
+	c.pacer.mu.Lock()
+	defer c.pacer.mu.Unlock()
+	state := PacingState{
+		Enabled:   c.pacer.enabled,
+		Interval:  c.pacer.interval,
+		Throttled: c.pacer.throttled,
+	}
+	if time.Now().Before(c.pacer.pausedUntil) {
+		state.PausedUntil = c.pacer.pausedUntil
+	}
+	return state
+}
+
+// pace waits for the turn of the next request.
+func (c *Client) pace(ctx context.Context) error {
+	c.pacer.mu.Lock()
+	if !c.pacer.enabled {
+		c.pacer.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	slot := now
+	if next := c.pacer.last.Add(c.pacer.interval); next.After(slot) {
+		slot = next
+	}
+	if c.pacer.pausedUntil.After(slot) {
+		slot = c.pacer.pausedUntil
+	}
+	c.pacer.last = slot
+	c.pacer.mu.Unlock()
+
+	wait := slot.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observePacing adjusts the pacing of the client to resp.
+func (c *Client) observePacing(resp *http.Response) {
+	c.pacer.mu.Lock()
+	defer c.pacer.mu.Unlock()
+	if !c.pacer.enabled || resp == nil {
+		return
+	}
+	now := time.Now()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		c.pacer.throttled++
+		c.pacer.interval *= 2
+		if c.pacer.interval < minPacingInterval {
+			c.pacer.interval = minPacingInterval
+		}
+		if c.pacer.interval > maxPacingInterval {
+			c.pacer.interval = maxPacingInterval
+		}
+		if details, ok := extractErrTooManyRequestsDetails(resp); ok {
+			c.pacer.pause(now.Add(details.RetryAfter))
+		}
+		return
+	}
+
+	remaining, errRemaining := strconv.Atoi(resp.Header.Get("RateLimit-Remaining"))
+	reset, errReset := strconv.Atoi(resp.Header.Get("RateLimit-Reset"))
+	if errRemaining == nil && errReset == nil && reset > 0 {
+		if remaining <= 0 {
+			c.pacer.pause(now.Add(time.Duration(reset) * time.Second))
+			return
+		}
+		// spread the remaining quota over the rest of the window
+		c.pacer.interval = time.Duration(reset) * time.Second / time.Duration(remaining)
+		if c.pacer.interval > maxPacingInterval {
+			c.pacer.interval = maxPacingInterval
+		}
+		return
+	}
+
+	// speed up again
+	c.pacer.interval /= 2
+	if c.pacer.interval < time.Millisecond {
+		c.pacer.interval = 0
+	}
+}
+
+func (p *pacer) pause(until time.Time) {
+	if until.After(p.pausedUntil) {
+		p.pausedUntil = until
+	}
+}
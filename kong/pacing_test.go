@@ -0,0 +1,68 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptivePacing(t *testing.T) {
+	var headers http.Header
+	status := http.StatusOK
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		for k, v := range headers {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"id":"s1"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetAdaptivePacing(true)
+
+	// throttled with Retry-After: requests are paused
+	status = http.StatusTooManyRequests
+	headers = http.Header{"Retry-After": []string{"30"}}
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.Error(t, err)
+	state := client.PacingState()
+	assert.True(t, state.Enabled)
+	assert.Equal(t, 1, state.Throttled)
+	assert.Equal(t, minPacingInterval, state.Interval)
+	assert.WithinDuration(t, time.Now().Add(30*time.Second), state.PausedUntil, time.Second)
+
+	ctx, cancel := context.WithTimeout(defaultCtx, 20*time.Millisecond)
+	defer cancel()
+	_, err = client.Services.Get(ctx, String("s1"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, requests)
+
+	// disabling pacing resets its state
+	client.SetAdaptivePacing(false)
+	client.SetAdaptivePacing(true)
+	assert.Zero(t, client.PacingState().Interval)
+	assert.True(t, client.PacingState().PausedUntil.IsZero())
+
+	// the remaining quota is spread over the rest of the window
+	status = http.StatusOK
+	headers = http.Header{"Ratelimit-Remaining": []string{"20"}, "Ratelimit-Reset": []string{"1"}}
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, client.PacingState().Interval)
+
+	start := time.Now()
+	headers = nil
+	_, err = client.Services.Get(defaultCtx, String("s1"))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	// successful requests speed the client up again
+	assert.Equal(t, 25*time.Millisecond, client.PacingState().Interval)
+}
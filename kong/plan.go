@@ -0,0 +1,208 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// planEntityOrder is the order in which entity types are created, each
+// one possibly referencing the previous ones. They are deleted in reverse
+// order.
+var planEntityOrder = []string{
+	"certificates", "snis", "ca_certificates", "services", "routes",
+	"upstreams", "targets", "consumers", "consumer_groups", "plugins",
+}
+
+// Plan is a list of operations to apply with an Applier, along with a
+// summary of the changes, e.g. to be reviewed in CI pipelines before
+// being applied. Plans marshal to JSON for machine consumption.
+type Plan struct {
+	Operations []Operation `json:"operations"`
+	// Create, Update and Delete count the operations by entity type.
+	Create map[string]int `json:"create,omitempty"`
+	Update map[string]int `json:"update,omitempty"`
+	Delete map[string]int `json:"delete,omitempty"`
+	// Destructive is true if the plan deletes entities, which pipelines
+	// may require an approval for.
+	Destructive bool `json:"destructive"`
+}
+
+// NewPlan returns the plan applying operations.
+func NewPlan(operations []Operation) *Plan {
+	plan := &Plan{
+		Operations: operations,
+		Create:     map[string]int{},
+		Update:     map[string]int{},
+		Delete:     map[string]int{},
+	}
+	if plan.Operations == nil {
+		plan.Operations = []Operation{}
+	}
+	for _, operation := range operations {
+		entityType := planEntityType(operation.EntityType)
+		switch operation.Action {
+		case MutationCreate:
+			plan.Create[entityType]++
+		case MutationUpdate:
+			plan.Update[entityType]++
+		case MutationDelete:
+			plan.Delete[entityType]++
+			plan.Destructive = true
+		}
+	}
+	return plan
+}
+
+// PlanDrift returns the plan reverting drift found by a Watcher, making
+// Kong match the reference state: added entities are deleted, removed
+// ones are created with their ID, and changed ones are updated.
+// Entities are created and updated in dependency order, then deleted in
+// reverse order.
+func PlanDrift(events []DriftEvent) (*Plan, error) {
+	events = append([]DriftEvent(nil), events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return planEntityRank(events[i].EntityType) < planEntityRank(events[j].EntityType)
+	})
+	var operations, deletes []Operation
+	for _, event := range events {
+		operation := Operation{EntityType: event.EntityType, ID: event.ID}
+		entity := event.Expected
+		switch event.Kind {
+		case DriftAdded:
+			operation.Action = MutationDelete
+			entity = event.Actual
+		case DriftRemoved:
+			operation.Action, operation.Entity = MutationCreate, event.Expected
+		case DriftChanged:
+			patch, err := jsonPatch(event.Expected, event.Actual)
+			if err != nil {
+				return nil, fmt.Errorf("comparing %s %s: %w", event.EntityType, event.ID, err)
+			}
+			operation.Action, operation.Entity = MutationUpdate, patch
+		default:
+			return nil, fmt.Errorf("unknown drift kind: %q", event.Kind)
+		}
+		if event.EntityType == "targets" {
+			// targets are only reachable through their upstream
+			var target Target
+			if err := json.Unmarshal(entity, &target); err != nil {
+				return nil, fmt.Errorf("decoding target %s: %w", event.ID, err)
+			}
+			if target.Upstream == nil || isEmptyString(target.Upstream.ID) {
+				return nil, fmt.Errorf("target %s: missing upstream", event.ID)
+			}
+			operation.EntityType = "upstreams/" + *target.Upstream.ID + "/targets"
+		}
+		if operation.Action == MutationDelete {
+			deletes = append(deletes, operation)
+		} else {
+			operations = append(operations, operation)
+		}
+	}
+	for i := len(deletes) - 1; i >= 0; i-- {
+		operations = append(operations, deletes[i])
+	}
+	return NewPlan(operations), nil
+}
+
+// jsonPatch returns the fields of expected which differ in actual, and
+// the fields only in actual set to null.
+func jsonPatch(expected, actual json.RawMessage) (json.RawMessage, error) {
+	var e, a map[string]json.RawMessage
+	if err := json.Unmarshal(expected, &e); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(actual, &a); err != nil {
+		return nil, err
+	}
+	patch := map[string]json.RawMessage{}
+	for field, value := range e {
+		if !jsonEqual(value, a[field]) {
+			patch[field] = value
+		}
+	}
+	for field := range a {
+		if _, ok := e[field]; !ok {
+			patch[field] = json.RawMessage("null")
+		}
+	}
+	return json.Marshal(patch)
+}
+
+func planEntityRank(entityType string) int {
+	for i, t := range planEntityOrder {
+		if t == entityType {
+			return i
+		}
+	}
+	return len(planEntityOrder)
+}
+
+// planEntityType returns the type of entities of nested collections,
+// e.g. "targets" for "upstreams/{id}/targets".
+func planEntityType(entityType string) string {
+	entityType = strings.Trim(entityType, "/")
+	return entityType[strings.LastIndex(entityType, "/")+1:]
+}
+
+// IsEmpty returns true if the plan doesn't change anything.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Operations) == 0
+}
+
+// String returns a human summary of the plan, such as
+// "+3 services, ~2 routes, -1 plugin", or "no changes".
+func (p *Plan) String() string {
+	var parts []string
+	for _, counts := range []struct {
+		sign  string
+		count map[string]int
+	}{{"+", p.Create}, {"~", p.Update}, {"-", p.Delete}} {
+		entityTypes := make([]string, 0, len(counts.count))
+		for entityType := range counts.count {
+			entityTypes = append(entityTypes, entityType)
+		}
+		sort.Slice(entityTypes, func(i, j int) bool {
+			ri, rj := planEntityRank(entityTypes[i]), planEntityRank(entityTypes[j])
+			if ri != rj {
+				return ri < rj
+			}
+			return entityTypes[i] < entityTypes[j]
+		})
+		for _, entityType := range entityTypes {
+			n := counts.count[entityType]
+			if n == 1 {
+				entityType = strings.TrimSuffix(entityType, "s")
+			}
+			parts = append(parts, fmt.Sprintf("%s%d %s", counts.sign, n, entityType))
+		}
+	}
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Annotations returns the plan as GitHub Actions workflow commands, which
+// most CI systems can render: a notice with the summary of the plan, and
+// a warning listing the deletions of destructive plans.
+func (p *Plan) Annotations() []string {
+	annotations := []string{"::notice title=Kong plan::" + escapeAnnotation(p.String())}
+	if p.Destructive {
+		var deletions []string
+		for _, operation := range p.Operations {
+			if operation.Action == MutationDelete {
+				deletions = append(deletions, operation.endpoint())
+			}
+		}
+		annotations = append(annotations, "::warning title=Destructive Kong plan::"+
+			escapeAnnotation("deletes "+strings.Join(deletions, ", ")))
+	}
+	return annotations
+}
+
+func escapeAnnotation(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
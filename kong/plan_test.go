@@ -0,0 +1,79 @@
+package kong
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan(t *testing.T) {
+	plan := NewPlan([]Operation{
+		{Action: MutationCreate, EntityType: "services", Entity: &Service{Host: String("a")}},
+		{Action: MutationCreate, EntityType: "services", Entity: &Service{Host: String("b")}},
+		{Action: MutationUpdate, EntityType: "routes", ID: "r1"},
+		{Action: MutationCreate, EntityType: "consumers/alice/key-auth", Entity: &KeyAuth{}},
+		{Action: MutationDelete, EntityType: "plugins", ID: "p1"},
+	})
+	assert.Equal(t, "+2 services, +1 key-auth, ~1 route, -1 plugin", plan.String())
+	assert.True(t, plan.Destructive)
+	assert.False(t, plan.IsEmpty())
+	assert.Equal(t, []string{
+		"::notice title=Kong plan::+2 services, +1 key-auth, ~1 route, -1 plugin",
+		"::warning title=Destructive Kong plan::deletes /plugins/p1",
+	}, plan.Annotations())
+
+	b, err := json.Marshal(plan)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, map[string]interface{}{"services": 2.0, "key-auth": 1.0}, decoded["create"])
+	assert.Equal(t, true, decoded["destructive"])
+	assert.Len(t, decoded["operations"], 5)
+
+	empty := NewPlan(nil)
+	assert.True(t, empty.IsEmpty())
+	assert.False(t, empty.Destructive)
+	assert.Equal(t, "no changes", empty.String())
+	assert.Equal(t, []string{"::notice title=Kong plan::no changes"}, empty.Annotations())
+	b, err = json.Marshal(empty)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"operations":[],"destructive":false}`, string(b))
+}
+
+func TestPlanDrift(t *testing.T) {
+	plan, err := PlanDrift([]DriftEvent{
+		{Kind: DriftAdded, EntityType: "plugins", ID: "p1", Actual: json.RawMessage(`{"id":"p1"}`)},
+		{Kind: DriftAdded, EntityType: "services", ID: "s9", Actual: json.RawMessage(`{"id":"s9"}`)},
+		{
+			Kind: DriftChanged, EntityType: "routes", ID: "r1",
+			Expected: json.RawMessage(`{"id":"r1","paths":["/a"],"name":"r"}`),
+			Actual:   json.RawMessage(`{"id":"r1","paths":["/b"],"name":"r","hosts":["x"]}`),
+		},
+		{
+			Kind: DriftRemoved, EntityType: "targets", ID: "t1",
+			Expected: json.RawMessage(`{"id":"t1","target":"a:80","upstream":{"id":"u1"}}`),
+		},
+		{Kind: DriftRemoved, EntityType: "services", ID: "s1", Expected: json.RawMessage(`{"id":"s1"}`)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "+1 service, +1 target, ~1 route, -1 service, -1 plugin", plan.String())
+	var operations []string
+	for _, operation := range plan.Operations {
+		operations = append(operations, string(operation.Action)+" "+operation.endpoint())
+	}
+	assert.Equal(t, []string{
+		"create /services/s1",
+		"update /routes/r1",
+		"create /upstreams/u1/targets/t1",
+		"delete /plugins/p1",
+		"delete /services/s9",
+	}, operations)
+	assert.JSONEq(t, `{"paths":["/a"],"hosts":null}`, string(plan.Operations[1].Entity.(json.RawMessage)))
+
+	_, err = PlanDrift([]DriftEvent{
+		{Kind: DriftRemoved, EntityType: "targets", ID: "t1", Expected: json.RawMessage(`{"id":"t1"}`)},
+	})
+	assert.EqualError(t, err, "target t1: missing upstream")
+}
@@ -36,6 +36,12 @@ type PluginOrdering struct {
 // +k8s:deepcopy-gen=true
 type PluginOrderingPhase map[string][]string
 
+// IsGlobal returns true if the Plugin is not scoped to a Service, a Route,
+// a Consumer or a Consumer Group, i.e. if it applies to all requests.
+func (p *Plugin) IsGlobal() bool {
+	return p.Service == nil && p.Route == nil && p.Consumer == nil && p.ConsumerGroup == nil
+}
+
 // FriendlyName returns the endpoint key name or ID.
 func (p *Plugin) FriendlyName() string {
 	if p.Name != nil {
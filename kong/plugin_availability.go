@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"context"
+	"sync"
+)
+
+type pluginCache struct {
+	mu      sync.Mutex
+	plugins *PluginsInfo
+}
+
+// HasPlugin returns true if the plugin named name is available on the Kong
+// node, i.e. loaded by it, so that automation can skip plugins the node
+// doesn't have instead of failing to configure them.
+//
+// The plugins available on the node are fetched from the root endpoint on
+// the first call and cached by the client, see ResetPluginCache.
+func (c *Client) HasPlugin(ctx context.Context, name string) (bool, error) {
+	plugins, err := c.availablePlugins(ctx)
+	if err != nil {
+		return false, err
+	}
+	return plugins.IsAvailable(name), nil
+}
+
+// IsPluginEnabled returns true if the plugin named name is configured in
+// the Kong cluster, as reported by the root endpoint. Like HasPlugin, it
+// uses the plugins cached by the client.
+func (c *Client) IsPluginEnabled(ctx context.Context, name string) (bool, error) {
+	plugins, err := c.availablePlugins(ctx)
+	if err != nil {
+		return false, err
+	}
+	return plugins.IsEnabled(name), nil
+}
+
+// ResetPluginCache clears the plugins cached by HasPlugin and
+// IsPluginEnabled, e.g. after plugins were configured or Kong was
+// restarted with other plugins.
+func (c *Client) ResetPluginCache() {
+	c.plugins.mu.Lock()
+	defer c.plugins.mu.Unlock()
+	c.plugins.plugins = nil
+}
+
+func (c *Client) availablePlugins(ctx context.Context) (*PluginsInfo, error) {
+	c.plugins.mu.Lock()
+	defer c.plugins.mu.Unlock()
+	if c.plugins.plugins != nil {
+		return c.plugins.plugins, nil
+	}
+	info, err := c.Info.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plugins := info.Plugins
+	if plugins == nil {
+		plugins = &PluginsInfo{}
+	}
+	// the configuration lists the loaded plugins too, e.g. on nodes
+	// answering without the plugins block
+	if len(plugins.AvailableOnServer) == 0 && info.Configuration != nil {
+		plugins.AvailableOnServer = map[string]interface{}{}
+		for name, loaded := range info.Configuration.LoadedPlugins {
+			if loaded {
+				plugins.AvailableOnServer[name] = true
+			}
+		}
+	}
+	c.plugins.plugins = plugins
+	return plugins, nil
+}
@@ -0,0 +1,48 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasPlugin(t *testing.T) {
+	roots := 0
+	root := `{"version":"3.4.1","plugins":{
+		"available_on_server":{"key-auth":{"version":"3.4.1","priority":1250},"acl":true},
+		"enabled_in_cluster":["key-auth"]}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		roots++
+		_, _ = w.Write([]byte(root))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	has, err := client.HasPlugin(defaultCtx, "key-auth")
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = client.HasPlugin(defaultCtx, "acl")
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = client.HasPlugin(defaultCtx, "opentelemetry")
+	require.NoError(t, err)
+	assert.False(t, has)
+	enabled, err := client.IsPluginEnabled(defaultCtx, "acl")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	enabled, err = client.IsPluginEnabled(defaultCtx, "key-auth")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, 1, roots)
+
+	root = `{"version":"3.4.1","configuration":{"loaded_plugins":{"opentelemetry":true}}}`
+	client.ResetPluginCache()
+	has, err = client.HasPlugin(defaultCtx, "opentelemetry")
+	require.NoError(t, err)
+	assert.True(t, has)
+	assert.Equal(t, 2, roots)
+}
@@ -0,0 +1,239 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EffectivePlugin is the plugin of a name applying to requests, as
+// resolved by ResolvePluginChain.
+type EffectivePlugin struct {
+	Name string
+	// Plugin is the plugin which applies.
+	Plugin *Plugin
+	// Scope is the scope of Plugin, e.g. "consumer+route" or "global".
+	Scope string
+	// Overridden holds the less specific plugins of the same name which
+	// Plugin takes precedence over, from the most specific.
+	Overridden []*Plugin
+}
+
+// String renders p, e.g. "rate-limiting: consumer+route (overrides
+// service, global)".
+func (p *EffectivePlugin) String() string {
+	s := p.Name + ": " + p.Scope
+	if len(p.Overridden) > 0 {
+		var scopes []string
+		for _, plugin := range p.Overridden {
+			scopes = append(scopes, pluginScopeName(plugin))
+		}
+		s += " (overrides " + strings.Join(scopes, ", ") + ")"
+	}
+	return s
+}
+
+// ResolvePluginChain returns the plugins applying to requests to the
+// route with routeNameOrID, sent by the consumer with
+// consumerUsernameOrID if it isn't nil, sorted by name. It helps finding
+// out e.g. which rate limit applies to a route.
+//
+// When several plugins of a name match, the most specific one applies,
+// following the precedence of Kong:
+//
+//  1. consumer, route and service
+//  2. consumer group, route and service
+//  3. consumer and route
+//  4. consumer and service
+//  5. consumer group and route
+//  6. consumer group and service
+//  7. route and service
+//  8. consumer
+//  9. consumer group
+//  10. route
+//  11. service
+//  12. global
+//
+// Disabled plugins never apply. Consumer groups are fetched from Kong
+// Enterprise; if the consumer is in several groups with plugins of the
+// same name, the group with the lowest name is assumed to apply.
+// The order in which plugins run depends on their priorities, which the
+// Admin API doesn't expose.
+func ResolvePluginChain(ctx context.Context, client *Client,
+	routeNameOrID, consumerUsernameOrID *string,
+) ([]*EffectivePlugin, error) {
+	if isEmptyString(routeNameOrID) {
+		return nil, fmt.Errorf("routeNameOrID cannot be nil for ResolvePluginChain operation")
+	}
+	route, err := client.Routes.Get(ctx, routeNameOrID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching route: %w", err)
+	}
+	scope := pluginChainScope{route: derefString(route.ID), groups: map[string]int{}}
+	if route.Service != nil {
+		scope.service = derefString(route.Service.ID)
+	}
+	if !isEmptyString(consumerUsernameOrID) {
+		consumer, err := client.Consumers.Get(ctx, consumerUsernameOrID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching consumer: %w", err)
+		}
+		scope.consumer = derefString(consumer.ID)
+		groups, err := client.listConsumerGroupsOfConsumer(ctx, consumer.ID)
+		if err != nil {
+			return nil, fmt.Errorf("fetching consumer groups of consumer: %w", err)
+		}
+		sort.Slice(groups, func(i, j int) bool {
+			return derefString(groups[i].Name) < derefString(groups[j].Name)
+		})
+		for i, group := range groups {
+			scope.groups[derefString(group.ID)] = i
+		}
+	}
+
+	plugins, err := client.Plugins.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins: %w", err)
+	}
+	type candidate struct {
+		plugin *Plugin
+		rank   int
+		group  int
+	}
+	candidates := map[string][]candidate{}
+	for _, plugin := range plugins {
+		if isEmptyString(plugin.Name) || (plugin.Enabled != nil && !*plugin.Enabled) {
+			continue
+		}
+		rank, group, ok := scope.rank(plugin)
+		if !ok {
+			continue
+		}
+		candidates[*plugin.Name] = append(candidates[*plugin.Name], candidate{plugin, rank, group})
+	}
+
+	var chain []*EffectivePlugin
+	for name, matching := range candidates {
+		sort.SliceStable(matching, func(i, j int) bool {
+			if matching[i].rank != matching[j].rank {
+				return matching[i].rank < matching[j].rank
+			}
+			return matching[i].group < matching[j].group
+		})
+		effective := &EffectivePlugin{
+			Name:   name,
+			Plugin: matching[0].plugin,
+			Scope:  pluginScopeName(matching[0].plugin),
+		}
+		for _, c := range matching[1:] {
+			effective.Overridden = append(effective.Overridden, c.plugin)
+		}
+		chain = append(chain, effective)
+	}
+	sort.Slice(chain, func(i, j int) bool { return chain[i].Name < chain[j].Name })
+	return chain, nil
+}
+
+// pluginChainScope is the route, service, consumer and consumer groups
+// of requests, identified by ID.
+type pluginChainScope struct {
+	route, service, consumer string
+	// groups maps the IDs of consumer groups to their order.
+	groups map[string]int
+}
+
+// rank returns the precedence of plugin for requests in s, lower ranks
+// taking precedence, and false if plugin doesn't apply to them.
+func (s pluginChainScope) rank(plugin *Plugin) (rank, group int, ok bool) {
+	consumer, consumerGroup := plugin.Consumer != nil, plugin.ConsumerGroup != nil
+	route, service := plugin.Route != nil, plugin.Service != nil
+	if consumer && (s.consumer == "" || derefString(plugin.Consumer.ID) != s.consumer) {
+		return 0, 0, false
+	}
+	if consumerGroup {
+		if group, ok = s.groups[derefString(plugin.ConsumerGroup.ID)]; !ok {
+			return 0, 0, false
+		}
+	}
+	if route && derefString(plugin.Route.ID) != s.route {
+		return 0, 0, false
+	}
+	if service && (s.service == "" || derefString(plugin.Service.ID) != s.service) {
+		return 0, 0, false
+	}
+	switch {
+	case consumer && route && service:
+		rank = 1
+	case consumerGroup && route && service:
+		rank = 2
+	case consumer && route:
+		rank = 3
+	case consumer && service:
+		rank = 4
+	case consumerGroup && route:
+		rank = 5
+	case consumerGroup && service:
+		rank = 6
+	case route && service:
+		rank = 7
+	case consumer:
+		rank = 8
+	case consumerGroup:
+		rank = 9
+	case route:
+		rank = 10
+	case service:
+		rank = 11
+	default:
+		rank = 12
+	}
+	return rank, group, true
+}
+
+func pluginScopeName(plugin *Plugin) string {
+	var scope []string
+	if plugin.Consumer != nil {
+		scope = append(scope, "consumer")
+	}
+	if plugin.ConsumerGroup != nil {
+		scope = append(scope, "consumer_group")
+	}
+	if plugin.Route != nil {
+		scope = append(scope, "route")
+	}
+	if plugin.Service != nil {
+		scope = append(scope, "service")
+	}
+	if len(scope) == 0 {
+		return "global"
+	}
+	return strings.Join(scope, "+")
+}
+
+// listConsumerGroupsOfConsumer fetches the consumer groups of a consumer.
+// It returns none if Kong doesn't support consumer groups.
+func (c *Client) listConsumerGroupsOfConsumer(ctx context.Context,
+	consumerID *string,
+) ([]*ConsumerGroup, error) {
+	var groups []*ConsumerGroup
+	opt := &ListOpt{Size: pageSize}
+	for opt != nil {
+		data, next, err := c.list(ctx, "/consumers/"+*consumerID+"/consumer_groups", opt)
+		if err != nil {
+			if IsNotFoundErr(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for _, object := range data {
+			var group ConsumerGroup
+			if err := convert(object, &group); err != nil {
+				return nil, err
+			}
+			groups = append(groups, &group)
+		}
+		opt = next
+	}
+	return groups, nil
+}
@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePluginChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/routes/orders":
+			_, _ = w.Write([]byte(`{"id":"r1","name":"orders","service":{"id":"s1"}}`))
+		case "/consumers/alice":
+			_, _ = w.Write([]byte(`{"id":"c1","username":"alice"}`))
+		case "/consumers/c1/consumer_groups":
+			_, _ = w.Write([]byte(`{"data":[{"id":"g2","name":"silver"},{"id":"g1","name":"gold"}]}`))
+		case "/plugins":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"rl-global","name":"rate-limiting"},
+				{"id":"rl-service","name":"rate-limiting","service":{"id":"s1"}},
+				{"id":"rl-route","name":"rate-limiting","route":{"id":"r1"}},
+				{"id":"rl-silver","name":"rate-limiting","consumer_group":{"id":"g2"}},
+				{"id":"rl-gold","name":"rate-limiting","consumer_group":{"id":"g1"}},
+				{"id":"rl-consumer-route","name":"rate-limiting","consumer":{"id":"c1"},"route":{"id":"r1"},"enabled":false},
+				{"id":"rl-other-route","name":"rate-limiting","route":{"id":"r2"}},
+				{"id":"rl-bob","name":"rate-limiting","consumer":{"id":"c2"}},
+				{"id":"cors-service","name":"cors","service":{"id":"s1"}},
+				{"id":"cors-route-service","name":"cors","service":{"id":"s1"},"route":{"id":"r1"}},
+				{"id":"key-auth","name":"key-auth","service":{"id":"s2"}}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	chain, err := ResolvePluginChain(defaultCtx, client, String("orders"), String("alice"))
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "cors", chain[0].Name)
+	assert.Equal(t, "cors-route-service", *chain[0].Plugin.ID)
+	assert.Equal(t, "cors: route+service (overrides service)", chain[0].String())
+
+	assert.Equal(t, "rate-limiting", chain[1].Name)
+	assert.Equal(t, "rl-gold", *chain[1].Plugin.ID)
+	assert.Equal(t, "consumer_group", chain[1].Scope)
+	var overridden []string
+	for _, plugin := range chain[1].Overridden {
+		overridden = append(overridden, *plugin.ID)
+	}
+	assert.Equal(t, []string{"rl-silver", "rl-route", "rl-service", "rl-global"}, overridden)
+
+	// consumer scoped plugins don't apply to anonymous requests
+	chain, err = ResolvePluginChain(defaultCtx, client, String("orders"), nil)
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, "rl-route", *chain[1].Plugin.ID)
+	assert.Equal(t, "rate-limiting: route (overrides service, global)", chain[1].String())
+
+	_, err = ResolvePluginChain(defaultCtx, client, nil, nil)
+	assert.Error(t, err)
+}
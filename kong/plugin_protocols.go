@@ -0,0 +1,172 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PluginProtocolIssue is a plugin attached to a route or service receiving
+// requests over protocols the plugin doesn't support, see
+// CheckPluginProtocols.
+type PluginProtocolIssue struct {
+	Plugin *Plugin
+	// EntityType and EntityID identify the entity the plugin is attached
+	// to, i.e. "route" or "service" and its ID, or name if it has no ID.
+	EntityType string
+	EntityID   string
+	// Unsupported holds the protocols of the entity the plugin doesn't
+	// support, and Supported the protocols it does.
+	Unsupported []string
+	Supported   []string
+	Message     string
+}
+
+// LintPluginProtocols fetches all plugins, services and routes and checks
+// the plugins with CheckPluginProtocols.
+func LintPluginProtocols(ctx context.Context, client *Client) ([]PluginProtocolIssue, error) {
+	plugins, err := client.Plugins.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing plugins: %w", err)
+	}
+	services, err := client.Services.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing services: %w", err)
+	}
+	routes, err := client.Routes.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing routes: %w", err)
+	}
+	return CheckPluginProtocols(ctx, NewSchemaCache(client, 0), plugins, services, routes)
+}
+
+// CheckPluginProtocols checks that the plugins attached to routes and
+// services support the protocols of the requests they'll see, according
+// to their schemas, e.g. before plugins, services and routes are sent to
+// Kong. Plugins which don't, such as response-transformer on gRPC
+// routes, are either rejected by Kong or never run.
+//
+// Requests to a route are received over the protocols of the route.
+// Requests to a service are received over the protocols of its routes,
+// or its own protocol if it has no routes. Plugins are matched to
+// services and routes by ID or name. Plugins whose schema isn't
+// available are assumed to support every protocol.
+func CheckPluginProtocols(ctx context.Context, schemas *SchemaCache,
+	plugins []*Plugin, services []*Service, routes []*Route,
+) ([]PluginProtocolIssue, error) {
+	routeProtocols := map[string][]string{}
+	serviceProtocols := map[string][]string{}
+	for _, route := range routes {
+		protocols := []string{string(RouteProtocolHTTP), string(RouteProtocolHTTPS)}
+		if len(route.Protocols) > 0 {
+			protocols = nil
+			for _, protocol := range route.Protocols {
+				protocols = append(protocols, derefString(protocol))
+			}
+		}
+		for _, key := range []*string{route.ID, route.Name} {
+			if !isEmptyString(key) {
+				routeProtocols[*key] = protocols
+			}
+		}
+		if route.Service == nil {
+			continue
+		}
+		for _, key := range []*string{route.Service.ID, route.Service.Name} {
+			if !isEmptyString(key) {
+				serviceProtocols[*key] = append(serviceProtocols[*key], protocols...)
+			}
+		}
+	}
+	for _, service := range services {
+		var protocols []string
+		for _, key := range []*string{service.ID, service.Name} {
+			if !isEmptyString(key) && len(protocols) == 0 {
+				protocols = serviceProtocols[*key]
+			}
+		}
+		if len(protocols) == 0 && !isEmptyString(service.Protocol) {
+			protocols = []string{*service.Protocol}
+		}
+		for _, key := range []*string{service.ID, service.Name} {
+			if !isEmptyString(key) {
+				serviceProtocols[*key] = protocols
+			}
+		}
+	}
+
+	var issues []PluginProtocolIssue
+	supportedByPlugin := map[string][]string{}
+	for _, plugin := range plugins {
+		if plugin == nil || isEmptyString(plugin.Name) {
+			continue
+		}
+		var entityType, entityID string
+		var protocols []string
+		switch {
+		case plugin.Route != nil:
+			entityType, entityID = "route", pluginEntityKey(plugin.Route.ID, plugin.Route.Name)
+			protocols = routeProtocols[entityID]
+		case plugin.Service != nil:
+			entityType, entityID = "service", pluginEntityKey(plugin.Service.ID, plugin.Service.Name)
+			protocols = serviceProtocols[entityID]
+		default:
+			continue
+		}
+		if len(protocols) == 0 {
+			continue
+		}
+
+		supported, checked := supportedByPlugin[*plugin.Name]
+		if !checked {
+			schema, err := schemas.PluginSchema(ctx, *plugin.Name)
+			if err != nil && !IsNotFoundErr(err) {
+				return nil, fmt.Errorf("fetching schema of plugin %q: %w", *plugin.Name, err)
+			}
+			supported, err = PluginSchemaProtocols(schema)
+			if err != nil {
+				return nil, err
+			}
+			supportedByPlugin[*plugin.Name] = supported
+		}
+		if len(supported) == 0 {
+			continue
+		}
+		isSupported := map[string]bool{}
+		for _, protocol := range supported {
+			isSupported[protocol] = true
+		}
+		unsupported := map[string]bool{}
+		for _, protocol := range protocols {
+			if !isSupported[protocol] {
+				unsupported[protocol] = true
+			}
+		}
+		if len(unsupported) == 0 {
+			continue
+		}
+		issue := PluginProtocolIssue{
+			Plugin:     plugin,
+			EntityType: entityType,
+			EntityID:   entityID,
+			Supported:  supported,
+		}
+		for protocol := range unsupported {
+			issue.Unsupported = append(issue.Unsupported, protocol)
+		}
+		sort.Strings(issue.Unsupported)
+		issue.Message = fmt.Sprintf("plugin %s on %s %s doesn't support %s (supported: %s)",
+			plugin.FriendlyName(), entityType, entityID,
+			strings.Join(issue.Unsupported, ", "), strings.Join(supported, ", "))
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func pluginEntityKey(id, name *string) string {
+	if !isEmptyString(id) {
+		return *id
+	}
+	return derefString(name)
+}
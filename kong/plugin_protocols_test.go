@@ -0,0 +1,72 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintPluginProtocols(t *testing.T) {
+	schemaRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":"3.4.0"}`))
+		case "/schemas/plugins/response-transformer":
+			schemaRequests++
+			_, _ = w.Write([]byte(`{"fields":[{"protocols":{"elements":{"one_of":["http","https"]}}}]}`))
+		case "/schemas/plugins/custom":
+			schemaRequests++
+			w.WriteHeader(http.StatusNotFound)
+		case "/plugins":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","name":"response-transformer","service":{"id":"grpc-svc"}},
+				{"id":"p2","name":"response-transformer","route":{"id":"mixed"}},
+				{"id":"p3","name":"response-transformer","route":{"id":"web"}},
+				{"id":"p4","name":"response-transformer","service":{"id":"lonely"}},
+				{"id":"p5","name":"custom","route":{"id":"mixed"}},
+				{"id":"p6","name":"custom","service":{"id":"grpc-svc"}},
+				{"id":"p7","name":"response-transformer"}]}`))
+		case "/services":
+			_, _ = w.Write([]byte(`{"data":[{"id":"grpc-svc","protocol":"grpc"},{"id":"lonely","protocol":"grpcs"}]}`))
+		case "/routes":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"grpc-route","protocols":["grpc"],"service":{"id":"grpc-svc"}},
+				{"id":"mixed","protocols":["https","grpcs"]},
+				{"id":"web"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	issues, err := LintPluginProtocols(defaultCtx, client)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	assert.Equal(t, "service", issues[0].EntityType)
+	assert.Equal(t, "grpc-svc", issues[0].EntityID)
+	assert.Equal(t, []string{"grpc"}, issues[0].Unsupported)
+	assert.Equal(t, []string{"http", "https"}, issues[0].Supported)
+	assert.Equal(t, "plugin response-transformer on service grpc-svc doesn't support grpc (supported: http, https)",
+		issues[0].Message)
+	assert.Equal(t, "mixed", issues[1].EntityID)
+	assert.Equal(t, []string{"grpcs"}, issues[1].Unsupported)
+	assert.Equal(t, "p4", *issues[2].Plugin.ID)
+	assert.Equal(t, []string{"grpcs"}, issues[2].Unsupported)
+	// schemas are fetched once per plugin
+	assert.Equal(t, 2, schemaRequests)
+
+	// desired configuration, referencing entities by name
+	issues, err = CheckPluginProtocols(defaultCtx, NewSchemaCache(client, 0),
+		[]*Plugin{{Name: String("response-transformer"), Route: &Route{Name: String("r")}}},
+		nil,
+		[]*Route{{Name: String("r"), Protocols: StringSlice("grpcs")}})
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "r", issues[0].EntityID)
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -18,8 +17,8 @@ type AbstractPluginService interface {
 	CreateForRoute(ctx context.Context, routeIDorName *string, plugin *Plugin) (*Plugin, error)
 	// CreateForConsumerGroup creates a Plugin in Kong.
 	CreateForConsumerGroup(ctx context.Context, cgIDorName *string, plugin *Plugin) (*Plugin, error)
-	// Get fetches a Plugin in Kong.
-	Get(ctx context.Context, usernameOrID *string) (*Plugin, error)
+	// Get fetches a Plugin in Kong by ID or, on Kong 3.2+, by instance name.
+	Get(ctx context.Context, idOrInstanceName *string) (*Plugin, error)
 	// Update updates a Plugin in Kong
 	Update(ctx context.Context, plugin *Plugin) (*Plugin, error)
 	// UpdateForService updates a Plugin in Kong for a service
@@ -28,24 +27,36 @@ type AbstractPluginService interface {
 	UpdateForRoute(ctx context.Context, routeIDorName *string, plugin *Plugin) (*Plugin, error)
 	// UpdateForConsumerGrou updates a Plugin in Kong for a consumer-group
 	UpdateForConsumerGroup(ctx context.Context, cgIDorName *string, plugin *Plugin) (*Plugin, error)
-	// Delete deletes a Plugin in Kong
-	Delete(ctx context.Context, usernameOrID *string) error
+	// SetEnabled enables or disables a Plugin in Kong
+	SetEnabled(ctx context.Context, pluginID *string, enabled bool) (*Plugin, error)
+	// Delete deletes a Plugin in Kong by ID or, on Kong 3.2+, by instance name.
+	Delete(ctx context.Context, idOrInstanceName *string) error
 	// DeleteForService deletes a Plugin in Kong
 	DeleteForService(ctx context.Context, serviceIDorName *string, pluginID *string) error
 	// DeleteForRoute deletes a Plugin in Kong
 	DeleteForRoute(ctx context.Context, routeIDorName *string, pluginID *string) error
+	// DeleteForConsumerGroup deletes a Plugin in Kong at ConsumerGroup level.
+	DeleteForConsumerGroup(ctx context.Context, cgIDorName *string, pluginID *string) error
 	// List fetches a list of Plugins in Kong.
 	List(ctx context.Context, opt *ListOpt) ([]*Plugin, *ListOpt, error)
 	// ListAll fetches all Plugins in Kong.
 	ListAll(ctx context.Context) ([]*Plugin, error)
+	// DeleteWhere deletes the Plugins in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Plugin) bool) ([]*Plugin, error)
 	// ListAllForConsumer fetches all Plugins in Kong enabled for a consumer.
 	ListAllForConsumer(ctx context.Context, consumerIDorName *string) ([]*Plugin, error)
 	// ListAllForService fetches all Plugins in Kong enabled for a service.
 	ListAllForService(ctx context.Context, serviceIDorName *string) ([]*Plugin, error)
 	// ListAllForRoute fetches all Plugins in Kong enabled for a service.
 	ListAllForRoute(ctx context.Context, routeID *string) ([]*Plugin, error)
+	// ListAllForConsumerGroup fetches all Plugins in Kong enabled for a consumer group.
+	ListAllForConsumerGroup(ctx context.Context, cgIDorName *string) ([]*Plugin, error)
 	// ListAllForConsumerGroups fetches all Plugins in Kong enabled for a consumer group.
 	ListAllForConsumerGroups(ctx context.Context, cgID *string) ([]*Plugin, error)
+	// ListAllGlobal fetches all Plugins in Kong which are not scoped to any entity.
+	ListAllGlobal(ctx context.Context) ([]*Plugin, error)
+	// ListAllScoped fetches all Plugins in Kong which are scoped to at least one entity.
+	ListAllScoped(ctx context.Context) ([]*Plugin, error)
 	// Validate validates a Plugin against its schema
 	Validate(ctx context.Context, plugin *Plugin) (bool, string, error)
 	// GetSchema retrieves the config schema of a plugin.
@@ -55,6 +66,9 @@ type AbstractPluginService interface {
 	// GetFullSchema retrieves the full schema of a plugin.
 	// This makes the use of `/schemas` endpoint in Kong.
 	GetFullSchema(ctx context.Context, pluginName *string) (Schema, error)
+	// ValidateWebSocketPlugins checks that plugins can run on ws and wss
+	// Routes and Services.
+	ValidateWebSocketPlugins(ctx context.Context, plugins []*Plugin) error
 }
 
 // PluginService handles Plugins in Kong.
@@ -184,15 +198,16 @@ func (s *PluginService) CreateForConsumerGroup(ctx context.Context,
 	return s.sendRequest(ctx, plugin, fmt.Sprintf("/consumer_groups/%v"+queryPath, *cgIDorName), method)
 }
 
-// Get fetches a Plugin in Kong.
+// Get fetches a Plugin in Kong by ID or, on Kong 3.2+, by instance name,
+// which addresses one of several plugins of the same type deterministically.
 func (s *PluginService) Get(ctx context.Context,
-	usernameOrID *string,
+	idOrInstanceName *string,
 ) (*Plugin, error) {
-	if isEmptyString(usernameOrID) {
-		return nil, fmt.Errorf("usernameOrID cannot be nil for Get operation")
+	if isEmptyString(idOrInstanceName) {
+		return nil, fmt.Errorf("idOrInstanceName cannot be nil for Get operation")
 	}
 
-	endpoint := fmt.Sprintf("/plugins/%v", *usernameOrID)
+	endpoint := fmt.Sprintf("/plugins/%v", *idOrInstanceName)
 	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
 	if err != nil {
 		return nil, err
@@ -206,30 +221,47 @@ func (s *PluginService) Get(ctx context.Context,
 	return &plugin, nil
 }
 
-// Update updates a Plugin in Kong
+// Update updates a Plugin in Kong, addressed by its ID or, if it has none,
+// by its instance name on Kong 3.2+. So do the scoped Update methods.
 func (s *PluginService) Update(ctx context.Context,
 	plugin *Plugin,
 ) (*Plugin, error) {
-	if isEmptyString(plugin.ID) {
-		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	idOrInstanceName := pluginIDOrInstanceName(plugin)
+	if isEmptyString(idOrInstanceName) {
+		return nil, fmt.Errorf("ID or InstanceName cannot be nil for Update operation")
 	}
 
-	endpoint := fmt.Sprintf("/plugins/%v", *plugin.ID)
+	endpoint := fmt.Sprintf("/plugins/%v", *idOrInstanceName)
 	return s.sendRequest(ctx, plugin, endpoint, "PATCH")
 }
 
+// SetEnabled enables or disables a Plugin in Kong.
+// Only the enabled field is sent, leaving the rest of the Plugin,
+// including its configuration, untouched.
+func (s *PluginService) SetEnabled(ctx context.Context,
+	pluginID *string, enabled bool,
+) (*Plugin, error) {
+	if isEmptyString(pluginID) {
+		return nil, fmt.Errorf("pluginID cannot be nil for SetEnabled operation")
+	}
+
+	endpoint := fmt.Sprintf("/plugins/%v", *pluginID)
+	return s.sendRequest(ctx, &Plugin{Enabled: Bool(enabled)}, endpoint, "PATCH")
+}
+
 // UpdateForService updates a Plugin in Kong at Service level.
 func (s *PluginService) UpdateForService(ctx context.Context,
 	serviceIDorName *string, plugin *Plugin,
 ) (*Plugin, error) {
-	if isEmptyString(plugin.ID) {
-		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	idOrInstanceName := pluginIDOrInstanceName(plugin)
+	if isEmptyString(idOrInstanceName) {
+		return nil, fmt.Errorf("ID or InstanceName cannot be nil for Update operation")
 	}
 	if isEmptyString(serviceIDorName) {
 		return nil, fmt.Errorf("serviceIDorName cannot be nil")
 	}
 
-	endpoint := fmt.Sprintf("/services/%v/plugins/%v", *serviceIDorName, *plugin.ID)
+	endpoint := fmt.Sprintf("/services/%v/plugins/%v", *serviceIDorName, *idOrInstanceName)
 	return s.sendRequest(ctx, plugin, endpoint, "PATCH")
 }
 
@@ -237,14 +269,15 @@ func (s *PluginService) UpdateForService(ctx context.Context,
 func (s *PluginService) UpdateForRoute(ctx context.Context,
 	routeIDorName *string, plugin *Plugin,
 ) (*Plugin, error) {
-	if isEmptyString(plugin.ID) {
-		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	idOrInstanceName := pluginIDOrInstanceName(plugin)
+	if isEmptyString(idOrInstanceName) {
+		return nil, fmt.Errorf("ID or InstanceName cannot be nil for Update operation")
 	}
 	if isEmptyString(routeIDorName) {
 		return nil, fmt.Errorf("routeIDorName cannot be nil")
 	}
 
-	endpoint := fmt.Sprintf("/routes/%v/plugins/%v", *routeIDorName, *plugin.ID)
+	endpoint := fmt.Sprintf("/routes/%v/plugins/%v", *routeIDorName, *idOrInstanceName)
 	return s.sendRequest(ctx, plugin, endpoint, "PATCH")
 }
 
@@ -255,26 +288,27 @@ func (s *PluginService) UpdateForConsumerGroup(ctx context.Context,
 	if plugin == nil {
 		return nil, fmt.Errorf("plugin cannot be nil")
 	}
-	if isEmptyString(plugin.ID) {
-		return nil, fmt.Errorf("ID cannot be nil for Update operation")
+	idOrInstanceName := pluginIDOrInstanceName(plugin)
+	if isEmptyString(idOrInstanceName) {
+		return nil, fmt.Errorf("ID or InstanceName cannot be nil for Update operation")
 	}
 	if isEmptyString(cgIDorName) {
 		return nil, fmt.Errorf("cgIDorName cannot be nil")
 	}
 
-	endpoint := fmt.Sprintf("/consumer_groups/%v/plugins/%v", *cgIDorName, *plugin.ID)
+	endpoint := fmt.Sprintf("/consumer_groups/%v/plugins/%v", *cgIDorName, *idOrInstanceName)
 	return s.sendRequest(ctx, plugin, endpoint, "PATCH")
 }
 
-// Delete deletes a Plugin in Kong
+// Delete deletes a Plugin in Kong by ID or, on Kong 3.2+, by instance name.
 func (s *PluginService) Delete(ctx context.Context,
-	pluginID *string,
+	idOrInstanceName *string,
 ) error {
-	if isEmptyString(pluginID) {
-		return fmt.Errorf("pluginID cannot be nil for Delete operation")
+	if isEmptyString(idOrInstanceName) {
+		return fmt.Errorf("idOrInstanceName cannot be nil for Delete operation")
 	}
 
-	endpoint := fmt.Sprintf("/plugins/%v", *pluginID)
+	endpoint := fmt.Sprintf("/plugins/%v", *idOrInstanceName)
 	_, err := s.sendRequest(ctx, nil, endpoint, "DELETE")
 	if err != nil {
 		return err
@@ -320,6 +354,22 @@ func (s *PluginService) DeleteForRoute(ctx context.Context,
 	return nil
 }
 
+// DeleteForConsumerGroup deletes a Plugin in Kong at ConsumerGroup level.
+func (s *PluginService) DeleteForConsumerGroup(ctx context.Context,
+	cgIDorName *string, pluginID *string,
+) error {
+	if isEmptyString(pluginID) {
+		return fmt.Errorf("plugin ID cannot be nil for Delete operation")
+	}
+	if isEmptyString(cgIDorName) {
+		return fmt.Errorf("cgIDorName cannot be nil")
+	}
+
+	endpoint := fmt.Sprintf("/consumer_groups/%v/plugins/%v", *cgIDorName, *pluginID)
+	_, err := s.sendRequest(ctx, nil, endpoint, "DELETE")
+	return err
+}
+
 // Validate validates a Plugin against its schema
 func (s *PluginService) Validate(ctx context.Context, plugin *Plugin) (bool, string, error) {
 	endpoint := "/schemas/plugins/validate"
@@ -368,7 +418,7 @@ func (s *PluginService) listByPath(ctx context.Context,
 			return nil, nil, err
 		}
 		var plugin Plugin
-		err = json.Unmarshal(b, &plugin)
+		err = s.client.decode(b, &plugin)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -413,6 +463,17 @@ func (s *PluginService) ListAll(ctx context.Context) ([]*Plugin, error) {
 	return s.listAllByPath(ctx, "/plugins")
 }
 
+// DeleteWhere deletes the Plugins in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Plugins are deleted from them,
+// so that unlike deleting Plugins while paginating, none are skipped.
+func (s *PluginService) DeleteWhere(ctx context.Context,
+	predicate func(*Plugin) bool,
+) ([]*Plugin, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Plugin) *string { return entity.ID }, predicate)
+}
+
 // ListAllForConsumer fetches all Plugins in Kong enabled for a consumer.
 func (s *PluginService) ListAllForConsumer(ctx context.Context,
 	consumerIDorName *string,
@@ -443,6 +504,16 @@ func (s *PluginService) ListAllForRoute(ctx context.Context,
 	return s.listAllByPath(ctx, "/routes/"+*routeID+"/plugins")
 }
 
+// ListAllForConsumerGroup fetches all Plugins in Kong enabled for a consumer group.
+func (s *PluginService) ListAllForConsumerGroup(ctx context.Context,
+	cgIDorName *string,
+) ([]*Plugin, error) {
+	if isEmptyString(cgIDorName) {
+		return nil, fmt.Errorf("cgIDorName cannot be nil")
+	}
+	return s.listAllByPath(ctx, "/consumer_groups/"+*cgIDorName+"/plugins")
+}
+
 // ListAllForConsumerGroups fetches all Plugins in Kong enabled for a consumer group.
 func (s *PluginService) ListAllForConsumerGroups(ctx context.Context,
 	cgID *string,
@@ -450,7 +521,47 @@ func (s *PluginService) ListAllForConsumerGroups(ctx context.Context,
 	if isEmptyString(cgID) {
 		return nil, fmt.Errorf("cgID cannot be nil")
 	}
-	return s.listAllByPath(ctx, "/consumer_groups/"+*cgID+"/plugins")
+	return s.ListAllForConsumerGroup(ctx, cgID)
+}
+
+// ListAllGlobal fetches all Plugins in Kong which are not scoped to a
+// service, a route, a consumer or a consumer group.
+func (s *PluginService) ListAllGlobal(ctx context.Context) ([]*Plugin, error) {
+	return s.listAllFiltered(ctx, func(p *Plugin) bool { return p.IsGlobal() })
+}
+
+// ListAllScoped fetches all Plugins in Kong which are scoped to at least
+// one of a service, a route, a consumer or a consumer group.
+func (s *PluginService) ListAllScoped(ctx context.Context) ([]*Plugin, error) {
+	return s.listAllFiltered(ctx, func(p *Plugin) bool { return !p.IsGlobal() })
+}
+
+func (s *PluginService) listAllFiltered(ctx context.Context,
+	keep func(*Plugin) bool,
+) ([]*Plugin, error) {
+	plugins, err := s.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var res []*Plugin
+	for _, plugin := range plugins {
+		if keep(plugin) {
+			res = append(res, plugin)
+		}
+	}
+	return res, nil
+}
+
+// pluginIDOrInstanceName returns the ID of plugin or, if it has none, its
+// instance name, which Kong 3.2+ accepts in place of IDs.
+func pluginIDOrInstanceName(plugin *Plugin) *string {
+	if plugin == nil {
+		return nil
+	}
+	if isEmptyString(plugin.ID) {
+		return plugin.InstanceName
+	}
+	return plugin.ID
 }
 
 func (s *PluginService) sendRequest(ctx context.Context, plugin *Plugin, endpoint, method string) (*Plugin, error) {
@@ -1,6 +1,9 @@
 package kong
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -877,3 +880,133 @@ func comparePlugins(T *testing.T, expected, actual []*Plugin) bool {
 
 	return (compareSlices(expectedNames, actualNames))
 }
+
+func TestPluginListAllGlobalAndScoped(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/plugins", r.URL.Path)
+		_, _ = w.Write([]byte(`{"data":[
+			{"id":"p1","name":"prometheus"},
+			{"id":"p2","name":"key-auth","service":{"id":"s1"}},
+			{"id":"p3","name":"rate-limiting","consumer_group":{"id":"cg1"}},
+			{"id":"p4","name":"cors","route":null}
+		]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	global, err := client.Plugins.ListAllGlobal(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, global, 2)
+	assert.Equal(t, "p1", *global[0].ID)
+	assert.Equal(t, "p4", *global[1].ID)
+
+	scoped, err := client.Plugins.ListAllScoped(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, scoped, 2)
+	assert.Equal(t, "p2", *scoped[0].ID)
+	assert.Equal(t, "p3", *scoped[1].ID)
+}
+
+func TestPluginSetEnabled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH /plugins/p1", r.Method+" "+r.URL.Path)
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"enabled":false}`, string(body))
+		_, _ = w.Write([]byte(`{"id":"p1","name":"key-auth","enabled":false,"config":{"hide_credentials":true}}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, err = client.Plugins.SetEnabled(defaultCtx, nil, false)
+	assert.Error(t, err)
+
+	plugin, err := client.Plugins.SetEnabled(defaultCtx, String("p1"), false)
+	require.NoError(t, err)
+	assert.False(t, *plugin.Enabled)
+	assert.Equal(t, true, plugin.Config["hide_credentials"])
+}
+
+func TestPluginsForConsumerGroupScoping(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","name":"rate-limiting-advanced","consumer_group":{"id":"cg1"}}
+			]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"p1","name":"rate-limiting-advanced","consumer_group":{"id":"cg1"}}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	plugin, err := client.Plugins.CreateForConsumerGroup(defaultCtx, String("gold"),
+		&Plugin{Name: String("rate-limiting-advanced")})
+	require.NoError(t, err)
+	assert.Equal(t, "cg1", *plugin.ConsumerGroup.ID)
+
+	plugins, err := client.Plugins.ListAllForConsumerGroup(defaultCtx, String("gold"))
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "cg1", *plugins[0].ConsumerGroup.ID)
+
+	require.NoError(t, client.Plugins.DeleteForConsumerGroup(defaultCtx, String("gold"), String("p1")))
+
+	_, err = client.Plugins.ListAllForConsumerGroup(defaultCtx, nil)
+	assert.Error(t, err)
+	assert.Error(t, client.Plugins.DeleteForConsumerGroup(defaultCtx, String("gold"), nil))
+	assert.Error(t, client.Plugins.DeleteForConsumerGroup(defaultCtx, nil, String("p1")))
+
+	assert.Equal(t, []string{
+		"POST /consumer_groups/gold/plugins",
+		"GET /consumer_groups/gold/plugins",
+		"DELETE /consumer_groups/gold/plugins/p1",
+	}, requests)
+}
+
+func TestPluginsByInstanceName(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"p1","name":"rate-limiting","instance_name":"rl-gold"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	plugin, err := client.Plugins.Get(defaultCtx, String("rl-gold"))
+	require.NoError(t, err)
+	assert.Equal(t, "rl-gold", *plugin.InstanceName)
+
+	byName := &Plugin{InstanceName: String("rl-gold"), Config: Configuration{"minute": 10}}
+	_, err = client.Plugins.Update(defaultCtx, byName)
+	require.NoError(t, err)
+	_, err = client.Plugins.UpdateForService(defaultCtx, String("svc"), byName)
+	require.NoError(t, err)
+	_, err = client.Plugins.Update(defaultCtx, &Plugin{ID: String("p1"), InstanceName: String("rl-gold")})
+	require.NoError(t, err)
+	require.NoError(t, client.Plugins.Delete(defaultCtx, String("rl-gold")))
+
+	_, err = client.Plugins.Update(defaultCtx, &Plugin{Name: String("rate-limiting")})
+	assert.EqualError(t, err, "ID or InstanceName cannot be nil for Update operation")
+
+	assert.Equal(t, []string{
+		"GET /plugins/rl-gold",
+		"PATCH /plugins/rl-gold",
+		"PATCH /services/svc/plugins/rl-gold",
+		"PATCH /plugins/p1",
+		"DELETE /plugins/rl-gold",
+	}, requests)
+}
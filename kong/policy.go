@@ -0,0 +1,167 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Policy checks the operations planned for an Applier before they are
+// applied, e.g. to enforce the guardrails of an organization. It returns
+// the operations to apply, possibly changed, or an error, usually an
+// ErrPolicyViolation, to veto them.
+type Policy func(ctx context.Context, operations []Operation) ([]Operation, error)
+
+// ErrPolicyViolation is returned by policies vetoing operations.
+type ErrPolicyViolation struct {
+	// Policy is the name of the policy, as registered with
+	// Applier.AddPolicy.
+	Policy string
+	// Operation is the operation violating the policy, if any.
+	Operation *Operation
+	Reason    string
+}
+
+func (e *ErrPolicyViolation) Error() string {
+	if e.Operation != nil {
+		return fmt.Sprintf("policy %s: %s %s: %s", e.Policy, e.Operation.Action, e.Operation.endpoint(), e.Reason)
+	}
+	return fmt.Sprintf("policy %s: %s", e.Policy, e.Reason)
+}
+
+// IsPolicyViolationErr returns true if the error or its cause is
+// an ErrPolicyViolation.
+func IsPolicyViolationErr(e error) bool {
+	var policyErr *ErrPolicyViolation
+	return errors.As(e, &policyErr)
+}
+
+type namedPolicy struct {
+	name   string
+	policy Policy
+}
+
+// AddPolicy registers policy under name. Policies are run in the order
+// they were registered, each one receiving the operations returned by the
+// previous one.
+func (a *Applier) AddPolicy(name string, policy Policy) {
+	a.policies = append(a.policies, namedPolicy{name: name, policy: policy})
+}
+
+// CheckPolicies runs the registered policies on operations, as Apply does,
+// and returns the operations to apply, e.g. to check a plan before asking
+// for its approval.
+func (a *Applier) CheckPolicies(ctx context.Context, operations []Operation) ([]Operation, error) {
+	for _, p := range a.policies {
+		checked, err := p.policy(ctx, operations)
+		if err != nil {
+			var policyErr *ErrPolicyViolation
+			if errors.As(err, &policyErr) && policyErr.Policy == "" {
+				policyErr.Policy = p.name
+			}
+			if !IsPolicyViolationErr(err) {
+				err = fmt.Errorf("policy %s: %w", p.name, err)
+			}
+			return nil, err
+		}
+		operations = checked
+	}
+	return operations, nil
+}
+
+// ForbidDeletion returns a Policy vetoing the deletion of entities of
+// entityTypes, e.g. "consumers", including nested ones, e.g.
+// "consumers/alice/key-auth" for "key-auth".
+func ForbidDeletion(entityTypes ...string) Policy {
+	return func(_ context.Context, operations []Operation) ([]Operation, error) {
+		for i, operation := range operations {
+			if operation.Action != MutationDelete {
+				continue
+			}
+			entityType := planEntityType(operation.EntityType)
+			for _, forbidden := range entityTypes {
+				if entityType == forbidden {
+					return nil, &ErrPolicyViolation{
+						Operation: &operations[i],
+						Reason:    "deleting " + forbidden + " is forbidden",
+					}
+				}
+			}
+		}
+		return operations, nil
+	}
+}
+
+// RequireRoutePlugin returns a Policy vetoing the creation of routes
+// without one of plugins, e.g. authentication plugins, created along in
+// the same operations. Routes must be created with an ID, or a name, for
+// their plugins to reference them.
+func RequireRoutePlugin(plugins ...string) Policy {
+	return func(_ context.Context, operations []Operation) ([]Operation, error) {
+		covered := map[string]bool{}
+		for _, operation := range operations {
+			if operation.Action != MutationCreate || planEntityType(operation.EntityType) != "plugins" {
+				continue
+			}
+			var plugin Plugin
+			if err := decodeOperationEntity(operation, &plugin); err != nil {
+				return nil, err
+			}
+			if !containsString(plugins, derefString(plugin.Name)) {
+				continue
+			}
+			// plugins created with "routes/{id}/plugins"
+			parts := strings.Split(strings.Trim(operation.EntityType, "/"), "/")
+			if len(parts) == 3 && parts[0] == "routes" {
+				covered[parts[1]] = true
+			}
+			if plugin.Route != nil {
+				covered[derefString(plugin.Route.ID)] = true
+				covered[derefString(plugin.Route.Name)] = true
+			}
+		}
+		for i, operation := range operations {
+			if operation.Action != MutationCreate || strings.Trim(operation.EntityType, "/") != "routes" {
+				continue
+			}
+			var route Route
+			if err := decodeOperationEntity(operation, &route); err != nil {
+				return nil, err
+			}
+			if covered[operation.ID] || (!isEmptyString(route.ID) && covered[*route.ID]) ||
+				(!isEmptyString(route.Name) && covered[*route.Name]) {
+				continue
+			}
+			return nil, &ErrPolicyViolation{
+				Operation: &operations[i],
+				Reason:    "routes require one of the plugins " + strings.Join(plugins, ", "),
+			}
+		}
+		return operations, nil
+	}
+}
+
+func decodeOperationEntity(operation Operation, v interface{}) error {
+	if operation.Entity == nil {
+		return nil
+	}
+	b, err := json.Marshal(operation.Entity)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("decoding entity of %s %s: %w", operation.Action, operation.endpoint(), err)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplierPolicies(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"x"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	t.Run("veto", func(t *testing.T) {
+		requests = nil
+		applier := NewApplier(client)
+		applier.AddPolicy("no-consumer-deletion", ForbidDeletion("consumers", "key-auth"))
+		report, err := applier.Apply(defaultCtx, []Operation{
+			{Action: MutationCreate, EntityType: "services", Entity: &Service{Host: String("a")}},
+			{Action: MutationDelete, EntityType: "consumers/alice/key-auth", ID: "k1"},
+		})
+		require.Error(t, err)
+		assert.True(t, IsPolicyViolationErr(err))
+		assert.EqualError(t, err,
+			"policy no-consumer-deletion: delete /consumers/alice/key-auth/k1: deleting key-auth is forbidden")
+		assert.Equal(t, err.Error(), report.Error)
+		assert.False(t, report.Succeeded)
+		for _, result := range report.Results {
+			assert.Equal(t, OperationSkipped, result.Status)
+		}
+		assert.Empty(t, requests)
+	})
+	t.Run("mutation", func(t *testing.T) {
+		requests = nil
+		applier := NewApplier(client)
+		applier.AddPolicy("no-deletion", func(_ context.Context, operations []Operation) ([]Operation, error) {
+			var res []Operation
+			for _, operation := range operations {
+				if operation.Action != MutationDelete {
+					res = append(res, operation)
+				}
+			}
+			return res, nil
+		})
+		report, err := applier.Apply(defaultCtx, []Operation{
+			{Action: MutationCreate, EntityType: "services", Entity: &Service{Host: String("a")}},
+			{Action: MutationDelete, EntityType: "routes", ID: "r1"},
+		})
+		require.NoError(t, err)
+		assert.True(t, report.Succeeded)
+		assert.Len(t, report.Results, 1)
+		assert.Equal(t, []string{"POST /services"}, requests)
+	})
+	t.Run("errors", func(t *testing.T) {
+		applier := NewApplier(client)
+		applier.AddPolicy("broken", func(context.Context, []Operation) ([]Operation, error) {
+			return nil, errors.New("boom")
+		})
+		_, err := applier.CheckPolicies(defaultCtx, nil)
+		assert.EqualError(t, err, "policy broken: boom")
+		assert.False(t, IsPolicyViolationErr(err))
+	})
+}
+
+func TestRequireRoutePlugin(t *testing.T) {
+	policy := RequireRoutePlugin("key-auth", "openid-connect")
+	operations := []Operation{
+		{Action: MutationCreate, EntityType: "routes", ID: "r1", Entity: &Route{}},
+		{Action: MutationCreate, EntityType: "routes", Entity: &Route{Name: String("r2")}},
+		{Action: MutationCreate, EntityType: "routes/r1/plugins", Entity: &Plugin{Name: String("key-auth")}},
+		{Action: MutationCreate, EntityType: "plugins", Entity: &Plugin{
+			Name: String("openid-connect"), Route: &Route{Name: String("r2")},
+		}},
+		{Action: MutationUpdate, EntityType: "routes", ID: "r3", Entity: &Route{}},
+	}
+	checked, err := policy(defaultCtx, operations)
+	require.NoError(t, err)
+	assert.Equal(t, operations, checked)
+
+	_, err = policy(defaultCtx, append(operations, Operation{
+		Action: MutationCreate, EntityType: "routes", Entity: &Route{Name: String("r4")},
+	}, Operation{
+		Action: MutationCreate, EntityType: "plugins", Entity: &Plugin{
+			Name: String("cors"), Route: &Route{Name: String("r4")},
+		},
+	}))
+	require.Error(t, err)
+	var policyErr *ErrPolicyViolation
+	require.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, "routes require one of the plugins key-auth, openid-connect", policyErr.Reason)
+	assert.Equal(t, String("r4"), policyErr.Operation.Entity.(*Route).Name)
+}
@@ -0,0 +1,174 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// PortalAuthType is the authentication plugin developers log in to the
+// Dev Portal of a workspace with.
+type PortalAuthType string
+
+const (
+	// PortalAuthBasic authenticates developers with their email and
+	// password.
+	PortalAuthBasic PortalAuthType = "basic-auth"
+	// PortalAuthKey authenticates developers with API keys.
+	PortalAuthKey PortalAuthType = "key-auth"
+	// PortalAuthOpenIDConnect authenticates developers with an OpenID
+	// Connect identity provider.
+	PortalAuthOpenIDConnect PortalAuthType = "openid-connect"
+)
+
+// PortalAuth is the developer authentication configuration of the Dev
+// Portal of a workspace, stored in the portal_* fields of its config.
+// Nil fields are left unchanged by WorkspaceService.UpdatePortalAuth.
+type PortalAuth struct {
+	// Enabled enables the Dev Portal of the workspace.
+	Enabled *bool
+	Type    *PortalAuthType
+	// Conf is the configuration of the authentication plugin, e.g. built
+	// with PortalOpenIDConnectConf.Map.
+	Conf map[string]interface{}
+	// SessionConf configures the sessions of logged in developers. It is
+	// required by basic-auth and openid-connect.
+	SessionConf *PortalSessionConf
+	// AutoApprove approves developers as soon as they register.
+	AutoApprove *bool
+}
+
+// PortalSessionConf configures the session plugin used for developer
+// sessions.
+type PortalSessionConf struct {
+	Secret         *string `json:"secret,omitempty" yaml:"secret,omitempty"`
+	CookieName     *string `json:"cookie_name,omitempty" yaml:"cookie_name,omitempty"`
+	CookieDomain   *string `json:"cookie_domain,omitempty" yaml:"cookie_domain,omitempty"`
+	CookieSecure   *bool   `json:"cookie_secure,omitempty" yaml:"cookie_secure,omitempty"`
+	CookieSameSite *string `json:"cookie_samesite,omitempty" yaml:"cookie_samesite,omitempty"`
+	CookieLifetime *int    `json:"cookie_lifetime,omitempty" yaml:"cookie_lifetime,omitempty"`
+	Storage        *string `json:"storage,omitempty" yaml:"storage,omitempty"`
+}
+
+// PortalOpenIDConnectConf holds the settings of the openid-connect
+// plugin commonly needed to log developers in with an identity provider.
+type PortalOpenIDConnectConf struct {
+	Issuer               *string   `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+	ClientID             []*string `json:"client_id,omitempty" yaml:"client_id,omitempty"`
+	ClientSecret         []*string `json:"client_secret,omitempty" yaml:"client_secret,omitempty"`
+	RedirectURI          []*string `json:"redirect_uri,omitempty" yaml:"redirect_uri,omitempty"`
+	LoginRedirectURI     []*string `json:"login_redirect_uri,omitempty" yaml:"login_redirect_uri,omitempty"`
+	LogoutRedirectURI    []*string `json:"logout_redirect_uri,omitempty" yaml:"logout_redirect_uri,omitempty"`
+	ForbiddenRedirectURI []*string `json:"forbidden_redirect_uri,omitempty" yaml:"forbidden_redirect_uri,omitempty"`
+	Scopes               []*string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	ConsumerBy           []*string `json:"consumer_by,omitempty" yaml:"consumer_by,omitempty"`
+	ConsumerClaim        []*string `json:"consumer_claim,omitempty" yaml:"consumer_claim,omitempty"`
+	AuthMethods          []*string `json:"auth_methods,omitempty" yaml:"auth_methods,omitempty"`
+}
+
+// Map returns c as a PortalAuth.Conf.
+func (c *PortalOpenIDConnectConf) Map() (map[string]interface{}, error) {
+	var conf map[string]interface{}
+	if err := convert(c, &conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// GetPortalAuth fetches the developer authentication configuration of
+// the Dev Portal of the workspace with nameOrID.
+func (s *WorkspaceService) GetPortalAuth(ctx context.Context,
+	nameOrID *string,
+) (*PortalAuth, error) {
+	workspace, err := s.Get(ctx, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	config := workspace.Config
+	auth := &PortalAuth{}
+	if enabled, ok := config["portal"].(bool); ok {
+		auth.Enabled = Bool(enabled)
+	}
+	if authType, ok := config["portal_auth"].(string); ok && authType != "" {
+		auth.Type = (*PortalAuthType)(String(authType))
+	}
+	if autoApprove, ok := config["portal_auto_approve"].(bool); ok {
+		auth.AutoApprove = Bool(autoApprove)
+	}
+	if err := decodePortalConf(config["portal_auth_conf"], &auth.Conf); err != nil {
+		return nil, fmt.Errorf("decoding portal_auth_conf: %w", err)
+	}
+	if err := decodePortalConf(config["portal_session_conf"], &auth.SessionConf); err != nil {
+		return nil, fmt.Errorf("decoding portal_session_conf: %w", err)
+	}
+	return auth, nil
+}
+
+// UpdatePortalAuth updates the developer authentication configuration
+// of the Dev Portal of the workspace with nameOrID. Only the non-nil
+// fields of auth are sent, the other settings of the workspace are kept.
+func (s *WorkspaceService) UpdatePortalAuth(ctx context.Context,
+	nameOrID *string, auth *PortalAuth,
+) (*Workspace, error) {
+	if isEmptyString(nameOrID) {
+		return nil, fmt.Errorf("nameOrID cannot be nil for UpdatePortalAuth operation")
+	}
+	if auth == nil {
+		return nil, fmt.Errorf("cannot update a nil portal auth")
+	}
+
+	config := map[string]interface{}{}
+	if auth.Enabled != nil {
+		config["portal"] = *auth.Enabled
+	}
+	if auth.Type != nil {
+		config["portal_auth"] = *auth.Type
+	}
+	if auth.AutoApprove != nil {
+		config["portal_auto_approve"] = *auth.AutoApprove
+	}
+	// Kong stores these plugin configurations as JSON strings
+	if auth.Conf != nil {
+		b, err := json.Marshal(auth.Conf)
+		if err != nil {
+			return nil, err
+		}
+		config["portal_auth_conf"] = string(b)
+	}
+	if auth.SessionConf != nil {
+		b, err := json.Marshal(auth.SessionConf)
+		if err != nil {
+			return nil, err
+		}
+		config["portal_session_conf"] = string(b)
+	}
+
+	endpoint := fmt.Sprintf("/workspaces/%v", *nameOrID)
+	req, err := s.client.NewRequest("PATCH", endpoint, nil, &Workspace{Config: config})
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedWorkspace Workspace
+	_, err = s.client.Do(ctx, req, &updatedWorkspace)
+	if err != nil {
+		return nil, err
+	}
+	return &updatedWorkspace, nil
+}
+
+// decodePortalConf decodes a plugin configuration of the workspace
+// config, which Kong returns as a JSON string or as an object.
+func decodePortalConf(value interface{}, v interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if value == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(value), v)
+	default:
+		return convert(value, v)
+	}
+}
@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceServicePortalAuth(t *testing.T) {
+	var patched struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /workspaces/team-a":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&patched))
+			_, _ = w.Write([]byte(`{"id":"ws1","name":"team-a"}`))
+		case "GET /workspaces/team-a":
+			_, _ = w.Write([]byte(`{"id":"ws1","name":"team-a","config":{"portal":true,
+				"portal_auth":"openid-connect","portal_auto_approve":false,
+				"portal_auth_conf":"{\"issuer\":\"https://idp.example.com\"}",
+				"portal_session_conf":{"secret":"s3cret","cookie_name":"portal_session"}}}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	oidc := &PortalOpenIDConnectConf{
+		Issuer:   String("https://idp.example.com"),
+		ClientID: StringSlice("portal"),
+		Scopes:   StringSlice("openid", "email"),
+	}
+	conf, err := oidc.Map()
+	require.NoError(t, err)
+	authType := PortalAuthOpenIDConnect
+	_, err = client.Workspaces.UpdatePortalAuth(defaultCtx, String("team-a"), &PortalAuth{
+		Enabled:     Bool(true),
+		Type:        &authType,
+		Conf:        conf,
+		SessionConf: &PortalSessionConf{Secret: String("s3cret")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, true, patched.Config["portal"])
+	assert.Equal(t, "openid-connect", patched.Config["portal_auth"])
+	assert.JSONEq(t, `{"issuer":"https://idp.example.com","client_id":["portal"],"scopes":["openid","email"]}`,
+		patched.Config["portal_auth_conf"].(string))
+	assert.JSONEq(t, `{"secret":"s3cret"}`, patched.Config["portal_session_conf"].(string))
+	assert.NotContains(t, patched.Config, "portal_auto_approve")
+
+	auth, err := client.Workspaces.GetPortalAuth(defaultCtx, String("team-a"))
+	require.NoError(t, err)
+	assert.True(t, *auth.Enabled)
+	assert.Equal(t, PortalAuthOpenIDConnect, *auth.Type)
+	assert.False(t, *auth.AutoApprove)
+	assert.Equal(t, "https://idp.example.com", auth.Conf["issuer"])
+	assert.Equal(t, "portal_session", *auth.SessionConf.CookieName)
+
+	_, err = client.Workspaces.UpdatePortalAuth(defaultCtx, String("team-a"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package kong
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrProtectedEntity is returned for requests updating or deleting
+// entities carrying protected tags, see SetProtectedTags.
+type ErrProtectedEntity struct {
+	Method string
+	Path   string
+	// Tags holds the protected tags of the entity.
+	Tags []string
+}
+
+func (e *ErrProtectedEntity) Error() string {
+	return fmt.Sprintf("%s %s: the entity is protected by tags %s",
+		e.Method, e.Path, strings.Join(e.Tags, ", "))
+}
+
+// IsProtectedEntityErr returns true if the error or its cause is
+// an ErrProtectedEntity.
+func IsProtectedEntityErr(e error) bool {
+	var protectedErr *ErrProtectedEntity
+	return errors.As(e, &protectedErr)
+}
+
+// SetProtectedTags makes the client refuse to update or delete entities
+// carrying any of tags, e.g. "managed-by:other-team", with an
+// ErrProtectedEntity, so that automation doesn't change entities owned by
+// others. Calling it without tags removes the protection.
+//
+// Entities are fetched before every PUT, PATCH and DELETE request to
+// check their tags, at the cost of an additional request. Requests fail
+// if entities can't be fetched, unless they don't exist.
+func (c *Client) SetProtectedTags(tags ...string) {
+	c.protectedTags.Store(mergeTags(nil, tags))
+}
+
+// ProtectedTags returns the tags protecting entities from changes, see
+// SetProtectedTags.
+func (c *Client) ProtectedTags() []string {
+	tags, _ := c.protectedTags.Load().([]string)
+	return tags
+}
+
+// checkProtected returns an ErrProtectedEntity if req updates or deletes
+// an entity carrying protected tags.
+func (c *Client) checkProtected(ctx context.Context, req *http.Request) error {
+	protected := c.ProtectedTags()
+	if len(protected) == 0 {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return nil
+	}
+	path := c.relativePath(req)
+	segments := pathSegments(path)
+	// these endpoints don't write entities
+	if len(segments)%2 == 1 || segments[0] == "schemas" || segments[0] == "config" ||
+		isHealthPath(segments) {
+		return nil
+	}
+
+	get, err := c.NewRequestRaw(http.MethodGet, c.workspacedBaseURL(c.requestWorkspace(req)), path, nil, nil)
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = defaultCtx
+	}
+	var entity struct {
+		Tags []string `json:"tags"`
+	}
+	if _, err := c.Do(ctx, get, &entity); err != nil {
+		if IsNotFoundErr(err) {
+			return nil
+		}
+		return fmt.Errorf("checking tags of %s: %w", path, err)
+	}
+	isProtected := map[string]bool{}
+	for _, tag := range protected {
+		isProtected[tag] = true
+	}
+	var tags []string
+	for _, tag := range entity.Tags {
+		if isProtected[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return &ErrProtectedEntity{Method: req.Method, Path: path, Tags: tags}
+}
@@ -0,0 +1,78 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectedTags(t *testing.T) {
+	var writes []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes = append(writes, r.Method+" "+r.URL.Path)
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/services/foreign", "/services/healthy":
+			_, _ = w.Write([]byte(`{"id":"foreign","tags":["x","managed-by:other-team"]}`))
+		case "/services/mine":
+			_, _ = w.Write([]byte(`{"id":"mine","tags":["managed-by:us"]}`))
+		case "/services/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	// without protected tags, entities aren't fetched
+	require.NoError(t, client.Services.Delete(defaultCtx, String("broken")))
+
+	client.SetProtectedTags("managed-by:other-team", "managed-by:legacy")
+	assert.Equal(t, []string{"managed-by:other-team", "managed-by:legacy"}, client.ProtectedTags())
+
+	writes = nil
+	err = client.Services.Delete(defaultCtx, String("foreign"))
+	require.Error(t, err)
+	assert.True(t, IsProtectedEntityErr(err))
+	var protectedErr *ErrProtectedEntity
+	require.ErrorAs(t, err, &protectedErr)
+	assert.Equal(t, []string{"managed-by:other-team"}, protectedErr.Tags)
+	assert.Equal(t, "DELETE /services/foreign: the entity is protected by tags managed-by:other-team",
+		err.Error())
+	_, err = client.Services.Update(defaultCtx, &Service{ID: String("foreign"), Host: String("h")})
+	assert.True(t, IsProtectedEntityErr(err))
+	_, err = client.Services.Create(defaultCtx, &Service{ID: String("foreign")})
+	assert.True(t, IsProtectedEntityErr(err))
+	// entities may be named like health endpoints
+	_, err = client.Services.Create(defaultCtx, &Service{ID: String("healthy")})
+	assert.True(t, IsProtectedEntityErr(err))
+	assert.True(t, IsProtectedEntityErr(client.Services.Delete(defaultCtx, String("healthy"))))
+	assert.Empty(t, writes)
+
+	_, err = client.Services.Update(defaultCtx, &Service{ID: String("mine"), Host: String("h")})
+	require.NoError(t, err)
+	_, err = client.Services.Create(defaultCtx, &Service{ID: String("new")})
+	require.NoError(t, err)
+	_, err = client.Routes.Create(defaultCtx, &Route{Paths: StringSlice("/")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PATCH /services/mine", "PUT /services/new", "POST /routes"}, writes)
+
+	err = client.Services.Delete(defaultCtx, String("broken"))
+	require.Error(t, err)
+	assert.False(t, IsProtectedEntityErr(err))
+
+	client.SetProtectedTags()
+	require.NoError(t, client.Services.Delete(defaultCtx, String("foreign")))
+}
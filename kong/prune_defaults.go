@@ -0,0 +1,128 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/tidwall/gjson"
+)
+
+// PruneDefaults returns entity as JSON without the fields whose values
+// equal their defaults in schema, as returned by SchemaService.Get or
+// PluginService.GetFullSchema, nor the null fields without defaults, so
+// that dumps only hold what was configured. Records left empty are
+// removed too. Kong fills the pruned fields back when the entity is
+// created.
+func PruneDefaults(schema Schema, entity interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]interface{}
+	if err := convert(entity, &values); err != nil {
+		return nil, err
+	}
+	pruneRecord(gjson.ParseBytes(b), values)
+	return json.Marshal(values)
+}
+
+func pruneRecord(record gjson.Result, values map[string]interface{}) {
+	for _, field := range record.Get("fields").Array() {
+		for name, schema := range field.Map() {
+			value, ok := values[name]
+			if !ok {
+				continue
+			}
+			def := schema.Get("default")
+			if nested, ok := value.(map[string]interface{}); ok && schema.Get("fields").Exists() {
+				pruneRecord(schema, nested)
+				if len(nested) == 0 && (!def.Exists() || def.Type == gjson.Null) {
+					delete(values, name)
+				}
+				continue
+			}
+			switch {
+			case value == nil:
+				if !def.Exists() || def.Type == gjson.Null {
+					delete(values, name)
+				}
+			case def.Exists() && reflect.DeepEqual(value, def.Value()):
+				delete(values, name)
+			}
+		}
+	}
+}
+
+// defaultsPruner prunes the defaults of entities, fetching their schemas
+// once.
+type defaultsPruner struct {
+	client  *Client
+	schemas map[string]Schema
+}
+
+func newDefaultsPruner(client *Client) *defaultsPruner {
+	return &defaultsPruner{client: client, schemas: map[string]Schema{}}
+}
+
+// prune prunes the defaults of entity, of entityType as named in
+// BackupArchive.
+func (p *defaultsPruner) prune(ctx context.Context, entityType string, entity json.RawMessage) (json.RawMessage, error) {
+	key := entityType
+	if entityType == "plugins" {
+		var plugin struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(entity, &plugin); err != nil {
+			return nil, err
+		}
+		key = "plugins/" + plugin.Name
+	}
+	schema, ok := p.schemas[key]
+	if !ok {
+		var err error
+		if entityType == "plugins" {
+			schema, err = p.client.Plugins.GetFullSchema(ctx, String(key[len("plugins/"):]))
+		} else {
+			schema, err = p.client.Schemas.Get(ctx, entityType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("fetching schema of %s: %w", key, err)
+		}
+		p.schemas[key] = schema
+	}
+	return PruneDefaults(schema, entity)
+}
+
+// pruneArchive returns a copy of archive without the defaults of its
+// entities.
+func (p *defaultsPruner) pruneArchive(ctx context.Context, archive *BackupArchive) (*BackupArchive, error) {
+	var fields map[string]json.RawMessage
+	if err := convert(archive, &fields); err != nil {
+		return nil, err
+	}
+	for entityType, raw := range fields {
+		var entities []json.RawMessage
+		if entityType == "tags" || json.Unmarshal(raw, &entities) != nil {
+			continue
+		}
+		for i, entity := range entities {
+			pruned, err := p.prune(ctx, entityType, entity)
+			if err != nil {
+				return nil, err
+			}
+			entities[i] = pruned
+		}
+		b, err := json.Marshal(entities)
+		if err != nil {
+			return nil, err
+		}
+		fields[entityType] = b
+	}
+	var res BackupArchive
+	if err := convert(fields, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -0,0 +1,104 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pruneServiceSchema = `{"fields":[
+	{"id":{"type":"string","auto":true}},
+	{"name":{"type":"string"}},
+	{"host":{"type":"string","required":true}},
+	{"port":{"type":"integer","default":80}},
+	{"path":{"type":"string"}},
+	{"retries":{"type":"integer","default":5}},
+	{"tags":{"type":"set","elements":{"type":"string"}}}
+]}`
+
+const pruneSessionSchema = `{"fields":[
+	{"name":{"type":"string"}},
+	{"protocols":{"type":"set","default":["grpc","grpcs","http","https"]}},
+	{"config":{"type":"record","fields":[
+		{"cookie_name":{"type":"string","default":"session"}},
+		{"secret":{"type":"string"}},
+		{"redis":{"type":"record","fields":[{"host":{"type":"string"}},{"port":{"type":"integer","default":6379}}]}}
+	]}}
+]}`
+
+func TestPruneDefaults(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(pruneSessionSchema), &schema))
+	b, err := PruneDefaults(schema, &Plugin{
+		Name:      String("session"),
+		Protocols: StringSlice("grpc", "grpcs", "http", "https"),
+		Config: Configuration{
+			"cookie_name": "session",
+			"secret":      "s",
+			"redis":       map[string]interface{}{"host": nil, "port": 6379},
+			"unknown":     nil,
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"session","config":{"secret":"s","unknown":null}}`, string(b))
+
+	b, err = PruneDefaults(schema, &Plugin{
+		Name:      String("session"),
+		Protocols: StringSlice("https"),
+		Config:    Configuration{"cookie_name": "custom", "redis": map[string]interface{}{"port": 6380}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"session","protocols":["https"],
+		"config":{"cookie_name":"custom","redis":{"port":6380}}}`, string(b))
+}
+
+func TestBackupPruneDefaults(t *testing.T) {
+	var schemaRequests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/schemas/") {
+			schemaRequests = append(schemaRequests, r.URL.Path)
+		}
+		switch r.URL.Path {
+		case "/services":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"s1","name":"a","host":"a.internal","port":80,"path":null,"retries":5,"tags":null},
+				{"id":"s2","name":"b","host":"b.internal","port":8080,"path":"/b","retries":5,"tags":["t"]}]}`))
+		case "/plugins":
+			_, _ = w.Write([]byte(`{"data":[{"id":"p1","name":"session",
+				"protocols":["grpc","grpcs","http","https"],"config":{"cookie_name":"session","secret":"s"}}]}`))
+		case "/schemas/services":
+			_, _ = w.Write([]byte(pruneServiceSchema))
+		case "/schemas/plugins/session":
+			_, _ = w.Write([]byte(pruneSessionSchema))
+		case "/schemas/certificates", "/schemas/snis", "/schemas/ca_certificates", "/schemas/routes",
+			"/schemas/upstreams", "/schemas/consumers", "/schemas/consumer_groups":
+			_, _ = w.Write([]byte(`{"fields":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, client.Backup(defaultCtx, &buf, BackupOpts{PruneDefaults: true}))
+	var archive map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+	assert.JSONEq(t, `[{"id":"s1","name":"a","host":"a.internal"},
+		{"id":"s2","name":"b","host":"b.internal","port":8080,"path":"/b","tags":["t"]}]`, string(archive["services"]))
+	assert.JSONEq(t, `[{"id":"p1","name":"session","config":{"secret":"s"}}]`, string(archive["plugins"]))
+	assert.Equal(t, []string{"/schemas/services", "/schemas/plugins/session"}, schemaRequests)
+
+	buf.Reset()
+	schemaRequests = nil
+	require.NoError(t, client.StreamBackup(defaultCtx, &buf, StreamBackupOpts{PruneDefaults: true}))
+	assert.Contains(t, buf.String(), `{"type":"services","entity":{"host":"a.internal","id":"s1","name":"a"}}`)
+	assert.Equal(t, []string{"/schemas/services", "/schemas/plugins/session"}, schemaRequests)
+}
@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DoEndpoint makes a request to an endpoint of the Admin API which isn't
+// modeled by the services of the client, with everything the client
+// applies to its own requests: workspace, headers and authentication of
+// the HTTP client, pacing, read-only and dry-run modes, hooks and errors.
+//
+// path is relative to the workspace of the client, e.g. "/new-entities".
+// query and body are optional; body is encoded to JSON unless it is a
+// string, a byte slice or an io.Reader. If out is not nil, the response
+// body is decoded into it, or copied into it if out is an io.Writer.
+//
+// Use ListEndpoint and ListAllEndpoint to list entities of an endpoint.
+func (c *Client) DoEndpoint(ctx context.Context, method, path string, query url.Values,
+	body, out interface{},
+) (*Response, error) {
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := c.NewRequest(method, path, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		req.URL.RawQuery = query.Encode()
+	}
+	return c.Do(ctx, req, out)
+}
+
+// ListEndpoint lists a page of the entities of an endpoint which isn't
+// modeled by the services of the client, e.g. "/new-entities", decoding
+// them into T. opt can be used to control pagination.
+func ListEndpoint[T any](ctx context.Context, c *Client, path string,
+	opt *ListOpt,
+) ([]*T, *ListOpt, error) {
+	data, next, err := c.list(ctx, path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	entities := make([]*T, 0, len(data))
+	for _, object := range data {
+		var entity T
+		if err := c.decode(object, &entity); err != nil {
+			return nil, nil, fmt.Errorf("decoding entity of %s: %w", path, err)
+		}
+		entities = append(entities, &entity)
+	}
+	return entities, next, nil
+}
+
+// ListAllEndpoint lists all the entities of an endpoint which isn't
+// modeled by the services of the client, see ListEndpoint.
+func ListAllEndpoint[T any](ctx context.Context, c *Client, path string) ([]*T, error) {
+	var entities, data []*T
+	var err error
+	opt := &ListOpt{Size: pageSize}
+
+	for opt != nil {
+		data, opt, err = ListEndpoint[T](ctx, c, path, opt)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, data...)
+	}
+	return entities, nil
+}
@@ -0,0 +1,73 @@
+package kong
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type widget struct {
+	ID   *string `json:"id,omitempty"`
+	Name *string `json:"name,omitempty"`
+}
+
+func TestDoEndpoint(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch {
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"w"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"w1","name":"w"}`))
+		case r.URL.Path == "/team/widgets/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		case r.URL.Query().Get("offset") == "":
+			_, _ = w.Write([]byte(`{"data":[{"id":"w1"},{"id":"w2"}],"offset":"next"}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[{"id":"w3"}]}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team")
+
+	var created widget
+	resp, err := client.DoEndpoint(defaultCtx, http.MethodPost, "/widgets", url.Values{"force": {"true"}},
+		&widget{Name: String("w")}, &created)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "w1", *created.ID)
+
+	var raw json.RawMessage
+	_, err = client.DoEndpoint(defaultCtx, "", "/widgets/missing", nil, nil, &raw)
+	assert.True(t, IsNotFoundErr(err))
+
+	defer func(size int) { pageSize = size }(pageSize)
+	pageSize = 2
+	widgets, err := ListAllEndpoint[widget](defaultCtx, client, "/widgets")
+	require.NoError(t, err)
+	require.Len(t, widgets, 3)
+	assert.Equal(t, "w3", *widgets[2].ID)
+
+	assert.Equal(t, []string{
+		"POST /team/widgets?force=true",
+		"GET /team/widgets/missing",
+		"GET /team/widgets?size=2",
+		"GET /team/widgets?offset=next&size=2",
+	}, requests)
+
+	client.SetReadOnly(true)
+	_, err = client.DoEndpoint(defaultCtx, http.MethodDelete, "/widgets/w1", nil, nil, nil)
+	assert.Error(t, err)
+	assert.Len(t, requests, 4)
+}
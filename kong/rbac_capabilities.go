@@ -0,0 +1,145 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RBACAction is an action RBAC endpoint permissions allow or deny.
+type RBACAction string
+
+const (
+	// RBACActionRead allows GET requests.
+	RBACActionRead RBACAction = "read"
+	// RBACActionCreate allows POST requests.
+	RBACActionCreate RBACAction = "create"
+	// RBACActionUpdate allows PUT and PATCH requests.
+	RBACActionUpdate RBACAction = "update"
+	// RBACActionDelete allows DELETE requests.
+	RBACActionDelete RBACAction = "delete"
+)
+
+// RBACActionForMethod returns the action checked by Kong for requests
+// with the HTTP method.
+func RBACActionForMethod(method string) RBACAction {
+	switch method {
+	case http.MethodPost:
+		return RBACActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return RBACActionUpdate
+	case http.MethodDelete:
+		return RBACActionDelete
+	}
+	return RBACActionRead
+}
+
+type rbacEndpointRule struct {
+	Actions  []RBACAction `json:"actions"`
+	Negative bool         `json:"negative"`
+}
+
+// Capabilities is what the admin token of a client is allowed to do,
+// as found by ProbeCapabilities.
+type Capabilities struct {
+	// Restricted is false if Kong doesn't enforce RBAC, in which case
+	// everything is allowed.
+	Restricted bool
+	// endpoints maps workspaces, or "*", to endpoint rules.
+	endpoints map[string]map[string]rbacEndpointRule
+}
+
+// ProbeCapabilities fetches the permissions of the admin token set on the
+// client (with the Kong-Admin-Token header) with UserInfoService.Get.
+// Tools can use the result to skip what they aren't allowed to do instead
+// of failing with 403 errors mid-run.
+//
+// If Kong doesn't serve /userinfo, because it doesn't enforce RBAC or
+// isn't Kong Enterprise, the returned Capabilities allow everything.
+func (c *Client) ProbeCapabilities(ctx context.Context) (*Capabilities, error) {
+	userInfo, err := c.UserInfo.Get(ctx)
+	if err != nil {
+		if IsNotFoundErr(err) {
+			return &Capabilities{}, nil
+		}
+		return nil, err
+	}
+	capabilities := &Capabilities{Restricted: true}
+	if userInfo.Permissions != nil {
+		if err := convert(userInfo.Permissions.Endpoints, &capabilities.endpoints); err != nil {
+			return nil, fmt.Errorf("decoding endpoint permissions: %w", err)
+		}
+	}
+	return capabilities, nil
+}
+
+// Can returns true if the permissions allow action on endpoint
+// (e.g. "/services" or "/services/foo/routes") in workspace.
+//
+// Like Kong, Can uses the most specific permission matching the endpoint,
+// permissions of the workspace coming before those of all workspaces
+// ("*"), and exact endpoints before wildcards, and denies the action if
+// it is negative. Without matching permission, the action is denied.
+func (c *Capabilities) Can(workspace, endpoint string, action RBACAction) bool {
+	if !c.Restricted {
+		return true
+	}
+	if workspace == "" {
+		workspace = "default"
+	}
+	endpoint = "/" + strings.Trim(endpoint, "/")
+	for _, ws := range []string{workspace, "*"} {
+		rule, ok := matchRBACEndpoint(c.endpoints[ws], workspace, endpoint)
+		if !ok {
+			continue
+		}
+		for _, a := range rule.Actions {
+			if a == action {
+				return !rule.Negative
+			}
+		}
+		// Kong only considers the most specific permission
+		return false
+	}
+	return false
+}
+
+// CanRequest returns true if the permissions allow a request with the
+// HTTP method on endpoint in workspace, see Can.
+func (c *Capabilities) CanRequest(workspace, method, endpoint string) bool {
+	return c.Can(workspace, endpoint, RBACActionForMethod(method))
+}
+
+// matchRBACEndpoint returns the most specific of rules matching endpoint.
+// Rules can name endpoints with or without the workspace prefix, and end
+// with "*" to match everything under an endpoint.
+func matchRBACEndpoint(rules map[string]rbacEndpointRule,
+	workspace, endpoint string,
+) (rbacEndpointRule, bool) {
+	candidates := []string{
+		"/" + workspace + endpoint,
+		endpoint,
+	}
+	for _, candidate := range candidates {
+		if rule, ok := rules[candidate]; ok {
+			return rule, true
+		}
+	}
+	var (
+		best    rbacEndpointRule
+		bestLen = -1
+	)
+	for pattern, rule := range rules {
+		if !strings.HasSuffix(pattern, "*") {
+			continue
+		}
+		prefix := strings.TrimSuffix(pattern, "*")
+		for _, candidate := range candidates {
+			if strings.HasPrefix(candidate+"/", prefix) && len(prefix) > bestLen {
+				best, bestLen = rule, len(prefix)
+			}
+		}
+	}
+	return best, bestLen >= 0
+}
@@ -0,0 +1,56 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeCapabilities(t *testing.T) {
+	rbac := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/userinfo", r.URL.Path)
+		assert.Equal(t, "t0k3n", r.Header.Get("Kong-Admin-Token"))
+		if !rbac {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"admin":{"username":"ci"},"permissions":{"endpoints":{
+			"*": {"/status": {"actions": ["read"], "negative": false}},
+			"default": {
+				"/default/*": {"actions": ["read", "create", "update", "delete"], "negative": false},
+				"/default/services/*": {"actions": ["read"], "negative": false},
+				"/default/consumers": {"actions": ["read", "create"], "negative": true}
+			},
+			"team-a": {"*": {"actions": ["read"], "negative": false}}
+		}}}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL),
+		HTTPClientWithHeaders(nil, http.Header{"Kong-Admin-Token": []string{"t0k3n"}}))
+	require.NoError(t, err)
+
+	capabilities, err := client.ProbeCapabilities(defaultCtx)
+	require.NoError(t, err)
+	assert.True(t, capabilities.Restricted)
+
+	assert.True(t, capabilities.Can("default", "/routes", RBACActionCreate))
+	assert.True(t, capabilities.Can("", "/routes/foo", RBACActionDelete))
+	assert.True(t, capabilities.Can("default", "/services/foo", RBACActionRead))
+	assert.False(t, capabilities.CanRequest("default", http.MethodPatch, "/services/foo"))
+	assert.False(t, capabilities.Can("default", "/consumers", RBACActionCreate))
+	assert.True(t, capabilities.CanRequest("team-a", http.MethodGet, "/plugins"))
+	assert.False(t, capabilities.CanRequest("team-a", http.MethodPost, "/plugins"))
+	assert.False(t, capabilities.Can("team-b", "/plugins", RBACActionRead))
+	assert.True(t, capabilities.Can("team-b", "/status", RBACActionRead))
+
+	rbac = false
+	capabilities, err = client.ProbeCapabilities(defaultCtx)
+	require.NoError(t, err)
+	assert.False(t, capabilities.Restricted)
+	assert.True(t, capabilities.Can("team-b", "/plugins", RBACActionDelete))
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -133,7 +132,7 @@ func (s *RBACRoleService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var role RBACRole
-		err = json.Unmarshal(b, &role)
+		err = s.client.decode(b, &role)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -144,7 +143,7 @@ func (s *RBACUserService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var user RBACUser
-		err = json.Unmarshal(b, &user)
+		err = s.client.decode(b, &user)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,4 @@
+// Package readers fetches entity collections of Kong concurrently, with
+// the clients of the kong package, for tools reading the full state of
+// Kong, such as sync tools computing what to change.
+package readers
@@ -0,0 +1,275 @@
+package readers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kong/go-kong/kong"
+)
+
+const pageSize = 1000
+
+// Type is a type of entities fetched by FetchAll.
+type Type string
+
+const (
+	Certificates   Type = "certificates"
+	CACertificates Type = "ca_certificates"
+	SNIs           Type = "snis"
+	Services       Type = "services"
+	Routes         Type = "routes"
+	Upstreams      Type = "upstreams"
+	// Targets are fetched for every upstream, after the upstreams.
+	Targets   Type = "targets"
+	Consumers Type = "consumers"
+	// ConsumerGroups aren't supported by Kong OSS, which is reported in
+	// State.Unsupported rather than as an error.
+	ConsumerGroups Type = "consumer_groups"
+	Plugins        Type = "plugins"
+)
+
+// Types are the types of entities to fetch.
+type Types []Type
+
+// State holds the fetched entities. Only the fields of the fetched types
+// are set.
+type State struct {
+	Certificates   []*kong.Certificate
+	CACertificates []*kong.CACertificate
+	SNIs           []*kong.SNI
+	Services       []*kong.Service
+	Routes         []*kong.Route
+	Upstreams      []*kong.Upstream
+	Targets        []*kong.Target
+	Consumers      []*kong.Consumer
+	ConsumerGroups []*kong.ConsumerGroup
+	Plugins        []*kong.Plugin
+	// Unsupported holds the types Kong doesn't support.
+	Unsupported Types
+}
+
+// Options configures FetchAllWithOptions.
+type Options struct {
+	// Concurrency is the maximum number of collections fetched at the
+	// same time, all of them if zero.
+	Concurrency int
+	// RequestsPerSecond limits the rate of the requests, shared by all the
+	// fetches. Zero means no limit. The adaptive pacing of the client, if
+	// enabled, applies too.
+	RequestsPerSecond float64
+	// Tags and MatchAllTags restrict the fetched entities, see
+	// kong.ListOpt.
+	Tags         []string
+	MatchAllTags bool
+}
+
+// FetchAll fetches all the entities of types concurrently, see
+// FetchAllWithOptions.
+func FetchAll(ctx context.Context, client *kong.Client, types Types) (*State, error) {
+	return FetchAllWithOptions(ctx, client, types, Options{})
+}
+
+// FetchAllWithOptions fetches all the entities of types concurrently from
+// the workspace set on client. The first error cancels the fetches still
+// running and is returned.
+func FetchAllWithOptions(ctx context.Context, client *kong.Client, types Types,
+	opts Options,
+) (*State, error) {
+	f := &fetcher{client: client, opts: opts, state: &State{}}
+	if opts.RequestsPerSecond > 0 {
+		f.interval = time.Duration(float64(time.Second) / opts.RequestsPerSecond)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	requested := map[Type]bool{}
+	for _, t := range types {
+		requested[t] = true
+	}
+	var jobs []func(ctx context.Context) error
+	queued := map[Type]bool{}
+	for _, t := range types {
+		if queued[t] {
+			continue
+		}
+		queued[t] = true
+		job, err := f.job(t, requested)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 || concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+	queue := make(chan func(ctx context.Context) error, len(jobs))
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := job(ctx); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.state, nil
+}
+
+type fetcher struct {
+	client *kong.Client
+	opts   Options
+	state  *State
+
+	lock        sync.Mutex
+	interval    time.Duration
+	nextRequest time.Time
+}
+
+func (f *fetcher) job(t Type, types map[Type]bool) (func(ctx context.Context) error, error) {
+	c := f.client
+	switch t {
+	case Certificates:
+		return fetchInto(f, t, c.Certificates.List, &f.state.Certificates), nil
+	case CACertificates:
+		return fetchInto(f, t, c.CACertificates.List, &f.state.CACertificates), nil
+	case SNIs:
+		return fetchInto(f, t, c.SNIs.List, &f.state.SNIs), nil
+	case Services:
+		return fetchInto(f, t, c.Services.List, &f.state.Services), nil
+	case Routes:
+		return fetchInto(f, t, c.Routes.List, &f.state.Routes), nil
+	case Upstreams:
+		if types[Targets] {
+			// fetched along with the targets
+			return func(context.Context) error { return nil }, nil
+		}
+		return fetchInto(f, t, c.Upstreams.List, &f.state.Upstreams), nil
+	case Targets:
+		return f.fetchTargets(types[Upstreams]), nil
+	case Consumers:
+		return fetchInto(f, t, c.Consumers.List, &f.state.Consumers), nil
+	case ConsumerGroups:
+		return fetchInto(f, t, c.ConsumerGroups.List, &f.state.ConsumerGroups), nil
+	case Plugins:
+		return fetchInto(f, t, c.Plugins.List, &f.state.Plugins), nil
+	}
+	return nil, fmt.Errorf("unknown entity type: %q", t)
+}
+
+// wait paces requests according to RequestsPerSecond.
+func (f *fetcher) wait(ctx context.Context) error {
+	if f.interval <= 0 {
+		return ctx.Err()
+	}
+	f.lock.Lock()
+	now := time.Now()
+	at := f.nextRequest
+	if at.Before(now) {
+		at = now
+	}
+	f.nextRequest = at.Add(f.interval)
+	f.lock.Unlock()
+
+	timer := time.NewTimer(time.Until(at))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type lister[T any] func(ctx context.Context, opt *kong.ListOpt) ([]*T, *kong.ListOpt, error)
+
+func listAll[T any](ctx context.Context, f *fetcher, t Type, list lister[T]) ([]*T, error) {
+	var all []*T
+	opt := &kong.ListOpt{
+		Size:         pageSize,
+		Tags:         kong.StringSlice(f.opts.Tags...),
+		MatchAllTags: f.opts.MatchAllTags,
+	}
+	for opt != nil {
+		if err := f.wait(ctx); err != nil {
+			return nil, err
+		}
+		entities, next, err := list(ctx, opt)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", t, err)
+		}
+		all = append(all, entities...)
+		opt = next
+	}
+	return all, nil
+}
+
+func fetchInto[T any](f *fetcher, t Type, list lister[T], into *[]*T) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		entities, err := listAll(ctx, f, t, list)
+		if err != nil {
+			if t == ConsumerGroups && kong.IsNotFoundErr(err) {
+				f.lock.Lock()
+				f.state.Unsupported = append(f.state.Unsupported, t)
+				f.lock.Unlock()
+				return nil
+			}
+			return err
+		}
+		*into = entities
+		return nil
+	}
+}
+
+// fetchTargets fetches the upstreams, then their targets, keeping the
+// upstreams if they were requested.
+func (f *fetcher) fetchTargets(keepUpstreams bool) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		upstreams, err := listAll(ctx, f, Upstreams, f.client.Upstreams.List)
+		if err != nil {
+			return err
+		}
+		if keepUpstreams {
+			f.state.Upstreams = upstreams
+		}
+		var targets []*kong.Target
+		for _, upstream := range upstreams {
+			upstreamTargets, err := listAll(ctx, f, Targets,
+				func(ctx context.Context, opt *kong.ListOpt) ([]*kong.Target, *kong.ListOpt, error) {
+					return f.client.Targets.List(ctx, upstream.ID, opt)
+				})
+			if err != nil {
+				return err
+			}
+			targets = append(targets, upstreamTargets...)
+		}
+		f.state.Targets = targets
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package readers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kong/go-kong/kong"
+)
+
+type server struct {
+	client *kong.Client
+
+	lock     sync.Mutex
+	requests []string
+	// inFlight and maxInFlight count concurrent requests.
+	inFlight    int32
+	maxInFlight int32
+	// delay delays the responses.
+	delay time.Duration
+}
+
+func newServer(t *testing.T, responses map[string]string) *server {
+	s := &server{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&s.inFlight, 1)
+		defer atomic.AddInt32(&s.inFlight, -1)
+		s.lock.Lock()
+		if n > s.maxInFlight {
+			s.maxInFlight = n
+		}
+		s.requests = append(s.requests, r.URL.RequestURI())
+		s.lock.Unlock()
+		time.Sleep(s.delay)
+
+		key := r.URL.Path
+		if offset := r.URL.Query().Get("offset"); offset != "" {
+			key += "?" + offset
+		}
+		response, ok := responses[key]
+		switch {
+		case !ok:
+			response = `{"data":[]}`
+		case response == "404":
+			w.WriteHeader(http.StatusNotFound)
+			response = `{"message":"Not found"}`
+		case response == "500":
+			w.WriteHeader(http.StatusInternalServerError)
+			response = `{"message":"An unexpected error occurred"}`
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(srv.Close)
+	client, err := kong.NewClient(kong.String(srv.URL), nil)
+	require.NoError(t, err)
+	s.client = client
+	return s
+}
+
+func TestFetchAll(t *testing.T) {
+	s := newServer(t, map[string]string{
+		"/services":             `{"data":[{"id":"s1","name":"one"}],"offset":"page2"}`,
+		"/services?page2":       `{"data":[{"id":"s2","name":"two"}]}`,
+		"/routes":               `{"data":[{"id":"r1","service":{"id":"s1"}}]}`,
+		"/plugins":              `{"data":[{"id":"p1","name":"key-auth"}]}`,
+		"/upstreams":            `{"data":[{"id":"u1","name":"up1"},{"id":"u2","name":"up2"}]}`,
+		"/upstreams/u1/targets": `{"data":[{"id":"t1","target":"10.0.0.1:80"}]}`,
+		"/upstreams/u2/targets": `{"data":[{"id":"t2","target":"10.0.0.2:80"}]}`,
+		"/consumer_groups":      "404",
+	})
+	s.delay = 20 * time.Millisecond
+
+	state, err := FetchAll(context.Background(), s.client,
+		Types{Services, Routes, Plugins, Targets, Upstreams, ConsumerGroups})
+	require.NoError(t, err)
+	require.Len(t, state.Services, 2)
+	assert.Equal(t, "two", *state.Services[1].Name)
+	require.Len(t, state.Routes, 1)
+	require.Len(t, state.Plugins, 1)
+	require.Len(t, state.Upstreams, 2)
+	require.Len(t, state.Targets, 2)
+	assert.Equal(t, "10.0.0.2:80", *state.Targets[1].Target)
+	assert.Nil(t, state.Consumers)
+	assert.Equal(t, Types{ConsumerGroups}, state.Unsupported)
+	assert.Greater(t, s.maxInFlight, int32(1))
+
+	// upstreams are listed once, along with the targets
+	upstreamLists := 0
+	for _, request := range s.requests {
+		if request == "/upstreams?size=1000" {
+			upstreamLists++
+		}
+	}
+	assert.Equal(t, 1, upstreamLists)
+}
+
+func TestFetchAllWithOptions(t *testing.T) {
+	t.Run("limits concurrency", func(t *testing.T) {
+		s := newServer(t, nil)
+		s.delay = 10 * time.Millisecond
+		_, err := FetchAllWithOptions(context.Background(), s.client,
+			Types{Services, Routes, Plugins, Consumers}, Options{Concurrency: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), s.maxInFlight)
+		assert.Len(t, s.requests, 4)
+	})
+	t.Run("filters by tags", func(t *testing.T) {
+		s := newServer(t, nil)
+		_, err := FetchAllWithOptions(context.Background(), s.client,
+			Types{Services}, Options{Tags: []string{"a", "b"}, MatchAllTags: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"/services?size=1000&tags=a%2Cb"}, s.requests)
+	})
+	t.Run("limits the rate of requests", func(t *testing.T) {
+		s := newServer(t, nil)
+		start := time.Now()
+		_, err := FetchAllWithOptions(context.Background(), s.client,
+			Types{Services, Routes, Plugins}, Options{RequestsPerSecond: 20})
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+	t.Run("fails on the first error", func(t *testing.T) {
+		s := newServer(t, map[string]string{"/routes": "500"})
+		s.delay = 10 * time.Millisecond
+		state, err := FetchAllWithOptions(context.Background(), s.client,
+			Types{Routes, Services, Plugins, Consumers}, Options{Concurrency: 1})
+		require.Error(t, err)
+		assert.Nil(t, state)
+		assert.Contains(t, err.Error(), "fetching routes")
+		assert.Len(t, s.requests, 1)
+	})
+	t.Run("rejects unknown types", func(t *testing.T) {
+		s := newServer(t, nil)
+		_, err := FetchAll(context.Background(), s.client, Types{"nope"})
+		require.EqualError(t, err, `unknown entity type: "nope"`)
+		assert.Empty(t, s.requests)
+	})
+}
@@ -0,0 +1,54 @@
+package kong
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrReadOnlyClient is returned for requests which could change the
+// configuration of Kong when they are made with a read-only client,
+// see SetReadOnly.
+type ErrReadOnlyClient struct {
+	Method string
+	Path   string
+}
+
+func (e *ErrReadOnlyClient) Error() string {
+	return fmt.Sprintf("%s %s: the client is read-only", e.Method, e.Path)
+}
+
+// IsReadOnlyClientErr returns true if the error or its cause is
+// an ErrReadOnlyClient.
+func IsReadOnlyClientErr(e error) bool {
+	var readOnlyErr *ErrReadOnlyClient
+	return errors.As(e, &readOnlyErr)
+}
+
+// SetReadOnly makes the client read-only, or writable again.
+// A read-only client fails all requests but GET, HEAD and OPTIONS ones
+// with an ErrReadOnlyClient without sending them to Kong, including
+// requests which don't write anything such as schema validations.
+// It suits jobs which must never change the configuration of Kong,
+// whatever bug they might have.
+func (c *Client) SetReadOnly(readOnly bool) {
+	c.readOnly.Store(readOnly)
+}
+
+// IsReadOnly returns whether the client is read-only, see SetReadOnly.
+func (c *Client) IsReadOnly() bool {
+	return c.readOnly.Load()
+}
+
+// checkReadOnly returns an ErrReadOnlyClient for requests a read-only
+// client must not send.
+func (c *Client) checkReadOnly(req *http.Request) error {
+	if !c.IsReadOnly() {
+		return nil
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+	return &ErrReadOnlyClient{Method: req.Method, Path: req.URL.Path}
+}
@@ -0,0 +1,45 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"s1","name":"foo"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetReadOnly(true)
+	assert.True(t, client.IsReadOnly())
+
+	_, err = client.Services.Get(defaultCtx, String("foo"))
+	assert.NoError(t, err)
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("bar")})
+	require.Error(t, err)
+	assert.True(t, IsReadOnlyClientErr(err))
+	assert.EqualError(t, err, "POST /services: the client is read-only")
+
+	err = client.Services.Delete(defaultCtx, String("foo"))
+	assert.True(t, IsReadOnlyClientErr(err))
+	_, _, err = client.Plugins.Validate(defaultCtx, &Plugin{Name: String("key-auth")})
+	assert.True(t, IsReadOnlyClientErr(err))
+
+	// the guard applies to dry-run clients as well
+	client.SetDryRun(true, func(DryRunRequest) {})
+	_, err = client.Services.Update(defaultCtx, &Service{ID: String("s1")})
+	assert.True(t, IsReadOnlyClientErr(err))
+
+	client.SetReadOnly(false)
+	assert.False(t, client.IsReadOnly())
+}
@@ -0,0 +1,397 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+)
+
+// RedactedValue replaces the values of sensitive fields redacted by a
+// Redactor.
+const RedactedValue = "[REDACTED]"
+
+// redactionAliases maps the names of credential collections, and of
+// their schemas, to the entity types of Redactor.
+var redactionAliases = map[string]string{
+	"key-auths":             "key-auth",
+	"keyauth_credentials":   "key-auth",
+	"basic-auths":           "basic-auth",
+	"basicauth_credentials": "basic-auth",
+	"hmac-auths":            "hmac-auth",
+	"hmacauth_credentials":  "hmac-auth",
+	"jwts":                  "jwt",
+	"jwt_secrets":           "jwt",
+	"oauth2s":               "oauth2",
+	"oauth2_credentials":    "oauth2",
+}
+
+// redactedHeaders are the headers redacted from debug logs.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Kong-Admin-Token", "Cookie", "Set-Cookie"}
+
+// Redactor masks the values of sensitive fields, such as the keys of
+// key-auth credentials or the private keys of certificates, with
+// RedactedValue, so that dumps, diffs and debug logs can be shared.
+//
+// Fields are paths relative to entities, such as "key" or
+// "config.client_secret", registered by entity type, as named in the
+// Admin API, e.g. "certificates" or "key-auth", by plugin name for
+// plugins, and by vault name for vaults. Arrays along the path are
+// traversed. Unset fields are left as they are.
+//
+// A Redactor is safe for concurrent use.
+type Redactor struct {
+	lock    sync.RWMutex
+	fields  map[string][]string
+	plugins map[string][]string
+	vaults  map[string][]string
+}
+
+// NewRedactor returns a Redactor masking the secrets of credentials,
+// certificates, common plugins and vaults. More fields can be added with
+// AddFields, or found in schemas with AddSchemaFields.
+func NewRedactor() *Redactor {
+	r := &Redactor{
+		fields:  map[string][]string{},
+		plugins: map[string][]string{},
+		vaults:  map[string][]string{},
+	}
+	r.AddFields("certificates", "key", "key_alt")
+	r.AddFields("key-auth", "key")
+	r.AddFields("basic-auth", "password")
+	r.AddFields("hmac-auth", "secret")
+	r.AddFields("jwt", "secret")
+	r.AddFields("oauth2", "client_secret")
+	r.AddFields("consumers",
+		"keyauth_credentials.key",
+		"basicauth_credentials.password",
+		"hmacauth_credentials.secret",
+		"jwt_secrets.secret",
+	)
+	r.AddPluginFields("openid-connect", "config.client_secret", "config.session_secret")
+	r.AddPluginFields("session", "config.secret")
+	r.AddPluginFields("aws-lambda", "config.aws_secret")
+	r.AddPluginFields("azure-functions", "config.apikey", "config.clientid")
+	for _, plugin := range []string{"rate-limiting", "response-ratelimiting", "proxy-cache-advanced",
+		"rate-limiting-advanced", "graphql-rate-limiting-advanced"} {
+		r.AddPluginFields(plugin, "config.redis_password", "config.redis.password",
+			"config.redis.sentinel_password")
+	}
+	r.AddVaultFields("hcv", "config.token", "config.approle_secret_id")
+	r.AddVaultFields("conjur", "config.api_key")
+	r.AddVaultFields("azure", "config.client_secret")
+	return r
+}
+
+// AddFields registers sensitive fields of entityType.
+func (r *Redactor) AddFields(entityType string, fields ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entityType = redactionEntityType(entityType)
+	r.fields[entityType] = appendFields(r.fields[entityType], fields)
+}
+
+// AddPluginFields registers sensitive fields of the plugin named plugin.
+func (r *Redactor) AddPluginFields(plugin string, fields ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.plugins[plugin] = appendFields(r.plugins[plugin], fields)
+}
+
+// AddVaultFields registers sensitive fields of the vaults named name,
+// e.g. "hcv".
+func (r *Redactor) AddVaultFields(name string, fields ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.vaults[name] = appendFields(r.vaults[name], fields)
+}
+
+// AddSchemaFields registers the fields marked as encrypted in schema, as
+// returned by SchemaService.Get or PluginService.GetFullSchema, as
+// sensitive fields of entityType, or of the plugin named plugin if
+// entityType is "plugins".
+func (r *Redactor) AddSchemaFields(entityType string, plugin string, schema Schema) error {
+	fields, err := SensitiveFields(schema)
+	if err != nil {
+		return err
+	}
+	if entityType == "plugins" {
+		r.AddPluginFields(plugin, fields...)
+	} else {
+		r.AddFields(entityType, fields...)
+	}
+	return nil
+}
+
+// SensitiveFields returns the paths of the fields marked as encrypted in
+// schema, as returned by SchemaService.Get or PluginService.GetFullSchema,
+// sorted.
+func SensitiveFields(schema Schema) ([]string, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	res := sensitiveFields(gjson.ParseBytes(b), "")
+	sort.Strings(res)
+	return res, nil
+}
+
+func sensitiveFields(record gjson.Result, prefix string) []string {
+	var res []string
+	for _, field := range record.Get("fields").Array() {
+		for name, value := range field.Map() {
+			if value.Get("encrypted").Bool() {
+				res = append(res, prefix+name)
+			}
+			if value.Get("fields").Exists() {
+				res = append(res, sensitiveFields(value, prefix+name+".")...)
+			}
+		}
+	}
+	return res
+}
+
+func appendFields(fields, added []string) []string {
+	for _, field := range added {
+		if !containsString(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// redactionEntityType returns the entity type of collections such as
+// "consumers/{id}/key-auth" or "key-auths".
+func redactionEntityType(entityType string) string {
+	entityType = planEntityType(entityType)
+	if alias, ok := redactionAliases[entityType]; ok {
+		return alias
+	}
+	return entityType
+}
+
+// sensitiveFieldsOf returns the sensitive fields of entity, of
+// entityType.
+func (r *Redactor) sensitiveFieldsOf(entityType string, entity map[string]interface{}) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	entityType = redactionEntityType(entityType)
+	fields := r.fields[entityType]
+	name, _ := entity["name"].(string)
+	switch entityType {
+	case "plugins":
+		fields = append(fields[:len(fields):len(fields)], r.plugins[name]...)
+	case "vaults":
+		fields = append(fields[:len(fields):len(fields)], r.vaults[name]...)
+	}
+	return fields
+}
+
+// Redact returns entity, of entityType, e.g. "certificates" or
+// "consumers/{id}/key-auth", as JSON with its sensitive fields redacted.
+func (r *Redactor) Redact(entityType string, entity interface{}) (json.RawMessage, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return b, nil
+	}
+	r.redact(entityType, object)
+	return json.Marshal(object)
+}
+
+func (r *Redactor) redact(entityType string, entity map[string]interface{}) {
+	r.replace(entityType, entity, func([]string, interface{}) interface{} { return RedactedValue })
+}
+
+// replace replaces the values of the sensitive fields of entity with the
+// values returned by fn, called with their paths, including array
+// indexes.
+func (r *Redactor) replace(entityType string, entity map[string]interface{},
+	fn func(path []string, value interface{}) interface{},
+) {
+	for _, field := range r.sensitiveFieldsOf(entityType, entity) {
+		replacePath(entity, strings.Split(field, "."), nil, fn)
+	}
+}
+
+func replacePath(value interface{}, path, prefix []string, fn func([]string, interface{}) interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, element := range v {
+			replacePath(element, path, append(prefix[:len(prefix):len(prefix)], strconv.Itoa(i)), fn)
+		}
+	case map[string]interface{}:
+		field, ok := v[path[0]]
+		if !ok || field == nil {
+			return
+		}
+		prefix = append(prefix[:len(prefix):len(prefix)], path[0])
+		if len(path) == 1 {
+			v[path[0]] = fn(prefix, field)
+			return
+		}
+		replacePath(field, path[1:], prefix, fn)
+	}
+}
+
+// RedactArchive returns a copy of archive with its sensitive fields
+// redacted.
+func (r *Redactor) RedactArchive(archive *BackupArchive) (*BackupArchive, error) {
+	var fields map[string]interface{}
+	if err := convert(archive, &fields); err != nil {
+		return nil, err
+	}
+	for entityType, entities := range fields {
+		list, ok := entities.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, entity := range list {
+			if object, ok := entity.(map[string]interface{}); ok {
+				r.redact(entityType, object)
+			}
+		}
+	}
+	var res BackupArchive
+	if err := convert(fields, &res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// RedactConsumerDocument returns a copy of document with the secrets of
+// its credentials redacted.
+func (r *Redactor) RedactConsumerDocument(document *ConsumerDocument) (*ConsumerDocument, error) {
+	res := &ConsumerDocument{Consumers: []*ExportedConsumer{}}
+	for _, consumer := range document.Consumers {
+		b, err := r.Redact("consumers", consumer)
+		if err != nil {
+			return nil, err
+		}
+		var redacted ExportedConsumer
+		if err := json.Unmarshal(b, &redacted); err != nil {
+			return nil, err
+		}
+		res.Consumers = append(res.Consumers, &redacted)
+	}
+	return res, nil
+}
+
+// RedactDrift returns a copy of events with the sensitive fields of their
+// entities redacted.
+func (r *Redactor) RedactDrift(events []DriftEvent) ([]DriftEvent, error) {
+	res := make([]DriftEvent, 0, len(events))
+	for _, event := range events {
+		var err error
+		if event.Expected != nil {
+			if event.Expected, err = r.Redact(event.EntityType, event.Expected); err != nil {
+				return nil, err
+			}
+		}
+		if event.Actual != nil {
+			if event.Actual, err = r.Redact(event.EntityType, event.Actual); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, event)
+	}
+	return res, nil
+}
+
+// RedactPlan returns a copy of plan with the sensitive fields of the
+// entities of its operations redacted.
+func (r *Redactor) RedactPlan(plan *Plan) (*Plan, error) {
+	res := *plan
+	res.Operations = make([]Operation, 0, len(plan.Operations))
+	for _, operation := range plan.Operations {
+		if operation.Entity != nil {
+			entity, err := r.Redact(operation.EntityType, operation.Entity)
+			if err != nil {
+				return nil, err
+			}
+			operation.Entity = entity
+		}
+		res.Operations = append(res.Operations, operation)
+	}
+	return &res, nil
+}
+
+// SetRedactor sets the Redactor redacting the requests and responses
+// logged in debug mode, see SetDebugMode. Authentication headers are
+// redacted too. A nil redactor disables redaction.
+func (c *Client) SetRedactor(redactor *Redactor) {
+	c.redactor.Store(redactor)
+}
+
+// redactDump redacts the headers, and the JSON body, of an HTTP request
+// or response dumped for path.
+func (c *Client) redactDump(dump []byte, path string) []byte {
+	redactor, _ := c.redactor.Load().(*Redactor)
+	if redactor == nil {
+		return dump
+	}
+	head, body, found := bytes.Cut(dump, []byte("\r\n\r\n"))
+	lines := bytes.Split(head, []byte("\r\n"))
+	for i, line := range lines {
+		name, _, ok := bytes.Cut(line, []byte(":"))
+		if !ok {
+			continue
+		}
+		for _, header := range redactedHeaders {
+			if http.CanonicalHeaderKey(string(bytes.TrimSpace(name))) == header {
+				lines[i] = append(append([]byte{}, name...), ": "+RedactedValue...)
+			}
+		}
+	}
+	head = bytes.Join(lines, []byte("\r\n"))
+	if !found {
+		return head
+	}
+
+	var value interface{}
+	if json.Unmarshal(body, &value) == nil {
+		entityType := redactor.pathEntityType(path)
+		object, _ := value.(map[string]interface{})
+		if data, ok := object["data"].([]interface{}); ok {
+			for _, entity := range data {
+				if e, ok := entity.(map[string]interface{}); ok {
+					redactor.redact(entityType, e)
+				}
+			}
+		} else if object != nil {
+			redactor.redact(entityType, object)
+		}
+		if b, err := json.Marshal(value); err == nil {
+			body = b
+		}
+	}
+	return append(append(head, "\r\n\r\n"...), body...)
+}
+
+// pathEntityType returns the entity type of the last collection of path
+// with sensitive fields, e.g. "key-auth" for
+// "/consumers/alice/key-auth/{id}".
+func (r *Redactor) pathEntityType(path string) string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		entityType := redactionEntityType(segments[i])
+		if _, ok := r.fields[entityType]; ok || entityType == "plugins" || entityType == "vaults" {
+			return entityType
+		}
+	}
+	return ""
+}
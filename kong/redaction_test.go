@@ -0,0 +1,121 @@
+package kong
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor(t *testing.T) {
+	r := NewRedactor()
+
+	b, err := r.Redact("consumers/alice/key-auth", &KeyAuth{ID: String("k1"), Key: String("secret")})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"k1","key":"[REDACTED]"}`, string(b))
+
+	b, err = r.Redact("plugins", &Plugin{Name: String("session"), Config: Configuration{
+		"secret": "s", "cookie_name": "c",
+	}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"session","config":{"secret":"[REDACTED]","cookie_name":"c"}}`, string(b))
+
+	b, err = r.Redact("plugins", json.RawMessage(`{"name":"rate-limiting","config":{"redis":{"password":null}}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"rate-limiting","config":{"redis":{"password":null}}}`, string(b))
+
+	b, err = r.Redact("vaults", &Vault{Name: String("hcv"), Config: Configuration{"token": "t"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"hcv","config":{"token":"[REDACTED]"}}`, string(b))
+
+	document, err := r.RedactConsumerDocument(&ConsumerDocument{Consumers: []*ExportedConsumer{{
+		Consumer: Consumer{Username: String("alice")},
+		ConsumerCredentials: ConsumerCredentials{
+			KeyAuths:   []*KeyAuth{{Key: String("k1")}, {Key: String("k2")}},
+			BasicAuths: []*BasicAuth{{Username: String("alice")}},
+		},
+	}}})
+	require.NoError(t, err)
+	assert.Equal(t, String(RedactedValue), document.Consumers[0].KeyAuths[1].Key)
+	assert.Nil(t, document.Consumers[0].BasicAuths[0].Password)
+
+	archive, err := r.RedactArchive(&BackupArchive{
+		Version:      BackupFormatVersion,
+		Certificates: []*Certificate{{ID: String("c1"), Cert: String("cert"), Key: String("key")}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, String("cert"), archive.Certificates[0].Cert)
+	assert.Equal(t, String(RedactedValue), archive.Certificates[0].Key)
+
+	events, err := r.RedactDrift([]DriftEvent{{
+		Kind: DriftChanged, EntityType: "certificates", ID: "c1",
+		Expected: json.RawMessage(`{"key":"old"}`), Actual: json.RawMessage(`{"key":"new"}`),
+	}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"key":"[REDACTED]"}`, string(events[0].Expected))
+	assert.JSONEq(t, `{"key":"[REDACTED]"}`, string(events[0].Actual))
+
+	plan, err := r.RedactPlan(NewPlan([]Operation{{
+		Action: MutationCreate, EntityType: "consumers/alice/basic-auth",
+		Entity: &BasicAuth{Username: String("alice"), Password: String("p")},
+	}}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username":"alice","password":"[REDACTED]"}`,
+		string(plan.Operations[0].Entity.(json.RawMessage)))
+}
+
+func TestSensitiveFields(t *testing.T) {
+	var schema Schema
+	require.NoError(t, json.Unmarshal([]byte(`{"fields":[
+		{"name":{"type":"string"}},
+		{"config":{"type":"record","fields":[
+			{"client_secret":{"type":"string","encrypted":true,"referenceable":true}},
+			{"session":{"type":"record","fields":[{"password":{"type":"string","encrypted":true}}]}}
+		]}}
+	]}`), &schema))
+	fields, err := SensitiveFields(schema)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config.client_secret", "config.session.password"}, fields)
+
+	r := NewRedactor()
+	require.NoError(t, r.AddSchemaFields("plugins", "custom", schema))
+	b, err := r.Redact("plugins", &Plugin{Name: String("custom"), Config: Configuration{"client_secret": "s"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"custom","config":{"client_secret":"[REDACTED]"}}`, string(b))
+}
+
+func TestRedactedDebugLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"k1","key":"from-kong"}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	var logs bytes.Buffer
+	client.SetDebugMode(true)
+	client.SetLogger(&logs)
+	client.SetRedactor(NewRedactor())
+
+	req, err := client.NewRequest(http.MethodPost, "/consumers/alice/key-auth", nil, &KeyAuth{Key: String("to-kong")})
+	require.NoError(t, err)
+	req.Header.Set("Kong-Admin-Token", "token")
+	_, err = client.Do(defaultCtx, req, nil)
+	require.NoError(t, err)
+	_, _, err = client.KeyAuths.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, logs.String(), "token\r\n")
+	assert.NotContains(t, logs.String(), "to-kong")
+	assert.NotContains(t, logs.String(), "from-kong")
+	assert.Contains(t, logs.String(), "Kong-Admin-Token: [REDACTED]")
+	assert.Contains(t, logs.String(), `"key":"[REDACTED]"`)
+
+	logs.Reset()
+	client.SetRedactor(nil)
+	_, _, err = client.KeyAuths.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "from-kong")
+}
@@ -0,0 +1,200 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ReferenceResolver resolves references to Services, Routes, Consumers
+// and Consumer Groups made by name into references made by ID.
+//
+// Kong accepts names in most endpoints but requires IDs in foreign keys
+// of some entities. ReferenceResolver fills those IDs before writes and
+// caches every lookup, so it is meant to be reused across a batch of
+// writes and discarded (or Reset) once the batch is done.
+// It is safe for concurrent use.
+type ReferenceResolver struct {
+	client *Client
+
+	lock           sync.Mutex
+	services       map[string]string
+	routes         map[string]string
+	consumers      map[string]string
+	consumerGroups map[string]string
+}
+
+// NewReferenceResolver returns a ReferenceResolver which uses client
+// to look up entities.
+func NewReferenceResolver(client *Client) *ReferenceResolver {
+	r := &ReferenceResolver{client: client}
+	r.Reset()
+	return r
+}
+
+// Reset clears all cached lookups.
+func (r *ReferenceResolver) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.services = map[string]string{}
+	r.routes = map[string]string{}
+	r.consumers = map[string]string{}
+	r.consumerGroups = map[string]string{}
+}
+
+// ServiceID returns the ID of the Service identified by nameOrID.
+func (r *ReferenceResolver) ServiceID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, r.services, nameOrID, func(ctx context.Context) (*string, error) {
+		s, err := r.client.Services.Get(ctx, &nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		return s.ID, nil
+	})
+}
+
+// RouteID returns the ID of the Route identified by nameOrID.
+func (r *ReferenceResolver) RouteID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, r.routes, nameOrID, func(ctx context.Context) (*string, error) {
+		route, err := r.client.Routes.Get(ctx, &nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		return route.ID, nil
+	})
+}
+
+// ConsumerID returns the ID of the Consumer identified by usernameOrID.
+func (r *ReferenceResolver) ConsumerID(ctx context.Context, usernameOrID string) (string, error) {
+	return r.resolve(ctx, r.consumers, usernameOrID, func(ctx context.Context) (*string, error) {
+		c, err := r.client.Consumers.Get(ctx, &usernameOrID)
+		if err != nil {
+			return nil, err
+		}
+		return c.ID, nil
+	})
+}
+
+// ConsumerGroupID returns the ID of the Consumer Group identified by nameOrID.
+func (r *ReferenceResolver) ConsumerGroupID(ctx context.Context, nameOrID string) (string, error) {
+	return r.resolve(ctx, r.consumerGroups, nameOrID, func(ctx context.Context) (*string, error) {
+		cg, err := r.client.ConsumerGroups.Get(ctx, &nameOrID)
+		if err != nil {
+			return nil, err
+		}
+		if cg.ConsumerGroup == nil {
+			return nil, nil
+		}
+		return cg.ConsumerGroup.ID, nil
+	})
+}
+
+func (r *ReferenceResolver) resolve(ctx context.Context, cache map[string]string,
+	nameOrID string, lookup func(context.Context) (*string, error),
+) (string, error) {
+	if nameOrID == "" {
+		return "", fmt.Errorf("nameOrID cannot be empty")
+	}
+	// IDs don't need a round-trip.
+	if IsUUID(nameOrID) {
+		return nameOrID, nil
+	}
+
+	r.lock.Lock()
+	id, ok := cache[nameOrID]
+	r.lock.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	fetched, err := lookup(ctx)
+	if err != nil {
+		return "", fmt.Errorf("resolving %q: %w", nameOrID, err)
+	}
+	if isEmptyString(fetched) {
+		return "", fmt.Errorf("resolving %q: entity has no ID", nameOrID)
+	}
+
+	r.lock.Lock()
+	cache[nameOrID] = *fetched
+	r.lock.Unlock()
+	return *fetched, nil
+}
+
+// ResolveRoute replaces a Service referenced by name in route
+// with a reference by ID.
+func (r *ReferenceResolver) ResolveRoute(ctx context.Context, route *Route) error {
+	if route == nil {
+		return fmt.Errorf("route cannot be nil")
+	}
+	if route.Service == nil {
+		return nil
+	}
+	id, err := r.serviceRef(ctx, route.Service)
+	if err != nil {
+		return err
+	}
+	route.Service = &Service{ID: String(id)}
+	return nil
+}
+
+// ResolvePlugin replaces Services, Routes, Consumers and Consumer Groups
+// referenced by name in plugin with references by ID.
+func (r *ReferenceResolver) ResolvePlugin(ctx context.Context, plugin *Plugin) error {
+	if plugin == nil {
+		return fmt.Errorf("plugin cannot be nil")
+	}
+	if plugin.Service != nil {
+		id, err := r.serviceRef(ctx, plugin.Service)
+		if err != nil {
+			return err
+		}
+		plugin.Service = &Service{ID: String(id)}
+	}
+	if plugin.Route != nil {
+		id, err := r.ref(ctx, plugin.Route.ID, plugin.Route.Name, r.RouteID)
+		if err != nil {
+			return fmt.Errorf("route: %w", err)
+		}
+		plugin.Route = &Route{ID: String(id)}
+	}
+	if plugin.Consumer != nil {
+		id, err := r.ref(ctx, plugin.Consumer.ID, plugin.Consumer.Username, r.ConsumerID)
+		if err != nil {
+			return fmt.Errorf("consumer: %w", err)
+		}
+		plugin.Consumer = &Consumer{ID: String(id)}
+	}
+	if plugin.ConsumerGroup != nil {
+		id, err := r.ref(ctx, plugin.ConsumerGroup.ID, plugin.ConsumerGroup.Name, r.ConsumerGroupID)
+		if err != nil {
+			return fmt.Errorf("consumer group: %w", err)
+		}
+		plugin.ConsumerGroup = &ConsumerGroup{ID: String(id)}
+	}
+	return nil
+}
+
+func (r *ReferenceResolver) serviceRef(ctx context.Context, s *Service) (string, error) {
+	id, err := r.ref(ctx, s.ID, s.Name, r.ServiceID)
+	if err != nil {
+		return "", fmt.Errorf("service: %w", err)
+	}
+	return id, nil
+}
+
+// ref resolves a reference holding an ID and/or a name.
+// Kong also accepts names in the ID field of foreign keys in some places,
+// so the ID is resolved as well when it isn't a UUID.
+func (r *ReferenceResolver) ref(ctx context.Context, id, name *string,
+	resolve func(context.Context, string) (string, error),
+) (string, error) {
+	switch {
+	case !isEmptyString(id):
+		return resolve(ctx, *id)
+	case !isEmptyString(name):
+		return resolve(ctx, *name)
+	default:
+		return "", fmt.Errorf("reference has neither an ID nor a name")
+	}
+}
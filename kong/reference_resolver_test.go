@@ -0,0 +1,64 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceResolver(t *testing.T) {
+	const (
+		serviceID  = "c4b2e1b8-25a8-4b37-a4a4-6b1b0f6c3a01"
+		consumerID = "5f3a7a9e-0c6b-4c39-9f3e-0b6a2f0d7b02"
+		routeID    = "0e8c7f0b-6b1d-4c0a-8d7e-4f2b9a1c3d03"
+	)
+	hits := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		switch r.URL.Path {
+		case "/services/foo":
+			_, _ = w.Write([]byte(`{"id":"` + serviceID + `","name":"foo"}`))
+		case "/consumers/alice":
+			_, _ = w.Write([]byte(`{"id":"` + consumerID + `","username":"alice"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	resolver := NewReferenceResolver(client)
+
+	route := &Route{Name: String("r"), Service: &Service{Name: String("foo")}}
+	require.NoError(t, resolver.ResolveRoute(defaultCtx, route))
+	assert.Equal(t, serviceID, *route.Service.ID)
+	assert.Nil(t, route.Service.Name)
+
+	plugin := &Plugin{
+		Name:     String("key-auth"),
+		Service:  &Service{ID: String("foo")},
+		Route:    &Route{ID: String(routeID)},
+		Consumer: &Consumer{Username: String("alice")},
+	}
+	require.NoError(t, resolver.ResolvePlugin(defaultCtx, plugin))
+	assert.Equal(t, serviceID, *plugin.Service.ID)
+	assert.Equal(t, routeID, *plugin.Route.ID)
+	assert.Equal(t, consumerID, *plugin.Consumer.ID)
+
+	// lookups are cached and UUIDs are never looked up
+	assert.Equal(t, map[string]int{"/services/foo": 1, "/consumers/alice": 1}, hits)
+
+	err = resolver.ResolvePlugin(defaultCtx, &Plugin{Service: &Service{Name: String("missing")}})
+	require.Error(t, err)
+	assert.True(t, IsNotFoundErr(err))
+
+	resolver.Reset()
+	_, err = resolver.ServiceID(defaultCtx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 2, hits["/services/foo"])
+}
@@ -2,7 +2,7 @@ package kong
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -28,7 +28,7 @@ func (c *Client) NewRequestRaw(method, baseURL string, endpoint string, qs inter
 		case io.Reader:
 			r = v
 		default:
-			b, err := json.Marshal(body)
+			b, err := c.marshalJSON(body)
 			if err != nil {
 				return nil, err
 			}
@@ -65,5 +65,10 @@ func (c *Client) NewRequestRaw(method, baseURL string, endpoint string, qs inter
 func (c *Client) NewRequest(method, endpoint string, qs interface{},
 	body interface{},
 ) (*http.Request, error) {
-	return c.NewRequestRaw(method, c.workspacedBaseURL(c.Workspace()), endpoint, qs, body)
+	workspace := c.Workspace()
+	req, err := c.NewRequestRaw(method, c.workspacedBaseURL(workspace), endpoint, qs, body)
+	if err != nil {
+		return nil, err
+	}
+	return req.WithContext(context.WithValue(req.Context(), requestWorkspaceCtxKey{}, workspace)), nil
 }
@@ -15,6 +15,9 @@ type Response struct {
 	Header     http.Header
 	Status     string
 	StatusCode int
+	// Deprecations are the deprecations Kong reported in the headers of
+	// the response.
+	Deprecations []Deprecation
 }
 
 func newResponse(res *http.Response) *Response {
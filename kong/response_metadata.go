@@ -0,0 +1,98 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type responseMetadataCtxKey struct{}
+
+// ResponseMetadata is the metadata of a response of the Admin API.
+type ResponseMetadata struct {
+	Method string
+	// Path is the path of the request, relative to the workspace.
+	Path       string
+	StatusCode int
+	Header     http.Header
+	// RequestID is the ID Kong assigned to the request, if any.
+	RequestID string
+	// Latency is the time until the response headers were received.
+	Latency time.Duration
+}
+
+// ResponseMetadataRecorder records the metadata of the responses received
+// with a context returned by WithResponseMetadata. It is safe for
+// concurrent use.
+type ResponseMetadataRecorder struct {
+	lock      sync.Mutex
+	responses []ResponseMetadata
+}
+
+// WithResponseMetadata returns a context recording the metadata of the
+// responses to the requests made with it by service methods, along with
+// the recorder, so that callers can e.g. cache entities using their
+// headers or trace requests by ID without making raw requests.
+//
+// Methods making several requests, such as ListAll, record a response
+// per request.
+func WithResponseMetadata(ctx context.Context) (context.Context, *ResponseMetadataRecorder) {
+	recorder := &ResponseMetadataRecorder{}
+	return context.WithValue(ctx, responseMetadataCtxKey{}, recorder), recorder
+}
+
+// All returns the metadata of the recorded responses, in the order they
+// were received.
+func (r *ResponseMetadataRecorder) All() []ResponseMetadata {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]ResponseMetadata(nil), r.responses...)
+}
+
+// Last returns the metadata of the last recorded response, or nil if none
+// was recorded.
+func (r *ResponseMetadataRecorder) Last() *ResponseMetadata {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.responses) == 0 {
+		return nil
+	}
+	last := r.responses[len(r.responses)-1]
+	return &last
+}
+
+// Reset forgets the recorded responses.
+func (r *ResponseMetadataRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.responses = nil
+}
+
+// recordResponseMetadata records the metadata of resp, the response to
+// req, if ctx has a recorder.
+func (c *Client) recordResponseMetadata(ctx context.Context, req *http.Request,
+	resp *http.Response, latency time.Duration,
+) {
+	if ctx == nil || resp == nil {
+		return
+	}
+	recorder, ok := ctx.Value(responseMetadataCtxKey{}).(*ResponseMetadataRecorder)
+	if !ok {
+		return
+	}
+	requestID := resp.Header.Get("X-Kong-Admin-Request-ID")
+	if requestID == "" {
+		requestID = resp.Header.Get("X-Kong-Request-Id")
+	}
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	recorder.responses = append(recorder.responses, ResponseMetadata{
+		Method:     req.Method,
+		Path:       c.relativePath(req),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		RequestID:  requestID,
+		Latency:    latency,
+	})
+}
@@ -0,0 +1,54 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithResponseMetadata(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Kong-Admin-Request-ID", "req-"+r.Method)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"s1","name":"foo"}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team")
+
+	ctx, responses := WithResponseMetadata(defaultCtx)
+	assert.Nil(t, responses.Last())
+	service, err := client.Services.Get(ctx, String("foo"))
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *service.ID)
+	last := responses.Last()
+	require.NotNil(t, last)
+	assert.Equal(t, "GET", last.Method)
+	assert.Equal(t, "/services/foo", last.Path)
+	assert.Equal(t, http.StatusOK, last.StatusCode)
+	assert.Equal(t, `"v1"`, last.Header.Get("ETag"))
+	assert.Equal(t, "req-GET", last.RequestID)
+	assert.Positive(t, last.Latency)
+
+	err = client.Services.Delete(ctx, String("foo"))
+	require.Error(t, err)
+	all := responses.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, http.StatusNotFound, all[1].StatusCode)
+	assert.Equal(t, "req-DELETE", all[1].RequestID)
+
+	responses.Reset()
+	assert.Empty(t, responses.All())
+	_, err = client.Services.Get(defaultCtx, String("foo"))
+	require.NoError(t, err)
+	assert.Empty(t, responses.All())
+}
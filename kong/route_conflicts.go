@@ -0,0 +1,381 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RouteConflictKind is the kind of conflict reported by
+// FindRouteConflicts.
+type RouteConflictKind string
+
+const (
+	// RouteConflictDuplicate is reported for a route matching the same
+	// requests as another route on a path, which Kong evaluates first.
+	RouteConflictDuplicate RouteConflictKind = "duplicate"
+	// RouteConflictShadowed is reported for a route which never receives
+	// the requests to one of its paths, since another route Kong
+	// evaluates first matches all of them.
+	RouteConflictShadowed RouteConflictKind = "shadowed"
+	// RouteConflictOverlap is reported for a route which doesn't receive
+	// some of the requests to one of its paths, e.g. those with some
+	// methods, since another route Kong evaluates first matches them.
+	RouteConflictOverlap RouteConflictKind = "overlap"
+)
+
+// RouteConflict is a conflict between two routes found by
+// FindRouteConflicts.
+type RouteConflict struct {
+	Kind RouteConflictKind
+	// Route is the route losing requests to Path, or all requests if
+	// Path is empty, to ShadowedBy, which matches them on ShadowingPath.
+	Route         *Route
+	Path          string
+	ShadowedBy    *Route
+	ShadowingPath string
+	Message       string
+}
+
+// RouteConflictReport holds the conflicts found by FindRouteConflicts.
+type RouteConflictReport struct {
+	Conflicts []RouteConflict
+	// Skipped holds the routes which weren't analyzed, i.e. stream routes
+	// and routes defined with expressions.
+	Skipped []*Route
+}
+
+// Filter returns the conflicts of kind.
+func (r *RouteConflictReport) Filter(kind RouteConflictKind) []RouteConflict {
+	var res []RouteConflict
+	for _, c := range r.Conflicts {
+		if c.Kind == kind {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// AnalyzeRoutes fetches all routes, or the routes of the service with
+// serviceNameOrID if it isn't nil, and finds those conflicting with each
+// other with FindRouteConflicts.
+func AnalyzeRoutes(ctx context.Context, client *Client,
+	serviceNameOrID *string,
+) (*RouteConflictReport, error) {
+	var routes []*Route
+	if serviceNameOrID == nil {
+		var err error
+		routes, err = client.Routes.ListAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing routes: %w", err)
+		}
+	} else {
+		opt := &ListOpt{Size: pageSize}
+		for opt != nil {
+			var data []*Route
+			var err error
+			data, opt, err = client.Routes.ListForService(ctx, serviceNameOrID, opt)
+			if err != nil {
+				return nil, fmt.Errorf("listing routes of service %s: %w", *serviceNameOrID, err)
+			}
+			routes = append(routes, data...)
+		}
+	}
+	return FindRouteConflicts(routes), nil
+}
+
+// FindRouteConflicts finds the HTTP and gRPC routes which don't receive
+// some of the requests they match, because Kong routes them to another
+// route, such as routes with the same path, or prefix paths matched by
+// a regex path.
+//
+// Conflicts are found following the order in which the traditional
+// router of Kong 3 evaluates routes: routes with more kinds of matching
+// rules (hosts, headers, methods, paths and SNIs) first, then regex
+// paths (starting with "~") by regex_priority, then prefix paths from
+// the longest, then the oldest routes. Regexes are interpreted with the
+// syntax of Go, regexes it doesn't support are ignored. Host wildcards
+// are compared as is, since Kong evaluates plain hosts first.
+func FindRouteConflicts(routes []*Route) *RouteConflictReport {
+	report := &RouteConflictReport{}
+	var analyzed []*routeMatcher
+	for _, route := range routes {
+		if route == nil {
+			continue
+		}
+		m, ok := newRouteMatcher(route)
+		if !ok {
+			report.Skipped = append(report.Skipped, route)
+			continue
+		}
+		analyzed = append(analyzed, m)
+	}
+
+	for _, b := range analyzed {
+		for _, pb := range b.paths {
+			for _, a := range analyzed {
+				if a == b || a.categories != b.categories || !a.sharesProtocol(b) {
+					continue
+				}
+				covers, intersects := a.compare(b)
+				if !intersects {
+					continue
+				}
+				pa, ok := a.matchFirst(pb, b)
+				if !ok {
+					continue
+				}
+				report.Conflicts = append(report.Conflicts, newRouteConflict(a, pa, b, pb, covers))
+				// one conflict per path is enough
+				break
+			}
+		}
+	}
+	return report
+}
+
+func newRouteConflict(a *routeMatcher, pa routePath, b *routeMatcher, pb routePath, covers bool) RouteConflict {
+	conflict := RouteConflict{
+		Route:         b.route,
+		Path:          pb.raw,
+		ShadowedBy:    a.route,
+		ShadowingPath: pa.raw,
+	}
+	on := "requests"
+	if pb.raw != "" {
+		on = "requests to " + pb.raw
+	}
+	switch {
+	case !covers:
+		conflict.Kind = RouteConflictOverlap
+		conflict.Message = fmt.Sprintf("route %s: some %s are routed to route %s",
+			b.route.FriendlyName(), on, a.route.FriendlyName())
+	case pa.raw == pb.raw && b.covers(a):
+		conflict.Kind = RouteConflictDuplicate
+		conflict.Message = fmt.Sprintf("route %s: %s are routed to route %s, which matches the same requests",
+			b.route.FriendlyName(), on, a.route.FriendlyName())
+	default:
+		conflict.Kind = RouteConflictShadowed
+		conflict.Message = fmt.Sprintf("route %s: %s are routed to route %s",
+			b.route.FriendlyName(), on, a.route.FriendlyName())
+	}
+	if pa.raw != pb.raw {
+		conflict.Message += " (path " + pa.raw + ")"
+	}
+	return conflict
+}
+
+const (
+	routeMatchHosts = 1 << iota
+	routeMatchHeaders
+	routeMatchMethods
+	routeMatchPaths
+	routeMatchSNIs
+)
+
+// routeMatcher holds the matching rules of a route, normalized.
+type routeMatcher struct {
+	route      *Route
+	categories int
+	protocols  map[string]bool
+	hosts      map[string]bool
+	methods    map[string]bool
+	headers    map[string]map[string]bool
+	snis       map[string]bool
+	// paths holds a single empty path for routes without paths.
+	paths []routePath
+}
+
+type routePath struct {
+	raw   string
+	regex *regexp.Regexp
+	// invalid is true for regexes which can't be compiled.
+	invalid bool
+}
+
+func (p routePath) isRegex() bool {
+	return strings.HasPrefix(p.raw, "~")
+}
+
+var streamProtocols = map[string]bool{
+	"tcp": true, "tls": true, "udp": true, "tls_passthrough": true,
+}
+
+func newRouteMatcher(route *Route) (*routeMatcher, bool) {
+	if !isEmptyString(route.Expression) {
+		return nil, false
+	}
+	m := &routeMatcher{
+		route:     route,
+		protocols: lowerSet(route.Protocols),
+		hosts:     lowerSet(route.Hosts),
+		methods:   map[string]bool{},
+		headers:   map[string]map[string]bool{},
+		snis:      lowerSet(route.SNIs),
+	}
+	if len(m.protocols) == 0 {
+		m.protocols = map[string]bool{"http": true, "https": true}
+	}
+	for protocol := range m.protocols {
+		if streamProtocols[protocol] {
+			return nil, false
+		}
+	}
+	for _, method := range route.Methods {
+		if method != nil {
+			m.methods[strings.ToUpper(*method)] = true
+		}
+	}
+	for name, values := range route.Headers {
+		set := map[string]bool{}
+		for _, value := range values {
+			set[strings.ToLower(value)] = true
+		}
+		m.headers[strings.ToLower(name)] = set
+	}
+	for _, path := range route.Paths {
+		if path == nil {
+			continue
+		}
+		p := routePath{raw: *path}
+		if p.isRegex() {
+			regex, err := regexp.Compile("^(?:" + strings.TrimPrefix(*path, "~") + ")")
+			p.regex, p.invalid = regex, err != nil
+		}
+		m.paths = append(m.paths, p)
+	}
+	for category, present := range map[int]bool{
+		routeMatchHosts:   len(m.hosts) > 0,
+		routeMatchHeaders: len(m.headers) > 0,
+		routeMatchMethods: len(m.methods) > 0,
+		routeMatchPaths:   len(m.paths) > 0,
+		routeMatchSNIs:    len(m.snis) > 0,
+	} {
+		if present {
+			m.categories |= category
+		}
+	}
+	if len(m.paths) == 0 {
+		m.paths = []routePath{{}}
+	}
+	return m, true
+}
+
+func lowerSet(values []*string) map[string]bool {
+	set := map[string]bool{}
+	for _, value := range values {
+		if value != nil {
+			set[strings.ToLower(*value)] = true
+		}
+	}
+	return set
+}
+
+func (m *routeMatcher) sharesProtocol(other *routeMatcher) bool {
+	for protocol := range m.protocols {
+		if other.protocols[protocol] {
+			return true
+		}
+	}
+	return false
+}
+
+// compare returns whether m matches all the requests, and some requests,
+// other matches, leaving paths aside. Routes with the same categories
+// only are compared.
+func (m *routeMatcher) compare(other *routeMatcher) (covers, intersects bool) {
+	covers, intersects = true, true
+	for _, sets := range [][2]map[string]bool{
+		{m.hosts, other.hosts},
+		{m.methods, other.methods},
+		{m.snis, other.snis},
+	} {
+		c, i := compareSets(sets[0], sets[1])
+		covers, intersects = covers && c, intersects && i
+	}
+	// Kong evaluates routes with more headers first
+	if len(m.headers) != len(other.headers) {
+		return false, false
+	}
+	for name, values := range m.headers {
+		otherValues, ok := other.headers[name]
+		if !ok {
+			return false, false
+		}
+		c, i := compareSets(values, otherValues)
+		covers, intersects = covers && c, intersects && i
+	}
+	return covers, intersects
+}
+
+func (m *routeMatcher) covers(other *routeMatcher) bool {
+	covers, _ := m.compare(other)
+	return covers
+}
+
+// compareSets returns whether a holds all the values, and some values,
+// of b. Empty sets match everything.
+func compareSets(a, b map[string]bool) (covers, intersects bool) {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == 0, true
+	}
+	covers = true
+	for value := range b {
+		if a[value] {
+			intersects = true
+		} else {
+			covers = false
+		}
+	}
+	return covers, intersects
+}
+
+// matchFirst returns the path of m matching the requests to pb of other
+// and evaluated before it by Kong, if any.
+func (m *routeMatcher) matchFirst(pb routePath, other *routeMatcher) (routePath, bool) {
+	for _, pa := range m.paths {
+		switch {
+		case pa.raw == pb.raw:
+			if pa.isRegex() && regexPriority(m.route) != regexPriority(other.route) {
+				if regexPriority(m.route) > regexPriority(other.route) {
+					return pa, true
+				}
+				continue
+			}
+			if m.createdBefore(other) {
+				return pa, true
+			}
+		case pa.isRegex() && !pa.invalid && !pb.isRegex():
+			// regex paths are evaluated before prefix paths
+			if pa.regex.MatchString(pb.raw) {
+				return pa, true
+			}
+		}
+	}
+	return routePath{}, false
+}
+
+func regexPriority(route *Route) int {
+	if route.RegexPriority == nil {
+		return 0
+	}
+	return *route.RegexPriority
+}
+
+// createdBefore returns true if Kong evaluates m before other when their
+// rules are otherwise equal. Routes without creation time, e.g. those
+// which aren't created yet, come last.
+func (m *routeMatcher) createdBefore(other *routeMatcher) bool {
+	a, b := m.route.CreatedAt, other.route.CreatedAt
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	case *a != *b:
+		return *a < *b
+	}
+	// the order isn't defined, report one of the routes only
+	return derefString(m.route.ID) < derefString(other.route.ID)
+}
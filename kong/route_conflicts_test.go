@@ -0,0 +1,94 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRouteConflicts(t *testing.T) {
+	route := func(name string, createdAt int, paths []string, methods ...string) *Route {
+		return &Route{
+			ID:        String(name),
+			Name:      String(name),
+			CreatedAt: Int(createdAt),
+			Paths:     StringSlice(paths...),
+			Methods:   StringSlice(methods...),
+		}
+	}
+	regexPriority := func(r *Route, priority int) *Route {
+		r.RegexPriority = Int(priority)
+		return r
+	}
+	withHosts := route("hosts", 0, []string{"/api"}, "GET")
+	withHosts.Hosts = StringSlice("example.com")
+	grpc := route("grpc", 0, []string{"/api"}, "GET")
+	grpc.Protocols = StringSlice("grpc")
+	stream := &Route{Name: String("stream"), Protocols: StringSlice("tcp")}
+	expression := &Route{Name: String("expression"), Expression: String(`http.path == "/api"`)}
+
+	report := FindRouteConflicts([]*Route{
+		route("api", 1, []string{"/api"}, "GET"),
+		route("api-copy", 2, []string{"/api"}, "get"),
+		route("user", 1, []string{`~/users/\d+$`}),
+		route("user-42", 1, []string{"/users/42", "/users"}),
+		route("orders", 1, []string{"/orders"}, "GET", "POST"),
+		route("orders-write", 2, []string{"/orders"}, "POST", "PUT"),
+		regexPriority(route("v1", 3, []string{`~/v\d+`}), 10),
+		regexPriority(route("v1-old", 1, []string{`~/v\d+`}), 0),
+		route("bad-regex", 1, []string{`~/(?=x)`}),
+		withHosts, grpc, stream, expression,
+	})
+	assert.Equal(t, []*Route{stream, expression}, report.Skipped)
+	require.Len(t, report.Conflicts, 4)
+
+	duplicates := report.Filter(RouteConflictDuplicate)
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, "api-copy", *duplicates[0].Route.Name)
+	assert.Equal(t, "api", *duplicates[0].ShadowedBy.Name)
+	assert.Equal(t, "/api", duplicates[0].Path)
+	assert.Equal(t, "v1-old", *duplicates[1].Route.Name)
+	assert.Equal(t, "v1", *duplicates[1].ShadowedBy.Name)
+
+	shadowed := report.Filter(RouteConflictShadowed)
+	require.Len(t, shadowed, 1)
+	assert.Equal(t, "user-42", *shadowed[0].Route.Name)
+	assert.Equal(t, "/users/42", shadowed[0].Path)
+	assert.Equal(t, `~/users/\d+$`, shadowed[0].ShadowingPath)
+	assert.Equal(t, `route user-42: requests to /users/42 are routed to route user (path ~/users/\d+$)`,
+		shadowed[0].Message)
+
+	overlaps := report.Filter(RouteConflictOverlap)
+	require.Len(t, overlaps, 1)
+	assert.Equal(t, "orders-write", *overlaps[0].Route.Name)
+	assert.Equal(t, "orders", *overlaps[0].ShadowedBy.Name)
+}
+
+func TestAnalyzeRoutes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/routes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","paths":["/a"],"created_at":1},
+				{"id":"r2","paths":["/a"],"created_at":2}]}`))
+		case "/services/svc/routes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","paths":["/a"]}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	report, err := AnalyzeRoutes(defaultCtx, client, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Conflicts, 1)
+	assert.Equal(t, "r2", *report.Conflicts[0].Route.ID)
+
+	report, err = AnalyzeRoutes(defaultCtx, client, String("svc"))
+	require.NoError(t, err)
+	assert.Empty(t, report.Conflicts)
+}
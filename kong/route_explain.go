@@ -0,0 +1,500 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// RouteRequest is a hypothetical request evaluated by MatchRoutes.
+type RouteRequest struct {
+	// Protocol is the protocol of the request, "http" if empty.
+	Protocol string
+	Method   string
+	// Host is the value of the Host header, with or without port.
+	Host    string
+	Path    string
+	Headers map[string][]string
+	// SNI is the server name sent by the client for TLS requests.
+	SNI string
+}
+
+// RouteExplanation is the outcome of MatchRoutes.
+type RouteExplanation struct {
+	// Route is the route the request would be routed to, nil if none.
+	Route *Route
+	// Matching holds all the routes matching the request, in the order
+	// Kong evaluates them, Route first.
+	Matching []*Route
+	// Skipped holds the routes which couldn't be evaluated, e.g.
+	// expressions using fields or operators MatchRoutes doesn't support.
+	Skipped []*Route
+	// Errors holds why routes were skipped, by route name or ID.
+	Errors map[string]string
+}
+
+// ExplainRequest fetches all routes and evaluates req against them with
+// MatchRoutes.
+func ExplainRequest(ctx context.Context, client *Client, req RouteRequest) (*RouteExplanation, error) {
+	routes, err := client.Routes.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing routes: %w", err)
+	}
+	return MatchRoutes(routes, req), nil
+}
+
+// MatchRoutes finds the route Kong would route req to among routes,
+// e.g. to check changes to routes before deploying them. It
+// approximates the router of Kong, and regexes are interpreted with the
+// syntax of Go.
+//
+// Routes defined with expressions are evaluated first, by decreasing
+// priority. They support the net.protocol, tls.sni, http.method,
+// http.host, http.path and http.headers.* fields, the lower() function,
+// and the ==, !=, ~, ^=, =^ and contains operators on strings.
+//
+// Routes defined with fields (hosts, methods, paths, headers and SNIs)
+// are then evaluated in the order of the traditional router: routes
+// with more kinds of fields, then more headers, then plain hosts only,
+// then matching a regex path by regex_priority, then matching the
+// longest prefix path, then the oldest.
+func MatchRoutes(routes []*Route, req RouteRequest) *RouteExplanation {
+	if req.Protocol == "" {
+		req.Protocol = string(RouteProtocolHTTP)
+	}
+	req.Method = strings.ToUpper(req.Method)
+	req.Host = strings.ToLower(req.Host)
+	if host, _, found := strings.Cut(req.Host, ":"); found {
+		req.Host = host
+	}
+
+	explanation := &RouteExplanation{Errors: map[string]string{}}
+	skip := func(route *Route, err error) {
+		explanation.Skipped = append(explanation.Skipped, route)
+		explanation.Errors[route.FriendlyName()] = err.Error()
+	}
+	type match struct {
+		route *Route
+		key   []int
+	}
+	var expressions, traditional []match
+	for _, route := range routes {
+		if route == nil {
+			continue
+		}
+		if !isEmptyString(route.Expression) {
+			expr, err := parseRouteExpression(*route.Expression)
+			if err != nil {
+				skip(route, err)
+				continue
+			}
+			ok, err := expr.eval(req)
+			if err != nil {
+				skip(route, err)
+				continue
+			}
+			if ok {
+				priority := 0
+				if route.Priority != nil {
+					priority = *route.Priority
+				}
+				expressions = append(expressions, match{route, []int{-priority}})
+			}
+			continue
+		}
+		m, ok := newRouteMatcher(route)
+		if !ok {
+			skip(route, fmt.Errorf("stream routes are not supported"))
+			continue
+		}
+		if key, ok := m.match(req); ok {
+			traditional = append(traditional, match{route, key})
+		}
+	}
+
+	for _, matches := range [][]match{expressions, traditional} {
+		sort.SliceStable(matches, func(i, j int) bool {
+			a, b := matches[i], matches[j]
+			for k := range a.key {
+				if a.key[k] != b.key[k] {
+					return a.key[k] < b.key[k]
+				}
+			}
+			return (&routeMatcher{route: a.route}).createdBefore(&routeMatcher{route: b.route})
+		})
+		for _, m := range matches {
+			explanation.Matching = append(explanation.Matching, m.route)
+		}
+	}
+	if len(explanation.Matching) > 0 {
+		explanation.Route = explanation.Matching[0]
+	}
+	return explanation
+}
+
+// match returns whether m matches req, and if so a key sorting routes in
+// the order the traditional router evaluates them.
+func (m *routeMatcher) match(req RouteRequest) ([]int, bool) {
+	if !m.protocols[req.Protocol] {
+		return nil, false
+	}
+	plainHosts := 1
+	if len(m.hosts) > 0 {
+		matched := false
+		for host := range m.hosts {
+			if strings.Contains(host, "*") {
+				plainHosts = 0
+			}
+			matched = matched || matchRouteHost(host, req.Host)
+		}
+		if !matched {
+			return nil, false
+		}
+	}
+	if len(m.methods) > 0 && !m.methods[req.Method] {
+		return nil, false
+	}
+	for name, values := range m.headers {
+		matched := false
+		for requestName, requestValues := range req.Headers {
+			if !strings.EqualFold(name, requestName) {
+				continue
+			}
+			for _, value := range requestValues {
+				matched = matched || values[strings.ToLower(value)]
+			}
+		}
+		if !matched {
+			return nil, false
+		}
+	}
+	if len(m.snis) > 0 && !m.snis[strings.ToLower(req.SNI)] {
+		return nil, false
+	}
+
+	// regex paths first, by regex_priority, then prefix paths by length
+	pathKey := []int{0, 0, 0}
+	if m.categories&routeMatchPaths != 0 {
+		matched := false
+		for _, path := range m.paths {
+			var key []int
+			switch {
+			case path.isRegex():
+				if path.invalid || !path.regex.MatchString(req.Path) {
+					continue
+				}
+				key = []int{0, -regexPriority(m.route), 0}
+			case strings.HasPrefix(req.Path, path.raw):
+				key = []int{1, 0, -len(path.raw)}
+			default:
+				continue
+			}
+			if !matched || lessKey(key, pathKey) {
+				pathKey, matched = key, true
+			}
+		}
+		if !matched {
+			return nil, false
+		}
+	}
+	return append([]int{-bits.OnesCount(uint(m.categories)), -len(m.headers), -plainHosts}, pathKey...), true
+}
+
+func lessKey(a, b []int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+// matchRouteHost matches host against pattern, a host of a route which
+// can start or end with a wildcard.
+func matchRouteHost(pattern, host string) bool {
+	switch {
+	case strings.HasPrefix(pattern, "*"):
+		return strings.HasSuffix(host, pattern[1:])
+	case strings.HasSuffix(pattern, "*"):
+		return strings.HasPrefix(host, pattern[:len(pattern)-1])
+	}
+	if p, _, found := strings.Cut(pattern, ":"); found {
+		pattern = p
+	}
+	return pattern == host
+}
+
+// routeExpression is a parsed route expression.
+type routeExpression interface {
+	eval(req RouteRequest) (bool, error)
+}
+
+type routeExprAnd [2]routeExpression
+
+func (e routeExprAnd) eval(req RouteRequest) (bool, error) {
+	ok, err := e[0].eval(req)
+	if err != nil || !ok {
+		return false, err
+	}
+	return e[1].eval(req)
+}
+
+type routeExprOr [2]routeExpression
+
+func (e routeExprOr) eval(req RouteRequest) (bool, error) {
+	ok, err := e[0].eval(req)
+	if err != nil || ok {
+		return ok, err
+	}
+	return e[1].eval(req)
+}
+
+type routeExprNot struct {
+	expr routeExpression
+}
+
+func (e routeExprNot) eval(req RouteRequest) (bool, error) {
+	ok, err := e.expr.eval(req)
+	return !ok, err
+}
+
+type routeExprPredicate struct {
+	field string
+	lower bool
+	op    string
+	value string
+	regex *regexp.Regexp
+}
+
+func (p *routeExprPredicate) eval(req RouteRequest) (bool, error) {
+	var values []string
+	switch {
+	case p.field == "net.protocol":
+		values = []string{req.Protocol}
+	case p.field == "tls.sni":
+		values = []string{req.SNI}
+	case p.field == "http.method":
+		values = []string{req.Method}
+	case p.field == "http.host":
+		values = []string{req.Host}
+	case p.field == "http.path":
+		values = []string{req.Path}
+	case strings.HasPrefix(p.field, "http.headers."):
+		name := strings.TrimPrefix(p.field, "http.headers.")
+		for requestName, requestValues := range req.Headers {
+			if strings.ReplaceAll(strings.ToLower(requestName), "-", "_") == name {
+				values = append(values, requestValues...)
+			}
+		}
+	default:
+		return false, fmt.Errorf("unsupported field %q", p.field)
+	}
+	// predicates on missing fields don't match
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		if p.lower {
+			value = strings.ToLower(value)
+		}
+		var ok bool
+		switch p.op {
+		case "==":
+			ok = value == p.value
+		case "!=":
+			ok = value != p.value
+		case "~":
+			ok = p.regex.MatchString(value)
+		case "^=":
+			ok = strings.HasPrefix(value, p.value)
+		case "=^":
+			ok = strings.HasSuffix(value, p.value)
+		case "contains":
+			ok = strings.Contains(value, p.value)
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseRouteExpression parses expr, a route expression of the
+// expressions router.
+func parseRouteExpression(expr string) (routeExpression, error) {
+	p := &routeExprParser{input: expr}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+	return e, nil
+}
+
+type routeExprParser struct {
+	input string
+	pos   int
+}
+
+func (p *routeExprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid expression at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *routeExprParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// consume skips token if it's next in the input.
+func (p *routeExprParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *routeExprParser) parseOr() (routeExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = routeExprOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *routeExprParser) parseAnd() (routeExpression, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.consume("&&") {
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = routeExprAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *routeExprParser) parseFactor() (routeExpression, error) {
+	if p.consume("!") {
+		e, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return routeExprNot{e}, nil
+	}
+	if p.consume("(") {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing )")
+		}
+		return e, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *routeExprParser) parsePredicate() (routeExpression, error) {
+	predicate := &routeExprPredicate{}
+	if p.consume("lower(") {
+		predicate.lower = true
+	}
+	predicate.field = p.parseIdentifier()
+	if predicate.field == "" {
+		return nil, p.errorf("expected field")
+	}
+	if predicate.lower && !p.consume(")") {
+		return nil, p.errorf("missing )")
+	}
+
+	ops := []string{"==", "!=", "^=", "=^", "~", "contains", "not in", "in", ">=", "<=", ">", "<"}
+	for _, op := range ops {
+		if p.consume(op) {
+			predicate.op = op
+			break
+		}
+	}
+	switch predicate.op {
+	case "":
+		return nil, p.errorf("expected operator")
+	case "in", "not in", ">", "<", ">=", "<=":
+		return nil, p.errorf("unsupported operator %q", predicate.op)
+	}
+
+	value, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+	predicate.value = value
+	if predicate.op == "~" {
+		if predicate.regex, err = regexp.Compile(value); err != nil {
+			return nil, p.errorf("invalid regex %q: %v", value, err)
+		}
+	}
+	return predicate, nil
+}
+
+func (p *routeExprParser) parseIdentifier() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' && c != '.' {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+// parseString parses a string literal, either quoted or raw (r#"..."#).
+func (p *routeExprParser) parseString() (string, error) {
+	p.skipSpaces()
+	rest := p.input[p.pos:]
+	if strings.HasPrefix(rest, `r#"`) {
+		end := strings.Index(rest[3:], `"#`)
+		if end < 0 {
+			return "", p.errorf("unterminated raw string")
+		}
+		p.pos += 3 + end + 2
+		return rest[3 : 3+end], nil
+	}
+	if !strings.HasPrefix(rest, `"`) {
+		return "", p.errorf("expected string")
+	}
+	for i := 1; i < len(rest); i++ {
+		switch rest[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(rest[:i+1])
+			if err != nil {
+				return "", p.errorf("invalid string %s", rest[:i+1])
+			}
+			p.pos += i + 1
+			return value, nil
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
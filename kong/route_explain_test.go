@@ -0,0 +1,154 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchRoutes(t *testing.T) {
+	route := func(name string, createdAt int, paths ...string) *Route {
+		return &Route{
+			ID:        String(name),
+			Name:      String(name),
+			CreatedAt: Int(createdAt),
+			Paths:     StringSlice(paths...),
+		}
+	}
+	names := func(routes []*Route) []string {
+		var names []string
+		for _, r := range routes {
+			names = append(names, *r.Name)
+		}
+		return names
+	}
+
+	api := route("api", 1, "/api")
+	apiUsers := route("api-users", 2, "/api/users")
+	user := route("user", 3, `~/api/users/\d+$`)
+	user.RegexPriority = Int(5)
+	userOld := route("user-old", 1, `~/api/users/\d+`)
+	withHost := route("host", 4, "/")
+	withHost.Hosts = StringSlice("*.example.com")
+	post := route("post", 5, "/api")
+	post.Methods = StringSlice("POST")
+	withHeader := route("header", 6, "/api")
+	withHeader.Headers = map[string][]string{"x-version": {"v2"}}
+	grpc := route("grpc", 1, "/")
+	grpc.Protocols = StringSlice("grpc")
+	routes := []*Route{api, apiUsers, user, userOld, withHost, post, withHeader, grpc}
+
+	explanation := MatchRoutes(routes, RouteRequest{Method: "get", Path: "/api/users/42"})
+	assert.Equal(t, "user", *explanation.Route.Name)
+	assert.Equal(t, []string{"user", "user-old", "api-users", "api"}, names(explanation.Matching))
+	assert.Empty(t, explanation.Skipped)
+
+	explanation = MatchRoutes(routes, RouteRequest{Method: "POST", Host: "api.example.com:8000", Path: "/api"})
+	assert.Equal(t, []string{"post", "host", "api"}, names(explanation.Matching))
+
+	explanation = MatchRoutes(routes, RouteRequest{
+		Method:  "GET",
+		Path:    "/api",
+		Headers: map[string][]string{"X-Version": {"V2"}},
+	})
+	assert.Equal(t, "header", *explanation.Route.Name)
+
+	explanation = MatchRoutes(routes, RouteRequest{Protocol: "grpc", Path: "/svc.Echo/Say"})
+	assert.Equal(t, "grpc", *explanation.Route.Name)
+
+	explanation = MatchRoutes(routes, RouteRequest{Method: "GET", Path: "/other"})
+	assert.Nil(t, explanation.Route)
+	assert.Empty(t, explanation.Matching)
+}
+
+func TestMatchRoutesExpressions(t *testing.T) {
+	expression := func(name, expr string, priority int) *Route {
+		return &Route{Name: String(name), Expression: String(expr), Priority: Int(priority)}
+	}
+	routes := []*Route{
+		expression("users", `http.path ^= "/users" && (http.method == "GET" || http.method == "HEAD")`, 10),
+		expression("user", `http.path ~ r#"^/users/\d+$"# && !(http.headers.x_debug == "1")`, 20),
+		expression("host", `lower(http.host) =^ ".example.com" && net.protocol == "https"`, 5),
+		expression("sni", `tls.sni contains "internal"`, 1),
+		expression("segments", `http.path.segments.0 == "users"`, 1),
+		expression("invalid", `http.path == "/a" &&`, 1),
+		{Name: String("legacy"), Paths: StringSlice("/")},
+	}
+	names := func(routes []*Route) []string {
+		var names []string
+		for _, r := range routes {
+			names = append(names, *r.Name)
+		}
+		return names
+	}
+
+	explanation := MatchRoutes(routes, RouteRequest{Method: "GET", Path: "/users/42"})
+	assert.Equal(t, []string{"user", "users", "legacy"}, names(explanation.Matching))
+	assert.Equal(t, []string{"segments", "invalid"}, names(explanation.Skipped))
+	assert.Equal(t, `unsupported field "http.path.segments.0"`, explanation.Errors["segments"])
+	assert.Contains(t, explanation.Errors["invalid"], "invalid expression")
+
+	explanation = MatchRoutes(routes, RouteRequest{
+		Method:  "GET",
+		Path:    "/users/42",
+		Headers: map[string][]string{"X-Debug": {"1"}},
+	})
+	assert.Equal(t, "users", *explanation.Route.Name)
+
+	explanation = MatchRoutes(routes, RouteRequest{
+		Protocol: "https",
+		Method:   "DELETE",
+		Host:     "API.Example.com",
+		Path:     "/orders",
+		SNI:      "internal.example.com",
+	})
+	assert.Equal(t, []string{"host", "sni", "legacy"}, names(explanation.Matching))
+}
+
+func TestParseRouteExpression(t *testing.T) {
+	for _, expr := range []string{
+		`http.path == "/a"`,
+		`!http.path == "/a"`,
+		`(http.path == "/a" || http.path == "/b") && http.host == "x"`,
+		`http.path == "/a\"b"`,
+		`http.path ~ r#"^/a"b"#`,
+	} {
+		_, err := parseRouteExpression(expr)
+		assert.NoError(t, err, expr)
+	}
+	for _, expr := range []string{
+		`http.path`,
+		`http.path == `,
+		`http.path == "/a`,
+		`(http.path == "/a"`,
+		`http.path == "/a" http.host == "x"`,
+		`http.path ~ "("`,
+		`net.src.ip in 10.0.0.0/8`,
+	} {
+		_, err := parseRouteExpression(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestExplainRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/routes" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"r1","paths":["/a"],"created_at":1},
+			{"id":"r2","paths":["/a/b"],"created_at":2}]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	explanation, err := ExplainRequest(defaultCtx, client, RouteRequest{Method: "GET", Path: "/a/b/c"})
+	require.NoError(t, err)
+	require.NotNil(t, explanation.Route)
+	assert.Equal(t, "r2", *explanation.Route.ID)
+	assert.Len(t, explanation.Matching, 2)
+}
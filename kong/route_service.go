@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -22,6 +21,8 @@ type AbstractRouteService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Route, *ListOpt, error)
 	// ListAll fetches all Routes in Kong.
 	ListAll(ctx context.Context) ([]*Route, error)
+	// DeleteWhere deletes the Routes in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Route) bool) ([]*Route, error)
 	// ListForService fetches a list of Routes in Kong associated with a service.
 	ListForService(ctx context.Context, serviceNameOrID *string, opt *ListOpt) ([]*Route, *ListOpt, error)
 }
@@ -32,13 +33,29 @@ type RouteService service
 // Create creates a Route in Kong
 // If an ID is specified, it will be used to
 // create a route in Kong, otherwise an ID
-// is auto-generated.
+// is auto-generated, unless creates are idempotent,
+// see WithIdempotencyKey and SetIdempotentCreates.
 func (s *RouteService) Create(ctx context.Context,
 	route *Route,
 ) (*Route, error) {
 	if route == nil {
 		return nil, fmt.Errorf("cannot create a nil route")
 	}
+	if err := validateNameAndTags(route.Name, route.Tags); err != nil {
+		return nil, err
+	}
+
+	if route.ID == nil {
+		id, err := s.client.idempotentID(ctx, route, route.Name)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			withID := *route
+			withID.ID = id
+			route = &withID
+		}
+	}
 
 	endpoint := "/routes"
 	method := "POST"
@@ -107,6 +124,9 @@ func (s *RouteService) Update(ctx context.Context,
 	if isEmptyString(route.ID) {
 		return nil, fmt.Errorf("ID cannot be nil for Update operation")
 	}
+	if err := validateNameAndTags(route.Name, route.Tags); err != nil {
+		return nil, err
+	}
 
 	endpoint := fmt.Sprintf("/routes/%v", *route.ID)
 	req, err := s.client.NewRequest("PATCH", endpoint, nil, route)
@@ -154,7 +174,7 @@ func (s *RouteService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var route Route
-		err = json.Unmarshal(b, &route)
+		err = s.client.decode(b, &route)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -182,6 +202,17 @@ func (s *RouteService) ListAll(ctx context.Context) ([]*Route, error) {
 	return routes, nil
 }
 
+// DeleteWhere deletes the Routes in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Routes are deleted from them,
+// so that unlike deleting Routes while paginating, none are skipped.
+func (s *RouteService) DeleteWhere(ctx context.Context,
+	predicate func(*Route) bool,
+) ([]*Route, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Route) *string { return entity.ID }, predicate)
+}
+
 // ListForService fetches a list of Routes in Kong associated with a service.
 // opt can be used to control pagination.
 func (s *RouteService) ListForService(ctx context.Context,
@@ -199,7 +230,7 @@ func (s *RouteService) ListForService(ctx context.Context,
 			return nil, nil, err
 		}
 		var route Route
-		err = json.Unmarshal(b, &route)
+		err = s.client.decode(b, &route)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,132 @@
+package kong
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type schemaCacheKey struct {
+	plugin bool
+	name   string
+}
+
+// SchemaCache caches the entity and plugin schemas of a Kong, so that code
+// validating many entities doesn't fetch the same schema for each of them.
+//
+// Schemas are cached along with the version of Kong they were fetched
+// from. The version is checked again when schemas are requested at most
+// once per check interval, and the cache is emptied if it changed, e.g.
+// after Kong was upgraded. A SchemaCache is safe for concurrent use.
+type SchemaCache struct {
+	client        *Client
+	checkInterval time.Duration
+
+	lock         sync.Mutex
+	version      string
+	versionCheck time.Time
+	schemas      map[schemaCacheKey]Schema
+	// generation is incremented whenever schemas is emptied, so that
+	// schemas fetched before aren't cached.
+	generation uint64
+}
+
+// NewSchemaCache returns a SchemaCache fetching schemas with client.
+// If checkInterval is zero, the version of Kong is only checked when
+// the first schema is requested and on calls to Refresh.
+func NewSchemaCache(client *Client, checkInterval time.Duration) *SchemaCache {
+	return &SchemaCache{
+		client:        client,
+		checkInterval: checkInterval,
+		schemas:       map[schemaCacheKey]Schema{},
+	}
+}
+
+// EntitySchema returns the schema of entity, e.g. "services".
+func (c *SchemaCache) EntitySchema(ctx context.Context, entity string) (Schema, error) {
+	return c.get(ctx, schemaCacheKey{name: entity}, func() (Schema, error) {
+		return c.client.Schemas.Get(ctx, entity)
+	})
+}
+
+// PluginSchema returns the full schema of the plugin named name, as
+// returned by PluginService.GetFullSchema.
+func (c *SchemaCache) PluginSchema(ctx context.Context, name string) (Schema, error) {
+	return c.get(ctx, schemaCacheKey{plugin: true, name: name}, func() (Schema, error) {
+		return c.client.Plugins.GetFullSchema(ctx, String(name))
+	})
+}
+
+// Version returns the version of Kong the cached schemas were fetched
+// from, or an empty string if it wasn't checked yet.
+func (c *SchemaCache) Version() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.version
+}
+
+// Refresh checks the version of Kong right away, emptying the cache if
+// it changed.
+func (c *SchemaCache) Refresh(ctx context.Context) error {
+	info, err := c.client.Root(ctx)
+	if err != nil {
+		return err
+	}
+	version := VersionFromInfo(info)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if version != c.version {
+		c.version = version
+		c.clear()
+	}
+	c.versionCheck = time.Now()
+	return nil
+}
+
+// Invalidate empties the cache.
+func (c *SchemaCache) Invalidate() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.clear()
+}
+
+// clear must be called with c.lock held.
+func (c *SchemaCache) clear() {
+	c.schemas = map[schemaCacheKey]Schema{}
+	c.generation++
+}
+
+// get returns the schema cached for key, or fetches it. Fetches happen
+// without c.lock held, so that a slow fetch doesn't hold up the others;
+// concurrent misses of a key may fetch it more than once.
+func (c *SchemaCache) get(ctx context.Context, key schemaCacheKey,
+	fetch func() (Schema, error),
+) (Schema, error) {
+	c.lock.Lock()
+	check := c.versionCheck.IsZero() ||
+		(c.checkInterval > 0 && time.Since(c.versionCheck) >= c.checkInterval)
+	c.lock.Unlock()
+	if check {
+		if err := c.Refresh(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	c.lock.Lock()
+	schema, ok := c.schemas[key]
+	generation := c.generation
+	c.lock.Unlock()
+	if ok {
+		return schema, nil
+	}
+	schema, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.generation == generation {
+		c.schemas[key] = schema
+	}
+	return schema, nil
+}
@@ -0,0 +1,112 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCache(t *testing.T) {
+	var version atomic.Value
+	version.Store("3.3.0")
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":"` + version.Load().(string) + `"}`))
+		case "/schemas/services", "/schemas/plugins/key-auth":
+			fetches.Add(1)
+			_, _ = w.Write([]byte(`{"fields":[{"version":{"type":"string","default":"` +
+				version.Load().(string) + `"}}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	cache := NewSchemaCache(client, 0)
+	assert.Equal(t, "", cache.Version())
+	for i := 0; i < 3; i++ {
+		_, err := cache.EntitySchema(defaultCtx, "services")
+		require.NoError(t, err)
+		_, err = cache.PluginSchema(defaultCtx, "key-auth")
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 2, fetches.Load())
+	assert.Equal(t, "3.3.0", cache.Version())
+
+	// the version is only checked on Refresh without a check interval
+	version.Store("3.4.0")
+	_, err = cache.EntitySchema(defaultCtx, "services")
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, fetches.Load())
+	require.NoError(t, cache.Refresh(defaultCtx))
+	assert.Equal(t, "3.4.0", cache.Version())
+	schema, err := cache.EntitySchema(defaultCtx, "services")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, fetches.Load())
+	assert.Contains(t, schema["fields"].([]interface{})[0], "version")
+
+	// refreshing with an unchanged version keeps the cache
+	require.NoError(t, cache.Refresh(defaultCtx))
+	_, err = cache.EntitySchema(defaultCtx, "services")
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, fetches.Load())
+
+	cache.Invalidate()
+	_, err = cache.EntitySchema(defaultCtx, "services")
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, fetches.Load())
+
+	// with a check interval, version changes are detected on access
+	cache = NewSchemaCache(client, time.Nanosecond)
+	_, err = cache.PluginSchema(defaultCtx, "key-auth")
+	require.NoError(t, err)
+	version.Store("3.5.0")
+	_, err = cache.PluginSchema(defaultCtx, "key-auth")
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, fetches.Load())
+	assert.Equal(t, "3.5.0", cache.Version())
+}
+
+func TestSchemaCacheSlowFetch(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`{"version":"3.4.0"}`))
+		case "/schemas/plugins/slow":
+			<-unblock
+			_, _ = w.Write([]byte(`{"fields":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"fields":[]}`))
+		}
+	}))
+	defer srv.Close()
+	defer close(unblock)
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	cache := NewSchemaCache(client, 0)
+	require.NoError(t, cache.Refresh(defaultCtx))
+
+	go func() { _, _ = cache.PluginSchema(defaultCtx, "slow") }()
+	done := make(chan error)
+	go func() {
+		_, err := cache.EntitySchema(defaultCtx, "services")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("a slow fetch held up the cache")
+	}
+}
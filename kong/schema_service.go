@@ -2,13 +2,17 @@ package kong
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 )
 
 // AbstractSchemaService handles schemas in Kong.
 type AbstractSchemaService interface {
 	// Get fetches an entity schema from Kong.
 	Get(ctx context.Context, entity string) (Schema, error)
+	// Validate validates an entity against its schema.
+	Validate(ctx context.Context, entity string, body interface{}) (bool, string, error)
 }
 
 // SchemaService handles schemas in Kong.
@@ -30,3 +34,35 @@ func (s *SchemaService) Get(ctx context.Context, entity string) (Schema, error)
 	}
 	return schema, nil
 }
+
+// Validate validates body, an entity of type entity (e.g. "services"),
+// against its schema without creating it. It returns false and the
+// message of Kong if body is not valid.
+func (s *SchemaService) Validate(ctx context.Context, entity string,
+	body interface{},
+) (bool, string, error) {
+	if entity == "" {
+		return false, "", fmt.Errorf("entity cannot be empty for Validate operation")
+	}
+	endpoint := fmt.Sprintf("/schemas/%s/validate", entity)
+	req, err := s.client.NewRequest("POST", endpoint, nil, body)
+	if err != nil {
+		return false, "", err
+	}
+	resp, err := s.client.Do(ctx, req, nil)
+	if err != nil {
+		if resp == nil {
+			return false, "", err
+		}
+		// Kong returns a 400 for invalid entities, see PluginService.Validate.
+		if resp.StatusCode == http.StatusBadRequest {
+			var apiError *APIError
+			if !errors.As(err, &apiError) {
+				return false, "", err
+			}
+			return false, apiError.message, nil
+		}
+		return false, "", err
+	}
+	return resp.StatusCode == http.StatusCreated || resp.StatusCode == http.StatusOK, "", nil
+}
@@ -0,0 +1,101 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+)
+
+// ServiceBackend is where a Service proxies traffic to.
+// Host is either a hostname or the name of an Upstream.
+type ServiceBackend struct {
+	Host *string
+	Port *int
+}
+
+// String returns the backend formatted as host:port.
+func (b ServiceBackend) String() string {
+	s := ""
+	if b.Host != nil {
+		s = *b.Host
+	}
+	if b.Port != nil {
+		s = fmt.Sprintf("%s:%d", s, *b.Port)
+	}
+	return s
+}
+
+// matches returns true if b is the backend expected, where a nil
+// expected.Port matches any port.
+func (b ServiceBackend) matches(expected ServiceBackend) bool {
+	if derefString(b.Host) != derefString(expected.Host) {
+		return false
+	}
+	return expected.Port == nil || (b.Port != nil && *b.Port == *expected.Port)
+}
+
+// SwitchBackendOpts configures Svcservice.SwitchBackend.
+type SwitchBackendOpts struct {
+	// Expected, if not nil, is the backend the Service is expected to
+	// point at before the switch. If it points elsewhere, the switch is
+	// not performed. A nil Port matches any port. This is a best-effort
+	// precondition: the Service is fetched and then updated in separate
+	// requests, as the Admin API has no conditional updates, so it
+	// doesn't guard against concurrent switches.
+	Expected *ServiceBackend
+	// VerifyHealth requires the new backend to be an Upstream with at
+	// least one healthy target before switching. Targets reported as
+	// HEALTHCHECKS_OFF are considered healthy.
+	VerifyHealth bool
+}
+
+// SwitchBackend repoints the Service identified by nameOrID to backend in
+// a single update, e.g. to switch between a "blue" and a "green"
+// deployment. It returns the updated Service along with the backend the
+// Service pointed at before the switch, which can be passed back to
+// SwitchBackend to revert it. If backend.Port is nil, the port of the
+// Service is left unchanged.
+func (s *Svcservice) SwitchBackend(ctx context.Context, nameOrID *string,
+	backend ServiceBackend, opts SwitchBackendOpts,
+) (*Service, *ServiceBackend, error) {
+	if isEmptyString(nameOrID) {
+		return nil, nil, fmt.Errorf("nameOrID cannot be nil for SwitchBackend operation")
+	}
+	if isEmptyString(backend.Host) {
+		return nil, nil, fmt.Errorf("backend host cannot be nil for SwitchBackend operation")
+	}
+
+	service, err := s.Get(ctx, nameOrID)
+	if err != nil {
+		return nil, nil, err
+	}
+	previous := &ServiceBackend{Host: service.Host, Port: service.Port}
+	if opts.Expected != nil && !previous.matches(*opts.Expected) {
+		return nil, nil, fmt.Errorf("service %q points at %q instead of the expected %q",
+			service.FriendlyName(), previous.String(), opts.Expected.String())
+	}
+
+	if opts.VerifyHealth {
+		if err := s.verifyUpstreamHealth(ctx, backend.Host); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	updated, err := s.Update(ctx, &Service{ID: service.ID, Host: backend.Host, Port: backend.Port})
+	if err != nil {
+		return nil, nil, err
+	}
+	return updated, previous, nil
+}
+
+func (s *Svcservice) verifyUpstreamHealth(ctx context.Context, upstreamNameOrID *string) error {
+	health, err := s.client.UpstreamNodeHealth.ListAll(ctx, upstreamNameOrID)
+	if err != nil {
+		return fmt.Errorf("fetching health of upstream %q: %w", *upstreamNameOrID, err)
+	}
+	for _, node := range health {
+		if node.Health != nil && (*node.Health == "HEALTHY" || *node.Health == "HEALTHCHECKS_OFF") {
+			return nil
+		}
+	}
+	return fmt.Errorf("upstream %q has no healthy target", *upstreamNameOrID)
+}
@@ -0,0 +1,63 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceSwitchBackend(t *testing.T) {
+	current := &Service{ID: String("s1"), Name: String("foo"), Host: String("blue"), Port: Int(80)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /services/foo", "GET /services/s1":
+			require.NoError(t, json.NewEncoder(w).Encode(current))
+		case "PATCH /services/s1":
+			var update Service
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&update))
+			current.Host, current.Port = update.Host, update.Port
+			require.NoError(t, json.NewEncoder(w).Encode(current))
+		case "GET /upstreams/green/health":
+			_, _ = w.Write([]byte(`{"data":[{"target":"a:80","health":"UNHEALTHY"},{"target":"b:80","health":"HEALTHY"}]}`))
+		case "GET /upstreams/red/health":
+			_, _ = w.Write([]byte(`{"data":[{"target":"a:80","health":"UNHEALTHY"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, _, err = client.Services.SwitchBackend(defaultCtx, String("foo"),
+		ServiceBackend{Host: String("red"), Port: Int(80)}, SwitchBackendOpts{VerifyHealth: true})
+	assert.ErrorContains(t, err, `upstream "red" has no healthy target`)
+	assert.Equal(t, "blue", *current.Host)
+
+	_, _, err = client.Services.SwitchBackend(defaultCtx, String("foo"),
+		ServiceBackend{Host: String("green")}, SwitchBackendOpts{
+			Expected: &ServiceBackend{Host: String("blue"), Port: Int(8080)},
+		})
+	assert.ErrorContains(t, err, `service "foo" points at "blue:80" instead of the expected "blue:8080"`)
+
+	service, previous, err := client.Services.SwitchBackend(defaultCtx, String("foo"),
+		ServiceBackend{Host: String("green"), Port: Int(80)}, SwitchBackendOpts{
+			Expected:     &ServiceBackend{Host: String("blue"), Port: Int(80)},
+			VerifyHealth: true,
+		})
+	require.NoError(t, err)
+	assert.Equal(t, "green", *service.Host)
+	assert.Equal(t, "blue:80", previous.String())
+
+	// a nil expected port matches any port
+	service, _, err = client.Services.SwitchBackend(defaultCtx, String("foo"), *previous, SwitchBackendOpts{
+		Expected: &ServiceBackend{Host: String("green")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "blue", *service.Host)
+}
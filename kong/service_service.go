@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -22,6 +21,11 @@ type AbstractSvcService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Service, *ListOpt, error)
 	// ListAll fetches all Services in Kong.
 	ListAll(ctx context.Context) ([]*Service, error)
+	// DeleteWhere deletes the Services in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Service) bool) ([]*Service, error)
+	// SwitchBackend repoints a Service to another host/port or Upstream.
+	SwitchBackend(ctx context.Context, nameOrID *string, backend ServiceBackend,
+		opts SwitchBackendOpts) (*Service, *ServiceBackend, error)
 }
 
 // Svcservice handles services in Kong.
@@ -30,13 +34,29 @@ type Svcservice service
 // Create creates an Service in Kong
 // If an ID is specified, it will be used to
 // create a service in Kong, otherwise an ID
-// is auto-generated.
+// is auto-generated, unless creates are idempotent,
+// see WithIdempotencyKey and SetIdempotentCreates.
 func (s *Svcservice) Create(ctx context.Context,
 	service *Service,
 ) (*Service, error) {
 	if service == nil {
 		return nil, fmt.Errorf("cannot create a nil service")
 	}
+	if err := validateNameAndTags(service.Name, service.Tags); err != nil {
+		return nil, err
+	}
+
+	if service.ID == nil {
+		id, err := s.client.idempotentID(ctx, service, service.Name)
+		if err != nil {
+			return nil, err
+		}
+		if id != nil {
+			withID := *service
+			withID.ID = id
+			service = &withID
+		}
+	}
 
 	endpoint := "/services"
 	method := "POST"
@@ -112,6 +132,9 @@ func (s *Svcservice) Update(ctx context.Context,
 	if isEmptyString(service.ID) {
 		return nil, fmt.Errorf("ID cannot be nil for Update operation")
 	}
+	if err := validateNameAndTags(service.Name, service.Tags); err != nil {
+		return nil, err
+	}
 
 	endpoint := fmt.Sprintf("/services/%v", *service.ID)
 	req, err := s.client.NewRequest("PATCH", endpoint, nil, service)
@@ -159,7 +182,7 @@ func (s *Svcservice) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var service Service
-		err = json.Unmarshal(b, &service)
+		err = s.client.decode(b, &service)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -186,3 +209,14 @@ func (s *Svcservice) ListAll(ctx context.Context) ([]*Service, error) {
 	}
 	return services, nil
 }
+
+// DeleteWhere deletes the Services in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Services are deleted from them,
+// so that unlike deleting Services while paginating, none are skipped.
+func (s *Svcservice) DeleteWhere(ctx context.Context,
+	predicate func(*Service) bool,
+) ([]*Service, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Service) *string { return entity.ID }, predicate)
+}
@@ -0,0 +1,60 @@
+package kong
+
+import "fmt"
+
+// SetClientCertificate sets certificate as the client certificate Kong
+// presents to the upstream of the Service, for upstream mTLS.
+// certificate must have been created in Kong beforehand: only its ID is
+// sent. Passing nil unsets the client certificate on the next Update.
+func (s *Service) SetClientCertificate(certificate *Certificate) error {
+	if certificate == nil {
+		s.ClientCertificate = nil
+		return nil
+	}
+	if isEmptyString(certificate.ID) {
+		return fmt.Errorf("client certificate must have an ID")
+	}
+	s.ClientCertificate = &Certificate{ID: certificate.ID}
+	return nil
+}
+
+// SetTLSVerify enables verification of the certificate presented by the
+// upstream of the Service against caCertificates, which must have been
+// created in Kong beforehand. If depth is not nil, it sets the maximum
+// depth of the verified certificate chain.
+func (s *Service) SetTLSVerify(depth *int, caCertificates ...*CACertificate) error {
+	ids := make([]*string, 0, len(caCertificates))
+	for _, caCertificate := range caCertificates {
+		if caCertificate == nil || isEmptyString(caCertificate.ID) {
+			return fmt.Errorf("CA certificates must have an ID")
+		}
+		ids = append(ids, caCertificate.ID)
+	}
+	s.TLSVerify = Bool(true)
+	s.TLSVerifyDepth = depth
+	s.CACertificates = ids
+	return nil
+}
+
+// ValidateTLS checks that the upstream TLS settings of the Service are
+// only set on protocols which use TLS towards the upstream.
+func (s *Service) ValidateTLS() error {
+	if s == nil {
+		return fmt.Errorf("service is nil")
+	}
+	if s.ClientCertificate == nil && s.TLSVerify == nil && s.TLSVerifyDepth == nil && len(s.CACertificates) == 0 {
+		return nil
+	}
+	switch ServiceProtocol(derefString(s.Protocol)) {
+	case ServiceProtocolHTTPS, ServiceProtocolGRPCS, ServiceProtocolTLS, ServiceProtocolWSS:
+	default:
+		return fmt.Errorf("TLS settings cannot be set on a service using protocol %q", derefString(s.Protocol))
+	}
+	if s.ClientCertificate != nil && isEmptyString(s.ClientCertificate.ID) {
+		return fmt.Errorf("client certificate must have an ID")
+	}
+	if s.TLSVerifyDepth != nil && *s.TLSVerifyDepth < 0 {
+		return fmt.Errorf("tls_verify_depth must be positive, got %d", *s.TLSVerifyDepth)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceUpstreamMTLS(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH /services/s1", r.Method+" "+r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		body["id"] = "s1"
+		require.NoError(t, json.NewEncoder(w).Encode(body))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	service := &Service{ID: String("s1"), Protocol: String("https")}
+	require.NoError(t, service.SetClientCertificate(&Certificate{ID: String("c1"), Cert: String("PEM")}))
+	require.NoError(t, service.SetTLSVerify(Int(2), &CACertificate{ID: String("ca1")}))
+	require.NoError(t, service.ValidateTLS())
+
+	updated, err := client.Services.Update(defaultCtx, service)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "c1"}, body["client_certificate"])
+	assert.Equal(t, true, body["tls_verify"])
+	assert.Equal(t, float64(2), body["tls_verify_depth"])
+	assert.Equal(t, []interface{}{"ca1"}, body["ca_certificates"])
+	assert.Equal(t, "c1", *updated.ClientCertificate.ID)
+
+	assert.EqualError(t, service.SetClientCertificate(&Certificate{}), "client certificate must have an ID")
+	assert.EqualError(t, service.SetTLSVerify(nil, &CACertificate{}), "CA certificates must have an ID")
+
+	service.Protocol = String("http")
+	assert.EqualError(t, service.ValidateTLS(), `TLS settings cannot be set on a service using protocol "http"`)
+}
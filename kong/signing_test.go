@@ -0,0 +1,93 @@
+package kong
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigV4Signer(t *testing.T) {
+	// get-vanilla of the AWS SigV4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	signer := &SigV4Signer{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		Region:          "us-east-1",
+		Service:         "service",
+	}
+	require.NoError(t, signer.Sign(req, nil, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, "+
+		"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+
+	assert.Error(t, (&SigV4Signer{}).Sign(req, nil, time.Now()))
+}
+
+func TestHMACSigner(t *testing.T) {
+	var received *http.Request
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"s1"}`))
+	}))
+	defer srv.Close()
+	signer := &HMACSigner{Username: "deployer", Secret: []byte("secret")}
+	client, err := NewClient(String(srv.URL), HTTPClientWithSigner(nil, signer))
+	require.NoError(t, err)
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("foo"), Host: String("foo.internal")})
+	require.NoError(t, err)
+	require.NotNil(t, received)
+	assert.Contains(t, body, `"name":"foo"`)
+
+	digest := sha256.Sum256([]byte(body))
+	assert.Equal(t, "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]), received.Header.Get("Digest"))
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(strings.Join([]string{
+		"date: " + received.Header.Get("Date"),
+		"POST /services HTTP/1.1",
+		"digest: " + received.Header.Get("Digest"),
+	}, "\n")))
+	assert.Equal(t, `hmac username="deployer", algorithm="hmac-sha256", headers="date request-line digest", `+
+		`signature="`+base64.StdEncoding.EncodeToString(mac.Sum(nil))+`"`,
+		received.Header.Get("Authorization"))
+
+	signer.Algorithm = "hmac-md5"
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("foo"), Host: String("foo.internal")})
+	assert.ErrorContains(t, err, "unsupported HMAC algorithm")
+}
+
+func TestHTTPClientWithSigner(t *testing.T) {
+	signer := &HMACSigner{Username: "deployer", Secret: []byte("secret")}
+
+	client := &http.Client{Timeout: time.Second}
+	signing := HTTPClientWithSigner(client, signer)
+	assert.Nil(t, client.Transport)
+	assert.Equal(t, time.Second, signing.Timeout)
+	assert.Equal(t, http.DefaultTransport, signing.Transport.(signingRoundTripper).rt)
+
+	// wrapping a signing client leaves it alone
+	HTTPClientWithSigner(signing, signer)
+	assert.Equal(t, http.DefaultTransport, signing.Transport.(signingRoundTripper).rt)
+
+	signing = HTTPClientWithSigner(nil, signer)
+	assert.Equal(t, DefaultTimeout, signing.Timeout)
+	transport, ok := signing.Transport.(signingRoundTripper).rt.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, DefaultTimeout, transport.TLSHandshakeTimeout)
+}
@@ -0,0 +1,118 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Snapshot is the state of entities seen by a Watcher or by Watch,
+// indexed by entity type, e.g. "services", and ID, without timestamps.
+type Snapshot map[string]map[string]json.RawMessage
+
+// SnapshotStore persists the last state seen by a Watcher or by Watch, so
+// that long-lived processes resume from it after a restart instead of
+// reporting every entity again. Implementations, e.g. backed by a
+// database or an object storage, must be safe for concurrent use.
+type SnapshotStore interface {
+	// Load returns the snapshot saved with key, or nil if there is none.
+	Load(ctx context.Context, key string) (Snapshot, error)
+	// Save saves snapshot with key, replacing the previous one.
+	Save(ctx context.Context, key string, snapshot Snapshot) error
+}
+
+// MemorySnapshotStore is a SnapshotStore keeping snapshots in memory,
+// e.g. to share them between watchers of a process or in tests.
+type MemorySnapshotStore struct {
+	lock      sync.Mutex
+	snapshots map[string]Snapshot
+}
+
+// NewMemorySnapshotStore returns an empty MemorySnapshotStore.
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: map[string]Snapshot{}}
+}
+
+// Load satisfies the SnapshotStore interface.
+func (s *MemorySnapshotStore) Load(_ context.Context, key string) (Snapshot, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return copySnapshot(s.snapshots[key]), nil
+}
+
+// Save satisfies the SnapshotStore interface.
+func (s *MemorySnapshotStore) Save(_ context.Context, key string, snapshot Snapshot) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.snapshots[key] = copySnapshot(snapshot)
+	return nil
+}
+
+func copySnapshot(snapshot Snapshot) Snapshot {
+	if snapshot == nil {
+		return nil
+	}
+	res := Snapshot{}
+	for entityType, entities := range snapshot {
+		res[entityType] = map[string]json.RawMessage{}
+		for id, entity := range entities {
+			res[entityType][id] = entity
+		}
+	}
+	return res
+}
+
+// FileSnapshotStore is a SnapshotStore saving snapshots as JSON files in
+// a directory, one per key.
+type FileSnapshotStore struct {
+	// Dir is the directory of the files, which must exist.
+	Dir string
+}
+
+// Load satisfies the SnapshotStore interface.
+func (s *FileSnapshotStore) Load(_ context.Context, key string) (Snapshot, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, fmt.Errorf("decoding snapshot %q: %w", key, err)
+	}
+	return snapshot, nil
+}
+
+// Save satisfies the SnapshotStore interface. Files are replaced
+// atomically, so that a crash doesn't leave a partial snapshot.
+func (s *FileSnapshotStore) Save(_ context.Context, key string, snapshot Snapshot) error {
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(s.Dir, ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), s.path(key))
+}
+
+func (s *FileSnapshotStore) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key)+".json")
+}
@@ -0,0 +1,97 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotStores(t *testing.T) {
+	for name, store := range map[string]SnapshotStore{
+		"memory": NewMemorySnapshotStore(),
+		"file":   &FileSnapshotStore{Dir: t.TempDir()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			snapshot, err := store.Load(defaultCtx, "watch/team/services")
+			require.NoError(t, err)
+			assert.Nil(t, snapshot)
+
+			saved := Snapshot{"services": {"s1": json.RawMessage(`{"id":"s1"}`)}}
+			require.NoError(t, store.Save(defaultCtx, "watch/team/services", saved))
+			saved["services"]["s2"] = json.RawMessage(`{"id":"s2"}`)
+			snapshot, err = store.Load(defaultCtx, "watch/team/services")
+			require.NoError(t, err)
+			assert.Equal(t, Snapshot{"services": {"s1": json.RawMessage(`{"id":"s1"}`)}}, snapshot)
+
+			snapshot, err = store.Load(defaultCtx, "watch/team/routes")
+			require.NoError(t, err)
+			assert.Nil(t, snapshot)
+		})
+	}
+}
+
+func TestSnapshotStoreResumesWatching(t *testing.T) {
+	services := `[{"id":"s1","name":"foo"},{"id":"s2","name":"bar"}]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/services" {
+			_, _ = w.Write([]byte(`{"data":` + services + `}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	store := &FileSnapshotStore{Dir: t.TempDir()}
+
+	watch := func() []WatchEvent {
+		ctx, cancel := context.WithCancel(defaultCtx)
+		defer cancel()
+		events, err := client.Watch(ctx, "services", WatchOpts{Interval: time.Hour, Store: store, BufferSize: 10})
+		require.NoError(t, err)
+		var received []WatchEvent
+		for {
+			select {
+			case event := <-events:
+				received = append(received, event)
+			case <-time.After(100 * time.Millisecond):
+				return received
+			}
+		}
+	}
+	assert.Len(t, watch(), 2)
+	// restarted with the saved snapshot, only changes are sent
+	services = `[{"id":"s1","name":"foo"}]`
+	events := watch()
+	require.Len(t, events, 1)
+	assert.Equal(t, WatchDeleted, events[0].Type)
+	assert.Equal(t, "s2", events[0].ID)
+
+	// drift is reported against the state saved before a restart
+	watcher, err := NewWatcher(client, WatcherOpts{Store: store})
+	require.NoError(t, err)
+	drift, err := watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+	services = `[{"id":"s1","name":"foo"},{"id":"s3","name":"baz"}]`
+	watcher, err = NewWatcher(client, WatcherOpts{Store: store})
+	require.NoError(t, err)
+	drift, err = watcher.Check(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, drift, 1)
+	assert.Equal(t, DriftAdded, drift[0].Kind)
+	assert.Equal(t, "s3", drift[0].ID)
+
+	// snapshots are stored per workspace targeted by the context
+	_, err = watcher.Check(WithWorkspace(defaultCtx, "team"))
+	require.NoError(t, err)
+	snapshot, err := store.Load(defaultCtx, "drift/team")
+	require.NoError(t, err)
+	assert.NotNil(t, snapshot)
+}
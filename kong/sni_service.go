@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -22,6 +21,10 @@ type AbstractSNIService interface {
 	ListForCertificate(ctx context.Context, certificateID *string, opt *ListOpt) ([]*SNI, *ListOpt, error)
 	// ListAll fetches all SNIs in Kong.
 	ListAll(ctx context.Context) ([]*SNI, error)
+	// DeleteWhere deletes the SNIs in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*SNI) bool) ([]*SNI, error)
+	// ReassignAll re-points all SNIs of a Certificate to another Certificate.
+	ReassignAll(ctx context.Context, fromCertificateID, toCertificateID *string) ([]*SNI, error)
 }
 
 // SNIService handles SNIs in Kong.
@@ -126,7 +129,7 @@ func (s *SNIService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var sni SNI
-		err = json.Unmarshal(b, &sni)
+		err = s.client.decode(b, &sni)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -154,7 +157,7 @@ func (s *SNIService) ListForCertificate(ctx context.Context,
 			return nil, nil, err
 		}
 		var sni SNI
-		err = json.Unmarshal(b, &sni)
+		err = s.client.decode(b, &sni)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -181,3 +184,105 @@ func (s *SNIService) ListAll(ctx context.Context) ([]*SNI, error) {
 	}
 	return snis, nil
 }
+
+// DeleteWhere deletes the SNIs in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after SNIs are deleted from them,
+// so that unlike deleting SNIs while paginating, none are skipped.
+func (s *SNIService) DeleteWhere(ctx context.Context,
+	predicate func(*SNI) bool,
+) ([]*SNI, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *SNI) *string { return entity.ID }, predicate)
+}
+
+// SNIReassignError is returned by ReassignAll when an SNI
+// could not be re-pointed to the new Certificate.
+type SNIReassignError struct {
+	// SNI is the SNI which could not be re-pointed.
+	SNI *SNI
+	// Err is the error returned by Kong for SNI.
+	Err error
+	// RolledBack holds the SNIs which had been re-pointed
+	// before the failure and were re-pointed back successfully.
+	RolledBack []*SNI
+	// RollbackErrors holds the errors encountered while re-pointing
+	// SNIs back to the original Certificate, keyed by SNI name.
+	// These SNIs are left pointing at the new Certificate.
+	RollbackErrors map[string]error
+}
+
+func (e *SNIReassignError) Error() string {
+	msg := fmt.Sprintf("reassigning SNI %q: %v", e.SNI.FriendlyName(), e.Err)
+	if len(e.RollbackErrors) > 0 {
+		msg += fmt.Sprintf(" (rollback failed for %d SNI(s))", len(e.RollbackErrors))
+	}
+	return msg
+}
+
+func (e *SNIReassignError) Unwrap() error {
+	return e.Err
+}
+
+// ReassignAll re-points all SNIs associated with the Certificate
+// fromCertificateID to the Certificate toCertificateID and returns
+// the updated SNIs.
+// Kong has no transaction spanning multiple SNIs: if an update fails,
+// the SNIs updated so far are re-pointed back to fromCertificateID and
+// an *SNIReassignError describing the outcome of the rollback is returned.
+func (s *SNIService) ReassignAll(ctx context.Context,
+	fromCertificateID, toCertificateID *string,
+) ([]*SNI, error) {
+	if isEmptyString(fromCertificateID) || isEmptyString(toCertificateID) {
+		return nil, fmt.Errorf("certificate IDs cannot be nil for ReassignAll operation")
+	}
+
+	var snis, data []*SNI
+	var err error
+	opt := &ListOpt{Size: pageSize}
+	for opt != nil {
+		data, opt, err = s.ListForCertificate(ctx, fromCertificateID, opt)
+		if err != nil {
+			return nil, err
+		}
+		snis = append(snis, data...)
+	}
+
+	var reassigned []*SNI
+	for _, sni := range snis {
+		updated, err := s.setCertificate(ctx, sni.ID, toCertificateID)
+		if err != nil {
+			return nil, s.rollbackReassign(ctx, sni, err, reassigned, fromCertificateID)
+		}
+		reassigned = append(reassigned, updated)
+	}
+	return reassigned, nil
+}
+
+func (s *SNIService) rollbackReassign(ctx context.Context, failed *SNI, cause error,
+	reassigned []*SNI, fromCertificateID *string,
+) error {
+	reassignErr := &SNIReassignError{SNI: failed, Err: cause}
+	// roll back even if the reassignment failed because ctx is done
+	ctx, cancel := context.WithTimeout(detach(ctx), DefaultTimeout)
+	defer cancel()
+	for _, sni := range reassigned {
+		rolledBack, err := s.setCertificate(ctx, sni.ID, fromCertificateID)
+		if err != nil {
+			if reassignErr.RollbackErrors == nil {
+				reassignErr.RollbackErrors = map[string]error{}
+			}
+			reassignErr.RollbackErrors[sni.FriendlyName()] = err
+			continue
+		}
+		reassignErr.RolledBack = append(reassignErr.RolledBack, rolledBack)
+	}
+	return reassignErr
+}
+
+func (s *SNIService) setCertificate(ctx context.Context, sniID, certificateID *string) (*SNI, error) {
+	return s.Update(ctx, &SNI{
+		ID:          sniID,
+		Certificate: &Certificate{ID: certificateID},
+	})
+}
@@ -1,6 +1,11 @@
 package kong
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/google/uuid"
@@ -214,3 +219,89 @@ func compareSNIs(T *testing.T, expected, actual []*SNI) bool {
 
 	return (compareSlices(expectedUsernames, actualUsernames))
 }
+
+func TestSNIReassignAll(t *testing.T) {
+	newFakeKong := func(failOn string, onFail func()) (*httptest.Server, map[string]string) {
+		certs := map[string]string{"a.example.com": "old", "b.example.com": "old", "c.example.com": "old"}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				assert.Equal(t, "/certificates/old/snis", r.URL.Path)
+				_, _ = w.Write([]byte(`{"data":[{"id":"a.example.com"},{"id":"b.example.com"},{"id":"c.example.com"}]}`))
+			case http.MethodPatch:
+				var sni SNI
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&sni))
+				name := r.URL.Path[len("/snis/"):]
+				if name == failOn && *sni.Certificate.ID == "new" {
+					if onFail != nil {
+						onFail()
+					}
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"message":"boom"}`))
+					return
+				}
+				certs[name] = *sni.Certificate.ID
+				sni.Name = String(name)
+				require.NoError(t, json.NewEncoder(w).Encode(sni))
+			}
+		}))
+		return srv, certs
+	}
+
+	t.Run("success", func(t *testing.T) {
+		srv, certs := newFakeKong("", nil)
+		defer srv.Close()
+		client, err := NewClient(String(srv.URL), nil)
+		require.NoError(t, err)
+
+		snis, err := client.SNIs.ReassignAll(defaultCtx, String("old"), String("new"))
+		require.NoError(t, err)
+		assert.Len(t, snis, 3)
+		assert.Equal(t, map[string]string{"a.example.com": "new", "b.example.com": "new", "c.example.com": "new"}, certs)
+	})
+
+	t.Run("failure is rolled back", func(t *testing.T) {
+		srv, certs := newFakeKong("c.example.com", nil)
+		defer srv.Close()
+		client, err := NewClient(String(srv.URL), nil)
+		require.NoError(t, err)
+
+		_, err = client.SNIs.ReassignAll(defaultCtx, String("old"), String("new"))
+		var reassignErr *SNIReassignError
+		require.True(t, errors.As(err, &reassignErr))
+		assert.Equal(t, "c.example.com", *reassignErr.SNI.ID)
+		assert.Len(t, reassignErr.RolledBack, 2)
+		assert.Empty(t, reassignErr.RollbackErrors)
+		assert.Equal(t, map[string]string{"a.example.com": "old", "b.example.com": "old", "c.example.com": "old"}, certs)
+	})
+
+	t.Run("cancellation is rolled back", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(defaultCtx)
+		defer cancel()
+		srv, certs := newFakeKong("c.example.com", cancel)
+		defer srv.Close()
+		client, err := NewClient(String(srv.URL), nil)
+		require.NoError(t, err)
+
+		_, err = client.SNIs.ReassignAll(ctx, String("old"), String("new"))
+		var reassignErr *SNIReassignError
+		require.True(t, errors.As(err, &reassignErr))
+		assert.Len(t, reassignErr.RolledBack, 2)
+		assert.Empty(t, reassignErr.RollbackErrors)
+		assert.Equal(t, map[string]string{"a.example.com": "old", "b.example.com": "old", "c.example.com": "old"}, certs)
+	})
+
+	t.Run("failure is rolled back with a nil context", func(t *testing.T) {
+		srv, certs := newFakeKong("c.example.com", nil)
+		defer srv.Close()
+		client, err := NewClient(String(srv.URL), nil)
+		require.NoError(t, err)
+
+		_, err = client.SNIs.ReassignAll(nil, String("old"), String("new")) //nolint:staticcheck
+		var reassignErr *SNIReassignError
+		require.True(t, errors.As(err, &reassignErr))
+		assert.Len(t, reassignErr.RolledBack, 2)
+		assert.Empty(t, reassignErr.RollbackErrors)
+		assert.Equal(t, map[string]string{"a.example.com": "old", "b.example.com": "old", "c.example.com": "old"}, certs)
+	})
+}
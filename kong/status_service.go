@@ -0,0 +1,53 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AbstractStatusService handles the Status API of a Kong node.
+type AbstractStatusService interface {
+	// Metrics fetches the Prometheus metrics of the node and parses them.
+	Metrics(ctx context.Context) (*Metrics, error)
+}
+
+// StatusService handles the Status API of a Kong node, served on its own
+// listener (status_listen) rather than on the Admin API.
+type StatusService struct {
+	client  *Client
+	baseURL string
+}
+
+// NewStatusService returns a StatusService for the Status API at baseURL,
+// e.g. http://localhost:8007, sending requests with the HTTP client and
+// headers of client.
+func NewStatusService(client *Client, baseURL string) (*StatusService, error) {
+	if client == nil {
+		return nil, fmt.Errorf("client cannot be nil")
+	}
+	u, err := url.ParseRequestURI(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing URL: %w", err)
+	}
+	return &StatusService{client: client, baseURL: u.String()}, nil
+}
+
+// Metrics fetches the metrics of the node from the /metrics endpoint of
+// the Status API, served when the prometheus plugin is enabled, and
+// parses them.
+func (s *StatusService) Metrics(ctx context.Context) (*Metrics, error) {
+	req, err := s.client.NewRequestRaw("GET", s.baseURL, "/metrics", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.DoRAW(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := hasError(resp); err != nil {
+		return nil, err
+	}
+	return ParseMetrics(resp.Body)
+}
@@ -0,0 +1,202 @@
+package kong
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownFields is returned in strict decoding mode when Kong returns
+// fields the type decoded into doesn't model, see SetStrictDecoding.
+type ErrUnknownFields struct {
+	// Type is the type decoded into, e.g. "kong.Service".
+	Type string
+	// Fields are the paths of the unknown fields, e.g. "tls_sans" or
+	// "config.new_field", sorted.
+	Fields []string
+}
+
+func (e *ErrUnknownFields) Error() string {
+	return fmt.Sprintf("fields not modeled by %s: %s", e.Type, strings.Join(e.Fields, ", "))
+}
+
+// IsUnknownFieldsErr returns true if the error or its cause is
+// an ErrUnknownFields.
+func IsUnknownFieldsErr(e error) bool {
+	var unknownErr *ErrUnknownFields
+	return errors.As(e, &unknownErr)
+}
+
+type strictDecodingConfig struct {
+	allowed map[string]bool
+}
+
+// SetStrictDecoding enables strict decoding of responses, or disables it.
+// In strict decoding mode, methods fail with an ErrUnknownFields when Kong
+// returns fields the structs of this package don't model, so that users
+// notice when a newer Kong returns data which would otherwise be dropped,
+// e.g. while dumping and restoring entities.
+//
+// Fields in allowed are ignored. They are either field names, ignored
+// wherever they appear, or paths such as "config.new_field". Fields of
+// maps, such as the configuration of plugins, are never unknown.
+func (c *Client) SetStrictDecoding(strict bool, allowed ...string) {
+	if !strict {
+		c.strictDecoding.Store((*strictDecodingConfig)(nil))
+		return
+	}
+	config := &strictDecodingConfig{allowed: map[string]bool{}}
+	for _, field := range allowed {
+		config.allowed[field] = true
+	}
+	c.strictDecoding.Store(config)
+}
+
+// IsStrictDecoding returns whether strict decoding is enabled, see
+// SetStrictDecoding.
+func (c *Client) IsStrictDecoding() bool {
+	config, _ := c.strictDecoding.Load().(*strictDecodingConfig)
+	return config != nil
+}
+
+// decode decodes data, as returned by Kong, into v, checking for unknown
+// fields in strict decoding mode.
+func (c *Client) decode(data []byte, v interface{}) error {
+	if err := c.unmarshalJSON(data, v); err != nil {
+		return err
+	}
+	config, _ := c.strictDecoding.Load().(*strictDecodingConfig)
+	if config == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	var unknown []string
+	for _, field := range unknownFields(data, t, "") {
+		name := field[strings.LastIndex(field, ".")+1:]
+		if !config.allowed[field] && !config.allowed[name] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &ErrUnknownFields{Type: t.String(), Fields: unknown}
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// unknownFields returns the paths, prefixed with prefix, of the fields of
+// the JSON data which t doesn't model.
+// Anonymous and unexported struct types are envelopes of lists or partial
+// views of entities used internally, their unknown fields are not
+// reported but the fields they model are still checked.
+func unknownFields(data []byte, t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+	var res []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		fields := jsonFields(t)
+		partial := t.Name() == "" || !token.IsExported(t.Name())
+		for key, value := range object {
+			field, ok := fields[key]
+			if !ok {
+				for name, f := range fields {
+					if strings.EqualFold(name, key) {
+						field, ok = f, true
+						break
+					}
+				}
+			}
+			if !ok {
+				if !partial {
+					res = append(res, prefix+key)
+				}
+				continue
+			}
+			res = append(res, unknownFields(value, field, prefix+key+".")...)
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		for key, value := range object {
+			res = append(res, unknownFields(value, t.Elem(), prefix+key+".")...)
+		}
+	case reflect.Slice, reflect.Array:
+		var values []json.RawMessage
+		if json.Unmarshal(data, &values) != nil {
+			return nil
+		}
+		seen := map[string]bool{}
+		for _, value := range values {
+			for _, field := range unknownFields(value, t.Elem(), prefix) {
+				if !seen[field] {
+					seen[field] = true
+					res = append(res, field)
+				}
+			}
+		}
+	}
+	return res
+}
+
+var jsonFieldsCache sync.Map
+
+// jsonFields returns the types of the fields of struct type t by JSON
+// name, including the fields of embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	if fields, ok := jsonFieldsCache.Load(t); ok {
+		return fields.(map[string]reflect.Type)
+	}
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for n, f := range jsonFields(embedded) {
+					if _, ok := fields[n]; !ok {
+						fields[n] = f
+					}
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	jsonFieldsCache.Store(t, fields)
+	return fields
+}
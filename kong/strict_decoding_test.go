@@ -0,0 +1,77 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictDecoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/services/foo":
+			if r.Method == http.MethodDelete {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"s1","name":"foo","tls_sans":["a"],
+				"client_certificate":{"id":"c1","fingerprint":"x"}}`))
+		case "/plugins":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":"p1","name":"acl","config":{"anything":true},"ordering":{"before":{"access":["x"]}}},
+				{"id":"p2","name":"cors","partials":[]}
+			],"next":null,"offset":null}`))
+		case "/consumers/alice/acls":
+			_, _ = w.Write([]byte(`{"id":"a1","group":"admins","scope":"all"}`))
+		case "/ca_certificates":
+			_, _ = w.Write([]byte(`{"data":[{"id":"ca1","cert_digest":"abc"}],"next":null,"offset":null}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	service, err := client.Services.Get(defaultCtx, String("foo"))
+	require.NoError(t, err)
+	assert.Equal(t, "s1", *service.ID)
+	plugins, _, err := client.Plugins.List(defaultCtx, nil)
+	require.NoError(t, err)
+	assert.Len(t, plugins, 2)
+
+	client.SetStrictDecoding(true)
+	assert.True(t, client.IsStrictDecoding())
+	_, err = client.Services.Get(defaultCtx, String("foo"))
+	require.Error(t, err)
+	assert.True(t, IsUnknownFieldsErr(err))
+	assert.EqualError(t, err, "failed decoding response body: fields not modeled by kong.Service: "+
+		"client_certificate.fingerprint, tls_sans")
+
+	_, _, err = client.Plugins.List(defaultCtx, nil)
+	assert.EqualError(t, err, "fields not modeled by kong.Plugin: partials")
+
+	_, err = client.ACLs.Create(defaultCtx, String("alice"), &ACLGroup{Group: String("admins")})
+	assert.True(t, IsUnknownFieldsErr(err))
+
+	// only the entities returned are checked, not partial views of them
+	// nor the envelopes of lists
+	client.SetProtectedTags("managed")
+	require.NoError(t, client.Services.Delete(defaultCtx, String("foo")))
+	client.SetProtectedTags()
+	caCert, err := client.CACertificates.GetByDigest(defaultCtx, String("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, "ca1", *caCert.ID)
+
+	client.SetStrictDecoding(true, "tls_sans", "client_certificate.fingerprint", "partials", "scope")
+	_, err = client.Services.Get(defaultCtx, String("foo"))
+	require.NoError(t, err)
+	_, _, err = client.Plugins.List(defaultCtx, nil)
+	require.NoError(t, err)
+	_, err = client.ACLs.Create(defaultCtx, String("alice"), &ACLGroup{Group: String("admins")})
+	require.NoError(t, err)
+
+	client.SetStrictDecoding(false)
+	assert.False(t, client.IsStrictDecoding())
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -102,7 +101,7 @@ func (s *TargetService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var target Target
-		err = json.Unmarshal(b, &target)
+		err = s.client.decode(b, &target)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -0,0 +1,5 @@
+// Package templates provides typed presets of Kong plugin configurations,
+// such as rate limiting tiers, CORS policies and JWT authentication stacks,
+// which expand into kong.Plugin entities. Platform teams can compose them
+// to apply the same configuration to every service or route.
+package templates
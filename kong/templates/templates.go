@@ -0,0 +1,245 @@
+package templates
+
+import (
+	"fmt"
+
+	"github.com/kong/go-kong/kong"
+)
+
+// Template expands into plugins.
+type Template interface {
+	// Plugins returns the plugins of the template, without scope.
+	Plugins() ([]*kong.Plugin, error)
+}
+
+// Stack composes templates into a single one.
+type Stack []Template
+
+// Plugins returns the plugins of all templates of s.
+// Kong allows a single plugin of a name per scope, so templates expanding
+// into plugins of the same name can't be stacked.
+func (s Stack) Plugins() ([]*kong.Plugin, error) {
+	var plugins []*kong.Plugin
+	names := map[string]bool{}
+	for _, template := range s {
+		templatePlugins, err := template.Plugins()
+		if err != nil {
+			return nil, err
+		}
+		for _, plugin := range templatePlugins {
+			if names[*plugin.Name] {
+				return nil, fmt.Errorf("plugin %s is configured by several templates", *plugin.Name)
+			}
+			names[*plugin.Name] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins, nil
+}
+
+// Scope is the entity the plugins of a template apply to. Plugins are
+// global if all fields are nil.
+type Scope struct {
+	Service       *kong.Service
+	Route         *kong.Route
+	Consumer      *kong.Consumer
+	ConsumerGroup *kong.ConsumerGroup
+}
+
+// Expand returns the plugins of template scoped to scope and tagged with
+// tags. Only the IDs, or names if they have no ID, of the entities of
+// scope are referenced by the plugins.
+func Expand(template Template, scope Scope, tags ...string) ([]*kong.Plugin, error) {
+	plugins, err := template.Plugins()
+	if err != nil {
+		return nil, err
+	}
+	for _, plugin := range plugins {
+		if scope.Service != nil {
+			plugin.Service = &kong.Service{ID: scope.Service.ID}
+			if scope.Service.ID == nil {
+				plugin.Service.Name = scope.Service.Name
+			}
+		}
+		if scope.Route != nil {
+			plugin.Route = &kong.Route{ID: scope.Route.ID}
+			if scope.Route.ID == nil {
+				plugin.Route.Name = scope.Route.Name
+			}
+		}
+		if scope.Consumer != nil {
+			plugin.Consumer = &kong.Consumer{ID: scope.Consumer.ID}
+			if scope.Consumer.ID == nil {
+				plugin.Consumer.Username = scope.Consumer.Username
+			}
+		}
+		if scope.ConsumerGroup != nil {
+			plugin.ConsumerGroup = &kong.ConsumerGroup{ID: scope.ConsumerGroup.ID}
+			if scope.ConsumerGroup.ID == nil {
+				plugin.ConsumerGroup.Name = scope.ConsumerGroup.Name
+			}
+		}
+		plugin.Tags = append(plugin.Tags, kong.StringSlice(tags...)...)
+	}
+	return plugins, nil
+}
+
+func newPlugin(name string, config kong.Configuration) *kong.Plugin {
+	return &kong.Plugin{
+		Name:    kong.String(name),
+		Config:  config,
+		Enabled: kong.Bool(true),
+	}
+}
+
+// RateLimitTier limits the number of requests per period with the
+// rate-limiting plugin. Zero limits aren't enforced.
+type RateLimitTier struct {
+	Second int
+	Minute int
+	Hour   int
+	Day    int
+	// LimitBy is the entity requests are counted for, "consumer" if
+	// empty.
+	LimitBy string
+	// Policy is where counters are stored, "local" if empty. Clusters of
+	// several nodes should use "redis" or "cluster".
+	Policy string
+}
+
+// FreeTier returns the rate limiting tier for free plans:
+// 60 requests per minute and 1000 per day.
+func FreeTier() RateLimitTier {
+	return RateLimitTier{Minute: 60, Day: 1000}
+}
+
+// StandardTier returns the rate limiting tier for standard plans:
+// 600 requests per minute and 100000 per day.
+func StandardTier() RateLimitTier {
+	return RateLimitTier{Minute: 600, Day: 100000}
+}
+
+// PremiumTier returns the rate limiting tier for premium plans:
+// 100 requests per second and 6000 per minute.
+func PremiumTier() RateLimitTier {
+	return RateLimitTier{Second: 100, Minute: 6000}
+}
+
+// Plugins returns the rate-limiting plugin of t.
+func (t RateLimitTier) Plugins() ([]*kong.Plugin, error) {
+	config := kong.Configuration{}
+	for period, limit := range map[string]int{
+		"second": t.Second, "minute": t.Minute, "hour": t.Hour, "day": t.Day,
+	} {
+		if limit < 0 {
+			return nil, fmt.Errorf("rate limit per %s cannot be negative", period)
+		}
+		if limit > 0 {
+			config[period] = limit
+		}
+	}
+	if len(config) == 0 {
+		return nil, fmt.Errorf("rate limit tier must limit at least one period")
+	}
+	config["limit_by"] = defaultString(t.LimitBy, "consumer")
+	config["policy"] = defaultString(t.Policy, "local")
+	return []*kong.Plugin{newPlugin("rate-limiting", config)}, nil
+}
+
+// CORSPolicy allows browsers to call APIs from other origins with the
+// cors plugin. Empty fields are left to the defaults of the plugin.
+type CORSPolicy struct {
+	Origins        []string
+	Methods        []string
+	Headers        []string
+	ExposedHeaders []string
+	// Credentials allows requests with credentials, e.g. cookies. It
+	// can't be used along with the "*" origin.
+	Credentials bool
+	// MaxAge is the number of seconds preflight requests are cached.
+	MaxAge int
+}
+
+// Plugins returns the cors plugin of p.
+func (p CORSPolicy) Plugins() ([]*kong.Plugin, error) {
+	if len(p.Origins) == 0 {
+		return nil, fmt.Errorf("CORS policy must allow at least one origin")
+	}
+	config := kong.Configuration{
+		"origins":     p.Origins,
+		"credentials": p.Credentials,
+	}
+	for _, origin := range p.Origins {
+		if origin == "*" && p.Credentials {
+			return nil, fmt.Errorf("CORS policy cannot allow credentials from all origins")
+		}
+	}
+	if len(p.Methods) > 0 {
+		config["methods"] = p.Methods
+	}
+	if len(p.Headers) > 0 {
+		config["headers"] = p.Headers
+	}
+	if len(p.ExposedHeaders) > 0 {
+		config["exposed_headers"] = p.ExposedHeaders
+	}
+	if p.MaxAge > 0 {
+		config["max_age"] = p.MaxAge
+	}
+	return []*kong.Plugin{newPlugin("cors", config)}, nil
+}
+
+// JWTAuthStack authenticates requests with JWTs with the jwt plugin,
+// optionally restricting access to consumers of some ACL groups with the
+// acl plugin.
+type JWTAuthStack struct {
+	// ClaimsToVerify are the registered claims verified by Kong, "exp" if
+	// empty.
+	ClaimsToVerify []string
+	// MaximumExpiration is the maximum lifetime of tokens in seconds, if
+	// not zero. It requires the "exp" claim to be verified.
+	MaximumExpiration int
+	// KeyClaimName is the claim holding the key of the JWT credential,
+	// "iss" if empty.
+	KeyClaimName string
+	// AllowedGroups are the ACL groups of the consumers allowed, if any.
+	AllowedGroups []string
+}
+
+// Plugins returns the jwt plugin, and the acl plugin if groups are
+// allowed, of s.
+func (s JWTAuthStack) Plugins() ([]*kong.Plugin, error) {
+	claims := s.ClaimsToVerify
+	if len(claims) == 0 {
+		claims = []string{"exp"}
+	}
+	config := kong.Configuration{
+		"claims_to_verify": claims,
+		"key_claim_name":   defaultString(s.KeyClaimName, "iss"),
+	}
+	if s.MaximumExpiration > 0 {
+		verifiesExp := false
+		for _, claim := range claims {
+			verifiesExp = verifiesExp || claim == "exp"
+		}
+		if !verifiesExp {
+			return nil, fmt.Errorf("maximum expiration requires the exp claim to be verified")
+		}
+		config["maximum_expiration"] = s.MaximumExpiration
+	}
+	plugins := []*kong.Plugin{newPlugin("jwt", config)}
+	if len(s.AllowedGroups) > 0 {
+		plugins = append(plugins, newPlugin("acl", kong.Configuration{
+			"allow":              s.AllowedGroups,
+			"hide_groups_header": true,
+		}))
+	}
+	return plugins, nil
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/kong/go-kong/kong"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	stack := Stack{
+		StandardTier(),
+		CORSPolicy{Origins: []string{"https://app.example.com"}, Credentials: true, MaxAge: 3600},
+		JWTAuthStack{MaximumExpiration: 900, AllowedGroups: []string{"partners"}},
+	}
+	plugins, err := Expand(stack, Scope{Service: &kong.Service{Name: kong.String("billing")}}, "golden-path")
+	require.NoError(t, err)
+	require.Len(t, plugins, 4)
+
+	for _, plugin := range plugins {
+		assert.Equal(t, &kong.Service{Name: kong.String("billing")}, plugin.Service)
+		assert.Nil(t, plugin.Route)
+		assert.Equal(t, kong.StringSlice("golden-path"), plugin.Tags)
+		assert.True(t, *plugin.Enabled)
+	}
+	assert.Equal(t, "rate-limiting", *plugins[0].Name)
+	assert.Equal(t, kong.Configuration{
+		"minute": 600, "day": 100000, "limit_by": "consumer", "policy": "local",
+	}, plugins[0].Config)
+	assert.Equal(t, "cors", *plugins[1].Name)
+	assert.Equal(t, kong.Configuration{
+		"origins": []string{"https://app.example.com"}, "credentials": true, "max_age": 3600,
+	}, plugins[1].Config)
+	assert.Equal(t, "jwt", *plugins[2].Name)
+	assert.Equal(t, kong.Configuration{
+		"claims_to_verify": []string{"exp"}, "key_claim_name": "iss", "maximum_expiration": 900,
+	}, plugins[2].Config)
+	assert.Equal(t, "acl", *plugins[3].Name)
+	assert.Equal(t, []string{"partners"}, plugins[3].Config["allow"])
+
+	plugins, err = Expand(FreeTier(), Scope{Consumer: &kong.Consumer{ID: kong.String("c1"), Username: kong.String("alice")}})
+	require.NoError(t, err)
+	assert.Equal(t, &kong.Consumer{ID: kong.String("c1")}, plugins[0].Consumer)
+	assert.Nil(t, plugins[0].Tags)
+
+	plugins, err = JWTAuthStack{}.Plugins()
+	require.NoError(t, err)
+	assert.Len(t, plugins, 1)
+}
+
+func TestTemplateErrors(t *testing.T) {
+	for name, template := range map[string]Template{
+		"duplicate plugins":    Stack{FreeTier(), PremiumTier()},
+		"no limit":             RateLimitTier{},
+		"negative limit":       RateLimitTier{Minute: -1},
+		"no origin":            CORSPolicy{},
+		"credentials from all": CORSPolicy{Origins: []string{"*"}, Credentials: true},
+		"expiration":           JWTAuthStack{ClaimsToVerify: []string{"nbf"}, MaximumExpiration: 60},
+	} {
+		_, err := Expand(template, Scope{})
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,101 @@
+package kong
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ThreeWayPatch is the change computed by ThreeWayMerge.
+type ThreeWayPatch struct {
+	// Patch is the body of the PATCH request making the live entity
+	// match the desired one. Fields to reset are set to nil, i.e. null.
+	Patch map[string]interface{}
+	// Overwritten holds the paths of the fields, e.g. "config.minute",
+	// which were changed outside of the desired configuration since it
+	// was last applied and which Patch overwrites.
+	Overwritten []string
+}
+
+// IsEmpty returns true if the live entity already matches the desired one.
+func (p *ThreeWayPatch) IsEmpty() bool {
+	return len(p.Patch) == 0
+}
+
+// ThreeWayMerge computes the patch to apply to live, an entity as fetched
+// from Kong, to make it match desired, using lastApplied, the desired
+// entity as it was last applied, to tell the fields managed by the
+// desired configuration from those managed out of band, e.g. by another
+// controller, like kubectl apply does.
+//
+// Fields set in desired are set to their desired value. Fields set in
+// lastApplied but not in desired are reset, unless their live value was
+// changed since. Other fields are left untouched. Objects, such as plugin
+// configurations, are merged field by field, while arrays are replaced.
+// lastApplied can be nil for entities applied for the first time.
+//
+// Entities can be structs, such as *Service, or maps, and are compared in
+// their JSON form. The patch can be sent with the Update method of
+// services, or as the entity of a MutationUpdate Operation.
+func ThreeWayMerge(lastApplied, desired, live interface{}) (*ThreeWayPatch, error) {
+	var last, want, current map[string]interface{}
+	for _, entity := range []struct {
+		name   string
+		from   interface{}
+		target *map[string]interface{}
+	}{
+		{"last applied", lastApplied, &last},
+		{"desired", desired, &want},
+		{"live", live, &current},
+	} {
+		if entity.from == nil {
+			continue
+		}
+		if err := convert(entity.from, entity.target); err != nil {
+			return nil, fmt.Errorf("decoding %s entity: %w", entity.name, err)
+		}
+	}
+	p := &ThreeWayPatch{}
+	p.Patch = threeWayMerge(last, want, current, "", &p.Overwritten)
+	sort.Strings(p.Overwritten)
+	return p, nil
+}
+
+func threeWayMerge(last, desired, live map[string]interface{},
+	prefix string, overwritten *[]string,
+) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, want := range desired {
+		path := prefix + key
+		current, set := live[key]
+		lastValue, managed := last[key]
+		wantObject, ok := want.(map[string]interface{})
+		currentObject, currentIsObject := current.(map[string]interface{})
+		if ok && currentIsObject {
+			lastObject, _ := lastValue.(map[string]interface{})
+			if sub := threeWayMerge(lastObject, wantObject, currentObject, path+".", overwritten); len(sub) > 0 {
+				patch[key] = sub
+			}
+			continue
+		}
+		if reflect.DeepEqual(want, current) {
+			continue
+		}
+		patch[key] = want
+		// the field was changed out of band since last applied, or was
+		// set out of band before being managed
+		if (managed && !reflect.DeepEqual(lastValue, current)) || (!managed && set && current != nil) {
+			*overwritten = append(*overwritten, path)
+		}
+	}
+	for key, lastValue := range last {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+		// fields changed since last applied are managed by someone else
+		if current := live[key]; current != nil && reflect.DeepEqual(lastValue, current) {
+			patch[key] = nil
+		}
+	}
+	return patch
+}
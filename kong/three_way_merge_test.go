@@ -0,0 +1,61 @@
+package kong
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreeWayMerge(t *testing.T) {
+	lastApplied := &Plugin{
+		Name:   String("rate-limiting"),
+		Config: Configuration{"minute": 10, "hour": 100},
+		Tags:   StringSlice("team-a"),
+	}
+	desired := &Plugin{
+		Name:   String("rate-limiting"),
+		Config: Configuration{"minute": 20},
+		Tags:   StringSlice("team-a"),
+	}
+	live := map[string]interface{}{
+		"id":        "p1",
+		"name":      "rate-limiting",
+		"config":    map[string]interface{}{"minute": 10, "hour": 100, "day": 5},
+		"tags":      []string{"team-a"},
+		"enabled":   true,
+		"protocols": []string{"http"},
+	}
+
+	patch, err := ThreeWayMerge(lastApplied, desired, live)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"config": map[string]interface{}{"minute": float64(20), "hour": nil},
+	}, patch.Patch)
+	assert.Empty(t, patch.Overwritten)
+
+	// fields changed out of band are overwritten if managed, kept otherwise
+	live["config"] = map[string]interface{}{"minute": 15, "hour": 200}
+	live["tags"] = []string{"team-b"}
+	patch, err = ThreeWayMerge(lastApplied, desired, live)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"config": map[string]interface{}{"minute": float64(20)},
+		"tags":   []interface{}{"team-a"},
+	}, patch.Patch)
+	assert.Equal(t, []string{"config.minute", "tags"}, patch.Overwritten)
+
+	// without last applied configuration, nothing is reset
+	desired.Enabled = Bool(false)
+	patch, err = ThreeWayMerge(nil, desired, live)
+	require.NoError(t, err)
+	assert.Equal(t, false, patch.Patch["enabled"])
+	assert.Equal(t, []string{"config.minute", "enabled", "tags"}, patch.Overwritten)
+
+	patch, err = ThreeWayMerge(desired, desired, desired)
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty())
+
+	_, err = ThreeWayMerge(nil, desired, "not an entity")
+	assert.Error(t, err)
+}
@@ -0,0 +1,111 @@
+package kong
+
+import (
+	"math"
+	"time"
+)
+
+// Kong stores timestamps as Unix seconds, but some entities (e.g. Targets
+// in Kong 3.x) are returned with millisecond precision, either as fractional
+// seconds or as integer milliseconds. Any value above this threshold cannot
+// be a timestamp in seconds before year 33658 and is considered to be
+// expressed in milliseconds.
+const unixMillisThreshold = 1e12
+
+// unixTime converts a timestamp returned by Kong to a time.Time.
+// The zero time.Time is returned if ts is nil.
+func unixTime[T int | int64 | float64](ts *T) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	v := float64(*ts)
+	if math.Abs(v) >= unixMillisThreshold {
+		v /= 1000
+	}
+	sec, frac := math.Modf(v)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC()
+}
+
+// CreatedAtTime returns the creation time of the Service or the zero
+// time.Time if it isn't set.
+func (s *Service) CreatedAtTime() time.Time { return unixTime(s.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the Service or the zero
+// time.Time if it isn't set.
+func (s *Service) UpdatedAtTime() time.Time { return unixTime(s.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the Route or the zero
+// time.Time if it isn't set.
+func (r *Route) CreatedAtTime() time.Time { return unixTime(r.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the Route or the zero
+// time.Time if it isn't set.
+func (r *Route) UpdatedAtTime() time.Time { return unixTime(r.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the Plugin or the zero
+// time.Time if it isn't set.
+func (p *Plugin) CreatedAtTime() time.Time { return unixTime(p.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the Consumer or the zero
+// time.Time if it isn't set.
+func (c *Consumer) CreatedAtTime() time.Time { return unixTime(c.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the ConsumerGroup or the zero
+// time.Time if it isn't set.
+func (cg *ConsumerGroup) CreatedAtTime() time.Time { return unixTime(cg.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the Upstream or the zero
+// time.Time if it isn't set.
+func (u *Upstream) CreatedAtTime() time.Time { return unixTime(u.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the Target or the zero
+// time.Time if it isn't set.
+func (t *Target) CreatedAtTime() time.Time { return unixTime(t.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the Certificate or the zero
+// time.Time if it isn't set.
+func (c *Certificate) CreatedAtTime() time.Time { return unixTime(c.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the CACertificate or the zero
+// time.Time if it isn't set.
+func (c *CACertificate) CreatedAtTime() time.Time { return unixTime(c.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the SNI or the zero
+// time.Time if it isn't set.
+func (s *SNI) CreatedAtTime() time.Time { return unixTime(s.CreatedAt) }
+
+// CreatedAtTime returns the creation time of the Key or the zero
+// time.Time if it isn't set.
+func (k *Key) CreatedAtTime() time.Time { return unixTime(k.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the Key or the zero
+// time.Time if it isn't set.
+func (k *Key) UpdatedAtTime() time.Time { return unixTime(k.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the KeySet or the zero
+// time.Time if it isn't set.
+func (k *KeySet) CreatedAtTime() time.Time { return unixTime(k.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the KeySet or the zero
+// time.Time if it isn't set.
+func (k *KeySet) UpdatedAtTime() time.Time { return unixTime(k.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the Vault or the zero
+// time.Time if it isn't set.
+func (v *Vault) CreatedAtTime() time.Time { return unixTime(v.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the Vault or the zero
+// time.Time if it isn't set.
+func (v *Vault) UpdatedAtTime() time.Time { return unixTime(v.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the License or the zero
+// time.Time if it isn't set.
+func (l *License) CreatedAtTime() time.Time { return unixTime(l.CreatedAt) }
+
+// UpdatedAtTime returns the last update time of the License or the zero
+// time.Time if it isn't set.
+func (l *License) UpdatedAtTime() time.Time { return unixTime(l.UpdatedAt) }
+
+// CreatedAtTime returns the creation time of the Workspace or the zero
+// time.Time if it isn't set.
+func (w *Workspace) CreatedAtTime() time.Time { return unixTime(w.CreatedAt) }
@@ -0,0 +1,32 @@
+package kong
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatedAtTime(t *testing.T) {
+	expected := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("seconds", func(t *testing.T) {
+		s := &Service{CreatedAt: Int(int(expected.Unix()))}
+		assert.Equal(t, expected, s.CreatedAtTime())
+	})
+
+	t.Run("milliseconds", func(t *testing.T) {
+		u := &Upstream{CreatedAt: Int64(expected.UnixMilli())}
+		assert.Equal(t, expected, u.CreatedAtTime())
+	})
+
+	t.Run("fractional seconds", func(t *testing.T) {
+		ts := float64(expected.Unix()) + 0.25
+		target := &Target{CreatedAt: &ts}
+		assert.Equal(t, expected.Add(250*time.Millisecond), target.CreatedAtTime())
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		assert.True(t, (&Route{}).UpdatedAtTime().IsZero())
+	})
+}
@@ -0,0 +1,138 @@
+package kong
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EntityTraffic is the volume of data exchanged by a client with the Admin
+// API for an entity type. Only the bodies of requests and responses are
+// accounted for.
+type EntityTraffic struct {
+	// EntityType is the last collection in the path of the requests, e.g.
+	// "routes" for "/services/{id}/routes", or the endpoint for other
+	// requests, e.g. "status". It is empty for the root endpoint and
+	// for the total.
+	EntityType    string
+	Requests      int
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// TrafficStats is a snapshot of the data exchanged by a client with the
+// Admin API.
+type TrafficStats struct {
+	Total EntityTraffic
+	// Entities is the traffic per entity type, sorted by decreasing bytes
+	// exchanged, so that the entity types saturating the network path to
+	// Kong come first.
+	Entities []EntityTraffic
+}
+
+type trafficRecorder struct {
+	mu       sync.Mutex
+	entities map[string]*EntityTraffic
+}
+
+// TrafficStats returns a snapshot of the bytes sent and received by the
+// client, in total and per entity type. Response bodies are accounted for
+// as they are read or, for bodies closed before being read entirely, with
+// their Content-Length.
+func (c *Client) TrafficStats() TrafficStats {
+	c.traffic.mu.Lock()
+	defer c.traffic.mu.Unlock()
+	stats := TrafficStats{Entities: make([]EntityTraffic, 0, len(c.traffic.entities))}
+	for _, e := range c.traffic.entities {
+		stats.Entities = append(stats.Entities, *e)
+		stats.Total.Requests += e.Requests
+		stats.Total.BytesSent += e.BytesSent
+		stats.Total.BytesReceived += e.BytesReceived
+	}
+	sort.Slice(stats.Entities, func(i, j int) bool {
+		a, b := stats.Entities[i], stats.Entities[j]
+		if a.BytesSent+a.BytesReceived != b.BytesSent+b.BytesReceived {
+			return a.BytesSent+a.BytesReceived > b.BytesSent+b.BytesReceived
+		}
+		return a.EntityType < b.EntityType
+	})
+	return stats
+}
+
+// ResetTrafficStats clears the traffic statistics of the client.
+func (c *Client) ResetTrafficStats() {
+	c.traffic.mu.Lock()
+	defer c.traffic.mu.Unlock()
+	c.traffic.entities = nil
+}
+
+// recordTraffic records the body of req as sent and wraps the body of
+// resp (nil if the request failed) to record it as received once read.
+func (c *Client) recordTraffic(req *http.Request, resp *http.Response) {
+	entityType := trafficEntityType(c.relativePath(req))
+	c.addTraffic(entityType, func(e *EntityTraffic) {
+		e.Requests++
+		if req.ContentLength > 0 {
+			e.BytesSent += req.ContentLength
+		}
+	})
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	contentLength := resp.ContentLength
+	resp.Body = &countingBody{
+		ReadCloser: resp.Body,
+		done: func(read int64, eof bool) {
+			if !eof && contentLength > read {
+				read = contentLength
+			}
+			c.addTraffic(entityType, func(e *EntityTraffic) { e.BytesReceived += read })
+		},
+	}
+}
+
+func (c *Client) addTraffic(entityType string, update func(*EntityTraffic)) {
+	c.traffic.mu.Lock()
+	defer c.traffic.mu.Unlock()
+	if c.traffic.entities == nil {
+		c.traffic.entities = map[string]*EntityTraffic{}
+	}
+	e := c.traffic.entities[entityType]
+	if e == nil {
+		e = &EntityTraffic{EntityType: entityType}
+		c.traffic.entities[entityType] = e
+	}
+	update(e)
+}
+
+// trafficEntityType returns the last collection of path, relative to the
+// workspace.
+func trafficEntityType(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	return segments[(len(segments)-1)&^1]
+}
+
+// countingBody counts the bytes read from a response body and reports
+// them once, when the body is read entirely or closed.
+type countingBody struct {
+	io.ReadCloser
+	read int64
+	once sync.Once
+	done func(read int64, eof bool)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.read += int64(n)
+	if err == io.EOF {
+		b.once.Do(func() { b.done(b.read, true) })
+	}
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() { b.done(b.read, false) })
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,83 @@
+package kong
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrafficStats(t *testing.T) {
+	var sent atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sent.Add(int64(len(body)))
+		switch r.URL.Path {
+		case "/team-a/services", "/team-a/services/svc":
+			_, _ = w.Write([]byte(`{"id":"s1","name":"svc"}`))
+		case "/team-a/services/svc/routes":
+			_, _ = w.Write([]byte(`{"data":[{"id":"r1","name":"route"}],"next":null}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team-a")
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("svc"), Host: String("example.com")})
+	require.NoError(t, err)
+	_, err = client.Services.Get(defaultCtx, String("svc"))
+	require.NoError(t, err)
+	_, _, err = client.Routes.ListForService(defaultCtx, String("svc"), nil)
+	require.NoError(t, err)
+	_, err = client.Status(defaultCtx)
+	require.NoError(t, err)
+
+	stats := client.TrafficStats()
+	require.Len(t, stats.Entities, 3)
+	assert.Equal(t, "services", stats.Entities[0].EntityType)
+	assert.Equal(t, 2, stats.Entities[0].Requests)
+	assert.Equal(t, sent.Load(), stats.Entities[0].BytesSent)
+	assert.Equal(t, int64(2*len(`{"id":"s1","name":"svc"}`)), stats.Entities[0].BytesReceived)
+	assert.Equal(t, EntityTraffic{
+		EntityType:    "routes",
+		Requests:      1,
+		BytesReceived: int64(len(`{"data":[{"id":"r1","name":"route"}],"next":null}`)),
+	}, stats.Entities[1])
+	assert.Equal(t, EntityTraffic{EntityType: "status", Requests: 1, BytesReceived: 2}, stats.Entities[2])
+	assert.Equal(t, 4, stats.Total.Requests)
+	assert.Equal(t, sent.Load(), stats.Total.BytesSent)
+	assert.Equal(t, stats.Entities[0].BytesReceived+stats.Entities[1].BytesReceived+2, stats.Total.BytesReceived)
+
+	// the body of raw responses is accounted for as it is read
+	req, err := client.NewRequest(http.MethodGet, "/services/svc", nil, nil)
+	require.NoError(t, err)
+	resp, err := client.DoRAW(defaultCtx, req)
+	require.NoError(t, err)
+	_, err = io.Copy(io.Discard, resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, int64(3*len(`{"id":"s1","name":"svc"}`)), client.TrafficStats().Entities[0].BytesReceived)
+
+	client.ResetTrafficStats()
+	assert.Equal(t, TrafficStats{Entities: []EntityTraffic{}}, client.TrafficStats())
+}
+
+func TestTrafficEntityType(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/":                          "",
+		"/status":                    "status",
+		"/services":                  "services",
+		"/services/svc":              "services",
+		"/services/svc/routes":       "routes",
+		"/consumers/alice/key-auth/": "key-auth",
+	} {
+		assert.Equal(t, expected, trafficEntityType(path), path)
+	}
+}
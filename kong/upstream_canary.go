@@ -0,0 +1,216 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCanarySteps  = 5
+	defaultTargetWeight = 100
+	defaultTargetPort   = "8000"
+)
+
+// CanaryRolloutOpts configures UpstreamService.CanaryRollout.
+type CanaryRolloutOpts struct {
+	// From holds the targets traffic is shifted away from, identified by
+	// ID or by target (host:port, the port defaulting to 8000 as in
+	// Kong). They must exist in the upstream and end up with a weight
+	// of 0.
+	From []*Target
+	// To holds the targets traffic is shifted to, identified like From.
+	// Targets which don't exist yet are created with a weight of 0
+	// before the first step. Weight sets the weight a target ends up
+	// with and defaults to 100.
+	To []*Target
+	// Steps is the number of steps the shift is performed in and
+	// defaults to 5.
+	Steps int
+	// Interval is how long to wait after each step before checking the
+	// health of the upstream.
+	Interval time.Duration
+	// HealthCheck gates the next step. It is called after each step
+	// with the current health of the nodes of the upstream, and a
+	// non-nil error aborts the rollout.
+	// If nil, all the To targets must be reported as HEALTHY or
+	// HEALTHCHECKS_OFF.
+	HealthCheck func(ctx context.Context, health []*UpstreamNodeHealth) error
+	// OnStep, if not nil, is called after each step passed the health
+	// check.
+	OnStep func(step, steps int)
+}
+
+type canaryTarget struct {
+	idOrTarget string
+	target     string
+	initial    int
+	final      int
+}
+
+func (t canaryTarget) weightAt(step, steps int) int {
+	return t.initial + (t.final-t.initial)*step/steps
+}
+
+// CanaryRollout gradually shifts traffic of an upstream from the
+// opts.From targets to the opts.To targets by updating their weights in
+// opts.Steps steps. After each step, it waits for opts.Interval and
+// checks the health of the upstream before moving on.
+//
+// If a health check fails, the weights of all the targets are restored
+// to the values they had before the rollout and an error is returned.
+// Targets created by the rollout are left in place with a weight of 0.
+// If any other error occurs, including ctx being done, the weights are
+// left as they are.
+func (s *UpstreamService) CanaryRollout(ctx context.Context,
+	upstreamNameOrID *string, opts CanaryRolloutOpts,
+) error {
+	if isEmptyString(upstreamNameOrID) {
+		return fmt.Errorf("upstreamNameOrID cannot be nil for CanaryRollout operation")
+	}
+	if len(opts.From) == 0 || len(opts.To) == 0 {
+		return fmt.Errorf("both From and To targets are required for CanaryRollout operation")
+	}
+	steps := opts.Steps
+	if steps <= 0 {
+		steps = defaultCanarySteps
+	}
+	existing, err := s.client.Targets.ListAll(ctx, upstreamNameOrID)
+	if err != nil {
+		return fmt.Errorf("listing targets of upstream %q: %w", *upstreamNameOrID, err)
+	}
+	var targets []canaryTarget
+	for _, t := range opts.From {
+		found := findTarget(existing, t)
+		if found == nil {
+			return fmt.Errorf("target %q not found in upstream %q", t.FriendlyName(), *upstreamNameOrID)
+		}
+		targets = append(targets, canaryTarget{
+			idOrTarget: *found.ID,
+			target:     *found.Target,
+			initial:    targetWeight(found),
+		})
+	}
+	for _, t := range opts.To {
+		final := targetWeight(t)
+		found := findTarget(existing, t)
+		if found == nil {
+			if isEmptyString(t.Target) {
+				return fmt.Errorf("target %q not found in upstream %q", t.FriendlyName(), *upstreamNameOrID)
+			}
+			found, err = s.client.Targets.Create(ctx, upstreamNameOrID,
+				&Target{Target: t.Target, Weight: Int(0), Tags: t.Tags})
+			if err != nil {
+				return fmt.Errorf("creating target %q: %w", *t.Target, err)
+			}
+		}
+		targets = append(targets, canaryTarget{
+			idOrTarget: *found.ID,
+			target:     *found.Target,
+			initial:    targetWeight(found),
+			final:      final,
+		})
+	}
+	healthCheck := opts.HealthCheck
+	if healthCheck == nil {
+		healthCheck = defaultCanaryHealthCheck(targets[len(opts.From):])
+	}
+
+	for step := 1; step <= steps; step++ {
+		for _, t := range targets {
+			if err := s.setTargetWeight(ctx, upstreamNameOrID, t.idOrTarget, t.weightAt(step, steps)); err != nil {
+				return fmt.Errorf("step %d/%d: updating weight of target %q: %w", step, steps, t.target, err)
+			}
+		}
+
+		timer := time.NewTimer(opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		health, err := s.client.UpstreamNodeHealth.ListAll(ctx, upstreamNameOrID)
+		if err != nil {
+			return fmt.Errorf("step %d/%d: fetching health of upstream %q: %w", step, steps, *upstreamNameOrID, err)
+		}
+		if err := healthCheck(ctx, health); err != nil {
+			for _, t := range targets {
+				if rbErr := s.setTargetWeight(ctx, upstreamNameOrID, t.idOrTarget, t.initial); rbErr != nil {
+					return fmt.Errorf("step %d/%d: health check failed: %v; rolling back weight of target %q: %w",
+						step, steps, err, t.target, rbErr)
+				}
+			}
+			return fmt.Errorf("step %d/%d: health check failed, weights rolled back: %w", step, steps, err)
+		}
+		if opts.OnStep != nil {
+			opts.OnStep(step, steps)
+		}
+	}
+	return nil
+}
+
+func (s *UpstreamService) setTargetWeight(ctx context.Context,
+	upstreamNameOrID *string, targetOrID string, weight int,
+) error {
+	endpoint := fmt.Sprintf("/upstreams/%v/targets/%v", *upstreamNameOrID, targetOrID)
+	req, err := s.client.NewRequest("PATCH", endpoint, nil, &Target{Weight: Int(weight)})
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+func defaultCanaryHealthCheck(to []canaryTarget) func(context.Context, []*UpstreamNodeHealth) error {
+	return func(_ context.Context, health []*UpstreamNodeHealth) error {
+		for _, t := range to {
+			var node *UpstreamNodeHealth
+			for _, h := range health {
+				if (h.ID != nil && *h.ID == t.idOrTarget) ||
+					(h.Target != nil && normalizeTarget(*h.Target) == normalizeTarget(t.target)) {
+					node = h
+					break
+				}
+			}
+			if node == nil || node.Health == nil {
+				return fmt.Errorf("no health reported for target %q", t.target)
+			}
+			if *node.Health != "HEALTHY" && *node.Health != "HEALTHCHECKS_OFF" {
+				return fmt.Errorf("target %q is %s", t.target, *node.Health)
+			}
+		}
+		return nil
+	}
+}
+
+func findTarget(targets []*Target, t *Target) *Target {
+	for _, candidate := range targets {
+		if (t.ID != nil && candidate.ID != nil && *candidate.ID == *t.ID) ||
+			(t.Target != nil && candidate.Target != nil &&
+				normalizeTarget(*candidate.Target) == normalizeTarget(*t.Target)) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// normalizeTarget adds the port Kong defaults targets to, if target has
+// none, so that e.g. 10.0.0.2 and 10.0.0.2:8000 are the same target.
+func normalizeTarget(target string) string {
+	if _, _, err := net.SplitHostPort(target); err == nil {
+		return target
+	}
+	host := strings.TrimSuffix(strings.TrimPrefix(target, "["), "]")
+	return net.JoinHostPort(host, defaultTargetPort)
+}
+
+func targetWeight(t *Target) int {
+	if t.Weight == nil {
+		return defaultTargetWeight
+	}
+	return *t.Weight
+}
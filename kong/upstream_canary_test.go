@@ -0,0 +1,133 @@
+package kong
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanaryTestServer(t *testing.T, health string) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	weights := map[string]int{"t1": 100}
+	var log []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method + " " + r.URL.Path {
+		case "GET /upstreams/up/targets":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","target":"old:80","weight":100}]}`))
+		case "POST /upstreams/up/targets":
+			var target Target
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&target))
+			assert.Equal(t, "new:80", *target.Target)
+			assert.Equal(t, 0, *target.Weight)
+			weights["t2"] = 0
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"t2","target":"new:80","weight":0}`))
+		case "PATCH /upstreams/up/targets/t1", "PATCH /upstreams/up/targets/t2":
+			var target Target
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&target))
+			id := r.URL.Path[len("/upstreams/up/targets/"):]
+			weights[id] = *target.Weight
+			log = append(log, id+"="+strconv.Itoa(*target.Weight))
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /upstreams/up/health":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t2","target":"new:80","health":"` + health + `"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return srv, &log
+}
+
+func TestUpstreamCanaryRollout(t *testing.T) {
+	srv, log := newCanaryTestServer(t, "HEALTHY")
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	var steps []int
+	err = client.Upstreams.CanaryRollout(defaultCtx, String("up"), CanaryRolloutOpts{
+		From:   []*Target{{Target: String("old:80")}},
+		To:     []*Target{{Target: String("new:80"), Weight: Int(50)}},
+		Steps:  2,
+		OnStep: func(step, _ int) { steps = append(steps, step) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, steps)
+	assert.Equal(t, []string{"t1=50", "t2=25", "t1=0", "t2=50"}, *log)
+}
+
+func TestUpstreamCanaryRolloutRollback(t *testing.T) {
+	srv, log := newCanaryTestServer(t, "UNHEALTHY")
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	err = client.Upstreams.CanaryRollout(defaultCtx, String("up"), CanaryRolloutOpts{
+		From:  []*Target{{ID: String("t1")}},
+		To:    []*Target{{Target: String("new:80")}},
+		Steps: 4,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `step 1/4: health check failed, weights rolled back: target "new:80" is UNHEALTHY`)
+	assert.Equal(t, []string{"t1=75", "t2=25", "t1=100", "t2=0"}, *log)
+
+	err = client.Upstreams.CanaryRollout(defaultCtx, String("up"), CanaryRolloutOpts{
+		From: []*Target{{Target: String("missing:80")}},
+		To:   []*Target{{Target: String("new:80")}},
+	})
+	assert.ErrorContains(t, err, `target "missing:80" not found`)
+}
+
+func TestUpstreamCanaryRolloutDefaultPorts(t *testing.T) {
+	var log []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /upstreams/up/targets":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t1","target":"10.0.0.1:8000","weight":100}]}`))
+		case "POST /upstreams/up/targets":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"t2","target":"10.0.0.2:8000","weight":0}`))
+		case "PATCH /upstreams/up/targets/t1", "PATCH /upstreams/up/targets/t2":
+			var target Target
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&target))
+			log = append(log, r.URL.Path[len("/upstreams/up/targets/"):]+"="+strconv.Itoa(*target.Weight))
+			_, _ = w.Write([]byte(`{}`))
+		case "GET /upstreams/up/health":
+			_, _ = w.Write([]byte(`{"data":[{"id":"t2","target":"10.0.0.2:8000","health":"HEALTHY"}]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	// targets given without a port are matched with the ones reported
+	// by Kong, with the default port
+	err = client.Upstreams.CanaryRollout(defaultCtx, String("up"), CanaryRolloutOpts{
+		From:  []*Target{{Target: String("10.0.0.1")}},
+		To:    []*Target{{Target: String("10.0.0.2")}},
+		Steps: 2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"t1=50", "t2=50", "t1=0", "t2=100"}, log)
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	assert.Equal(t, "10.0.0.2:8000", normalizeTarget("10.0.0.2"))
+	assert.Equal(t, "10.0.0.2:80", normalizeTarget("10.0.0.2:80"))
+	assert.Equal(t, "example.com:8000", normalizeTarget("example.com"))
+	assert.Equal(t, "[::1]:8000", normalizeTarget("[::1]"))
+	assert.Equal(t, "[::1]:8000", normalizeTarget("::1"))
+	assert.Equal(t, "[::1]:80", normalizeTarget("[::1]:80"))
+}
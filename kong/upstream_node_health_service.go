@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -37,7 +36,7 @@ func (s *UpstreamNodeHealthService) List(
 			return nil, nil, err
 		}
 		var upstreamNodeHealth UpstreamNodeHealth
-		err = json.Unmarshal(b, &upstreamNodeHealth)
+		err = s.client.decode(b, &upstreamNodeHealth)
 		if err != nil {
 			return nil, nil, err
 		}
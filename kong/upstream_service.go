@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -20,6 +19,10 @@ type AbstractUpstreamService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Upstream, *ListOpt, error)
 	// ListAll fetches all Upstreams in Kong.
 	ListAll(ctx context.Context) ([]*Upstream, error)
+	// DeleteWhere deletes the Upstreams in Kong matching predicate.
+	DeleteWhere(ctx context.Context, predicate func(*Upstream) bool) ([]*Upstream, error)
+	// CanaryRollout gradually shifts traffic between targets of an Upstream.
+	CanaryRollout(ctx context.Context, upstreamNameOrID *string, opts CanaryRolloutOpts) error
 }
 
 // UpstreamService handles Upstreams in Kong.
@@ -131,7 +134,7 @@ func (s *UpstreamService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var upstream Upstream
-		err = json.Unmarshal(b, &upstream)
+		err = s.client.decode(b, &upstream)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -158,3 +161,14 @@ func (s *UpstreamService) ListAll(ctx context.Context) ([]*Upstream, error) {
 	}
 	return upstreams, nil
 }
+
+// DeleteWhere deletes the Upstreams in Kong matching predicate, and
+// returns the ones it deleted, leaving out those deleted by others in the
+// meantime. Pages are fetched again after Upstreams are deleted from them,
+// so that unlike deleting Upstreams while paginating, none are skipped.
+func (s *UpstreamService) DeleteWhere(ctx context.Context,
+	predicate func(*Upstream) bool,
+) ([]*Upstream, error) {
+	return deleteWhere(ctx, s.List, s.Delete,
+		func(entity *Upstream) *string { return entity.ID }, predicate)
+}
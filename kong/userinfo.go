@@ -0,0 +1,10 @@
+package kong
+
+// UserInfo is the identity and permissions of the admin making a request,
+// as returned by the /userinfo endpoint of Kong Enterprise.
+type UserInfo struct {
+	Admin       *Admin               `json:"admin,omitempty" yaml:"admin,omitempty"`
+	Groups      []*string            `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Workspaces  []*Workspace         `json:"workspaces,omitempty" yaml:"workspaces,omitempty"`
+	Permissions *RBACPermissionsList `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
@@ -0,0 +1,34 @@
+package kong
+
+import (
+	"context"
+)
+
+// AbstractUserInfoService handles the identity of the calling admin in
+// Kong Enterprise.
+type AbstractUserInfoService interface {
+	// Get fetches the identity, workspaces and permissions of the calling
+	// admin.
+	Get(ctx context.Context) (*UserInfo, error)
+}
+
+// UserInfoService handles the identity of the calling admin in Kong
+// Enterprise.
+type UserInfoService service
+
+// Get fetches the identity, workspaces and permissions of the admin
+// whose credentials (e.g. Kong-Admin-Token) the client sends.
+// /userinfo is not workspaced, the workspace of the client is ignored.
+func (s *UserInfoService) Get(ctx context.Context) (*UserInfo, error) {
+	req, err := s.client.NewRequestRaw("GET", s.client.baseRootURL, "/userinfo", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var userInfo UserInfo
+	_, err = s.client.Do(ctx, req, &userInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &userInfo, nil
+}
@@ -0,0 +1,36 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserInfoService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// /userinfo is not workspaced
+		assert.Equal(t, "/userinfo", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"admin": {"id": "a1", "username": "ci", "email": "ci@example.com"},
+			"groups": ["ops"],
+			"workspaces": [{"id": "w1", "name": "default"}],
+			"permissions": {"endpoints": {"default": {"/default/*": {"actions": ["read"], "negative": false}}}}
+		}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("team-a")
+
+	userInfo, err := client.UserInfo.Get(defaultCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "ci", *userInfo.Admin.Username)
+	assert.Equal(t, []*string{String("ops")}, userInfo.Groups)
+	require.Len(t, userInfo.Workspaces, 1)
+	assert.Equal(t, "default", *userInfo.Workspaces[0].Name)
+	require.NotNil(t, userInfo.Permissions)
+	assert.Contains(t, userInfo.Permissions.Endpoints, "default")
+}
@@ -32,6 +32,11 @@ func Int(i int) *int {
 	return &i
 }
 
+// Int64 returns a pointer to i.
+func Int64(i int64) *int64 {
+	return &i
+}
+
 // Float64 returns a pointer to f.
 func Float64(f float64) *float64 {
 	return &f
@@ -0,0 +1,92 @@
+package kong
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsUUID returns true if id is a UUID as Kong accepts them in IDs, i.e.
+// 32 hexadecimal digits in the 8-4-4-4-12 format, without braces or
+// urn:uuid: prefix.
+func IsUUID(id string) bool {
+	return uuidRegex.MatchString(id)
+}
+
+// ValidateUUID returns an error if id isn't a UUID, see IsUUID.
+func ValidateUUID(id string) error {
+	if !IsUUID(id) {
+		return fmt.Errorf("invalid UUID %q: expected a UUID", id)
+	}
+	return nil
+}
+
+// ValidateName checks that name is valid as the name of services and
+// routes, among others, following the rules of Kong: it must be
+// non-empty UTF-8 and contain only alphanumeric characters, '.', '-', '_',
+// '~' and non-ASCII characters.
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("invalid name: name cannot be empty")
+	}
+	if !utf8.ValidString(name) {
+		return fmt.Errorf("invalid name %q: invalid UTF-8", name)
+	}
+	for _, r := range name {
+		switch {
+		case r >= utf8.RuneSelf,
+			'a' <= r && r <= 'z', 'A' <= r && r <= 'Z', '0' <= r && r <= '9',
+			r == '.', r == '-', r == '_', r == '~':
+			continue
+		}
+		return fmt.Errorf("invalid name %q: it must only contain alphanumeric "+
+			"and '., -, _, ~' characters", name)
+	}
+	return nil
+}
+
+// ValidateTag checks that tag is valid as a tag, following the rules of
+// Kong: it must be non-empty UTF-8 and contain only printable ASCII
+// characters but ',' and '/', and non-ASCII characters.
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("invalid tag: tag cannot be empty")
+	}
+	if !utf8.ValidString(tag) {
+		return fmt.Errorf("invalid tag %q: invalid UTF-8", tag)
+	}
+	for _, r := range tag {
+		if r >= utf8.RuneSelf || ('!' <= r && r <= '~' && r != ',' && r != '/') {
+			continue
+		}
+		return fmt.Errorf("invalid tag %q: expected printable ASCII (except ',' and '/') "+
+			"or UTF-8 characters", tag)
+	}
+	return nil
+}
+
+// ValidateTags validates tags with ValidateTag. Nil tags are invalid.
+func ValidateTags(tags []*string) error {
+	for _, tag := range tags {
+		if tag == nil {
+			return fmt.Errorf("invalid tag: tag cannot be nil")
+		}
+		if err := ValidateTag(*tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateNameAndTags validates the name, if set, and the tags of an
+// entity before it's sent to Kong.
+func validateNameAndTags(name *string, tags []*string) error {
+	if name != nil {
+		if err := ValidateName(*name); err != nil {
+			return err
+		}
+	}
+	return ValidateTags(tags)
+}
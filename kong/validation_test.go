@@ -0,0 +1,66 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsUUID(t *testing.T) {
+	assert.True(t, IsUUID("fd02801f-0957-4a15-a55a-c8d9606f30b5"))
+	assert.True(t, IsUUID("FD02801F-0957-4A15-A55A-C8D9606F30B5"))
+	assert.False(t, IsUUID(""))
+	assert.False(t, IsUUID("fd02801f09574a15a55ac8d9606f30b5"))
+	assert.False(t, IsUUID("{fd02801f-0957-4a15-a55a-c8d9606f30b5}"))
+	assert.False(t, IsUUID("urn:uuid:fd02801f-0957-4a15-a55a-c8d9606f30b5"))
+	assert.False(t, IsUUID("fd02801f-0957-4a15-a55a-c8d9606f30bg"))
+	assert.NoError(t, ValidateUUID("fd02801f-0957-4a15-a55a-c8d9606f30b5"))
+	assert.Error(t, ValidateUUID("my-service"))
+}
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"my-service", "v1.2_beta~x", "servicé", "サービス"} {
+		assert.NoError(t, ValidateName(name), name)
+	}
+	for _, name := range []string{"", "my service", "a/b", "a:b", "a@b", "\xff"} {
+		assert.Error(t, ValidateName(name), name)
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	for _, tag := range []string{"team:payments", "env=prod", "a@b.c!", "étiquette"} {
+		assert.NoError(t, ValidateTag(tag), tag)
+	}
+	for _, tag := range []string{"", "a,b", "a/b", "with space", "tab\t", "\xff"} {
+		assert.Error(t, ValidateTag(tag), tag)
+	}
+	assert.NoError(t, ValidateTags(nil))
+	assert.NoError(t, ValidateTags(StringSlice("a", "b")))
+	assert.Error(t, ValidateTags([]*string{String("a"), nil}))
+	assert.Error(t, ValidateTags(StringSlice("a", "b,c")))
+}
+
+func TestValidationBeforeRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, err = client.Services.Create(defaultCtx, &Service{Name: String("my service")})
+	assert.ErrorContains(t, err, "invalid name")
+	_, err = client.Services.Update(defaultCtx, &Service{ID: String("id"), Tags: StringSlice("a/b")})
+	assert.ErrorContains(t, err, "invalid tag")
+	_, err = client.Routes.Create(defaultCtx, &Route{Name: String("a:b")})
+	assert.ErrorContains(t, err, "invalid name")
+	_, err = client.Routes.Update(defaultCtx, &Route{ID: String("id"), Tags: StringSlice("")})
+	assert.ErrorContains(t, err, "invalid tag")
+	_, err = client.Consumers.Create(defaultCtx, &Consumer{Username: String("a b"), Tags: StringSlice("x,y")})
+	assert.ErrorContains(t, err, "invalid tag")
+	_, err = client.Consumers.Update(defaultCtx, &Consumer{ID: String("id"), Tags: StringSlice("x/y")})
+	assert.ErrorContains(t, err, "invalid tag")
+}
@@ -0,0 +1,176 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// VaultPlaceholders replaces the values of sensitive fields with vault
+// references, e.g. {vault://env/KONG_CERTIFICATES_C1_KEY}, so that the
+// declarative files generated from exports are safe to commit, and
+// substitutes the values back before they are applied.
+type VaultPlaceholders struct {
+	// Redactor selects the sensitive fields, NewRedactor() if nil.
+	Redactor *Redactor
+	// Reference returns the reference replacing the field at path, e.g.
+	// []string{"config", "client_secret"}, of entity, of entityType. If
+	// nil, references to the env vault are named after the entity type,
+	// the ID, or name, of the entity and the path of the field.
+	Reference func(entityType string, entity map[string]interface{}, path []string) *VaultReference
+}
+
+// DefaultVaultPlaceholder returns a reference to the env vault, named
+// after entityType, the ID, or else the name or username, of entity and
+// path, e.g. {vault://env/KONG_CERTIFICATES_C1_KEY}.
+func DefaultVaultPlaceholder(entityType string, entity map[string]interface{}, path []string) *VaultReference {
+	parts := []string{"KONG", redactionEntityType(entityType)}
+	for _, field := range []string{"id", "name", "username"} {
+		if value, ok := entity[field].(string); ok && value != "" {
+			parts = append(parts, value)
+			break
+		}
+	}
+	parts = append(parts, path...)
+	name := strings.ToUpper(strings.Join(parts, "_"))
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	return &VaultReference{Name: "env", Resource: name}
+}
+
+// Mask returns entity, of entityType, e.g. "certificates", as JSON with
+// the values of its sensitive fields replaced by vault references, along
+// with the replaced values by reference, e.g. to store them in the
+// vault. Fields already holding references are left as they are.
+func (p *VaultPlaceholders) Mask(entityType string, entity interface{}) (json.RawMessage, map[string]string, error) {
+	secrets := map[string]string{}
+	b, err := p.mask(entityType, entity, secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, secrets, nil
+}
+
+func (p *VaultPlaceholders) mask(entityType string, entity interface{}, secrets map[string]string) (json.RawMessage, error) {
+	var value interface{}
+	if err := convert(entity, &value); err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return json.Marshal(value)
+	}
+	redactor := p.Redactor
+	if redactor == nil {
+		redactor = NewRedactor()
+	}
+	reference := p.Reference
+	if reference == nil {
+		reference = DefaultVaultPlaceholder
+	}
+	var err error
+	redactor.replace(entityType, object, func(path []string, value interface{}) interface{} {
+		secret, ok := value.(string)
+		if !ok || IsVaultReference(secret) {
+			return value
+		}
+		ref := reference(entityType, object, path).String()
+		if previous, ok := secrets[ref]; ok && previous != secret && err == nil {
+			err = fmt.Errorf("vault reference %s used for different values", ref)
+		}
+		secrets[ref] = secret
+		return ref
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(object)
+}
+
+// MaskArchive returns a copy of archive with the values of its sensitive
+// fields replaced by vault references, see Mask.
+func (p *VaultPlaceholders) MaskArchive(archive *BackupArchive) (*BackupArchive, map[string]string, error) {
+	var fields map[string]json.RawMessage
+	if err := convert(archive, &fields); err != nil {
+		return nil, nil, err
+	}
+	secrets := map[string]string{}
+	for entityType, raw := range fields {
+		var entities []json.RawMessage
+		if json.Unmarshal(raw, &entities) != nil {
+			continue
+		}
+		for i, entity := range entities {
+			masked, err := p.mask(entityType, entity, secrets)
+			if err != nil {
+				return nil, nil, err
+			}
+			entities[i] = masked
+		}
+		b, err := json.Marshal(entities)
+		if err != nil {
+			return nil, nil, err
+		}
+		fields[entityType] = b
+	}
+	var res BackupArchive
+	if err := convert(fields, &res); err != nil {
+		return nil, nil, err
+	}
+	return &res, secrets, nil
+}
+
+// Unmask returns entity as JSON with the vault references found in
+// secrets replaced by their values, reverting Mask, e.g. for Kong
+// deployments without the vault.
+func Unmask(entity interface{}, secrets map[string]string) (json.RawMessage, error) {
+	var value interface{}
+	if err := convert(entity, &value); err != nil {
+		return nil, err
+	}
+	var walk func(interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case string:
+			if secret, ok := secrets[v]; ok && IsVaultReference(v) {
+				return secret
+			}
+		case []interface{}:
+			for i, e := range v {
+				v[i] = walk(e)
+			}
+		case map[string]interface{}:
+			for k, e := range v {
+				v[k] = walk(e)
+			}
+		}
+		return v
+	}
+	return json.Marshal(walk(value))
+}
+
+// UnmaskPolicy returns a Policy substituting the vault references found
+// in secrets with their values in the entities of operations, see
+// Unmask, so that Appliers apply the values masked on export.
+func UnmaskPolicy(secrets map[string]string) Policy {
+	return func(_ context.Context, operations []Operation) ([]Operation, error) {
+		res := make([]Operation, 0, len(operations))
+		for _, operation := range operations {
+			if operation.Entity != nil {
+				entity, err := Unmask(operation.Entity, secrets)
+				if err != nil {
+					return nil, fmt.Errorf("unmasking entity of %s %s: %w",
+						operation.Action, operation.endpoint(), err)
+				}
+				operation.Entity = entity
+			}
+			res = append(res, operation)
+		}
+		return res, nil
+	}
+}
@@ -0,0 +1,70 @@
+package kong
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultPlaceholders(t *testing.T) {
+	placeholders := &VaultPlaceholders{}
+	archive := &BackupArchive{
+		Version: BackupFormatVersion,
+		Certificates: []*Certificate{
+			{ID: String("c-1"), Cert: String("cert"), Key: String("key"), KeyAlt: String("{vault://aws/alt}")},
+		},
+		Plugins: []*Plugin{{ID: String("p1"), Name: String("session"), Config: Configuration{"secret": "s"}}},
+	}
+	masked, secrets, err := placeholders.MaskArchive(archive)
+	require.NoError(t, err)
+	assert.Equal(t, String("{vault://env/KONG_CERTIFICATES_C_1_KEY}"), masked.Certificates[0].Key)
+	assert.Equal(t, String("{vault://aws/alt}"), masked.Certificates[0].KeyAlt)
+	assert.Equal(t, String("cert"), masked.Certificates[0].Cert)
+	assert.Equal(t, "{vault://env/KONG_PLUGINS_P1_CONFIG_SECRET}", masked.Plugins[0].Config["secret"])
+	assert.Equal(t, map[string]string{
+		"{vault://env/KONG_CERTIFICATES_C_1_KEY}":     "key",
+		"{vault://env/KONG_PLUGINS_P1_CONFIG_SECRET}": "s",
+	}, secrets)
+	assert.Equal(t, String("key"), archive.Certificates[0].Key)
+
+	b, err := Unmask(masked.Certificates[0], secrets)
+	require.NoError(t, err)
+	var certificate Certificate
+	require.NoError(t, json.Unmarshal(b, &certificate))
+	assert.Equal(t, archive.Certificates[0], &certificate)
+
+	operations, err := UnmaskPolicy(secrets)(defaultCtx, []Operation{
+		{Action: MutationCreate, EntityType: "plugins", Entity: masked.Plugins[0]},
+		{Action: MutationDelete, EntityType: "plugins", ID: "p2"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"p1","name":"session","config":{"secret":"s"}}`,
+		string(operations[0].Entity.(json.RawMessage)))
+	assert.Nil(t, operations[1].Entity)
+}
+
+func TestVaultPlaceholdersCustomReference(t *testing.T) {
+	placeholders := &VaultPlaceholders{
+		Reference: func(entityType string, entity map[string]interface{}, path []string) *VaultReference {
+			return &VaultReference{Name: "hcv", Resource: "kong/" + entity["username"].(string), Key: path[len(path)-1]}
+		},
+	}
+	b, secrets, err := placeholders.Mask("consumers", &ExportedConsumer{
+		Consumer: Consumer{Username: String("alice")},
+		ConsumerCredentials: ConsumerCredentials{
+			KeyAuths: []*KeyAuth{{Key: String("k1")}, {Key: String("k2")}},
+		},
+	})
+	assert.EqualError(t, err, "vault reference {vault://hcv/kong/alice/key} used for different values")
+	assert.Nil(t, b)
+	assert.Nil(t, secrets)
+
+	b, secrets, err = placeholders.Mask("consumers/alice/basic-auth", &BasicAuth{
+		Username: String("alice"), Password: String("p"),
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"username":"alice","password":"{vault://hcv/kong/alice/password}"}`, string(b))
+	assert.Equal(t, map[string]string{"{vault://hcv/kong/alice/password}": "p"}, secrets)
+}
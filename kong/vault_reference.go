@@ -0,0 +1,168 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	vaultReferencePrefix = "{vault://"
+	vaultReferenceSuffix = "}"
+)
+
+// BuiltinVaultNames are the names of the vaults bundled with Kong, which
+// can be referenced without a Vault entity, e.g. {vault://env/MY_VAR}.
+var BuiltinVaultNames = []string{"env", "aws", "gcp", "hcv", "azure", "conjur"}
+
+// VaultReference is a reference to a secret stored in a vault, which Kong
+// resolves when it finds it in place of a referenceable field, e.g.
+// {vault://aws/db-credentials/password?region=eu-west-1#2}.
+type VaultReference struct {
+	// Name is the name of a vault bundled with Kong or the prefix of a
+	// Vault entity.
+	Name string
+	// Resource is the name or path of the secret.
+	Resource string
+	// Key is the key of the value in secrets holding several values,
+	// if any.
+	Key string
+	// Query overrides the configuration of the vault.
+	Query url.Values
+	// Version is the version of the secret, 0 for the latest.
+	Version int
+}
+
+// IsVaultReference returns true if s looks like a vault reference, which
+// Kong would try to resolve. It doesn't mean that s is valid.
+func IsVaultReference(s string) bool {
+	return strings.HasPrefix(s, vaultReferencePrefix) && strings.HasSuffix(s, vaultReferenceSuffix)
+}
+
+// ParseVaultReference parses a reference of the form
+// {vault://<name>/<resource>[/<key>][?<query>][#<version>]}, following the
+// rules of Kong.
+func ParseVaultReference(ref string) (*VaultReference, error) {
+	if !IsVaultReference(ref) {
+		return nil, fmt.Errorf("invalid vault reference %q: must be of the form {vault://<name>/<resource>}", ref)
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(ref, vaultReferencePrefix), vaultReferenceSuffix)
+
+	var r VaultReference
+	if i := strings.LastIndexByte(body, '#'); i >= 0 {
+		version, err := strconv.Atoi(body[i+1:])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid vault reference %q: version must be a positive integer", ref)
+		}
+		r.Version = version
+		body = body[:i]
+	}
+	if i := strings.IndexByte(body, '?'); i >= 0 {
+		query, err := url.ParseQuery(body[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid vault reference %q: %w", ref, err)
+		}
+		r.Query = query
+		body = body[:i]
+	}
+
+	i := strings.IndexByte(body, '/')
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid vault reference %q: missing vault name", ref)
+	}
+	r.Name, r.Resource = body[:i], body[i+1:]
+	if i := strings.LastIndexByte(r.Resource, '/'); i >= 0 {
+		r.Resource, r.Key = r.Resource[:i], r.Resource[i+1:]
+	}
+	if r.Resource == "" {
+		return nil, fmt.Errorf("invalid vault reference %q: missing resource", ref)
+	}
+	return &r, nil
+}
+
+// String returns the reference as used in Kong configuration.
+func (r *VaultReference) String() string {
+	var b strings.Builder
+	b.WriteString(vaultReferencePrefix)
+	b.WriteString(r.Name)
+	b.WriteByte('/')
+	b.WriteString(r.Resource)
+	if r.Key != "" {
+		b.WriteByte('/')
+		b.WriteString(r.Key)
+	}
+	if len(r.Query) > 0 {
+		b.WriteByte('?')
+		b.WriteString(r.Query.Encode())
+	}
+	if r.Version > 0 {
+		b.WriteByte('#')
+		b.WriteString(strconv.Itoa(r.Version))
+	}
+	b.WriteString(vaultReferenceSuffix)
+	return b.String()
+}
+
+// FindVaultReferences returns the vault references found in the string
+// values of entity (e.g. a *Plugin) once encoded to JSON, sorted and
+// without duplicates.
+func FindVaultReferences(entity interface{}) ([]string, error) {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, err
+	}
+	found := map[string]struct{}{}
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case string:
+			if IsVaultReference(v) {
+				found[v] = struct{}{}
+			}
+		case []interface{}:
+			for _, e := range v {
+				walk(e)
+			}
+		case map[string]interface{}:
+			for _, e := range v {
+				walk(e)
+			}
+		}
+	}
+	walk(value)
+
+	refs := make([]string, 0, len(found))
+	for ref := range found {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
+
+// CheckReference checks that ref is a valid vault reference and that its
+// vault is either bundled with Kong or a Vault entity of the workspace.
+// The Admin API can't resolve secrets, so their existence isn't checked.
+func (s *VaultService) CheckReference(ctx context.Context, ref string) error {
+	r, err := ParseVaultReference(ref)
+	if err != nil {
+		return err
+	}
+	for _, name := range BuiltinVaultNames {
+		if r.Name == name {
+			return nil
+		}
+	}
+	_, err = s.Get(ctx, &r.Name)
+	if IsNotFoundErr(err) {
+		return fmt.Errorf("vault reference %q: no vault with prefix %q", ref, r.Name)
+	}
+	return err
+}
@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVaultReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    *VaultReference
+		wantErr bool
+	}{
+		{
+			ref:  "{vault://env/MY_SECRET}",
+			want: &VaultReference{Name: "env", Resource: "MY_SECRET"},
+		},
+		{
+			ref: "{vault://aws/db/creds/password?region=eu-west-1#2}",
+			want: &VaultReference{
+				Name: "aws", Resource: "db/creds", Key: "password",
+				Query: url.Values{"region": []string{"eu-west-1"}}, Version: 2,
+			},
+		},
+		{ref: "vault://env/MY_SECRET", wantErr: true},
+		{ref: "{vault://env}", wantErr: true},
+		{ref: "{vault:///secret}", wantErr: true},
+		{ref: "{vault://env/}", wantErr: true},
+		{ref: "{vault://env/MY_SECRET#latest}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseVaultReference(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ref, got.String())
+		})
+	}
+}
+
+func TestFindVaultReferences(t *testing.T) {
+	plugin := &Plugin{
+		Name: String("openid-connect"),
+		Config: Configuration{
+			"client_secret": []interface{}{"{vault://hcv/oidc/secret}"},
+			"issuer":        "https://idp.example.com",
+			"redis":         map[string]interface{}{"password": "{vault://env/REDIS_PASSWORD}"},
+			"client_id":     "{vault://hcv/oidc/secret}",
+		},
+	}
+	refs, err := FindVaultReferences(plugin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"{vault://env/REDIS_PASSWORD}", "{vault://hcv/oidc/secret}"}, refs)
+}
+
+func TestVaultServiceCheckReference(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vaults/my-aws":
+			_, _ = w.Write([]byte(`{"id":"v1","name":"aws","prefix":"my-aws"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Vaults.CheckReference(defaultCtx, "{vault://env/MY_SECRET}"))
+	assert.NoError(t, client.Vaults.CheckReference(defaultCtx, "{vault://my-aws/db/password}"))
+	err = client.Vaults.CheckReference(defaultCtx, "{vault://my-gcp/db/password}")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no vault with prefix "my-gcp"`)
+	assert.Error(t, client.Vaults.CheckReference(defaultCtx, "{vault://env}"))
+}
@@ -20,6 +20,8 @@ type AbstractVaultService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Vault, *ListOpt, error)
 	// ListAll fetches all Vaults in Kong.
 	ListAll(ctx context.Context) ([]*Vault, error)
+	// CheckReference checks that a vault reference refers to an existing vault.
+	CheckReference(ctx context.Context, ref string) error
 }
 
 // VaultService handles Vaults in Kong.
@@ -0,0 +1,126 @@
+package kong
+
+import (
+	"sort"
+	"time"
+)
+
+// VitalsInterval is the granularity of the statistics returned by Kong
+// Vitals.
+type VitalsInterval string
+
+const (
+	VitalsSeconds VitalsInterval = "seconds"
+	VitalsMinutes VitalsInterval = "minutes"
+	VitalsHours   VitalsInterval = "hours"
+	VitalsDays    VitalsInterval = "days"
+	VitalsWeeks   VitalsInterval = "weeks"
+)
+
+// Labels of the cluster statistics of Kong Vitals.
+const (
+	VitalsRequestsProxyTotal      = "requests_proxy_total"
+	VitalsLatencyProxyRequestMin  = "latency_proxy_request_min_ms"
+	VitalsLatencyProxyRequestMax  = "latency_proxy_request_max_ms"
+	VitalsLatencyProxyRequestAvg  = "latency_proxy_request_avg_ms"
+	VitalsLatencyUpstreamMin      = "latency_upstream_min_ms"
+	VitalsLatencyUpstreamMax      = "latency_upstream_max_ms"
+	VitalsLatencyUpstreamAvg      = "latency_upstream_avg_ms"
+	VitalsCacheDatastoreHitsTotal = "cache_datastore_hits_total"
+	VitalsCacheDatastoreMisses    = "cache_datastore_misses_total"
+)
+
+// VitalsOpts selects the time range of statistics.
+type VitalsOpts struct {
+	// Interval is the granularity of the statistics, VitalsMinutes if
+	// empty.
+	Interval VitalsInterval
+	// Start, if set, drops the statistics older than Start.
+	Start time.Time
+}
+
+// VitalsStats are the cluster-wide statistics of Kong Vitals.
+type VitalsStats struct {
+	Interval VitalsInterval
+	// Samples are sorted by time.
+	Samples []VitalsSample
+}
+
+// VitalsSample holds the values of statistics over an interval.
+type VitalsSample struct {
+	Timestamp time.Time
+	// Values are indexed by label, e.g. VitalsRequestsProxyTotal. Values
+	// which Kong didn't report are missing.
+	Values map[string]float64
+}
+
+// Total returns the sum of the values of label over the samples, e.g. the
+// number of requests for VitalsRequestsProxyTotal.
+func (s *VitalsStats) Total(label string) float64 {
+	var total float64
+	for _, sample := range s.Samples {
+		total += sample.Values[label]
+	}
+	return total
+}
+
+// Max returns the maximum value of label over the samples, e.g. the
+// maximum latency for VitalsLatencyProxyRequestMax.
+func (s *VitalsStats) Max(label string) float64 {
+	var max float64
+	for _, sample := range s.Samples {
+		if value, ok := sample.Values[label]; ok && value > max {
+			max = value
+		}
+	}
+	return max
+}
+
+// VitalsStatusCodes are the counts of responses by status code for a
+// service, route or consumer, as returned by Kong Vitals.
+type VitalsStatusCodes struct {
+	// EntityType is "service", "route" or "consumer".
+	EntityType string
+	EntityID   string
+	Interval   VitalsInterval
+	// Samples are sorted by time.
+	Samples []VitalsStatusCodeSample
+}
+
+// VitalsStatusCodeSample holds the counts of responses over an interval.
+type VitalsStatusCodeSample struct {
+	Timestamp time.Time
+	// Counts are indexed by status code, e.g. "200".
+	Counts map[string]int
+}
+
+// Requests returns the number of requests over the samples.
+func (s *VitalsStatusCodes) Requests() int {
+	var total int
+	for _, sample := range s.Samples {
+		for _, count := range sample.Counts {
+			total += count
+		}
+	}
+	return total
+}
+
+// ByClass returns the number of requests over the samples by class of
+// status code, e.g. "2xx" or "5xx".
+func (s *VitalsStatusCodes) ByClass() map[string]int {
+	res := map[string]int{}
+	for _, sample := range s.Samples {
+		for code, count := range sample.Counts {
+			if len(code) == 3 {
+				res[code[:1]+"xx"] += count
+			}
+		}
+	}
+	return res
+}
+
+func sortVitalsSamples[T any](samples []T, timestamp func(T) time.Time) {
+	sort.Slice(samples, func(i, j int) bool {
+		return timestamp(samples[i]).Before(timestamp(samples[j]))
+	})
+}
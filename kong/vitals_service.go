@@ -0,0 +1,170 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// AbstractVitalsService handles the statistics of Kong Vitals (Kong
+// Enterprise).
+type AbstractVitalsService interface {
+	// Cluster fetches cluster-wide statistics, such as request counts and
+	// latencies.
+	Cluster(ctx context.Context, opts *VitalsOpts) (*VitalsStats, error)
+	// ServiceStatusCodes fetches the responses by status code of a service.
+	ServiceStatusCodes(ctx context.Context, serviceID *string, opts *VitalsOpts) (*VitalsStatusCodes, error)
+	// RouteStatusCodes fetches the responses by status code of a route.
+	RouteStatusCodes(ctx context.Context, routeID *string, opts *VitalsOpts) (*VitalsStatusCodes, error)
+	// ConsumerStatusCodes fetches the responses by status code of a
+	// consumer.
+	ConsumerStatusCodes(ctx context.Context, consumerID *string, opts *VitalsOpts) (*VitalsStatusCodes, error)
+}
+
+// VitalsService handles the statistics of Kong Vitals (Kong Enterprise).
+type VitalsService service
+
+type vitalsQuery struct {
+	Interval   string `url:"interval"`
+	StartTS    int64  `url:"start_ts,omitempty"`
+	ServiceID  string `url:"service_id,omitempty"`
+	RouteID    string `url:"route_id,omitempty"`
+	ConsumerID string `url:"consumer_id,omitempty"`
+}
+
+type vitalsResponse[T any] struct {
+	Meta struct {
+		Interval   VitalsInterval `json:"interval,omitempty"`
+		Level      string         `json:"level,omitempty"`
+		EarliestTS int64          `json:"earliest_ts,omitempty"`
+		LatestTS   int64          `json:"latest_ts,omitempty"`
+		EntityType string         `json:"entity_type,omitempty"`
+		EntityID   string         `json:"entity_id,omitempty"`
+		StatLabels []string       `json:"stat_labels,omitempty"`
+	} `json:"meta"`
+	// Stats are indexed by level, e.g. "cluster", then by timestamp.
+	Stats map[string]map[string]T `json:"stats"`
+}
+
+func newVitalsQuery(opts *VitalsOpts) vitalsQuery {
+	query := vitalsQuery{Interval: string(VitalsMinutes)}
+	if opts != nil {
+		if opts.Interval != "" {
+			query.Interval = string(opts.Interval)
+		}
+		if !opts.Start.IsZero() {
+			query.StartTS = opts.Start.Unix()
+		}
+	}
+	return query
+}
+
+func vitalsTimestamp(ts string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid vitals timestamp %q", ts)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// Cluster fetches cluster-wide statistics, such as request counts and
+// latencies, over the time range of opts. Kong Vitals only reports
+// latencies cluster-wide.
+func (s *VitalsService) Cluster(ctx context.Context, opts *VitalsOpts) (*VitalsStats, error) {
+	req, err := s.client.NewRequest(http.MethodGet, "/vitals/cluster", newVitalsQuery(opts), nil)
+	if err != nil {
+		return nil, err
+	}
+	var response vitalsResponse[[]*float64]
+	if _, err := s.client.Do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	stats := &VitalsStats{Interval: response.Meta.Interval, Samples: []VitalsSample{}}
+	for ts, values := range response.Stats["cluster"] {
+		timestamp, err := vitalsTimestamp(ts)
+		if err != nil {
+			return nil, err
+		}
+		sample := VitalsSample{Timestamp: timestamp, Values: map[string]float64{}}
+		for i, value := range values {
+			if i < len(response.Meta.StatLabels) && value != nil {
+				sample.Values[response.Meta.StatLabels[i]] = *value
+			}
+		}
+		stats.Samples = append(stats.Samples, sample)
+	}
+	sortVitalsSamples(stats.Samples, func(s VitalsSample) time.Time { return s.Timestamp })
+	return stats, nil
+}
+
+func (s *VitalsService) statusCodes(ctx context.Context, endpoint string,
+	query vitalsQuery,
+) (*VitalsStatusCodes, error) {
+	req, err := s.client.NewRequest(http.MethodGet, endpoint, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	var response vitalsResponse[map[string]int]
+	if _, err := s.client.Do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	statusCodes := &VitalsStatusCodes{
+		EntityType: response.Meta.EntityType,
+		EntityID:   response.Meta.EntityID,
+		Interval:   response.Meta.Interval,
+		Samples:    []VitalsStatusCodeSample{},
+	}
+	for ts, counts := range response.Stats["cluster"] {
+		timestamp, err := vitalsTimestamp(ts)
+		if err != nil {
+			return nil, err
+		}
+		statusCodes.Samples = append(statusCodes.Samples, VitalsStatusCodeSample{
+			Timestamp: timestamp,
+			Counts:    counts,
+		})
+	}
+	sortVitalsSamples(statusCodes.Samples, func(s VitalsStatusCodeSample) time.Time { return s.Timestamp })
+	return statusCodes, nil
+}
+
+// ServiceStatusCodes fetches the responses by status code of a service
+// over the time range of opts.
+func (s *VitalsService) ServiceStatusCodes(ctx context.Context, serviceID *string,
+	opts *VitalsOpts,
+) (*VitalsStatusCodes, error) {
+	if isEmptyString(serviceID) {
+		return nil, fmt.Errorf("serviceID cannot be nil")
+	}
+	query := newVitalsQuery(opts)
+	query.ServiceID = *serviceID
+	return s.statusCodes(ctx, "/vitals/status_codes/by_service", query)
+}
+
+// RouteStatusCodes fetches the responses by status code of a route over
+// the time range of opts.
+func (s *VitalsService) RouteStatusCodes(ctx context.Context, routeID *string,
+	opts *VitalsOpts,
+) (*VitalsStatusCodes, error) {
+	if isEmptyString(routeID) {
+		return nil, fmt.Errorf("routeID cannot be nil")
+	}
+	query := newVitalsQuery(opts)
+	query.RouteID = *routeID
+	return s.statusCodes(ctx, "/vitals/status_codes/by_route", query)
+}
+
+// ConsumerStatusCodes fetches the responses by status code of a consumer
+// over the time range of opts.
+func (s *VitalsService) ConsumerStatusCodes(ctx context.Context, consumerID *string,
+	opts *VitalsOpts,
+) (*VitalsStatusCodes, error) {
+	if isEmptyString(consumerID) {
+		return nil, fmt.Errorf("consumerID cannot be nil")
+	}
+	query := newVitalsQuery(opts)
+	query.ConsumerID = *consumerID
+	return s.statusCodes(ctx, "/vitals/status_codes/by_consumer", query)
+}
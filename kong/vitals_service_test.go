@@ -0,0 +1,80 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVitalsService(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/vitals/cluster":
+			assert.Equal(t, "hours", query.Get("interval"))
+			assert.Equal(t, "1700000000", query.Get("start_ts"))
+			_, _ = w.Write([]byte(`{"meta":{"level":"cluster","interval":"hours",
+				"stat_labels":["requests_proxy_total","latency_proxy_request_max_ms","latency_upstream_avg_ms"]},
+				"stats":{"cluster":{"1700003600":[20,150,null],"1700000000":[10,90,12.5]}}}`))
+		case "/vitals/status_codes/by_service":
+			assert.Equal(t, "s1", query.Get("service_id"))
+			assert.Equal(t, "minutes", query.Get("interval"))
+			assert.Empty(t, query.Get("start_ts"))
+			_, _ = w.Write([]byte(`{"meta":{"entity_type":"service","entity_id":"s1","interval":"minutes",
+				"level":"cluster","stat_labels":["status_codes_per_service_total"]},
+				"stats":{"cluster":{"1700000060":{"200":7,"503":1},"1700000000":{"200":3,"404":2}}}}`))
+		case "/vitals/status_codes/by_route":
+			assert.Equal(t, "r1", query.Get("route_id"))
+			_, _ = w.Write([]byte(`{"meta":{"entity_type":"route","entity_id":"r1"},"stats":{}}`))
+		case "/vitals/status_codes/by_consumer":
+			assert.Equal(t, "c1", query.Get("consumer_id"))
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	stats, err := client.Vitals.Cluster(defaultCtx, &VitalsOpts{
+		Interval: VitalsHours,
+		Start:    time.Unix(1700000000, 0),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, VitalsHours, stats.Interval)
+	require.Len(t, stats.Samples, 2)
+	assert.Equal(t, time.Unix(1700000000, 0), stats.Samples[0].Timestamp)
+	assert.Equal(t, map[string]float64{
+		VitalsRequestsProxyTotal:     10,
+		VitalsLatencyProxyRequestMax: 90,
+		VitalsLatencyUpstreamAvg:     12.5,
+	}, stats.Samples[0].Values)
+	assert.NotContains(t, stats.Samples[1].Values, VitalsLatencyUpstreamAvg)
+	assert.Equal(t, 30.0, stats.Total(VitalsRequestsProxyTotal))
+	assert.Equal(t, 150.0, stats.Max(VitalsLatencyProxyRequestMax))
+
+	statusCodes, err := client.Vitals.ServiceStatusCodes(defaultCtx, String("s1"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "service", statusCodes.EntityType)
+	assert.Equal(t, "s1", statusCodes.EntityID)
+	require.Len(t, statusCodes.Samples, 2)
+	assert.Equal(t, map[string]int{"200": 3, "404": 2}, statusCodes.Samples[0].Counts)
+	assert.Equal(t, 13, statusCodes.Requests())
+	assert.Equal(t, map[string]int{"2xx": 10, "4xx": 2, "5xx": 1}, statusCodes.ByClass())
+
+	statusCodes, err = client.Vitals.RouteStatusCodes(defaultCtx, String("r1"), nil)
+	require.NoError(t, err)
+	assert.Empty(t, statusCodes.Samples)
+	assert.Equal(t, 0, statusCodes.Requests())
+
+	_, err = client.Vitals.ConsumerStatusCodes(defaultCtx, String("c1"), nil)
+	assert.True(t, IsNotFoundErr(err))
+	_, err = client.Vitals.ServiceStatusCodes(defaultCtx, nil, nil)
+	assert.EqualError(t, err, "serviceID cannot be nil")
+}
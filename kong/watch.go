@@ -0,0 +1,175 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WatchEventType is the type of a WatchEvent.
+type WatchEventType string
+
+const (
+	// WatchAdded is sent for entities which appeared since the previous
+	// listing, and for all entities on the first one unless a snapshot
+	// was saved, see WatchOpts.Store.
+	WatchAdded WatchEventType = "added"
+	// WatchUpdated is sent for entities which changed since the previous
+	// listing.
+	WatchUpdated WatchEventType = "updated"
+	// WatchDeleted is sent for entities which disappeared since the
+	// previous listing.
+	WatchDeleted WatchEventType = "deleted"
+	// WatchError is sent when a listing fails. Watching goes on, the
+	// next listing being compared to the last successful one.
+	WatchError WatchEventType = "error"
+)
+
+// WatchEvent is a change of an entity found by Watch.
+type WatchEvent struct {
+	Type WatchEventType
+	// EntityType is the watched entity type, e.g. "services".
+	EntityType string
+	ID         string
+	// Object is the entity as listed, or as last listed if it was
+	// deleted. Previous is the entity as previously listed, for updates.
+	Object   json.RawMessage
+	Previous json.RawMessage
+	// Err is the error of the listing, for WatchError events.
+	Err error
+}
+
+// WatchOpts configures Watch.
+type WatchOpts struct {
+	// Interval is the time between two listings.
+	Interval time.Duration
+	// Tags and MatchAllTags restrict the watched entities, see ListOpt.
+	Tags         []string
+	MatchAllTags bool
+	// BufferSize is the capacity of the returned channel.
+	BufferSize int
+	// Store, if set, persists the entities of the last listing, so that
+	// watching again after a restart only sends the changes made since.
+	Store SnapshotStore
+	// StoreKey is the key of the entities in Store, "watch/" followed by
+	// the workspace of the client and the entity type if empty.
+	StoreKey string
+}
+
+// Watch lists the entities of entityType, e.g. "services" or
+// "upstreams/{id}/targets", every opts.Interval and sends the changes
+// between listings on the returned channel, giving controllers an
+// informer-like view of Kong. Entities are compared by ID, ignoring
+// timestamps, and the events of a listing are sorted by ID.
+//
+// The channel is closed once ctx is done. Events are sent synchronously,
+// so slow consumers delay the next listing.
+func (c *Client) Watch(ctx context.Context, entityType string, opts WatchOpts) (<-chan WatchEvent, error) {
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("interval must be positive")
+	}
+	entityType = strings.Trim(entityType, "/")
+	if entityType == "" {
+		return nil, fmt.Errorf("entity type cannot be empty")
+	}
+	storeKey := opts.StoreKey
+	if storeKey == "" {
+		storeKey = "watch/" + c.contextWorkspace(ctx) + "/" + entityType
+	}
+	var previous Snapshot
+	if opts.Store != nil {
+		var err error
+		if previous, err = opts.Store.Load(ctx, storeKey); err != nil {
+			return nil, fmt.Errorf("loading snapshot: %w", err)
+		}
+	}
+	if previous == nil {
+		previous = Snapshot{}
+	}
+
+	events := make(chan WatchEvent, opts.BufferSize)
+	go func() {
+		defer close(events)
+		send := func(event WatchEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			current, err := c.watchSnapshot(ctx, entityType, opts)
+			if err != nil && ctx.Err() == nil {
+				if !send(WatchEvent{Type: WatchError, EntityType: entityType, Err: err}) {
+					return
+				}
+			}
+			if err == nil {
+				for _, d := range diffEntities(previous, current) {
+					event := WatchEvent{EntityType: entityType, ID: d.ID, Object: d.Actual}
+					switch d.Kind {
+					case DriftAdded:
+						event.Type = WatchAdded
+					case DriftChanged:
+						event.Type, event.Previous = WatchUpdated, d.Expected
+					case DriftRemoved:
+						event.Type, event.Object = WatchDeleted, d.Expected
+					}
+					if !send(event) {
+						return
+					}
+				}
+				previous = current
+				if opts.Store != nil {
+					if err := opts.Store.Save(ctx, storeKey, current); err != nil && ctx.Err() == nil {
+						err = fmt.Errorf("saving snapshot: %w", err)
+						if !send(WatchEvent{Type: WatchError, EntityType: entityType, Err: err}) {
+							return
+						}
+					}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return events, nil
+}
+
+// watchSnapshot lists the entities of entityType, indexed by ID without
+// their timestamps.
+func (c *Client) watchSnapshot(ctx context.Context, entityType string, opts WatchOpts) (Snapshot, error) {
+	entities := map[string]json.RawMessage{}
+	opt := &ListOpt{Size: pageSize, Tags: StringSlice(opts.Tags...), MatchAllTags: opts.MatchAllTags}
+	for opt != nil {
+		data, next, err := c.list(ctx, "/"+entityType, opt)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s: %w", entityType, err)
+		}
+		for _, raw := range data {
+			var entity map[string]interface{}
+			if err := json.Unmarshal(raw, &entity); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", entityType, err)
+			}
+			id, _ := entity["id"].(string)
+			delete(entity, "created_at")
+			delete(entity, "updated_at")
+			b, err := json.Marshal(entity)
+			if err != nil {
+				return nil, err
+			}
+			entities[id] = b
+		}
+		opt = next
+	}
+	return Snapshot{entityType: entities}, nil
+}
@@ -0,0 +1,81 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	var lock sync.Mutex
+	listings := []string{
+		`{"data":[{"id":"a","name":"one","updated_at":1},{"id":"b","name":"two"}]}`,
+		`{"data":[{"id":"a","name":"one","updated_at":2},{"id":"b","name":"deux"}]}`,
+		``,
+		`{"data":[{"id":"b","name":"deux"},{"id":"c","name":"three"}]}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		assert.Equal(t, "/services", r.URL.Path)
+		assert.Equal(t, "team", r.URL.Query().Get("tags"))
+		if len(listings) == 0 {
+			_, _ = w.Write([]byte(`{"data":[{"id":"b","name":"deux"},{"id":"c","name":"three"}]}`))
+			return
+		}
+		listing := listings[0]
+		listings = listings[1:]
+		if listing == "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		_, _ = w.Write([]byte(listing))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	_, err = client.Watch(defaultCtx, "services", WatchOpts{})
+	assert.Error(t, err)
+
+	ctx, cancel := context.WithCancel(defaultCtx)
+	defer cancel()
+	events, err := client.Watch(ctx, "services", WatchOpts{Interval: time.Millisecond, Tags: []string{"team"}})
+	require.NoError(t, err)
+
+	var received []WatchEvent
+	for len(received) < 6 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+	types := make([]WatchEventType, 0, len(received))
+	for _, event := range received {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []WatchEventType{
+		WatchAdded, WatchAdded, WatchUpdated, WatchError, WatchDeleted, WatchAdded,
+	}, types)
+	assert.Equal(t, "a", received[0].ID)
+	assert.JSONEq(t, `{"id":"a","name":"one"}`, string(received[0].Object))
+	assert.Equal(t, "b", received[2].ID)
+	assert.JSONEq(t, `{"id":"b","name":"two"}`, string(received[2].Previous))
+	assert.JSONEq(t, `{"id":"b","name":"deux"}`, string(received[2].Object))
+	assert.ErrorContains(t, received[3].Err, "boom")
+	assert.Equal(t, "a", received[4].ID)
+	assert.Equal(t, "c", received[5].ID)
+
+	cancel()
+	for range events { //nolint:revive // draining until the channel is closed
+	}
+}
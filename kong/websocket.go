@@ -0,0 +1,85 @@
+package kong
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// PluginSchemaProtocols returns the protocols a plugin supports according
+// to its full schema, as returned by PluginService.GetFullSchema.
+// It returns nil if the schema doesn't restrict protocols.
+func PluginSchemaProtocols(schema Schema) ([]string, error) {
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, field := range gjson.GetBytes(b, "fields").Array() {
+		protocols := field.Get("protocols.elements.one_of")
+		if !protocols.Exists() {
+			continue
+		}
+		for _, p := range protocols.Array() {
+			res = append(res, p.String())
+		}
+		return res, nil
+	}
+	return nil, nil
+}
+
+// ValidateWebSocketPlugins checks that plugins can run on a ws or wss
+// Route or Service, e.g. the ones returned by ListAllForRoute.
+// A plugin is incompatible if its protocols are restricted to
+// non-WebSocket ones or if its schema, fetched from Kong, doesn't list
+// ws or wss among the supported protocols. Plugins whose schema isn't
+// available are assumed to be compatible.
+func (s *PluginService) ValidateWebSocketPlugins(ctx context.Context, plugins []*Plugin) error {
+	supported := map[string]bool{}
+	var incompatible []string
+	for _, plugin := range plugins {
+		if plugin == nil || isEmptyString(plugin.Name) {
+			continue
+		}
+		if len(plugin.Protocols) > 0 && !hasWebSocketProtocol(plugin.Protocols) {
+			incompatible = append(incompatible, plugin.FriendlyName())
+			continue
+		}
+		ok, checked := supported[*plugin.Name]
+		if !checked {
+			schema, err := s.GetFullSchema(ctx, plugin.Name)
+			if err != nil && !IsNotFoundErr(err) {
+				return fmt.Errorf("fetching schema of plugin %q: %w", *plugin.Name, err)
+			}
+			protocols, err := PluginSchemaProtocols(schema)
+			if err != nil {
+				return err
+			}
+			ok = len(protocols) == 0 || hasWebSocketProtocol(StringSlice(protocols...))
+			supported[*plugin.Name] = ok
+		}
+		if !ok {
+			incompatible = append(incompatible, plugin.FriendlyName())
+		}
+	}
+	if len(incompatible) > 0 {
+		sort.Strings(incompatible)
+		return fmt.Errorf("plugins not compatible with WebSocket protocols: %s",
+			strings.Join(incompatible, ", "))
+	}
+	return nil
+}
+
+func hasWebSocketProtocol(protocols []*string) bool {
+	for _, p := range protocols {
+		switch RouteProtocol(derefString(p)) {
+		case RouteProtocolWS, RouteProtocolWSS:
+			return true
+		}
+	}
+	return false
+}
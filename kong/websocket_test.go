@@ -0,0 +1,51 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWebSocketPlugins(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/schemas/plugins/rate-limiting":
+			_, _ = w.Write([]byte(`{"fields":[{"protocols":{"type":"set",
+				"elements":{"type":"string","one_of":["grpc","grpcs","http","https","ws","wss"]}}}]}`))
+		case "/schemas/plugins/cors":
+			_, _ = w.Write([]byte(`{"fields":[{"protocols":{"type":"set",
+				"elements":{"type":"string","one_of":["grpc","grpcs","http","https"]}}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not found"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, client.Plugins.ValidateWebSocketPlugins(defaultCtx, []*Plugin{
+		{Name: String("rate-limiting")},
+		{Name: String("rate-limiting"), Protocols: RouteProtocols(RouteProtocolWSS)},
+		{Name: String("custom")},
+	}))
+	assert.Equal(t, 2, requests)
+
+	err = client.Plugins.ValidateWebSocketPlugins(defaultCtx, []*Plugin{
+		{Name: String("rate-limiting"), Protocols: RouteProtocols(RouteProtocolHTTP)},
+		{Name: String("cors")},
+	})
+	assert.EqualError(t, err, "plugins not compatible with WebSocket protocols: cors, rate-limiting")
+}
+
+func TestWebSocketProtocols(t *testing.T) {
+	route := &Route{Protocols: RouteProtocols(RouteProtocolWS, RouteProtocolWSS)}
+	assert.NoError(t, route.ValidateEnums())
+	service := &Service{Protocol: String(string(ServiceProtocolWSS))}
+	assert.NoError(t, service.ValidateEnums())
+}
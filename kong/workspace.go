@@ -10,6 +10,14 @@ type Workspace struct {
 	Meta      map[string]interface{} `json:"meta,omitempty" yaml:"meta,omitempty"`
 }
 
+// WorkspaceMeta holds the metadata of a Workspace in Kong,
+// as returned by the /workspaces/{workspace}/meta endpoint.
+type WorkspaceMeta struct {
+	// Counts maps entity types (e.g. "services") to the number of
+	// entities of this type in the workspace.
+	Counts map[string]int `json:"counts,omitempty" yaml:"counts,omitempty"`
+}
+
 // WorkspaceEntity represents a WorkspaceEntity in Kong
 // +k8s:deepcopy-gen=true
 type WorkspaceEntity struct {
@@ -0,0 +1,166 @@
+package kong
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// WorkspaceBlueprint describes a workspace and the baseline it's seeded
+// with by ProvisionWorkspace.
+type WorkspaceBlueprint struct {
+	Workspace *Workspace
+	// Roles are the RBAC roles to create in the workspace.
+	Roles []RoleBlueprint
+	// Plugins are the global plugins to create in the workspace.
+	Plugins []*Plugin
+	// GroupBindings bind admin groups to roles of the workspace.
+	GroupBindings []GroupRoleBinding
+}
+
+// RoleBlueprint is an RBAC role and its endpoint permissions.
+type RoleBlueprint struct {
+	Role *RBACRole
+	// Endpoints are the endpoint permissions of the role. Their workspace
+	// defaults to the provisioned workspace and their role is set.
+	Endpoints []*RBACEndpointPermission
+}
+
+// GroupRoleBinding grants the role of a workspace to an admin group.
+type GroupRoleBinding struct {
+	// Group is the name or ID of the admin group.
+	Group string
+	// Role is the name of a role of the blueprint.
+	Role string
+}
+
+// ProvisionedWorkspace holds the entities created by ProvisionWorkspace.
+type ProvisionedWorkspace struct {
+	Workspace *Workspace
+	Roles     []*RBACRole
+	Plugins   []*Plugin
+}
+
+// StandardWorkspaceBlueprint returns a blueprint of a workspace with an
+// admin role, with access to all the endpoints of the workspace but
+// RBAC ones, and a read-only role, named "workspace-admin" and
+// "workspace-read-only".
+func StandardWorkspaceBlueprint(name string) *WorkspaceBlueprint {
+	return &WorkspaceBlueprint{
+		Workspace: &Workspace{Name: String(name)},
+		Roles: []RoleBlueprint{
+			{
+				Role: &RBACRole{Name: String("workspace-admin"), Comment: String("Full access to " + name)},
+				Endpoints: []*RBACEndpointPermission{
+					{Endpoint: String("*"), Actions: StringSlice("read", "create", "update", "delete")},
+					{
+						Endpoint: String("/rbac/*"), Negative: Bool(true),
+						Actions: StringSlice("read", "create", "update", "delete"),
+					},
+					{
+						Endpoint: String("/rbac/*/*"), Negative: Bool(true),
+						Actions: StringSlice("read", "create", "update", "delete"),
+					},
+				},
+			},
+			{
+				Role: &RBACRole{Name: String("workspace-read-only"), Comment: String("Read access to " + name)},
+				Endpoints: []*RBACEndpointPermission{
+					{Endpoint: String("*"), Actions: StringSlice("read")},
+				},
+			},
+		},
+	}
+}
+
+// ProvisionWorkspace creates the workspace of blueprint and seeds it, in
+// a single call to onboard tenants on Kong Enterprise: it creates the
+// roles and their endpoint permissions, then the global plugins in the
+// workspace, and finally binds the roles to admin groups. The workspace
+// set on the client isn't used nor changed.
+//
+// Provisioning stops at the first error, which is returned along with
+// the entities created so far, so that they can be inspected or removed.
+func (c *Client) ProvisionWorkspace(ctx context.Context,
+	blueprint *WorkspaceBlueprint,
+) (*ProvisionedWorkspace, error) {
+	if blueprint == nil || blueprint.Workspace == nil || isEmptyString(blueprint.Workspace.Name) {
+		return nil, fmt.Errorf("workspace name cannot be nil for ProvisionWorkspace operation")
+	}
+	name := *blueprint.Workspace.Name
+	roleIDs := map[string]*string{}
+	for _, role := range blueprint.Roles {
+		if role.Role == nil || isEmptyString(role.Role.Name) {
+			return nil, fmt.Errorf("role name cannot be nil for ProvisionWorkspace operation")
+		}
+		roleIDs[*role.Role.Name] = nil
+	}
+	for _, binding := range blueprint.GroupBindings {
+		if _, ok := roleIDs[binding.Role]; !ok || binding.Group == "" {
+			return nil, fmt.Errorf("invalid binding of group %q to role %q", binding.Group, binding.Role)
+		}
+	}
+
+	workspace, err := c.Workspaces.Create(ctx, blueprint.Workspace)
+	if err != nil {
+		return nil, fmt.Errorf("creating workspace %q: %w", name, err)
+	}
+	provisioned := &ProvisionedWorkspace{Workspace: workspace}
+	// requests are sent to the new workspace rather than to the one of c
+	do := func(method, endpoint string, body, v interface{}) error {
+		req, err := c.NewRequestRaw(method, c.workspacedBaseURL(name), endpoint, nil, body)
+		if err != nil {
+			return err
+		}
+		_, err = c.Do(ctx, req, v)
+		return err
+	}
+
+	for _, blueprintRole := range blueprint.Roles {
+		var role RBACRole
+		if err := do(http.MethodPost, "/rbac/roles", blueprintRole.Role, &role); err != nil {
+			return provisioned, fmt.Errorf("creating role %q: %w", *blueprintRole.Role.Name, err)
+		}
+		provisioned.Roles = append(provisioned.Roles, &role)
+		roleIDs[*blueprintRole.Role.Name] = role.ID
+		for _, permission := range blueprintRole.Endpoints {
+			if permission == nil {
+				continue
+			}
+			p := *permission
+			if p.Workspace == nil {
+				p.Workspace = String(name)
+			}
+			if err := do(http.MethodPost, "/rbac/roles/"+*role.ID+"/endpoints", &p, nil); err != nil {
+				return provisioned, fmt.Errorf("creating endpoint permission %q of role %q: %w",
+					derefString(p.Endpoint), *blueprintRole.Role.Name, err)
+			}
+		}
+	}
+
+	for _, blueprintPlugin := range blueprint.Plugins {
+		if blueprintPlugin == nil {
+			continue
+		}
+		var plugin Plugin
+		if err := do(http.MethodPost, "/plugins", blueprintPlugin, &plugin); err != nil {
+			return provisioned, fmt.Errorf("creating plugin %q: %w", blueprintPlugin.FriendlyName(), err)
+		}
+		provisioned.Plugins = append(provisioned.Plugins, &plugin)
+	}
+
+	for _, binding := range blueprint.GroupBindings {
+		req, err := c.NewRequestRaw(http.MethodPost, c.workspacedBaseURL(""),
+			"/groups/"+binding.Group+"/roles", nil, map[string]interface{}{
+				"rbac_role_id": roleIDs[binding.Role],
+				"workspace_id": workspace.ID,
+			})
+		if err != nil {
+			return provisioned, err
+		}
+		if _, err := c.Do(ctx, req, nil); err != nil {
+			return provisioned, fmt.Errorf("binding group %q to role %q: %w", binding.Group, binding.Role, err)
+		}
+	}
+	return provisioned, nil
+}
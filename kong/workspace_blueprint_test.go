@@ -0,0 +1,89 @@
+package kong
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionWorkspace(t *testing.T) {
+	var requests []string
+	bodies := map[string][]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		requests = append(requests, request)
+		body, _ := io.ReadAll(r.Body)
+		var entity map[string]interface{}
+		_ = json.Unmarshal(body, &entity)
+		bodies[request] = append(bodies[request], entity)
+		if r.URL.Path == "/tenant/plugins" && entity["name"] == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"schema violation"}`))
+			return
+		}
+		entity["id"] = fmt.Sprintf("id-%d", len(requests))
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(entity)
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("other")
+
+	blueprint := StandardWorkspaceBlueprint("tenant")
+	blueprint.Plugins = []*Plugin{{Name: String("prometheus")}}
+	blueprint.GroupBindings = []GroupRoleBinding{{Group: "tenant-admins", Role: "workspace-admin"}}
+	provisioned, err := client.ProvisionWorkspace(defaultCtx, blueprint)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"POST /other/workspaces",
+		"POST /tenant/rbac/roles",
+		"POST /tenant/rbac/roles/id-2/endpoints",
+		"POST /tenant/rbac/roles/id-2/endpoints",
+		"POST /tenant/rbac/roles/id-2/endpoints",
+		"POST /tenant/rbac/roles",
+		"POST /tenant/rbac/roles/id-6/endpoints",
+		"POST /tenant/plugins",
+		"POST /groups/tenant-admins/roles",
+	}, requests)
+	assert.Equal(t, "id-1", *provisioned.Workspace.ID)
+	require.Len(t, provisioned.Roles, 2)
+	assert.Equal(t, "workspace-read-only", *provisioned.Roles[1].Name)
+	require.Len(t, provisioned.Plugins, 1)
+	assert.Equal(t, "id-8", *provisioned.Plugins[0].ID)
+
+	endpoint := bodies["POST /tenant/rbac/roles/id-6/endpoints"][0]
+	assert.Equal(t, "tenant", endpoint["workspace"])
+	assert.Equal(t, "read", endpoint["actions"])
+	binding := bodies["POST /groups/tenant-admins/roles"][0]
+	assert.Equal(t, "id-2", binding["rbac_role_id"])
+	assert.Equal(t, "id-1", binding["workspace_id"])
+	assert.Equal(t, "other", client.Workspace())
+
+	requests = nil
+	blueprint = &WorkspaceBlueprint{
+		Workspace: &Workspace{Name: String("tenant")},
+		Plugins:   []*Plugin{{Name: String("broken")}},
+	}
+	provisioned, err = client.ProvisionWorkspace(defaultCtx, blueprint)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `creating plugin "broken"`)
+	assert.Equal(t, "id-1", *provisioned.Workspace.ID)
+
+	requests = nil
+	_, err = client.ProvisionWorkspace(defaultCtx, &WorkspaceBlueprint{Workspace: &Workspace{}})
+	assert.Error(t, err)
+	_, err = client.ProvisionWorkspace(defaultCtx, &WorkspaceBlueprint{
+		Workspace:     &Workspace{Name: String("tenant")},
+		GroupBindings: []GroupRoleBinding{{Group: "g", Role: "missing"}},
+	})
+	assert.Error(t, err)
+	assert.Empty(t, requests)
+}
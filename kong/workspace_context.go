@@ -0,0 +1,78 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type workspaceCtxKey struct{}
+
+// requestWorkspaceCtxKey holds the workspace of requests created with
+// NewRequest in their context.
+type requestWorkspaceCtxKey struct{}
+
+// WithWorkspace returns a context making the requests done with it target
+// workspace instead of the workspace set on the client, so that a single
+// client can manage several workspaces, e.g. in reconcile loops. An empty
+// workspace targets the default workspace without prefixing paths.
+//
+// Only requests created with NewRequest, as the services of the client
+// do, are affected.
+func WithWorkspace(ctx context.Context, workspace string) context.Context {
+	return context.WithValue(ctx, workspaceCtxKey{}, workspace)
+}
+
+// contextWorkspace returns the workspace targeted with ctx, see
+// WithWorkspace.
+func (c *Client) contextWorkspace(ctx context.Context) string {
+	if ctx != nil {
+		if workspace, ok := ctx.Value(workspaceCtxKey{}).(string); ok {
+			return workspace
+		}
+	}
+	return c.Workspace()
+}
+
+// requestWorkspace returns the workspace req targets.
+func (c *Client) requestWorkspace(req *http.Request) string {
+	if workspace, ok := req.Context().Value(requestWorkspaceCtxKey{}).(string); ok {
+		return workspace
+	}
+	return c.Workspace()
+}
+
+// targetWorkspace returns req targeting the workspace of ctx, if it
+// overrides the workspace req was created for.
+func (c *Client) targetWorkspace(ctx context.Context, req *http.Request) *http.Request {
+	if ctx == nil {
+		return req
+	}
+	target, ok := ctx.Value(workspaceCtxKey{}).(string)
+	if !ok {
+		return req
+	}
+	current, ok := req.Context().Value(requestWorkspaceCtxKey{}).(string)
+	if !ok || current == target {
+		return req
+	}
+	from, err := url.Parse(c.workspacedBaseURL(current))
+	if err != nil {
+		return req
+	}
+	to, err := url.Parse(c.workspacedBaseURL(target))
+	if err != nil {
+		return req
+	}
+	fromPath, toPath := strings.TrimSuffix(from.Path, "/"), strings.TrimSuffix(to.Path, "/")
+	if !strings.HasPrefix(req.URL.Path, fromPath+"/") && req.URL.Path != fromPath {
+		return req
+	}
+	res := req.WithContext(context.WithValue(req.Context(), requestWorkspaceCtxKey{}, target))
+	u := *req.URL
+	u.Path = toPath + strings.TrimPrefix(req.URL.Path, fromPath)
+	u.RawPath = ""
+	res.URL = &u
+	return res
+}
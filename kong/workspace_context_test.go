@@ -0,0 +1,67 @@
+package kong
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithWorkspace(t *testing.T) {
+	var lock sync.Mutex
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		lock.Unlock()
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Path == "/team-a/status" {
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"s1","name":"svc","tags":["keep"],"data":[]}`))
+		default:
+			_, _ = w.Write([]byte(`{"id":"s1","name":"svc"}`))
+		}
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+	client.SetWorkspace("default")
+	var mutations []Mutation
+	client.SetMutationHook(func(_ context.Context, m Mutation) { mutations = append(mutations, m) }, true)
+	ctx, metadata := WithResponseMetadata(WithWorkspace(defaultCtx, "team-a"))
+
+	_, err = client.Services.Get(ctx, String("svc"))
+	require.NoError(t, err)
+	_, _, err = client.Services.List(ctx, nil)
+	require.NoError(t, err)
+	_, err = client.Services.Update(ctx, &Service{ID: String("s1"), Host: String("h")})
+	require.NoError(t, err)
+	_, err = client.Services.Get(WithWorkspace(defaultCtx, ""), String("svc"))
+	require.NoError(t, err)
+	_, err = client.Services.Get(defaultCtx, String("svc"))
+	require.NoError(t, err)
+	_, err = client.Status(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"GET /team-a/services/svc",
+		"GET /team-a/services",
+		"GET /team-a/services/s1",
+		"PATCH /team-a/services/s1",
+		"GET /services/svc",
+		"GET /default/services/svc",
+		"GET /team-a/status",
+	}, requests)
+	require.Len(t, mutations, 1)
+	assert.Equal(t, "services", mutations[0].EntityType)
+	assert.JSONEq(t, `{"id":"s1","name":"svc","tags":["keep"],"data":[]}`, string(mutations[0].Before))
+	assert.Equal(t, "/services/svc", metadata.All()[0].Path)
+	assert.Equal(t, "default", client.Workspace())
+}
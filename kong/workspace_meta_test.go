@@ -0,0 +1,27 @@
+package kong
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceServiceMeta(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces/team-a/meta", r.URL.Path)
+		_, _ = w.Write([]byte(`{"counts":{"services":2,"routes":5,"plugins":0}}`))
+	}))
+	defer srv.Close()
+	client, err := NewClient(String(srv.URL), nil)
+	require.NoError(t, err)
+
+	meta, err := client.Workspaces.Meta(defaultCtx, String("team-a"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"services": 2, "routes": 5, "plugins": 0}, meta.Counts)
+
+	_, err = client.Workspaces.Meta(defaultCtx, nil)
+	assert.Error(t, err)
+}
@@ -2,7 +2,6 @@ package kong
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -24,6 +23,12 @@ type AbstractWorkspaceService interface {
 	List(ctx context.Context, opt *ListOpt) ([]*Workspace, *ListOpt, error)
 	// ListAll fetches all workspaces in Kong.
 	ListAll(ctx context.Context) ([]*Workspace, error)
+	// Meta fetches the metadata of a Workspace, including entity counts, in Kong.
+	Meta(ctx context.Context, nameOrID *string) (*WorkspaceMeta, error)
+	// GetPortalAuth fetches the developer authentication configuration of a Workspace in Kong.
+	GetPortalAuth(ctx context.Context, nameOrID *string) (*PortalAuth, error)
+	// UpdatePortalAuth updates the developer authentication configuration of a Workspace in Kong.
+	UpdatePortalAuth(ctx context.Context, nameOrID *string, auth *PortalAuth) (*Workspace, error)
 	// AddEntities adds entity ids given as a a comma delimited string
 	// to a given workspace in Kong. The response is a representation
 	// of the entity that was added to the workspace.
@@ -177,7 +182,7 @@ func (s *WorkspaceService) List(ctx context.Context,
 			return nil, nil, err
 		}
 		var workspace Workspace
-		err = json.Unmarshal(b, &workspace)
+		err = s.client.decode(b, &workspace)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -204,6 +209,30 @@ func (s *WorkspaceService) ListAll(ctx context.Context) ([]*Workspace, error) {
 	return workspaces, nil
 }
 
+// Meta fetches the metadata of a Workspace in Kong, including the
+// number of entities of each type it holds, which is cheaper than
+// listing them.
+func (s *WorkspaceService) Meta(ctx context.Context,
+	nameOrID *string,
+) (*WorkspaceMeta, error) {
+	if isEmptyString(nameOrID) {
+		return nil, fmt.Errorf("nameOrID cannot be nil for Meta operation")
+	}
+
+	endpoint := fmt.Sprintf("/workspaces/%v/meta", *nameOrID)
+	req, err := s.client.NewRequest("GET", endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meta WorkspaceMeta
+	_, err = s.client.Do(ctx, req, &meta)
+	if err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
 // AddEntities adds entity ids given as a a comma delimited string
 // to a given workspace in Kong. The response is a representation
 // of the entity that was added to the workspace.
@@ -284,7 +313,7 @@ func (s *WorkspaceService) ListEntities(ctx context.Context,
 			return nil, err
 		}
 		var workspaceEntity WorkspaceEntity
-		err = json.Unmarshal(b, &workspaceEntity)
+		err = s.client.decode(b, &workspaceEntity)
 		if err != nil {
 			return nil, err
 		}